@@ -0,0 +1,56 @@
+package main
+
+import (
+	"io"
+	"io/ioutil"
+
+	"github.com/moznion/gowrtr/generator"
+)
+
+const fmtSynopsis = `[-w] [-s] [-goimports] file...
+
+The files are formatted by the formatter chain of generator.Root, that is gofmt and goimports.`
+
+func runFmt(args []string, stdout io.Writer, stderr io.Writer) error {
+	fs := newFlagSet("fmt", fmtSynopsis, stderr)
+	write := fs.Bool("w", false, "write the result to the file instead of stdout")
+	simplify := fs.Bool("s", false, "simplify the code (i.e. gofmt -s)")
+	goimports := fs.Bool("goimports", false, "run goimports after gofmt")
+	if err := parseFlags(fs, args, -1); err != nil {
+		return err
+	}
+
+	var gofmtOptions []string
+	if *simplify {
+		gofmtOptions = append(gofmtOptions, "-s")
+	}
+
+	for _, path := range fs.Args() {
+		src, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		root := generator.NewRoot(generator.NewRawStatement(string(src)).WithNewline(false)).Gofmt(gofmtOptions...)
+		if *goimports {
+			root = root.Goimports()
+		}
+		formatted, err := root.Generate(0)
+		if err != nil {
+			return err
+		}
+
+		if !*write {
+			if _, err := io.WriteString(stdout, formatted); err != nil {
+				return err
+			}
+			continue
+		}
+		if formatted != string(src) {
+			if err := ioutil.WriteFile(path, []byte(formatted), 0644); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/moznion/gowrtr/generator"
+)
+
+const specSourceSynopsis = "[-o dir] <spec.yaml|spec.json|script.go>"
+
+const specSourceHelp = `The spec is read by generator.LoadSpec(). The script is a golang program (e.g. with the "ignore" build tag)
+that is run by "go run"; it writes the spec to stdout, e.g. by generator.NewSpec(...).YAML(), so it builds the
+generator trees by golang without the plugin. The paths of the files are relative to the output directory.`
+
+func runGen(args []string, stdout io.Writer, stderr io.Writer) error {
+	fs := newFlagSet("gen", specSourceSynopsis+"\n\n"+specSourceHelp, stderr)
+	outDir := fs.String("o", ".", "the directory where the files are written")
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	files, err := generateFiles(fs.Arg(0), stderr)
+	if err != nil {
+		return err
+	}
+	for _, path := range sortedPaths(files) {
+		dest := filepath.Join(*outDir, path)
+		if current, err := ioutil.ReadFile(dest); err == nil && string(current) == files[path] {
+			// keep the file as it is, so the modification time is not changed
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+			return err
+		}
+		if err := ioutil.WriteFile(dest, []byte(files[path]), 0644); err != nil {
+			return err
+		}
+		fmt.Fprintln(stdout, dest)
+	}
+	return nil
+}
+
+func runCheck(args []string, stdout io.Writer, stderr io.Writer) error {
+	fs := newFlagSet("check", specSourceSynopsis+"\n\nIt exits with 1 if the files differ from the generated code.\n"+specSourceHelp, stderr)
+	outDir := fs.String("o", ".", "the directory where the files are written")
+	if err := parseFlags(fs, args, 1); err != nil {
+		return err
+	}
+
+	files, err := generateFiles(fs.Arg(0), stderr)
+	if err != nil {
+		return err
+	}
+	drifted := false
+	for _, path := range sortedPaths(files) {
+		dest := filepath.Join(*outDir, path)
+		current, err := ioutil.ReadFile(dest)
+		if os.IsNotExist(err) {
+			fmt.Fprintf(stderr, "%s: missing\n", dest)
+			drifted = true
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if string(current) != files[path] {
+			fmt.Fprintf(stderr, "%s: out of date\n", dest)
+			drifted = true
+		}
+	}
+	if drifted {
+		return errDrift
+	}
+	return nil
+}
+
+// generateFiles generates the files from the spec or the script; the result is the map of the path and the code.
+func generateFiles(source string, stderr io.Writer) (map[string]string, error) {
+	var spec *generator.Spec
+	if filepath.Ext(source) == ".go" {
+		out, err := runScript(source, stderr)
+		if err != nil {
+			return nil, err
+		}
+		if spec, err = generator.ParseSpec(source, out); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		if spec, err = generator.LoadSpec(source); err != nil {
+			return nil, err
+		}
+	}
+
+	files, err := spec.Generate()
+	if err != nil {
+		return nil, err
+	}
+	for path := range files {
+		if filepath.IsAbs(path) || strings.HasPrefix(filepath.Clean(path), "..") {
+			return nil, fmt.Errorf("path of the file must be relative and inside the output directory, but it gets %s", path)
+		}
+	}
+	return files, nil
+}
+
+func runScript(script string, stderr io.Writer) ([]byte, error) {
+	var out bytes.Buffer
+	cmd := exec.Command("go", "run", script)
+	cmd.Stdout = &out
+	cmd.Stderr = stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to run the script %s: %w", script, err)
+	}
+	return out.Bytes(), nil
+}
+
+func sortedPaths(files map[string]string) []string {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
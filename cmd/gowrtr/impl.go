@@ -0,0 +1,277 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/moznion/gowrtr/generator"
+)
+
+const implSynopsis = `-iface Name -type Name [-pkg path] [-dir dir] [-o file] [-package name]
+
+The interface is loaded from the package; the default is the package in the current directory, so it works in "go generate".
+If the output is in the same package, the types of the package are not qualified.
+If the type already exists in the package of the output, stub generates only the methods that are not implemented yet.`
+
+// implFlags is the flags that are common to stub and mock.
+type implFlags struct {
+	iface     *string
+	typeName  *string
+	pkgPath   *string
+	srcDir    *string
+	output    *string
+	pkgName   *string
+	goimports *bool
+}
+
+func newImplFlags(fs *flag.FlagSet) *implFlags {
+	return &implFlags{
+		iface:     fs.String("iface", "", "the name of the interface (required)"),
+		typeName:  fs.String("type", "", "the name of the type that implements the interface (required)"),
+		pkgPath:   fs.String("pkg", ".", "the package of the interface"),
+		srcDir:    fs.String("dir", ".", "the directory where the package is resolved from"),
+		output:    fs.String("o", "", "the file where the code is written; the default is stdout"),
+		pkgName:   fs.String("package", "", "the package name of the output; the default is $GOPACKAGE or the package of the output directory"),
+		goimports: fs.Bool("goimports", true, "whether to run goimports to add the imports"),
+	}
+}
+
+// implementation is the loaded interface and the package of the output.
+type implementation struct {
+	iface         *generator.Interface
+	interfaceType string
+	pkgName       string
+	outDir        string
+	imports       []string
+}
+
+func (f *implFlags) load(fs *flag.FlagSet) (*implementation, error) {
+	if *f.iface == "" || *f.typeName == "" {
+		fs.Usage()
+		return nil, errUsage
+	}
+
+	srcDir, err := filepath.Abs(*f.srcDir)
+	if err != nil {
+		return nil, err
+	}
+	pkg, err := build.Import(*f.pkgPath, srcDir, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the package %s: %w", *f.pkgPath, err)
+	}
+
+	outDir := "."
+	if *f.output != "" {
+		outDir = filepath.Dir(*f.output)
+	}
+	if outDir, err = filepath.Abs(outDir); err != nil {
+		return nil, err
+	}
+	local := outDir == pkg.Dir
+
+	impl := &implementation{
+		interfaceType: *f.iface,
+		pkgName:       *f.pkgName,
+		outDir:        outDir,
+	}
+	if local {
+		impl.iface, err = generator.LoadLocalInterface(srcDir, *f.pkgPath, *f.iface)
+	} else {
+		impl.iface, err = generator.LoadInterface(srcDir, *f.pkgPath, *f.iface)
+		impl.interfaceType = pkg.Name + "." + *f.iface
+		if !build.IsLocalImport(*f.pkgPath) {
+			impl.imports = []string{*f.pkgPath}
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if impl.pkgName == "" {
+		impl.pkgName = os.Getenv("GOPACKAGE")
+	}
+	if impl.pkgName == "" && local {
+		impl.pkgName = pkg.Name
+	}
+	if impl.pkgName == "" {
+		outPkg, err := build.ImportDir(outDir, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the package name of %s; please specify -package: %w", outDir, err)
+		}
+		impl.pkgName = outPkg.Name
+	}
+	return impl, nil
+}
+
+// existingMethods returns the names of the methods of the type if the type already exists in the output package.
+// The output file is ignored because it is overwritten; `ok` is false if the type doesn't exist.
+func (f *implFlags) existingMethods(outDir string) (names []string, ok bool, err error) {
+	outPkg, err := build.ImportDir(outDir, 0)
+	if err != nil {
+		var noGoErr *build.NoGoError
+		if errors.As(err, &noGoErr) {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+
+	output := ""
+	if *f.output != "" {
+		if output, err = filepath.Abs(*f.output); err != nil {
+			return nil, false, err
+		}
+	}
+
+	fset := token.NewFileSet()
+	var outputFile *ast.File
+	for _, filename := range outPkg.GoFiles {
+		path := filepath.Join(outPkg.Dir, filename)
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil, false, err
+		}
+		if path == output {
+			outputFile = file
+			continue
+		}
+		ok = ok || declaresType(file, *f.typeName)
+	}
+	if !ok {
+		return nil, false, nil
+	}
+
+	loaded, err := generator.LoadMethodNames(outDir, ".", *f.typeName)
+	if err != nil {
+		return nil, false, err
+	}
+	generated := map[string]bool{}
+	if outputFile != nil {
+		for _, name := range methodNamesOf(outputFile, *f.typeName) {
+			generated[name] = true
+		}
+	}
+	for _, name := range loaded {
+		if !generated[name] {
+			names = append(names, name)
+		}
+	}
+	return names, true, nil
+}
+
+func declaresType(file *ast.File, typeName string) bool {
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			if spec.(*ast.TypeSpec).Name.Name == typeName {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// methodNamesOf returns the names of the methods of the type that are declared in the file.
+func methodNamesOf(file *ast.File, typeName string) []string {
+	var names []string
+	for _, decl := range file.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Recv == nil || len(funcDecl.Recv.List) <= 0 {
+			continue
+		}
+		recv := funcDecl.Recv.List[0].Type
+		if star, ok := recv.(*ast.StarExpr); ok {
+			recv = star.X
+		}
+		if ident, ok := recv.(*ast.Ident); ok && ident.Name == typeName {
+			names = append(names, funcDecl.Name.Name)
+		}
+	}
+	return names
+}
+
+func (f *implFlags) write(stdout io.Writer, statements ...generator.Statement) error {
+	root := generator.NewRoot(statements...).Gofmt()
+	if *f.goimports {
+		root = root.Goimports()
+	}
+	generated, err := root.Generate(0)
+	if err != nil {
+		return err
+	}
+
+	if *f.output == "" {
+		_, err := io.WriteString(stdout, generated)
+		return err
+	}
+	return ioutil.WriteFile(*f.output, []byte(generated), 0644)
+}
+
+func runStub(args []string, stdout io.Writer, stderr io.Writer) error {
+	fs := newFlagSet("stub", implSynopsis, stderr)
+	flags := newImplFlags(fs)
+	receiver := fs.String("receiver", "", "the name of the receiver; the default is the first letter of the type")
+	valueReceiver := fs.Bool("value", false, "whether the methods have the value receiver")
+	zero := fs.Bool("zero", false, "whether the methods return the zero values instead of panic")
+	if err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+
+	impl, err := flags.load(fs)
+	if err != nil {
+		return err
+	}
+
+	stub := generator.NewInterfaceStub(*flags.typeName, impl.iface).ValueReceiver(*valueReceiver).ReturnZeroValues(*zero)
+	if *receiver != "" {
+		stub = stub.ReceiverName(*receiver)
+	}
+	// the existing type is not declared again, and only the methods that are not implemented yet are generated
+	implemented, exists, err := flags.existingMethods(impl.outDir)
+	if err != nil {
+		return err
+	}
+	if exists {
+		stub = stub.ExistingType(implemented...)
+	}
+	statements := []generator.Statement{generator.NewPackage(impl.pkgName), generator.NewNewline()}
+	if len(impl.imports) > 0 {
+		statements = append(statements, generator.NewImport(impl.imports...), generator.NewNewline())
+	}
+	return flags.write(stdout, append(statements, stub)...)
+}
+
+func runMock(args []string, stdout io.Writer, stderr io.Writer) error {
+	fs := newFlagSet("mock", implSynopsis, stderr)
+	flags := newImplFlags(fs)
+	if err := parseFlags(fs, args, 0); err != nil {
+		return err
+	}
+
+	impl, err := flags.load(fs)
+	if err != nil {
+		return err
+	}
+
+	statements := []generator.Statement{
+		generator.NewComment(" Code generated by gowrtr. DO NOT EDIT."),
+		generator.NewNewline(),
+		generator.NewPackage(impl.pkgName),
+		generator.NewNewline(),
+		generator.NewImport(append([]string{"sync"}, impl.imports...)...),
+		generator.NewNewline(),
+		generator.NewMock(*flags.typeName, impl.iface).InterfaceType(impl.interfaceType),
+	}
+	return flags.write(stdout, statements...)
+}
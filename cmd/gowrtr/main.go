@@ -0,0 +1,123 @@
+// Command gowrtr generates golang code by the generators of gowrtr.
+//
+// Usage:
+//
+//	gowrtr <command> [flags] [args]
+//
+// The commands are:
+//
+//	gen    generates the files from a spec (YAML or JSON) or a generator script
+//	check  verifies that the generated files are up to date
+//	stub   generates the stub implementation of an interface
+//	mock   generates the mock implementation of an interface
+//	fmt    formats the files by gofmt (and goimports)
+//
+// Each command is friendly to `go generate`, e.g.:
+//
+//	//go:generate gowrtr gen gowrtr.yaml
+//	//go:generate gowrtr mock -iface Store -type MockStore -o mock_store.go
+//
+// The errors of the generators point to the location in the spec (e.g. `(caused at gowrtr.yaml:12:7)`).
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+type command struct {
+	name        string
+	description string
+	run         func(args []string, stdout io.Writer, stderr io.Writer) error
+}
+
+var commands = []*command{
+	{name: "gen", description: "generates the files from a spec (YAML or JSON) or a generator script", run: runGen},
+	{name: "check", description: "verifies that the generated files are up to date", run: runCheck},
+	{name: "stub", description: "generates the stub implementation of an interface", run: runStub},
+	{name: "mock", description: "generates the mock implementation of an interface", run: runMock},
+	{name: "fmt", description: "formats the files by gofmt (and goimports)", run: runFmt},
+}
+
+var (
+	// errUsage is the error of the arguments; the usage has already been shown.
+	errUsage = errors.New("invalid usage")
+	// errDrift is the error that the generated files are out of date.
+	errDrift = errors.New("generated files are out of date; please run `gowrtr gen`")
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the command, and returns the exit code; 1 for the error and 2 for the invalid usage.
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) <= 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		usage(stderr)
+		if len(args) <= 0 {
+			return 2
+		}
+		return 0
+	}
+
+	for _, cmd := range commands {
+		if cmd.name != args[0] {
+			continue
+		}
+
+		err := cmd.run(args[1:], stdout, stderr)
+		switch {
+		case err == nil:
+			return 0
+		case errors.Is(err, flag.ErrHelp):
+			return 0
+		case errors.Is(err, errUsage):
+			return 2
+		}
+		fmt.Fprintf(stderr, "gowrtr %s: %s\n", cmd.name, err)
+		return 1
+	}
+
+	fmt.Fprintf(stderr, "gowrtr: unknown command %q\n", args[0])
+	usage(stderr)
+	return 2
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "Usage: gowrtr <command> [flags] [args]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-6s %s\n", cmd.name, cmd.description)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run `gowrtr <command> -h` for the flags of each command.")
+}
+
+func newFlagSet(name string, synopsis string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: gowrtr %s %s\n", name, synopsis)
+		fs.PrintDefaults()
+	}
+	return fs
+}
+
+// parseFlags parses the flags, and checks the number of the positional arguments; negative `nArgs` means one or more.
+func parseFlags(fs *flag.FlagSet, args []string, nArgs int) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return err
+		}
+		return errUsage
+	}
+	if (nArgs < 0 && fs.NArg() <= 0) || (nArgs >= 0 && fs.NArg() != nArgs) {
+		fs.Usage()
+		return errUsage
+	}
+	return nil
+}
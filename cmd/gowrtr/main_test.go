@@ -0,0 +1,248 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const expectedUserCode = "package user\n\ntype User struct {\n\tID int64 `json:\"id\"`\n}\n"
+
+func runCommand(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	code := run(args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "gowrtr-cmd")
+	assert.NoError(t, err)
+	return dir
+}
+
+// writeStorePackage writes the module that has the package `store`, and `Store` interface that has the methods.
+func writeStorePackage(t *testing.T, dir string, methods string) {
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "go.mod"), []byte("module store\n\ngo 1.14\n"), 0644))
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "store.go"), []byte(`package store
+
+import "context"
+
+type Item struct{}
+
+type Store interface {
+	`+methods+`
+}
+`), 0644))
+}
+
+func TestShouldShowUsage(t *testing.T) {
+	code, _, stderr := runCommand()
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "Usage: gowrtr <command> [flags] [args]")
+
+	code, _, stderr = runCommand("unknown")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, `gowrtr: unknown command "unknown"`)
+
+	code, _, stderr = runCommand("gen")
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr, "Usage: gowrtr gen [-o dir] <spec.yaml|spec.json|script.go>")
+}
+
+func TestShouldGenerateAndCheckFilesFromSpec(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	code, _, stderr := runCommand("check", "-o", dir, "testdata/gowrtr.yaml")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, filepath.Join(dir, "user/user.go")+": missing")
+
+	code, stdout, _ := runCommand("gen", "-o", dir, "testdata/gowrtr.yaml")
+	assert.Equal(t, 0, code)
+	assert.Equal(t, filepath.Join(dir, "user/user.go")+"\n", stdout)
+	generated, err := ioutil.ReadFile(filepath.Join(dir, "user/user.go"))
+	assert.NoError(t, err)
+	assert.Equal(t, expectedUserCode, string(generated))
+
+	code, stdout, _ = runCommand("gen", "-o", dir, "testdata/gowrtr.yaml")
+	assert.Equal(t, 0, code)
+	assert.Empty(t, stdout)
+
+	code, _, _ = runCommand("check", "-o", dir, "testdata/gowrtr.yaml")
+	assert.Equal(t, 0, code)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "user/user.go"), []byte("package user\n"), 0644))
+	code, _, stderr = runCommand("check", "-o", dir, "testdata/gowrtr.yaml")
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, filepath.Join(dir, "user/user.go")+": out of date")
+	assert.Contains(t, stderr, "gowrtr check: generated files are out of date")
+}
+
+func TestShouldReportErrorWithSpecLocation(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	spec := filepath.Join(dir, "gowrtr.yaml")
+	assert.NoError(t, ioutil.WriteFile(spec, []byte("files:\n  a.go:\n    statements:\n      - func: {body: []}\n"), 0644))
+
+	code, _, stderr := runCommand("gen", "-o", dir, spec)
+	assert.Equal(t, 1, code)
+	assert.Contains(t, stderr, "(caused at "+spec+":4:9)")
+}
+
+func TestShouldGenerateFilesFromScript(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this test runs the script by go run")
+	}
+
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	code, _, stderr := runCommand("gen", "-o", dir, "testdata/script.go")
+	assert.Equal(t, 0, code, stderr)
+	generated, err := ioutil.ReadFile(filepath.Join(dir, "user/user.go"))
+	assert.NoError(t, err)
+	assert.Equal(t, expectedUserCode, string(generated))
+}
+
+func TestShouldFormatFiles(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "a.go")
+	assert.NoError(t, ioutil.WriteFile(path, []byte("package a\nvar  x = []int{ 1 }\n"), 0644))
+
+	code, stdout, _ := runCommand("fmt", path)
+	assert.Equal(t, 0, code)
+	assert.Equal(t, "package a\n\nvar x = []int{1}\n", stdout)
+
+	code, stdout, _ = runCommand("fmt", "-w", path)
+	assert.Equal(t, 0, code)
+	assert.Empty(t, stdout)
+	formatted, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "package a\n\nvar x = []int{1}\n", string(formatted))
+}
+
+func TestShouldGenerateStubAndMockInLocalPackage(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this test builds the generated code")
+	}
+	if _, err := exec.LookPath("goimports"); err != nil {
+		t.Skip("goimports is not installed")
+	}
+
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	writeStorePackage(t, dir, "Get(ctx context.Context, key string) (*Item, error)")
+
+	code, _, stderr := runCommand("stub", "-dir", dir, "-iface", "Store", "-type", "memStore", "-zero", "-o", filepath.Join(dir, "mem_store.go"))
+	assert.Equal(t, 0, code, stderr)
+	code, _, stderr = runCommand("mock", "-dir", dir, "-iface", "Store", "-type", "MockStore", "-o", filepath.Join(dir, "mock_store.go"))
+	assert.Equal(t, 0, code, stderr)
+
+	stub, err := ioutil.ReadFile(filepath.Join(dir, "mem_store.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(stub), "package store\n")
+	assert.Contains(t, string(stub), "func (m *memStore) Get(\n\tctx context.Context,\n\tkey string,\n) (*Item, error) {")
+	mock, err := ioutil.ReadFile(filepath.Join(dir, "mock_store.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(mock), "// Code generated by gowrtr. DO NOT EDIT.\n\npackage store\n")
+	assert.Contains(t, string(mock), "var _ Store = (*MockStore)(nil)")
+
+	cmd := exec.Command("go", "vet", ".")
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	assert.NoError(t, err, string(out))
+}
+
+func TestShouldGenerateStubOfExistingType(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this test builds the generated code")
+	}
+	if _, err := exec.LookPath("goimports"); err != nil {
+		t.Skip("goimports is not installed")
+	}
+
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	writeStorePackage(t, dir, "Get(ctx context.Context, key string) (*Item, error)\n\tPut(ctx context.Context, item *Item) error")
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "mem_store.go"), []byte(`package store
+
+import "context"
+
+type memStore struct {
+	items map[string]*Item
+}
+
+func (m *memStore) Get(ctx context.Context, key string) (*Item, error) {
+	return m.items[key], nil
+}
+`), 0644))
+
+	output := filepath.Join(dir, "mem_store_stub.go")
+	args := []string{"stub", "-dir", dir, "-iface", "Store", "-type", "memStore", "-zero", "-o", output}
+	code, _, stderr := runCommand(args...)
+	assert.Equal(t, 0, code, stderr)
+	stub, err := ioutil.ReadFile(output)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(stub), "type memStore struct")
+	assert.NotContains(t, string(stub), ") Get(")
+	assert.Contains(t, string(stub), "func (m *memStore) Put(\n\tctx context.Context,\n\titem *Item,\n) error {")
+
+	// the methods in the output file are regenerated rather than skipped
+	code, _, stderr = runCommand(args...)
+	assert.Equal(t, 0, code, stderr)
+	regenerated, err := ioutil.ReadFile(output)
+	assert.NoError(t, err)
+	assert.Equal(t, string(stub), string(regenerated))
+
+	cmd := exec.Command("go", "vet", ".")
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	assert.NoError(t, err, string(out))
+}
+
+func TestShouldRegenerateMockAfterInterfaceChanges(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this test builds the generated code")
+	}
+	if _, err := exec.LookPath("goimports"); err != nil {
+		t.Skip("goimports is not installed")
+	}
+
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	writeStorePackage(t, dir, "Get(ctx context.Context, key string) (*Item, error)")
+	args := []string{"mock", "-dir", dir, "-iface", "Store", "-type", "MockStore", "-o", filepath.Join(dir, "mock_store.go")}
+	code, _, stderr := runCommand(args...)
+	assert.Equal(t, 0, code, stderr)
+
+	// the stale mock doesn't implement the new method, so the package doesn't compile until the mock is regenerated
+	writeStorePackage(t, dir, "Get(ctx context.Context, key string) (*Item, error)\n\tPut(ctx context.Context, item *Item) error")
+	code, _, stderr = runCommand(args...)
+	assert.Equal(t, 0, code, stderr)
+
+	mock, err := ioutil.ReadFile(filepath.Join(dir, "mock_store.go"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(mock), "func (mock *MockStore) Put(\n\tctx context.Context,\n\titem *Item,\n) error {")
+
+	cmd := exec.Command("go", "vet", ".")
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	assert.NoError(t, err, string(out))
+}
+
+func TestShouldReportErrorWhenInterfaceIsNotFound(t *testing.T) {
+	code, _, stderr := runCommand("mock", "-pkg", "io", "-iface", "Unknown", "-type", "MockUnknown", "-package", "mocks")
+	assert.Equal(t, 1, code)
+	assert.Regexp(t, `^gowrtr mock: \[GOWRTR-\d+] .* \(caused at .+:\d+\)\n$`, stderr)
+}
@@ -0,0 +1,27 @@
+//go:build ignore
+// +build ignore
+
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/moznion/gowrtr/generator"
+)
+
+func main() {
+	spec := generator.NewSpec(generator.NewSpecFile("user/user.go", generator.NewRoot(
+		generator.NewPackage("user"),
+		generator.NewNewline(),
+		generator.NewStruct("User").AddField("ID", "int64", `json:"id"`),
+	).Gofmt()))
+
+	out, err := spec.YAML()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err := os.Stdout.Write(out); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -61,7 +61,9 @@ func (ifg *AnonymousFunc) Invocation(funcInvocation *FuncInvocation) *AnonymousF
 }
 
 // Generate generates an anonymous func as golang code.
-func (ifg *AnonymousFunc) Generate(indentLevel int) (string, error) {
+func (ifg *AnonymousFunc) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, ifg.pathSegment())
+
 	indent := BuildIndent(indentLevel)
 
 	stmt := indent
@@ -70,8 +72,8 @@ func (ifg *AnonymousFunc) Generate(indentLevel int) (string, error) {
 	}
 	stmt += "func"
 
-	if ifg.anonymousFuncSignature == nil {
-		return "", errmsg.AnonymousFuncSignatureIsNilError(ifg.caller)
+	if err := firstError(ifg.validate()); err != nil {
+		return "", err
 	}
 
 	sig, err := ifg.anonymousFuncSignature.Generate(0)
@@ -103,3 +105,27 @@ func (ifg *AnonymousFunc) Generate(indentLevel int) (string, error) {
 
 	return stmt, nil
 }
+
+func (ifg *AnonymousFunc) validate() []error {
+	var errs []error
+	if ifg.anonymousFuncSignature == nil {
+		errs = append(errs, errmsg.AnonymousFuncSignatureIsNilError(ifg.caller))
+	}
+	return append(errs, validateLabels(ifg.statements)...)
+}
+
+func (ifg *AnonymousFunc) childStatements() []Statement {
+	var children []Statement
+	if ifg.anonymousFuncSignature != nil {
+		children = append(children, ifg.anonymousFuncSignature)
+	}
+	children = append(children, ifg.statements...)
+	if ifg.funcInvocation != nil {
+		children = append(children, ifg.funcInvocation)
+	}
+	return children
+}
+
+func (ifg *AnonymousFunc) pathSegment() errorPathSegment {
+	return errorPathSegment{name: "AnonymousFunc", caller: ifg.caller}
+}
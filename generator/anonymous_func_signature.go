@@ -11,11 +11,14 @@ type AnonymousFuncSignature struct {
 	funcParameters []*FuncParameter
 	returnTypes    []string
 	callers        []string
+	caller         string
 }
 
 // NewAnonymousFuncSignature returns a new `AnonymousFuncSignature`.
 func NewAnonymousFuncSignature() *AnonymousFuncSignature {
-	return &AnonymousFuncSignature{}
+	return &AnonymousFuncSignature{
+		caller: fetchClientCallerLine(),
+	}
 }
 
 // AddParameters adds parameters of function to `AnonymousFuncSignature`. This does "not" set, just add.
@@ -25,6 +28,7 @@ func (f *AnonymousFuncSignature) AddParameters(funcParameters ...*FuncParameter)
 		funcParameters: append(f.funcParameters, funcParameters...),
 		returnTypes:    f.returnTypes,
 		callers:        append(f.callers, fetchClientCallerLineAsSlice(len(funcParameters))...),
+		caller:         f.caller,
 	}
 }
 
@@ -35,6 +39,7 @@ func (f *AnonymousFuncSignature) Parameters(funcParameters ...*FuncParameter) *A
 		funcParameters: funcParameters,
 		returnTypes:    f.returnTypes,
 		callers:        fetchClientCallerLineAsSlice(len(funcParameters)),
+		caller:         f.caller,
 	}
 }
 
@@ -45,6 +50,7 @@ func (f *AnonymousFuncSignature) AddReturnTypes(returnTypes ...string) *Anonymou
 		funcParameters: f.funcParameters,
 		returnTypes:    append(f.returnTypes, returnTypes...),
 		callers:        f.callers,
+		caller:         f.caller,
 	}
 }
 
@@ -55,36 +61,29 @@ func (f *AnonymousFuncSignature) ReturnTypes(returnTypes ...string) *AnonymousFu
 		funcParameters: f.funcParameters,
 		returnTypes:    returnTypes,
 		callers:        f.callers,
+		caller:         f.caller,
 	}
 }
 
 // Generate generates a signature of the anonymous func as golang code.
-func (f *AnonymousFuncSignature) Generate(indentLevel int) (string, error) {
+func (f *AnonymousFuncSignature) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, f.pathSegment())
+
+	if err := firstError(f.validate()); err != nil {
+		return "", err
+	}
+
 	stmt := "("
 
-	typeExisted := true
-	typeMissingCaller := ""
 	params := make([]string, len(f.funcParameters))
 	for i, param := range f.funcParameters {
-		if param.name == "" {
-			return "", errmsg.FuncParameterNameIsEmptyErr(f.callers[i])
-		}
-
 		paramSet := param.name
-		typeExisted = param.typ != ""
-		if typeExisted {
+		if param.typ != "" {
 			paramSet += " " + param.typ
 		}
-		if !typeExisted {
-			typeMissingCaller = f.callers[i]
-		}
 		params[i] = paramSet
 	}
 
-	if !typeExisted {
-		return "", errmsg.LastFuncParameterTypeIsEmptyErr(typeMissingCaller)
-	}
-
 	stmt += strings.Join(params, ", ") + ")"
 
 	returnTypes := f.returnTypes
@@ -98,3 +97,38 @@ func (f *AnonymousFuncSignature) Generate(indentLevel int) (string, error) {
 	}
 	return stmt, nil
 }
+
+func (f *AnonymousFuncSignature) validate() []error {
+	var errs []error
+
+	typeExisted := true
+	typeMissingCaller := ""
+	for i, param := range f.funcParameters {
+		if param.name == "" {
+			errs = append(errs, errmsg.FuncParameterNameIsEmptyErr(f.callers[i]))
+		}
+		errs = appendIdentifierError(errs, param.name, f.callers[i])
+
+		typeExisted = param.typ != ""
+		if !typeExisted {
+			typeMissingCaller = f.callers[i]
+		}
+	}
+	if !typeExisted {
+		errs = append(errs, errmsg.LastFuncParameterTypeIsEmptyErr(typeMissingCaller))
+	}
+
+	return errs
+}
+
+func (f *AnonymousFuncSignature) warnings() []error {
+	var warnings []error
+	for i, param := range f.funcParameters {
+		warnings = appendShadowingWarning(warnings, param.name, f.callers[i])
+	}
+	return warnings
+}
+
+func (f *AnonymousFuncSignature) pathSegment() errorPathSegment {
+	return errorPathSegment{name: "AnonymousFuncSignature", caller: f.caller}
+}
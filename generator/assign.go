@@ -0,0 +1,227 @@
+package generator
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
+
+var compoundAssignOperators = map[string]bool{
+	"+=":  true,
+	"-=":  true,
+	"*=":  true,
+	"/=":  true,
+	"%=":  true,
+	"&=":  true,
+	"|=":  true,
+	"^=":  true,
+	"<<=": true,
+	">>=": true,
+	"&^=": true,
+}
+
+// Assign represents a code generator for assignment statement and short variable declaration.
+// It supports `=`, `:=` and compound operators (e.g. `+=`, `<<=`).
+//
+// example:
+// x := f()
+// a, b = b, a
+// x += 1
+type Assign struct {
+	leftHandSides  []string
+	operator       string
+	rightHandSides []Statement
+	caller         string
+}
+
+// NewAssign returns a new `Assign`.
+// `operator` should be `=`, `:=` or a compound assignment operator.
+// Each item of `rightHandSides` is generated as an expression, so it can receive a nested `Statement` like `AnonymousFunc` and `CompositeLiteral`.
+func NewAssign(leftHandSides []string, operator string, rightHandSides ...Statement) *Assign {
+	return &Assign{
+		leftHandSides:  leftHandSides,
+		operator:       operator,
+		rightHandSides: rightHandSides,
+		caller:         fetchClientCallerLine(),
+	}
+}
+
+// NewShortVarDecl returns a new `Assign` for short variable declaration (i.e. `:=`).
+func NewShortVarDecl(leftHandSides []string, rightHandSides ...Statement) *Assign {
+	return &Assign{
+		leftHandSides:  leftHandSides,
+		operator:       ":=",
+		rightHandSides: rightHandSides,
+		caller:         fetchClientCallerLine(),
+	}
+}
+
+// AddLeftHandSides adds left-hand side items to `Assign`. This does *not* set, just add.
+// This method returns a *new* `Assign`; it means this method acts as immutable.
+func (a *Assign) AddLeftHandSides(leftHandSides ...string) *Assign {
+	return &Assign{
+		leftHandSides:  append(a.leftHandSides, leftHandSides...),
+		operator:       a.operator,
+		rightHandSides: a.rightHandSides,
+		caller:         a.caller,
+	}
+}
+
+// LeftHandSides sets left-hand side items to `Assign`. This does *not* add, just set.
+// This method returns a *new* `Assign`; it means this method acts as immutable.
+func (a *Assign) LeftHandSides(leftHandSides ...string) *Assign {
+	return &Assign{
+		leftHandSides:  leftHandSides,
+		operator:       a.operator,
+		rightHandSides: a.rightHandSides,
+		caller:         a.caller,
+	}
+}
+
+// AddRightHandSides adds right-hand side items to `Assign`. This does *not* set, just add.
+// This method returns a *new* `Assign`; it means this method acts as immutable.
+func (a *Assign) AddRightHandSides(rightHandSides ...Statement) *Assign {
+	return &Assign{
+		leftHandSides:  a.leftHandSides,
+		operator:       a.operator,
+		rightHandSides: append(a.rightHandSides, rightHandSides...),
+		caller:         a.caller,
+	}
+}
+
+// RightHandSides sets right-hand side items to `Assign`. This does *not* add, just set.
+// This method returns a *new* `Assign`; it means this method acts as immutable.
+func (a *Assign) RightHandSides(rightHandSides ...Statement) *Assign {
+	return &Assign{
+		leftHandSides:  a.leftHandSides,
+		operator:       a.operator,
+		rightHandSides: rightHandSides,
+		caller:         a.caller,
+	}
+}
+
+// Generate generates an assignment statement as golang code.
+func (a *Assign) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, a.pathSegment())
+
+	if err := firstError(a.validate()); err != nil {
+		return "", err
+	}
+
+	values, err := generateExpressions(a.rightHandSides, indentLevel, a.caller)
+	if err != nil {
+		return "", err
+	}
+
+	indent := BuildIndent(indentLevel)
+	return indent + strings.Join(a.leftHandSides, ", ") + " " + a.operator + " " + strings.Join(values, ", ") + "\n", nil
+}
+
+func (a *Assign) validate() []error {
+	var errs []error
+
+	op := a.operator
+	isCompound := compoundAssignOperators[op]
+	if op != "=" && op != ":=" && !isCompound {
+		errs = append(errs, errmsg.AssignOperatorIsInvalidError(op, a.caller))
+	}
+
+	if len(a.leftHandSides) <= 0 {
+		errs = append(errs, errmsg.AssignLeftHandSideIsEmptyError(a.caller))
+	}
+	for _, lhs := range a.leftHandSides {
+		if lhs == "" {
+			errs = append(errs, errmsg.AssignLeftHandSideIsEmptyError(a.caller))
+			break
+		}
+	}
+
+	if len(a.rightHandSides) <= 0 {
+		errs = append(errs, errmsg.AssignRightHandSideIsEmptyError(a.caller))
+	}
+	for _, rhs := range a.rightHandSides {
+		if rhs == nil {
+			errs = append(errs, errmsg.AssignRightHandSideIsEmptyError(a.caller))
+			break
+		}
+	}
+
+	lhsCount := len(a.leftHandSides)
+	rhsCount := len(a.rightHandSides)
+	if lhsCount <= 0 || rhsCount <= 0 {
+		return errs
+	}
+	if isCompound && (lhsCount != 1 || rhsCount != 1) {
+		errs = append(errs, errmsg.CompoundAssignOperandIsNotSingleError(op, a.caller))
+	} else if lhsCount != rhsCount && !(rhsCount == 1 && isMultiValueExpression(a.rightHandSides[0], lhsCount)) {
+		errs = append(errs, errmsg.AssignOperandsCountMismatchError(lhsCount, rhsCount, a.caller))
+	}
+
+	return errs
+}
+
+// isMultiValueExpression reports whether the single right-hand side can be assigned to `lhsCount` left-hand sides;
+// that is the function call (e.g. `a, b := f()`), or the comma-ok expression for two left-hand sides (e.g. `v, ok := m[k]`).
+func isMultiValueExpression(rhs Statement, lhsCount int) bool {
+	switch s := rhs.(type) {
+	case *Call:
+		return true
+	case *AnonymousFunc:
+		return s.funcInvocation != nil
+	case *RawStatement:
+		expr, err := parser.ParseExpr(s.statement)
+		if err != nil {
+			return false
+		}
+		for {
+			paren, ok := expr.(*ast.ParenExpr)
+			if !ok {
+				break
+			}
+			expr = paren.X
+		}
+
+		switch e := expr.(type) {
+		case *ast.CallExpr:
+			return true
+		case *ast.IndexExpr, *ast.TypeAssertExpr:
+			return lhsCount == 2
+		case *ast.UnaryExpr:
+			return e.Op == token.ARROW && lhsCount == 2
+		}
+	}
+	return false
+}
+
+func (a *Assign) childStatements() []Statement {
+	return nonNilStatements(a.rightHandSides)
+}
+
+// generateExpressions generates each statement as an expression; that means leading indent and trailing newline are trimmed.
+func generateExpressions(statements []Statement, indentLevel int, caller string) ([]string, error) {
+	exprs := make([]string, len(statements))
+	for i, statement := range statements {
+		if statement == nil {
+			return nil, errmsg.AssignRightHandSideIsEmptyError(caller)
+		}
+
+		gen, err := statement.Generate(indentLevel)
+		if err != nil {
+			return nil, err
+		}
+
+		gen = strings.TrimSpace(gen)
+		if gen == "" {
+			return nil, errmsg.AssignRightHandSideIsEmptyError(caller)
+		}
+		exprs[i] = gen
+	}
+	return exprs, nil
+}
+
+func (a *Assign) pathSegment() errorPathSegment {
+	return errorPathSegment{name: "Assign", caller: a.caller}
+}
@@ -0,0 +1,16 @@
+package generator
+
+import (
+	"fmt"
+	"log"
+)
+
+func ExampleAssign_Generate() {
+	generator := NewShortVarDecl([]string{"v", "err"}, NewRawStatement("f()"))
+
+	generated, err := generator.Generate(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(generated)
+}
@@ -0,0 +1,160 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldGenerateAssign(t *testing.T) {
+	generator := NewShortVarDecl([]string{"x"}, NewRawStatement("f()"))
+
+	{
+		gen, err := generator.Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "x := f()\n", gen)
+	}
+
+	{
+		gen, err := generator.Generate(2)
+		assert.NoError(t, err)
+		assert.Equal(t, "\t\tx := f()\n", gen)
+	}
+
+	{
+		gen, err := NewAssign([]string{"a", "b"}, "=", NewRawStatement("b"), NewRawStatement("a")).Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "a, b = b, a\n", gen)
+	}
+
+	{
+		gen, err := NewAssign([]string{"x"}, "+=", NewRawStatement("1")).Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "x += 1\n", gen)
+	}
+
+	{
+		gen, err := NewAssign([]string{"x"}, "&^=", NewRawStatement("mask")).Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "x &^= mask\n", gen)
+	}
+}
+
+func TestShouldGenerateAssignWithSingleMultiValueRightHandSide(t *testing.T) {
+	gen, err := NewShortVarDecl([]string{"v", "err"}, NewRawStatement("f()")).Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, "v, err := f()\n", gen)
+}
+
+func TestShouldGenerateAssignWithCommaOkRightHandSide(t *testing.T) {
+	for _, rhs := range []string{"m[k]", "v.(string)", "<-ch"} {
+		gen, err := NewShortVarDecl([]string{"v", "ok"}, NewRawStatement(rhs)).Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "v, ok := "+rhs+"\n", gen)
+	}
+}
+
+func TestShouldGenerateAssignWithExpandingMethod(t *testing.T) {
+	generator := NewAssign(nil, "=").
+		AddLeftHandSides("a").
+		AddLeftHandSides("b").
+		AddRightHandSides(NewRawStatement("1")).
+		AddRightHandSides(NewRawStatement("2"))
+
+	{
+		gen, err := generator.Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "a, b = 1, 2\n", gen)
+	}
+
+	{
+		gen, err := generator.LeftHandSides("c").RightHandSides(NewRawStatement("3")).Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "c = 3\n", gen)
+	}
+}
+
+func TestShouldGenerateAssignWithNestedStatements(t *testing.T) {
+	generator := NewShortVarDecl(
+		[]string{"fn", "s"},
+		NewAnonymousFunc(
+			false,
+			NewAnonymousFuncSignature().ReturnTypes("bool"),
+			NewReturnStatement("true"),
+		),
+		NewCompositeLiteral("&Struct").AddFieldStr("foo", "bar"),
+	)
+
+	expected := `	fn, s := func() bool {
+		return true
+	}, &Struct{
+		foo: "bar",
+	}
+`
+	gen, err := generator.Generate(1)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+}
+
+func TestShouldGenerateAssignRaisesError(t *testing.T) {
+	errPattern := func(err error) *regexp.Regexp {
+		return regexp.MustCompile(`^\` + strings.Split(err.Error(), " ")[0])
+	}
+
+	{
+		_, err := NewAssign([]string{"x"}, "==", NewRawStatement("1")).Generate(0)
+		assert.Regexp(t, errPattern(errmsg.AssignOperatorIsInvalidError("", "")), err.Error())
+	}
+
+	{
+		_, err := NewAssign(nil, "=", NewRawStatement("1")).Generate(0)
+		assert.Regexp(t, errPattern(errmsg.AssignLeftHandSideIsEmptyError("")), err.Error())
+	}
+
+	{
+		_, err := NewAssign([]string{"x", ""}, "=", NewRawStatement("1")).Generate(0)
+		assert.Regexp(t, errPattern(errmsg.AssignLeftHandSideIsEmptyError("")), err.Error())
+	}
+
+	{
+		_, err := NewAssign([]string{"x"}, "=").Generate(0)
+		assert.Regexp(t, errPattern(errmsg.AssignRightHandSideIsEmptyError("")), err.Error())
+	}
+
+	{
+		_, err := NewAssign([]string{"x"}, "=", nil).Generate(0)
+		assert.Regexp(t, errPattern(errmsg.AssignRightHandSideIsEmptyError("")), err.Error())
+	}
+
+	{
+		_, err := NewAssign([]string{"x"}, "=", NewRawStatement("")).Generate(0)
+		assert.Regexp(t, errPattern(errmsg.AssignRightHandSideIsEmptyError("")), err.Error())
+	}
+
+	{
+		_, err := NewAssign([]string{"a", "b", "c"}, "=", NewRawStatement("1"), NewRawStatement("2")).Generate(0)
+		assert.Regexp(t, errPattern(errmsg.AssignOperandsCountMismatchError(0, 0, "")), err.Error())
+	}
+
+	{
+		// a single value is assigned to multiple names only if it returns multiple values
+		_, err := NewShortVarDecl([]string{"a", "b"}, NewRawStatement("1")).Generate(0)
+		assert.Regexp(t, errPattern(errmsg.AssignOperandsCountMismatchError(0, 0, "")), err.Error())
+
+		_, err = NewShortVarDecl([]string{"a", "b", "c"}, NewRawStatement("m[k]")).Generate(0)
+		assert.Regexp(t, errPattern(errmsg.AssignOperandsCountMismatchError(0, 0, "")), err.Error())
+	}
+
+	{
+		_, err := NewAssign([]string{"a", "b"}, "+=", NewRawStatement("1")).Generate(0)
+		assert.Regexp(t, errPattern(errmsg.CompoundAssignOperandIsNotSingleError("", "")), err.Error())
+	}
+
+	{
+		_, err := NewShortVarDecl([]string{"x"}, NewFunc(nil, NewFuncSignature(""))).Generate(0)
+		assert.Regexp(t, errPattern(errmsg.FuncNameIsEmptyError("")), err.Error())
+	}
+}
@@ -0,0 +1,77 @@
+package generator
+
+import (
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
+
+// Branch represents a code generator for branch statements; `break`, `continue` and `goto`.
+type Branch struct {
+	keyword string
+	label   string
+	caller  string
+}
+
+// NewBreak returns a new `Branch` that generates `break` statement.
+// `label` is an optional parameter. If this parameter is specified, it generates `break label`.
+func NewBreak(label ...string) *Branch {
+	l := ""
+	if len(label) > 0 {
+		l = label[0]
+	}
+	return &Branch{
+		keyword: "break",
+		label:   l,
+		caller:  fetchClientCallerLine(),
+	}
+}
+
+// NewContinue returns a new `Branch` that generates `continue` statement.
+// `label` is an optional parameter. If this parameter is specified, it generates `continue label`.
+func NewContinue(label ...string) *Branch {
+	l := ""
+	if len(label) > 0 {
+		l = label[0]
+	}
+	return &Branch{
+		keyword: "continue",
+		label:   l,
+		caller:  fetchClientCallerLine(),
+	}
+}
+
+// NewGoto returns a new `Branch` that generates `goto` statement.
+func NewGoto(label string) *Branch {
+	return &Branch{
+		keyword: "goto",
+		label:   label,
+		caller:  fetchClientCallerLine(),
+	}
+}
+
+// Generate generates a branch statement as golang code.
+func (b *Branch) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, b.pathSegment())
+
+	if err := firstError(b.validate()); err != nil {
+		return "", err
+	}
+
+	stmt := BuildIndent(indentLevel) + b.keyword
+	if b.label != "" {
+		stmt += " " + b.label
+	}
+	stmt += "\n"
+
+	return stmt, nil
+}
+
+func (b *Branch) validate() []error {
+	if b.keyword == "goto" && b.label == "" {
+		return []error{errmsg.GotoLabelIsEmptyError(b.caller)}
+	}
+	return nil
+}
+
+func (b *Branch) pathSegment() errorPathSegment {
+	return errorPathSegment{name: "Branch", caller: b.caller}
+}
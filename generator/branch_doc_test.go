@@ -0,0 +1,16 @@
+package generator
+
+import (
+	"fmt"
+	"log"
+)
+
+func ExampleBranch_Generate() {
+	generator := NewBreak("outer")
+
+	generated, err := generator.Generate(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(generated)
+}
@@ -0,0 +1,38 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldGenerateBranch(t *testing.T) {
+	for _, c := range []struct {
+		generator *Branch
+		expected  string
+	}{
+		{NewBreak(), "break\n"},
+		{NewBreak("outer"), "break outer\n"},
+		{NewContinue(), "continue\n"},
+		{NewContinue("outer"), "continue outer\n"},
+		{NewGoto("end"), "goto end\n"},
+	} {
+		gen, err := c.generator.Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, c.expected, gen)
+	}
+
+	gen, err := NewBreak().Generate(2)
+	assert.NoError(t, err)
+	assert.Equal(t, "\t\tbreak\n", gen)
+}
+
+func TestShouldGenerateBranchRaisesError(t *testing.T) {
+	_, err := NewGoto("").Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.GotoLabelIsEmptyError("").Error(), " ")[0],
+	), err.Error())
+}
@@ -0,0 +1,146 @@
+package generator
+
+// Builder represents a code generator for the immutable fluent builder of the struct.
+// It generates the builder type, `NewXxxBuilder()` constructor, a setter method for each field and `Build()` method.
+//
+// Each setter method returns a *new* builder, so a builder can be reused and derived like the generators of this library.
+// The tag of each field can control the builder:
+//
+//   - `default:"value"`: the constructor sets the default value; the value of the string field is quoted, and the others are used as expressions.
+//   - `required:"true"`: `Build()` returns an error if the field is not set (i.e. it is still the zero value).
+//
+// If there is a required field, the generated code depends on `errors` package (and `reflect` package for the required field
+// of the defined type, e.g. `time.Duration`); please add the imports, or enable `goimports`.
+type Builder struct {
+	structure       *Struct
+	builderTypeName string
+	caller          string
+}
+
+// NewBuilder returns a new `Builder` for the struct.
+// This doesn't generate the struct itself, so please generate that separately.
+func NewBuilder(structure *Struct) *Builder {
+	return &Builder{
+		structure: structure,
+		caller:    fetchClientCallerLine(),
+	}
+}
+
+// BuilderTypeName sets the name of the builder type. By default, it is the struct name with `Builder` suffix (e.g. `ServerBuilder`).
+// This method returns a *new* `Builder`; it means this method acts as immutable.
+func (b *Builder) BuilderTypeName(name string) *Builder {
+	return &Builder{
+		structure:       b.structure,
+		builderTypeName: name,
+		caller:          b.caller,
+	}
+}
+
+// Generate generates the builder as golang code.
+func (b *Builder) Generate(indentLevel int) (generated string, err error) {
+	defer annotateComposedErrorPath(&err, b.pathSegment())
+
+	if err := firstError(b.validate()); err != nil {
+		return "", err
+	}
+
+	return generateStatementGroups(b.statementGroups(), indentLevel)
+}
+
+func (b *Builder) statementGroups() [][]Statement {
+	structName := b.structure.name
+	builderType := b.builderTypeName
+	if builderType == "" {
+		builderType = structName + "Builder"
+	}
+
+	recv := "b"
+	for _, field := range b.structure.fields {
+		if ToUnexportedName(field.name) == recv {
+			recv += "_"
+			break
+		}
+	}
+
+	builderStruct := NewStruct(builderType)
+	defaults := NewCompositeLiteral("&" + builderType)
+	built := NewCompositeLiteral("&" + structName)
+	for _, field := range b.structure.fields {
+		builderField := ToUnexportedName(field.name)
+		builderStruct = builderStruct.AddField(builderField, field.typ)
+		if value, ok := field.defaultValue(); ok {
+			defaults = defaults.AddField(builderField, NewRawStatement(value))
+		}
+		built = built.AddField(field.name, NewRawStatement(recv+"."+builderField))
+	}
+
+	constructorName := "New" + ToExportedName(builderType)
+	groups := [][]Statement{
+		{
+			NewCommentf(" %s is an immutable builder of %s.", builderType, structName),
+			builderStruct,
+		},
+		{
+			NewCommentf(" %s returns a new %s with the default values.", constructorName, builderType),
+			NewFunc(
+				nil,
+				NewFuncSignature(constructorName).AddReturnTypes("*"+builderType),
+				NewReturnStatement().AddReturnStatements(defaults),
+			),
+		},
+	}
+
+	for _, field := range b.structure.fields {
+		methodName := ToExportedName(field.name)
+		param := ToUnexportedName(field.name)
+		copied := "copied"
+		if copied == param {
+			copied += "_"
+		}
+
+		groups = append(groups, []Statement{
+			NewCommentf(" %s sets %s of %s.", methodName, field.name, structName),
+			NewCommentf(" This method returns a *new* %s; it means this method acts as immutable.", builderType),
+			NewFunc(
+				NewFuncReceiver(recv, "*"+builderType),
+				NewFuncSignature(methodName).AddParameters(NewFuncParameter(param, field.typ)).AddReturnTypes("*"+builderType),
+				NewShortVarDecl([]string{copied}, NewRawStatement("*"+recv)),
+				NewAssign([]string{copied + "." + param}, "=", NewRawStatement(param)),
+				NewReturnStatement("&"+copied),
+			),
+		})
+	}
+
+	statements := requiredFieldChecks(b.structure, func(field *StructField) string {
+		return recv + "." + ToUnexportedName(field.name)
+	})
+	statements = append(statements, NewReturnStatement().AddReturnStatements(built, NewRawStatement("nil")))
+
+	groups = append(groups, []Statement{
+		NewCommentf(" Build returns a new %s with the values of the builder.", structName),
+		NewFunc(
+			NewFuncReceiver(recv, "*"+builderType),
+			NewFuncSignature("Build").AddReturnTypes("*"+structName, "error"),
+			statements...,
+		),
+	})
+
+	return groups
+}
+
+func (b *Builder) validate() []error {
+	return appendIdentifierError(validateDerivedStruct(b.structure, b.caller), b.builderTypeName, b.caller)
+}
+
+func (b *Builder) childStatements() []Statement {
+	if len(b.validate()) > 0 {
+		return nil
+	}
+	return flattenStatementGroups(b.statementGroups())
+}
+
+func (b *Builder) pathSegment() errorPathSegment {
+	return errorPathSegment{name: namedPathSegment("Builder", derivedStructName(b.structure)), caller: b.caller}
+}
+
+func (b *Builder) composesStatements() {}
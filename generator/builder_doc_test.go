@@ -0,0 +1,23 @@
+package generator
+
+import (
+	"fmt"
+	"log"
+)
+
+func ExampleBuilder_Generate() {
+	structure := NewStruct("Server").
+		AddField("Host", "string", `required:"true"`).
+		AddField("Port", "int", `default:"8080"`)
+
+	generated, err := NewRoot(
+		NewPackage("mypkg"),
+		NewImport("errors"),
+		structure,
+		NewBuilder(structure),
+	).Gofmt().Generate(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(generated)
+}
@@ -0,0 +1,126 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldGenerateBuilder(t *testing.T) {
+	structure := NewStruct("Server").
+		AddField("Host", "string", `required:"true"`).
+		AddField("Port", "int", `default:"8080"`)
+
+	expected := `// ServerBuilder is an immutable builder of Server.
+type ServerBuilder struct {
+	host string
+	port int
+}
+
+// NewServerBuilder returns a new ServerBuilder with the default values.
+func NewServerBuilder() *ServerBuilder {
+	return &ServerBuilder{
+		port: 8080,
+	}
+}
+
+// Host sets Host of Server.
+// This method returns a *new* ServerBuilder; it means this method acts as immutable.
+func (b *ServerBuilder) Host(host string) *ServerBuilder {
+	copied := *b
+	copied.host = host
+	return &copied
+}
+
+// Port sets Port of Server.
+// This method returns a *new* ServerBuilder; it means this method acts as immutable.
+func (b *ServerBuilder) Port(port int) *ServerBuilder {
+	copied := *b
+	copied.port = port
+	return &copied
+}
+
+// Build returns a new Server with the values of the builder.
+func (b *ServerBuilder) Build() (*Server, error) {
+	if b.host == "" {
+		return nil, errors.New("Server: Host is required")
+	}
+	return &Server{
+		Host: b.host,
+		Port: b.port,
+	}, nil
+}
+`
+
+	gen, err := NewBuilder(structure).Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+}
+
+func TestShouldGenerateBuilderWithoutConflictingNames(t *testing.T) {
+	structure := NewStruct("Pair").
+		AddField("B", "*int").
+		AddField("Copied", "bool")
+
+	expected := `// PairFactory is an immutable builder of Pair.
+type PairFactory struct {
+	b *int
+	copied bool
+}
+
+// NewPairFactory returns a new PairFactory with the default values.
+func NewPairFactory() *PairFactory {
+	return &PairFactory{
+	}
+}
+
+// B sets B of Pair.
+// This method returns a *new* PairFactory; it means this method acts as immutable.
+func (b_ *PairFactory) B(b *int) *PairFactory {
+	copied := *b_
+	copied.b = b
+	return &copied
+}
+
+// Copied sets Copied of Pair.
+// This method returns a *new* PairFactory; it means this method acts as immutable.
+func (b_ *PairFactory) Copied(copied bool) *PairFactory {
+	copied_ := *b_
+	copied_.copied = copied
+	return &copied_
+}
+
+// Build returns a new Pair with the values of the builder.
+func (b_ *PairFactory) Build() (*Pair, error) {
+	return &Pair{
+		B: b_.b,
+		Copied: b_.copied,
+	}, nil
+}
+`
+
+	gen, err := NewBuilder(structure).BuilderTypeName("PairFactory").Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+}
+
+func TestShouldRaiseErrorWhenBuilderIsInvalid(t *testing.T) {
+	_, err := NewBuilder(nil).Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.DerivedStructIsNilError("").Error(), " ")[0],
+	), err.Error())
+
+	_, err = NewBuilder(NewStruct("")).Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.StructNameIsNilErr("").Error(), " ")[0],
+	), err.Error())
+
+	_, err = NewBuilder(NewStruct("Server")).BuilderTypeName("func").Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.IdentifierIsKeywordError("", "").Error(), " ")[0],
+	), err.Error())
+}
@@ -0,0 +1,212 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
+
+// Call represents a code generator for call expression.
+// It can be used as a statement, and also as a value of other generators (e.g. `ReturnStatement`, `Assign` and `CompositeLiteral`).
+//
+// example:
+// client.Do(ctx, req)
+// b.Where(x).Limit(10).Build()
+// append(xs, ys...)
+type Call struct {
+	receiver           *Call
+	callee             string
+	typeArguments      []string
+	arguments          []Statement
+	spread             bool
+	multilineArguments bool
+	caller             string
+}
+
+// NewCall returns a new `Call`.
+// `callee` is an expression of the function to call (e.g. `fmt.Println`).
+// Each item of `arguments` is generated as an expression, so it can receive a nested `Statement` like `AnonymousFunc` and `CompositeLiteral`.
+func NewCall(callee string, arguments ...Statement) *Call {
+	return &Call{
+		callee:    callee,
+		arguments: arguments,
+		caller:    fetchClientCallerLine(),
+	}
+}
+
+// Chain returns a new `Call` that calls the method on the result of this call (e.g. `.Limit(10)` of `b.Where(x).Limit(10)`).
+func (c *Call) Chain(method string, arguments ...Statement) *Call {
+	return &Call{
+		receiver:  c,
+		callee:    method,
+		arguments: arguments,
+		caller:    fetchClientCallerLine(),
+	}
+}
+
+// AddArguments adds arguments to `Call`. This does *not* set, just add.
+// This method returns a *new* `Call`; it means this method acts as immutable.
+func (c *Call) AddArguments(arguments ...Statement) *Call {
+	return &Call{
+		receiver:           c.receiver,
+		callee:             c.callee,
+		typeArguments:      c.typeArguments,
+		arguments:          append(c.arguments, arguments...),
+		spread:             c.spread,
+		multilineArguments: c.multilineArguments,
+		caller:             c.caller,
+	}
+}
+
+// Arguments sets arguments to `Call`. This does *not* add, just set.
+// This method returns a *new* `Call`; it means this method acts as immutable.
+func (c *Call) Arguments(arguments ...Statement) *Call {
+	return &Call{
+		receiver:           c.receiver,
+		callee:             c.callee,
+		typeArguments:      c.typeArguments,
+		arguments:          arguments,
+		spread:             c.spread,
+		multilineArguments: c.multilineArguments,
+		caller:             c.caller,
+	}
+}
+
+// TypeArguments sets type arguments of generic function to `Call` (e.g. `[K, V]` of `Map[K, V](m)`).
+// This method returns a *new* `Call`; it means this method acts as immutable.
+func (c *Call) TypeArguments(typeArguments ...string) *Call {
+	return &Call{
+		receiver:           c.receiver,
+		callee:             c.callee,
+		typeArguments:      typeArguments,
+		arguments:          c.arguments,
+		spread:             c.spread,
+		multilineArguments: c.multilineArguments,
+		caller:             c.caller,
+	}
+}
+
+// WithSpread specifies whether the last argument is spread as variadic arguments (i.e. `args...`) or not.
+// Default value is `false`.
+// This method returns a *new* `Call`; it means this method acts as immutable.
+func (c *Call) WithSpread(spread bool) *Call {
+	return &Call{
+		receiver:           c.receiver,
+		callee:             c.callee,
+		typeArguments:      c.typeArguments,
+		arguments:          c.arguments,
+		spread:             spread,
+		multilineArguments: c.multilineArguments,
+		caller:             c.caller,
+	}
+}
+
+// WithMultilineArguments specifies whether the arguments are wrapped one per line or not.
+// Default value is `false`.
+// This method returns a *new* `Call`; it means this method acts as immutable.
+func (c *Call) WithMultilineArguments(multiline bool) *Call {
+	return &Call{
+		receiver:           c.receiver,
+		callee:             c.callee,
+		typeArguments:      c.typeArguments,
+		arguments:          c.arguments,
+		spread:             c.spread,
+		multilineArguments: multiline,
+		caller:             c.caller,
+	}
+}
+
+// Generate generates a call expression as golang code.
+func (c *Call) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, c.pathSegment())
+
+	expr, err := c.generateExpression(indentLevel)
+	if err != nil {
+		return "", err
+	}
+	return BuildIndent(indentLevel) + expr + "\n", nil
+}
+
+func (c *Call) generateExpression(indentLevel int) (string, error) {
+	if err := firstError(c.validate()); err != nil {
+		return "", err
+	}
+
+	expr := ""
+	if c.receiver != nil {
+		receiver, err := c.receiver.generateExpression(indentLevel)
+		if err != nil {
+			return "", err
+		}
+		expr += receiver + "."
+	}
+	expr += c.callee
+
+	if len(c.typeArguments) > 0 {
+		expr += "[" + strings.Join(c.typeArguments, ", ") + "]"
+	}
+
+	argIndentLevel := indentLevel
+	if c.multilineArguments {
+		argIndentLevel++
+	}
+
+	args := make([]string, len(c.arguments))
+	for i, argument := range c.arguments {
+		gen, err := argument.Generate(argIndentLevel)
+		if err != nil {
+			return "", err
+		}
+
+		gen = strings.TrimSpace(gen)
+		if gen == "" {
+			return "", errmsg.CallArgumentIsEmptyError(c.caller)
+		}
+		args[i] = gen
+	}
+	if c.spread {
+		args[len(args)-1] += "..."
+	}
+
+	if c.multilineArguments && len(args) > 0 {
+		nextIndent := BuildIndent(argIndentLevel)
+		return expr + "(\n" + nextIndent + strings.Join(args, ",\n"+nextIndent) + ",\n" + BuildIndent(indentLevel) + ")", nil
+	}
+
+	return expr + "(" + strings.Join(args, ", ") + ")", nil
+}
+
+func (c *Call) pathSegment() errorPathSegment {
+	return errorPathSegment{name: namedPathSegment("Call", c.callee), caller: c.caller}
+}
+
+func (c *Call) validate() []error {
+	var errs []error
+	if c.callee == "" {
+		errs = append(errs, errmsg.CallCalleeIsEmptyError(c.caller))
+	}
+	for _, typ := range c.typeArguments {
+		if typ == "" {
+			errs = append(errs, errmsg.CallTypeArgumentIsEmptyError(c.caller))
+			break
+		}
+	}
+	if c.spread && len(c.arguments) <= 0 {
+		errs = append(errs, errmsg.CallSpreadWithoutArgumentsError(c.caller))
+	}
+	for _, argument := range c.arguments {
+		if argument == nil {
+			errs = append(errs, errmsg.CallArgumentIsEmptyError(c.caller))
+			break
+		}
+	}
+	return errs
+}
+
+func (c *Call) childStatements() []Statement {
+	children := nonNilStatements(c.arguments)
+	if c.receiver != nil {
+		children = append([]Statement{c.receiver}, children...)
+	}
+	return children
+}
@@ -0,0 +1,18 @@
+package generator
+
+import (
+	"fmt"
+	"log"
+)
+
+func ExampleCall_Generate() {
+	generator := NewCall("b.Where", NewRawStatement("x")).
+		Chain("Limit", NewRawStatement("10")).
+		Chain("Build")
+
+	generated, err := generator.Generate(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(generated)
+}
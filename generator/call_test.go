@@ -0,0 +1,174 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldGenerateCall(t *testing.T) {
+	generator := NewCall("client.Do", NewRawStatement("ctx"), NewRawStatement("req"))
+
+	{
+		gen, err := generator.Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "client.Do(ctx, req)\n", gen)
+	}
+
+	{
+		gen, err := generator.Generate(2)
+		assert.NoError(t, err)
+		assert.Equal(t, "\t\tclient.Do(ctx, req)\n", gen)
+	}
+
+	{
+		gen, err := NewCall("f").Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "f()\n", gen)
+	}
+
+	{
+		gen, err := generator.Arguments(NewRawStatement("ctx")).AddArguments(NewRawStatement("req2")).Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "client.Do(ctx, req2)\n", gen)
+	}
+}
+
+func TestShouldGenerateCallWithMethodChain(t *testing.T) {
+	generator := NewCall("b.Where", NewRawStatement("x")).
+		Chain("Limit", NewRawStatement("10")).
+		Chain("Build")
+
+	gen, err := generator.Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, "b.Where(x).Limit(10).Build()\n", gen)
+}
+
+func TestShouldGenerateCallWithTypeArgumentsAndSpread(t *testing.T) {
+	{
+		gen, err := NewCall("Map", NewRawStatement("m")).TypeArguments("K", "V").Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "Map[K, V](m)\n", gen)
+	}
+
+	{
+		gen, err := NewCall("append", NewRawStatement("xs"), NewRawStatement("ys")).WithSpread(true).Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "append(xs, ys...)\n", gen)
+	}
+}
+
+func TestShouldGenerateCallWithMultilineArguments(t *testing.T) {
+	generator := NewCall(
+		"http.HandleFunc",
+		NewRawStatement(`"/"`),
+		NewAnonymousFunc(
+			false,
+			NewAnonymousFuncSignature().Parameters(
+				NewFuncParameter("w", "http.ResponseWriter"),
+				NewFuncParameter("r", "*http.Request"),
+			),
+			NewRawStatement("w.WriteHeader(http.StatusOK)"),
+		),
+	)
+
+	{
+		expected := `	http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+`
+		gen, err := generator.Generate(1)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, gen)
+	}
+
+	{
+		expected := `	http.HandleFunc(
+		"/",
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		},
+	)
+`
+		gen, err := generator.WithMultilineArguments(true).Generate(1)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, gen)
+	}
+
+	{
+		gen, err := NewCall("f").WithMultilineArguments(true).Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "f()\n", gen)
+	}
+}
+
+func TestShouldGenerateCallAsValue(t *testing.T) {
+	call := NewCall("fmt.Sprintf", NewRawStatement(`"%d"`), NewRawStatement("i"))
+
+	{
+		gen, err := NewReturnStatement().AddReturnStatements(call, NewRawStatement("nil")).Generate(1)
+		assert.NoError(t, err)
+		assert.Equal(t, "\treturn fmt.Sprintf(\"%d\", i), nil\n", gen)
+	}
+
+	{
+		gen, err := NewShortVarDecl([]string{"s"}, call).Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "s := fmt.Sprintf(\"%d\", i)\n", gen)
+	}
+
+	{
+		gen, err := NewCompositeLiteral("&Struct").AddField("foo", call).Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "&Struct{\n\tfoo: fmt.Sprintf(\"%d\", i),\n}\n", gen)
+	}
+}
+
+func TestShouldGenerateCallRaisesError(t *testing.T) {
+	errPattern := func(err error) *regexp.Regexp {
+		return regexp.MustCompile(`^\` + strings.Split(err.Error(), " ")[0])
+	}
+
+	{
+		_, err := NewCall("").Generate(0)
+		assert.Regexp(t, errPattern(errmsg.CallCalleeIsEmptyError("")), err.Error())
+	}
+
+	{
+		_, err := NewCall("b.Where").Chain("").Generate(0)
+		assert.Regexp(t, errPattern(errmsg.CallCalleeIsEmptyError("")), err.Error())
+	}
+
+	{
+		_, err := NewCall("").Chain("Build").Generate(0)
+		assert.Regexp(t, errPattern(errmsg.CallCalleeIsEmptyError("")), err.Error())
+	}
+
+	{
+		_, err := NewCall("f", nil).Generate(0)
+		assert.Regexp(t, errPattern(errmsg.CallArgumentIsEmptyError("")), err.Error())
+	}
+
+	{
+		_, err := NewCall("f", NewRawStatement("")).Generate(0)
+		assert.Regexp(t, errPattern(errmsg.CallArgumentIsEmptyError("")), err.Error())
+	}
+
+	{
+		_, err := NewCall("f").TypeArguments("").Generate(0)
+		assert.Regexp(t, errPattern(errmsg.CallTypeArgumentIsEmptyError("")), err.Error())
+	}
+
+	{
+		_, err := NewCall("f").WithSpread(true).Generate(0)
+		assert.Regexp(t, errPattern(errmsg.CallSpreadWithoutArgumentsError("")), err.Error())
+	}
+
+	{
+		_, err := NewCall("f", NewFunc(nil, NewFuncSignature(""))).Generate(0)
+		assert.Regexp(t, errPattern(errmsg.FuncNameIsEmptyError("")), err.Error())
+	}
+}
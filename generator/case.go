@@ -2,6 +2,7 @@ package generator
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/moznion/gowrtr/internal/errmsg"
 )
@@ -9,15 +10,26 @@ import (
 // Case represents a code generator for `case` statement.
 // See also: https://tour.golang.org/flowcontrol/9
 type Case struct {
-	condition  string
-	statements []Statement
-	caller     string
+	conditions      []string
+	statements      []Statement
+	withFallthrough bool
+	caller          string
 }
 
 // NewCase creates a new `Case`.
 func NewCase(condition string, statements ...Statement) *Case {
 	return &Case{
-		condition:  condition,
+		conditions: []string{condition},
+		statements: statements,
+		caller:     fetchClientCallerLine(),
+	}
+}
+
+// NewTypeCase creates a new `Case` that has a list of types (e.g. `case int, string:`).
+// This is mainly used with `TypeSwitch`, but it can be also used as a case that has multiple values in `Switch`.
+func NewTypeCase(types []string, statements ...Statement) *Case {
+	return &Case{
+		conditions: types,
 		statements: statements,
 		caller:     fetchClientCallerLine(),
 	}
@@ -27,8 +39,10 @@ func NewCase(condition string, statements ...Statement) *Case {
 // This method returns a *new* `Case`; it means this method acts as immutable.
 func (c *Case) AddStatements(statements ...Statement) *Case {
 	return &Case{
-		condition:  c.condition,
-		statements: append(c.statements, statements...),
+		conditions:      c.conditions,
+		statements:      append(c.statements, statements...),
+		withFallthrough: c.withFallthrough,
+		caller:          c.caller,
 	}
 }
 
@@ -36,22 +50,37 @@ func (c *Case) AddStatements(statements ...Statement) *Case {
 // This method returns a *new* `Case`; it means this method acts as immutable.
 func (c *Case) Statements(statements ...Statement) *Case {
 	return &Case{
-		condition:  c.condition,
-		statements: statements,
+		conditions:      c.conditions,
+		statements:      statements,
+		withFallthrough: c.withFallthrough,
+		caller:          c.caller,
+	}
+}
+
+// WithFallthrough specifies whether append `fallthrough` statement to the end of the case or not.
+// Default value is `false`.
+// This method returns a *new* `Case`; it means this method acts as immutable.
+func (c *Case) WithFallthrough(with bool) *Case {
+	return &Case{
+		conditions:      c.conditions,
+		statements:      c.statements,
+		withFallthrough: with,
+		caller:          c.caller,
 	}
 }
 
 // Generate generates `case` statement as golang code.
-func (c *Case) Generate(indentLevel int) (string, error) {
-	condition := c.condition
-	if condition == "" {
-		return "", errmsg.CaseConditionIsEmptyError(c.caller)
+func (c *Case) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, c.pathSegment())
+
+	if err := firstError(c.validate()); err != nil {
+		return "", err
 	}
 
 	indent := BuildIndent(indentLevel)
 	nextIndentLevel := indentLevel + 1
 
-	stmt := fmt.Sprintf("%scase %s:\n", indent, condition)
+	stmt := fmt.Sprintf("%scase %s:\n", indent, strings.Join(c.conditions, ", "))
 	for _, statement := range c.statements {
 		gen, err := statement.Generate(nextIndentLevel)
 		if err != nil {
@@ -60,5 +89,29 @@ func (c *Case) Generate(indentLevel int) (string, error) {
 		stmt += gen
 	}
 
+	if c.withFallthrough {
+		stmt += BuildIndent(nextIndentLevel) + "fallthrough\n"
+	}
+
 	return stmt, nil
 }
+
+func (c *Case) validate() []error {
+	if len(c.conditions) <= 0 {
+		return []error{errmsg.CaseConditionIsEmptyError(c.caller)}
+	}
+	for _, condition := range c.conditions {
+		if condition == "" {
+			return []error{errmsg.CaseConditionIsEmptyError(c.caller)}
+		}
+	}
+	return nil
+}
+
+func (c *Case) childStatements() []Statement {
+	return c.statements
+}
+
+func (c *Case) pathSegment() errorPathSegment {
+	return errorPathSegment{name: "Case", caller: c.caller}
+}
@@ -68,3 +68,49 @@ func TestShouldGenerateCaseRaisesErrorWhenStatementsRaisesError(t *testing.T) {
 		`^\`+strings.Split(errmsg.FuncNameIsEmptyError("").Error(), " ")[0],
 	), err.Error())
 }
+
+func TestShouldGenerateTypeCase(t *testing.T) {
+	generator := NewTypeCase([]string{"int", "int64"}, NewComment(" integer"))
+
+	{
+		gen, err := generator.Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "case int, int64:\n\t// integer\n", gen)
+	}
+
+	{
+		_, err := NewTypeCase([]string{"int", ""}).Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.CaseConditionIsEmptyError("").Error(), " ")[0],
+		), err.Error())
+	}
+
+	{
+		_, err := NewTypeCase(nil).Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.CaseConditionIsEmptyError("").Error(), " ")[0],
+		), err.Error())
+	}
+}
+
+func TestShouldGenerateCaseWithFallthrough(t *testing.T) {
+	generator := NewCase("1", NewComment(" one")).WithFallthrough(true)
+
+	{
+		gen, err := generator.Generate(1)
+		assert.NoError(t, err)
+		assert.Equal(t, "\tcase 1:\n\t\t// one\n\t\tfallthrough\n", gen)
+	}
+
+	{
+		gen, err := generator.AddStatements(NewComment(" added")).Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "case 1:\n\t// one\n\t// added\n\tfallthrough\n", gen)
+	}
+
+	{
+		gen, err := generator.WithFallthrough(false).Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "case 1:\n\t// one\n", gen)
+	}
+}
@@ -9,12 +9,14 @@ package generator
 // }
 type CodeBlock struct {
 	statements []Statement
+	caller     string
 }
 
 // NewCodeBlock returns a new `CodeBlock`.
 func NewCodeBlock(statements ...Statement) *CodeBlock {
 	return &CodeBlock{
 		statements: statements,
+		caller:     fetchClientCallerLine(),
 	}
 }
 
@@ -23,6 +25,7 @@ func NewCodeBlock(statements ...Statement) *CodeBlock {
 func (c *CodeBlock) AddStatements(statements ...Statement) *CodeBlock {
 	return &CodeBlock{
 		statements: append(c.statements, statements...),
+		caller:     c.caller,
 	}
 }
 
@@ -31,11 +34,14 @@ func (c *CodeBlock) AddStatements(statements ...Statement) *CodeBlock {
 func (c *CodeBlock) Statements(statements ...Statement) *CodeBlock {
 	return &CodeBlock{
 		statements: statements,
+		caller:     c.caller,
 	}
 }
 
 // Generate generates plain code block as golang code.
-func (c *CodeBlock) Generate(indentLevel int) (string, error) {
+func (c *CodeBlock) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, c.pathSegment())
+
 	indent := BuildIndent(indentLevel)
 
 	stmt := indent + "{\n"
@@ -52,3 +58,11 @@ func (c *CodeBlock) Generate(indentLevel int) (string, error) {
 	stmt += indent + "}\n"
 	return stmt, nil
 }
+
+func (c *CodeBlock) childStatements() []Statement {
+	return c.statements
+}
+
+func (c *CodeBlock) pathSegment() errorPathSegment {
+	return errorPathSegment{name: "CodeBlock", caller: c.caller}
+}
@@ -5,12 +5,14 @@ import "fmt"
 // Comment represents a code generator for one line comment.
 type Comment struct {
 	comment string
+	caller  string
 }
 
 // NewComment returns a new `Comment`.
 func NewComment(comment string) *Comment {
 	return &Comment{
 		comment: comment,
+		caller:  fetchClientCallerLine(),
 	}
 }
 
@@ -19,6 +21,7 @@ func NewComment(comment string) *Comment {
 func NewCommentf(comment string, args ...interface{}) *Comment {
 	return &Comment{
 		comment: fmt.Sprintf(comment, args...),
+		caller:  fetchClientCallerLine(),
 	}
 }
 
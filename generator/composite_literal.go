@@ -18,12 +18,15 @@ type CompositeLiteral struct {
 	typ     string
 	fields  []*compositeLiteralField
 	callers []string
+	caller  string
 }
 
 // NewCompositeLiteral returns a new `CompositeLiteral`.
+// `typ` can be a multi-line type (e.g. `[]struct {...}`); its continuation lines are indented as same as the literal.
 func NewCompositeLiteral(typ string) *CompositeLiteral {
 	return &CompositeLiteral{
-		typ: typ,
+		typ:    typ,
+		caller: fetchClientCallerLine(),
 	}
 }
 
@@ -37,6 +40,7 @@ func (c *CompositeLiteral) AddField(key string, value Statement) *CompositeLiter
 			value: value,
 		}),
 		callers: append(c.callers, fetchClientCallerLine()),
+		caller:  c.caller,
 	}
 }
 
@@ -50,6 +54,7 @@ func (c *CompositeLiteral) AddFieldStr(key string, value string) *CompositeLiter
 			value: NewRawStatement(fmt.Sprintf(`"%s"`, value)),
 		}),
 		callers: append(c.callers, fetchClientCallerLine()),
+		caller:  c.caller,
 	}
 }
 
@@ -63,15 +68,24 @@ func (c *CompositeLiteral) AddFieldRaw(key string, value interface{}) *Composite
 			value: NewRawStatement(fmt.Sprintf("%v", value)),
 		}),
 		callers: append(c.callers, fetchClientCallerLine()),
+		caller:  c.caller,
 	}
 }
 
 // Generate generates composite literal block as golang code.
-func (c *CompositeLiteral) Generate(indentLevel int) (string, error) {
+func (c *CompositeLiteral) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, c.pathSegment())
+
+	if err := firstError(c.validate()); err != nil {
+		return "", err
+	}
+
 	indent := BuildIndent(indentLevel)
 	nextLevelIndent := BuildIndent(indentLevel + 1)
 
-	stmt := fmt.Sprintf("%s%s{\n", indent, c.typ)
+	// the continuation lines of the multi-line type (e.g. anonymous struct) are indented as same as the literal
+	typ := strings.ReplaceAll(c.typ, "\n", "\n"+indent)
+	stmt := fmt.Sprintf("%s%s{\n", indent, typ)
 	for i, field := range c.fields {
 		genValue, err := field.value.Generate(indentLevel + 1)
 		if err != nil {
@@ -94,3 +108,27 @@ func (c *CompositeLiteral) Generate(indentLevel int) (string, error) {
 
 	return stmt, nil
 }
+
+func (c *CompositeLiteral) validate() []error {
+	var errs []error
+	for i, field := range c.fields {
+		if field.value == nil {
+			errs = append(errs, errmsg.ValueOfCompositeLiteralIsEmptyError(c.callers[i]))
+		}
+	}
+	return errs
+}
+
+func (c *CompositeLiteral) childStatements() []Statement {
+	children := make([]Statement, 0, len(c.fields))
+	for _, field := range c.fields {
+		if field.value != nil {
+			children = append(children, field.value)
+		}
+	}
+	return children
+}
+
+func (c *CompositeLiteral) pathSegment() errorPathSegment {
+	return errorPathSegment{name: namedPathSegment("CompositeLiteral", c.typ), caller: c.caller}
+}
@@ -86,3 +86,20 @@ func TestShouldGenerateCompositeLiteralRaiseErrorWhenValueIsEmpty(t *testing.T)
 		`^\`+strings.Split(errmsg.ValueOfCompositeLiteralIsEmptyError("").Error(), " ")[0],
 	), err.Error())
 }
+
+func TestShouldGenerateCompositeLiteralWithMultilineType(t *testing.T) {
+	generator := NewCompositeLiteral("[]struct {\n\tname string\n}").
+		AddField("", NewCompositeLiteral("").AddFieldStr("name", "foo"))
+
+	expected := `	[]struct {
+		name string
+	}{
+		{
+			name: "foo",
+		},
+	}
+`
+	gen, err := generator.Generate(1)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+}
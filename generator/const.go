@@ -0,0 +1,184 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
+
+// ConstSpec represents a code generator for a spec of `const` declaration.
+// In the `const` block, the spec that has no value repeats the previous expression (e.g. the spec after `A T = iota`).
+//
+// example:
+// A, B T = 1, 2
+type ConstSpec struct {
+	names  []string
+	typ    string
+	values []Statement
+	caller string
+}
+
+// NewConstSpec returns a new `ConstSpec`.
+// `typ` is optional; `values` can be empty only if the spec is not the first one of the `const` block and `typ` is empty.
+func NewConstSpec(names []string, typ string, values ...Statement) *ConstSpec {
+	return &ConstSpec{
+		names:  names,
+		typ:    typ,
+		values: values,
+		caller: fetchClientCallerLine(),
+	}
+}
+
+// Generate generates a spec of `const` declaration as golang code.
+func (c *ConstSpec) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, c.pathSegment())
+
+	if err := firstError(c.validate()); err != nil {
+		return "", err
+	}
+
+	stmt := BuildIndent(indentLevel) + strings.Join(c.names, ", ")
+	if c.typ != "" {
+		stmt += " " + c.typ
+	}
+
+	if len(c.values) > 0 {
+		values, err := generateExpressions(c.values, indentLevel, c.caller)
+		if err != nil {
+			return "", err
+		}
+		stmt += " = " + strings.Join(values, ", ")
+	}
+	stmt += "\n"
+
+	return stmt, nil
+}
+
+func (c *ConstSpec) validate() []error {
+	var errs []error
+
+	if len(c.names) <= 0 {
+		errs = append(errs, errmsg.ConstNameIsEmptyError(c.caller))
+	}
+	for _, name := range c.names {
+		if name == "" {
+			errs = append(errs, errmsg.ConstNameIsEmptyError(c.caller))
+			break
+		}
+		errs = appendIdentifierError(errs, name, c.caller)
+	}
+
+	if c.typ != "" && len(c.values) <= 0 {
+		errs = append(errs, errmsg.ConstTypeWithoutValueError(c.caller))
+	}
+	for _, value := range c.values {
+		if value == nil {
+			errs = append(errs, errmsg.AssignRightHandSideIsEmptyError(c.caller))
+			break
+		}
+	}
+
+	nameCount := len(c.names)
+	valueCount := len(c.values)
+	if nameCount > 0 && valueCount > 0 && nameCount != valueCount {
+		errs = append(errs, errmsg.AssignOperandsCountMismatchError(nameCount, valueCount, c.caller))
+	}
+
+	return errs
+}
+
+func (c *ConstSpec) childStatements() []Statement {
+	return nonNilStatements(c.values)
+}
+
+func (c *ConstSpec) pathSegment() errorPathSegment {
+	return errorPathSegment{name: namedPathSegment("ConstSpec", strings.Join(c.names, ", ")), caller: c.caller}
+}
+
+// Const represents a code generator for `const` declaration.
+// It generates a single line declaration if it has only one spec, otherwise it generates a `const` block.
+//
+// example:
+// const (A T = iota; B)
+type Const struct {
+	specs  []*ConstSpec
+	caller string
+}
+
+// NewConst returns a new `Const`.
+func NewConst(specs ...*ConstSpec) *Const {
+	return &Const{
+		specs:  specs,
+		caller: fetchClientCallerLine(),
+	}
+}
+
+// AddSpecs adds specs to `Const`. This does *not* set, just add.
+// This method returns a *new* `Const`; it means this method acts as immutable.
+func (c *Const) AddSpecs(specs ...*ConstSpec) *Const {
+	return &Const{
+		specs:  append(c.specs, specs...),
+		caller: c.caller,
+	}
+}
+
+// Specs sets specs to `Const`. This does *not* add, just set.
+// This method returns a *new* `Const`; it means this method acts as immutable.
+func (c *Const) Specs(specs ...*ConstSpec) *Const {
+	return &Const{
+		specs:  specs,
+		caller: c.caller,
+	}
+}
+
+// Generate generates `const` declaration as golang code.
+func (c *Const) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, c.pathSegment())
+
+	if err := firstError(c.validate()); err != nil {
+		return "", err
+	}
+
+	indent := BuildIndent(indentLevel)
+
+	if len(c.specs) == 1 {
+		spec, err := c.specs[0].Generate(0)
+		if err != nil {
+			return "", err
+		}
+		return indent + "const " + spec, nil
+	}
+
+	stmt := indent + "const (\n"
+	for _, spec := range c.specs {
+		gen, err := spec.Generate(indentLevel + 1)
+		if err != nil {
+			return "", err
+		}
+		stmt += gen
+	}
+	stmt += indent + ")\n"
+
+	return stmt, nil
+}
+
+func (c *Const) validate() []error {
+	if len(c.specs) > 0 && c.specs[0] != nil && len(c.specs[0].values) <= 0 {
+		return []error{errmsg.ConstValueIsEmptyError(c.specs[0].caller)}
+	}
+	return nil
+}
+
+func (c *Const) childStatements() []Statement {
+	children := make([]Statement, 0, len(c.specs))
+	for _, spec := range c.specs {
+		if spec != nil {
+			children = append(children, spec)
+		}
+	}
+	return children
+}
+
+func (c *Const) pathSegment() errorPathSegment {
+	return errorPathSegment{name: "Const", caller: c.caller}
+}
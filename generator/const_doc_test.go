@@ -0,0 +1,21 @@
+package generator
+
+import (
+	"fmt"
+	"log"
+)
+
+func ExampleConst_Generate() {
+	generator := NewConst(
+		NewConstSpec([]string{"KindA"}, "Kind", NewRawStatement("iota")),
+		NewConstSpec([]string{"KindB"}, ""),
+	).AddSpecs(
+		NewConstSpec([]string{"defaultName"}, "", NewRawStatement(`"foo"`)),
+	)
+
+	generated, err := generator.Generate(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(generated)
+}
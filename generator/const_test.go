@@ -0,0 +1,77 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldGenerateConst(t *testing.T) {
+	{
+		gen, err := NewConst(NewConstSpec([]string{"x"}, "int", NewRawStatement("1"))).Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "const x int = 1\n", gen)
+	}
+
+	{
+		gen, err := NewConst(NewConstSpec([]string{"a", "b"}, "", NewRawStatement("1"), NewRawStatement(`"b"`))).Generate(1)
+		assert.NoError(t, err)
+		assert.Equal(t, "\tconst a, b = 1, \"b\"\n", gen)
+	}
+
+	{
+		gen, err := NewConst(
+			NewConstSpec([]string{"A"}, "T", NewRawStatement("iota")),
+		).AddSpecs(
+			NewConstSpec([]string{"B"}, ""),
+			NewConstSpec([]string{"C"}, ""),
+		).Generate(0)
+		assert.NoError(t, err)
+		expected := `const (
+	A T = iota
+	B
+	C
+)
+`
+		assert.Equal(t, expected, gen)
+	}
+
+	{
+		gen, err := NewConst(NewConstSpec([]string{"x"}, "int", NewRawStatement("1"))).Specs().Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "const (\n)\n", gen)
+	}
+}
+
+func TestShouldGenerateConstRaisesError(t *testing.T) {
+	_, err := NewConst(NewConstSpec([]string{"x"}, "")).Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.ConstValueIsEmptyError("").Error(), " ")[0],
+	), err.Error())
+
+	_, err = NewConst(NewConstSpec([]string{""}, "", NewRawStatement("1"))).Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.ConstNameIsEmptyError("").Error(), " ")[0],
+	), err.Error())
+
+	_, err = NewConst(
+		NewConstSpec([]string{"A"}, "int", NewRawStatement("1")),
+		NewConstSpec([]string{"B"}, "int"),
+	).Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.ConstTypeWithoutValueError("").Error(), " ")[0],
+	), err.Error())
+
+	_, err = NewConst(NewConstSpec([]string{"a", "b"}, "", NewRawStatement("f()"))).Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.AssignOperandsCountMismatchError(0, 0, "").Error(), " ")[0],
+	), err.Error())
+
+	_, err = NewConst(NewConstSpec([]string{"type"}, "", NewRawStatement("1"))).Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.IdentifierIsKeywordError("", "").Error(), " ")[0],
+	), err.Error())
+}
@@ -5,12 +5,14 @@ import "fmt"
 // DefaultCase represents a code generator for `default` block of `switch-case` notation.
 type DefaultCase struct {
 	statements []Statement
+	caller     string
 }
 
 // NewDefaultCase returns a new `DefaultCase`.
 func NewDefaultCase(statements ...Statement) *DefaultCase {
 	return &DefaultCase{
 		statements: statements,
+		caller:     fetchClientCallerLine(),
 	}
 }
 
@@ -19,6 +21,7 @@ func NewDefaultCase(statements ...Statement) *DefaultCase {
 func (d *DefaultCase) AddStatements(statements ...Statement) *DefaultCase {
 	return &DefaultCase{
 		statements: append(d.statements, statements...),
+		caller:     d.caller,
 	}
 }
 
@@ -27,11 +30,14 @@ func (d *DefaultCase) AddStatements(statements ...Statement) *DefaultCase {
 func (d *DefaultCase) Statements(statements ...Statement) *DefaultCase {
 	return &DefaultCase{
 		statements: statements,
+		caller:     d.caller,
 	}
 }
 
 // Generate generates `default` block as golang code.
-func (d *DefaultCase) Generate(indentLevel int) (string, error) {
+func (d *DefaultCase) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, d.pathSegment())
+
 	indent := BuildIndent(indentLevel)
 	nextIndentLevel := indentLevel + 1
 
@@ -46,3 +52,11 @@ func (d *DefaultCase) Generate(indentLevel int) (string, error) {
 
 	return stmt, nil
 }
+
+func (d *DefaultCase) childStatements() []Statement {
+	return d.statements
+}
+
+func (d *DefaultCase) pathSegment() errorPathSegment {
+	return errorPathSegment{name: "DefaultCase", caller: d.caller}
+}
@@ -0,0 +1,74 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
+
+// Defer represents a code generator for `defer` statement.
+//
+// example:
+// defer f.Close()
+type Defer struct {
+	statement Statement
+	caller    string
+}
+
+// NewDefer returns a new `Defer`.
+// `statement` is generated as the deferred call; it can receive a nested `Statement` like `AnonymousFunc` with invocation.
+func NewDefer(statement Statement) *Defer {
+	return &Defer{
+		statement: statement,
+		caller:    fetchClientCallerLine(),
+	}
+}
+
+// Generate generates `defer` statement as golang code.
+func (d *Defer) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, d.pathSegment())
+
+	if err := firstError(d.validate()); err != nil {
+		return "", err
+	}
+
+	call, err := generateCallExpression(d.statement, indentLevel)
+	if err != nil {
+		return "", err
+	}
+	if call == "" {
+		return "", errmsg.DeferStatementIsEmptyError(d.caller)
+	}
+
+	return BuildIndent(indentLevel) + "defer " + call + "\n", nil
+}
+
+func generateCallExpression(statement Statement, indentLevel int) (string, error) {
+	if statement == nil {
+		return "", nil
+	}
+
+	gen, err := statement.Generate(indentLevel)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(gen), nil
+}
+
+func (d *Defer) validate() []error {
+	if d.statement == nil {
+		return []error{errmsg.DeferStatementIsEmptyError(d.caller)}
+	}
+	return nil
+}
+
+func (d *Defer) childStatements() []Statement {
+	if d.statement == nil {
+		return nil
+	}
+	return []Statement{d.statement}
+}
+
+func (d *Defer) pathSegment() errorPathSegment {
+	return errorPathSegment{name: "Defer", caller: d.caller}
+}
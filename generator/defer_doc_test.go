@@ -0,0 +1,16 @@
+package generator
+
+import (
+	"fmt"
+	"log"
+)
+
+func ExampleDefer_Generate() {
+	generator := NewDefer(NewRawStatement("f.Close()"))
+
+	generated, err := generator.Generate(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(generated)
+}
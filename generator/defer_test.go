@@ -0,0 +1,58 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldGenerateDefer(t *testing.T) {
+	{
+		gen, err := NewDefer(NewRawStatement("f.Close()")).Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "defer f.Close()\n", gen)
+	}
+
+	{
+		generator := NewDefer(
+			NewAnonymousFunc(
+				false,
+				NewAnonymousFuncSignature(),
+				NewRawStatement("mu.Unlock()"),
+			).Invocation(NewFuncInvocation()),
+		)
+		expected := `	defer func() {
+		mu.Unlock()
+	}()
+`
+		gen, err := generator.Generate(1)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, gen)
+	}
+}
+
+func TestShouldGenerateDeferRaisesError(t *testing.T) {
+	{
+		_, err := NewDefer(nil).Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.DeferStatementIsEmptyError("").Error(), " ")[0],
+		), err.Error())
+	}
+
+	{
+		_, err := NewDefer(NewRawStatement("")).Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.DeferStatementIsEmptyError("").Error(), " ")[0],
+		), err.Error())
+	}
+
+	{
+		_, err := NewDefer(NewAnonymousFunc(false, nil)).Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.AnonymousFuncSignatureIsNilError("").Error(), " ")[0],
+		), err.Error())
+	}
+}
@@ -5,12 +5,14 @@ import "fmt"
 // Else represents a code generator for `else` block.
 type Else struct {
 	statements []Statement
+	caller     string
 }
 
 // NewElse returns a new `Else`.
 func NewElse(statements ...Statement) *Else {
 	return &Else{
 		statements: statements,
+		caller:     fetchClientCallerLine(),
 	}
 }
 
@@ -19,6 +21,7 @@ func NewElse(statements ...Statement) *Else {
 func (e *Else) AddStatements(statements ...Statement) *Else {
 	return &Else{
 		statements: append(e.statements, statements...),
+		caller:     e.caller,
 	}
 }
 
@@ -27,23 +30,31 @@ func (e *Else) AddStatements(statements ...Statement) *Else {
 func (e *Else) Statements(statements ...Statement) *Else {
 	return &Else{
 		statements: statements,
+		caller:     e.caller,
 	}
 }
 
 // Generate generates `else` block as golang code.
-func (e *Else) Generate(indentLevel int) (string, error) {
+func (e *Else) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, e.pathSegment())
+
 	stmt := fmt.Sprintf(" else {\n")
 
 	indent := BuildIndent(indentLevel)
-	nextIndentLevel := indentLevel + 1
-	for _, c := range e.statements {
-		gen, err := c.Generate(nextIndentLevel)
-		if err != nil {
-			return "", err
-		}
-		stmt += gen
+	body, err := generateBlockBody(e.statements, indentLevel+1)
+	if err != nil {
+		return "", err
 	}
+	stmt += body
 	stmt += fmt.Sprintf("%s}", indent)
 
 	return stmt, nil
 }
+
+func (e *Else) childStatements() []Statement {
+	return e.statements
+}
+
+func (e *Else) pathSegment() errorPathSegment {
+	return errorPathSegment{name: "Else", caller: e.caller}
+}
@@ -1,11 +1,17 @@
 package generator
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
 
 // ElseIf represents a code generator for `else-if` block.
 type ElseIf struct {
-	condition  string
-	statements []Statement
+	initStatement Statement
+	condition     string
+	statements    []Statement
+	caller        string
 }
 
 // NewElseIf returns a new `ElseIf`.
@@ -13,6 +19,18 @@ func NewElseIf(condition string, statements ...Statement) *ElseIf {
 	return &ElseIf{
 		condition:  condition,
 		statements: statements,
+		caller:     fetchClientCallerLine(),
+	}
+}
+
+// Init sets an init statement to `ElseIf` (e.g. `err := f()` of `else if err := f(); err != nil {`).
+// This method returns a *new* `ElseIf`; it means this method acts as immutable.
+func (ei *ElseIf) Init(init Statement) *ElseIf {
+	return &ElseIf{
+		initStatement: init,
+		condition:     ei.condition,
+		statements:    ei.statements,
+		caller:        ei.caller,
 	}
 }
 
@@ -20,8 +38,10 @@ func NewElseIf(condition string, statements ...Statement) *ElseIf {
 // This method returns a *new* `ElseIf`; it means this method acts as immutable.
 func (ei *ElseIf) AddStatements(statements ...Statement) *ElseIf {
 	return &ElseIf{
-		condition:  ei.condition,
-		statements: append(ei.statements, statements...),
+		initStatement: ei.initStatement,
+		condition:     ei.condition,
+		statements:    append(ei.statements, statements...),
+		caller:        ei.caller,
 	}
 }
 
@@ -29,26 +49,55 @@ func (ei *ElseIf) AddStatements(statements ...Statement) *ElseIf {
 // This method returns a *new* `ElseIf`; it means this method acts as immutable.
 func (ei *ElseIf) Statements(statements ...Statement) *ElseIf {
 	return &ElseIf{
-		condition:  ei.condition,
-		statements: statements,
+		initStatement: ei.initStatement,
+		condition:     ei.condition,
+		statements:    statements,
+		caller:        ei.caller,
 	}
 }
 
 // Generate generates `else-if` block as golang code.
-func (ei *ElseIf) Generate(indentLevel int) (string, error) {
+func (ei *ElseIf) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, ei.pathSegment())
+
+	if err := firstError(ei.validate()); err != nil {
+		return "", err
+	}
+
 	indent := BuildIndent(indentLevel)
 
-	stmt := fmt.Sprintf(" else if %s {\n", ei.condition)
+	header, err := generateIfHeader(ei.initStatement, ei.condition)
+	if err != nil {
+		return "", err
+	}
 
-	nextIndentLevel := indentLevel + 1
-	for _, c := range ei.statements {
-		gen, err := c.Generate(nextIndentLevel)
-		if err != nil {
-			return "", err
-		}
-		stmt += gen
+	stmt := fmt.Sprintf(" else if %s {\n", header)
+
+	body, err := generateBlockBody(ei.statements, indentLevel+1)
+	if err != nil {
+		return "", err
 	}
+	stmt += body
+
 	stmt += fmt.Sprintf("%s}", indent)
 
 	return stmt, nil
 }
+
+func (ei *ElseIf) validate() []error {
+	if ei.condition == "" {
+		return []error{errmsg.ElseIfConditionIsEmptyError(ei.caller)}
+	}
+	return nil
+}
+
+func (ei *ElseIf) childStatements() []Statement {
+	if ei.initStatement == nil {
+		return ei.statements
+	}
+	return append([]Statement{ei.initStatement}, ei.statements...)
+}
+
+func (ei *ElseIf) pathSegment() errorPathSegment {
+	return errorPathSegment{name: "ElseIf", caller: ei.caller}
+}
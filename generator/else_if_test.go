@@ -76,3 +76,31 @@ func TestShouldGenerateElseIfRaisesError(t *testing.T) {
 		`^\`+strings.Split(errmsg.FuncNameIsEmptyError("").Error(), " ")[0],
 	), err.Error())
 }
+
+func TestShouldGenerateElseIfRaisesErrorWhenConditionIsEmpty(t *testing.T) {
+	{
+		_, err := NewElseIf("").Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.ElseIfConditionIsEmptyError("").Error(), " ")[0],
+		), err.Error())
+	}
+
+	{
+		_, err := NewIf("i > 0").AddElseIf(NewElseIf("").AddStatements(NewComment(" foo"))).Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.ElseIfConditionIsEmptyError("").Error(), " ")[0],
+		), err.Error())
+	}
+}
+
+func TestShouldGenerateElseIfWithInitStatement(t *testing.T) {
+	generator := NewElseIf("ok", NewComment(" found")).
+		Init(NewShortVarDecl([]string{"v", "ok"}, NewRawStatement("m[k]")))
+
+	expected := ` else if v, ok := m[k]; ok {
+	// found
+}`
+	gen, err := generator.Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+}
@@ -0,0 +1,470 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
+
+// EnumValue represents a value of the enum.
+type EnumValue struct {
+	name       string
+	stringName string
+	value      string
+}
+
+// NewEnumValue returns a new `EnumValue`; `name` is the name of the constant.
+func NewEnumValue(name string) *EnumValue {
+	return &EnumValue{
+		name: name,
+	}
+}
+
+// StringName sets the string representation of the value, that is used by `String()`, `ParseXxx()` and the text marshaling.
+// By default, it is the name of the constant.
+// This method returns a *new* `EnumValue`; it means this method acts as immutable.
+func (v *EnumValue) StringName(stringName string) *EnumValue {
+	return &EnumValue{
+		name:       v.name,
+		stringName: stringName,
+		value:      v.value,
+	}
+}
+
+// Value sets the explicit value of the constant as an expression (e.g. `10` and `Read | Write`).
+// By default, the value is the next of the previous one (or `iota`), or the next bit in bit-flag mode.
+// This method returns a *new* `EnumValue`; it means this method acts as immutable.
+func (v *EnumValue) Value(value string) *EnumValue {
+	return &EnumValue{
+		name:       v.name,
+		stringName: v.stringName,
+		value:      value,
+	}
+}
+
+func (v *EnumValue) stringRepresentation() string {
+	if v.stringName != "" {
+		return v.stringName
+	}
+	return v.name
+}
+
+// Enum represents a code generator for the enum; it generates the defined type and the following things, like `stringer` and `enumer`:
+//
+//   - the `const` block of the values
+//   - `String()` method
+//   - `ParseXxx(string) (Xxx, error)` func
+//   - `MarshalText()` and `UnmarshalText()` methods; they make the enum marshaled as string by `encoding/json` too
+//   - `IsValid()` method
+//   - `XxxValues()` func that returns all values
+//
+// The generated code depends on `fmt` package (and `strings` package in bit-flag mode); please add the import, or enable `goimports`.
+type Enum struct {
+	typeName       string
+	underlyingType string
+	values         []*EnumValue
+	unknownValue   *EnumValue
+	bitFlags       bool
+	caller         string
+	valuesCallers  []string
+}
+
+// NewEnum returns a new `Enum`.
+func NewEnum(typeName string, values ...*EnumValue) *Enum {
+	return &Enum{
+		typeName:      typeName,
+		values:        values,
+		caller:        fetchClientCallerLine(),
+		valuesCallers: fetchClientCallerLineAsSlice(len(values)),
+	}
+}
+
+// AddValues adds values to `Enum`. This does *not* set, just add.
+// This method returns a *new* `Enum`; it means this method acts as immutable.
+func (e *Enum) AddValues(values ...*EnumValue) *Enum {
+	return &Enum{
+		typeName:       e.typeName,
+		underlyingType: e.underlyingType,
+		values:         append(e.values, values...),
+		unknownValue:   e.unknownValue,
+		bitFlags:       e.bitFlags,
+		caller:         e.caller,
+		valuesCallers:  append(e.valuesCallers, fetchClientCallerLineAsSlice(len(values))...),
+	}
+}
+
+// Values sets values to `Enum`. This does *not* add, just set.
+// This method returns a *new* `Enum`; it means this method acts as immutable.
+func (e *Enum) Values(values ...*EnumValue) *Enum {
+	return &Enum{
+		typeName:       e.typeName,
+		underlyingType: e.underlyingType,
+		values:         values,
+		unknownValue:   e.unknownValue,
+		bitFlags:       e.bitFlags,
+		caller:         e.caller,
+		valuesCallers:  fetchClientCallerLineAsSlice(len(values)),
+	}
+}
+
+// UnderlyingType sets the underlying type of the enum; it must be an integer type. By default, it is `int`.
+// This method returns a *new* `Enum`; it means this method acts as immutable.
+func (e *Enum) UnderlyingType(typ string) *Enum {
+	return &Enum{
+		typeName:       e.typeName,
+		underlyingType: typ,
+		values:         e.values,
+		unknownValue:   e.unknownValue,
+		bitFlags:       e.bitFlags,
+		caller:         e.caller,
+		valuesCallers:  e.valuesCallers,
+	}
+}
+
+// UnknownValue sets the sentinel value that represents the unknown value; it is the zero value of the enum.
+// The sentinel is not valid for `IsValid()`, not contained in `XxxValues()`, and returned by `ParseXxx()` on error.
+// The explicit value of the sentinel is ignored.
+// This method returns a *new* `Enum`; it means this method acts as immutable.
+func (e *Enum) UnknownValue(value *EnumValue) *Enum {
+	return &Enum{
+		typeName:       e.typeName,
+		underlyingType: e.underlyingType,
+		values:         e.values,
+		unknownValue:   value,
+		bitFlags:       e.bitFlags,
+		caller:         e.caller,
+		valuesCallers:  e.valuesCallers,
+	}
+}
+
+// BitFlags enables bit-flag mode; each value is a bit (i.e. `1 << n`), and the combination of the values is valid.
+// In this mode, `String()` joins the names of the bits with `|`, and `ParseXxx()` accepts such a string.
+// This method returns a *new* `Enum`; it means this method acts as immutable.
+func (e *Enum) BitFlags(enabled bool) *Enum {
+	return &Enum{
+		typeName:       e.typeName,
+		underlyingType: e.underlyingType,
+		values:         e.values,
+		unknownValue:   e.unknownValue,
+		bitFlags:       enabled,
+		caller:         e.caller,
+		valuesCallers:  e.valuesCallers,
+	}
+}
+
+// Generate generates the enum as golang code.
+func (e *Enum) Generate(indentLevel int) (generated string, err error) {
+	defer annotateComposedErrorPath(&err, e.pathSegment())
+
+	if err := firstError(e.validate()); err != nil {
+		return "", err
+	}
+
+	return generateStatementGroups(e.statementGroups(), indentLevel)
+}
+
+// statementGroups builds the enum by the generators. Each group is a declaration with its doc comment.
+func (e *Enum) statementGroups() [][]Statement {
+	underlyingType := e.underlyingType
+	if underlyingType == "" {
+		underlyingType = "int"
+	}
+
+	return [][]Statement{
+		{
+			NewCommentf(" %s is an enum.", e.typeName),
+			NewRawStatementf("type %s %s", e.typeName, underlyingType),
+		},
+		{
+			e.constBlock(),
+		},
+		e.stringFunc(),
+		e.parseFunc(),
+		e.marshalTextFunc(),
+		e.unmarshalTextFunc(),
+		e.isValidFunc(),
+		e.valuesFunc(),
+	}
+}
+
+func (e *Enum) receiverName() string {
+	return strings.ToLower(string([]rune(e.typeName)[0]))
+}
+
+// zeroValue returns the expression that is returned by `ParseXxx()` on error.
+func (e *Enum) zeroValue() string {
+	if e.unknownValue != nil {
+		return e.unknownValue.name
+	}
+	return "0"
+}
+
+func (e *Enum) valueNames() []string {
+	names := make([]string, len(e.values))
+	for i, v := range e.values {
+		names[i] = v.name
+	}
+	return names
+}
+
+func (e *Enum) constBlock() *Const {
+	hasExplicitValue := false
+	for _, v := range e.values {
+		if v.value != "" {
+			hasExplicitValue = true
+		}
+	}
+
+	var specs []*ConstSpec
+	if e.unknownValue != nil {
+		zero := "iota"
+		if e.bitFlags || hasExplicitValue {
+			zero = "0"
+		}
+		specs = append(specs, NewConstSpec([]string{e.unknownValue.name}, e.typeName, NewRawStatement(zero)))
+	}
+
+	for i, v := range e.values {
+		switch {
+		case v.value != "":
+			specs = append(specs, NewConstSpec([]string{v.name}, e.typeName, NewRawStatement(v.value)))
+		case e.bitFlags:
+			specs = append(specs, NewConstSpec([]string{v.name}, e.typeName, NewRawStatementf("1 << %d", i)))
+		case !hasExplicitValue && len(specs) > 0:
+			// repeats `iota`
+			specs = append(specs, NewConstSpec([]string{v.name}, ""))
+		case i > 0:
+			specs = append(specs, NewConstSpec([]string{v.name}, e.typeName, NewRawStatement(e.values[i-1].name+" + 1")))
+		default:
+			specs = append(specs, NewConstSpec([]string{v.name}, e.typeName, NewRawStatement("iota")))
+		}
+	}
+
+	return NewConst(specs...)
+}
+
+func (e *Enum) stringFunc() []Statement {
+	recv := e.receiverName()
+
+	sw := NewSwitch(recv)
+	for _, v := range e.allValues() {
+		sw = sw.AddCase(NewCase(v.name, NewReturnStatement(fmt.Sprintf("%q", v.stringRepresentation()))))
+	}
+
+	statements := []Statement{sw}
+	if e.bitFlags {
+		statements = append(
+			statements,
+			NewVar([]string{"names"}, "[]string"),
+			NewShortVarDecl([]string{"rest"}, NewRawStatement(recv)),
+			NewForRange("_", "flag", e.typeName+"Values()",
+				NewIf(
+					fmt.Sprintf("flag != 0 && flag&(flag-1) == 0 && %s&flag == flag", recv),
+					NewAssign([]string{"names"}, "=", NewCall("append", NewRawStatement("names"), NewRawStatement("flag.String()"))),
+					NewAssign([]string{"rest"}, "&^=", NewRawStatement("flag")),
+				),
+			),
+			NewIf(
+				"len(names) > 0 && rest == 0",
+				NewReturnStatement().AddReturnStatements(NewCall("strings.Join", NewRawStatement("names"), NewRawStatement(`"|"`))),
+			),
+		)
+	}
+	statements = append(
+		statements,
+		NewReturnStatement().AddReturnStatements(
+			NewCall("fmt.Sprintf", NewRawStatement(fmt.Sprintf("%q", e.typeName+"(%d)")), NewRawStatement(recv)),
+		),
+	)
+
+	return []Statement{
+		NewCommentf(" String returns the string representation of %s.", e.typeName),
+		NewFunc(
+			NewFuncReceiver(recv, e.typeName),
+			NewFuncSignature("String").AddReturnTypes("string"),
+			statements...,
+		),
+	}
+}
+
+func (e *Enum) parseFunc() []Statement {
+	funcName := "Parse" + e.typeName
+	errorReturn := NewReturnStatement().AddReturnStatements(
+		NewRawStatement(e.zeroValue()),
+		NewCall("fmt.Errorf", NewRawStatement(fmt.Sprintf("%q", "invalid "+e.typeName+": %q")), NewRawStatement("s")),
+	)
+
+	var statements []Statement
+	if e.bitFlags {
+		sw := NewSwitch("name")
+		for _, v := range e.values {
+			sw = sw.AddCase(NewCase(fmt.Sprintf("%q", v.stringRepresentation()), NewAssign([]string{"parsed"}, "|=", NewRawStatement(v.name))))
+		}
+		sw = sw.Default(NewDefaultCase(errorReturn))
+
+		statements = []Statement{
+			NewVar([]string{"parsed"}, e.typeName),
+			NewForRange("_", "name", `strings.Split(s, "|")`, sw),
+			NewReturnStatement("parsed", "nil"),
+		}
+	} else {
+		sw := NewSwitch("s")
+		for _, v := range e.values {
+			sw = sw.AddCase(NewCase(fmt.Sprintf("%q", v.stringRepresentation()), NewReturnStatement(v.name, "nil")))
+		}
+		statements = []Statement{sw, errorReturn}
+	}
+
+	return []Statement{
+		NewCommentf(" %s parses the string representation of %s.", funcName, e.typeName),
+		NewFunc(
+			nil,
+			NewFuncSignature(funcName).
+				AddParameters(NewFuncParameter("s", "string")).
+				AddReturnTypes(e.typeName, "error"),
+			statements...,
+		),
+	}
+}
+
+func (e *Enum) marshalTextFunc() []Statement {
+	recv := e.receiverName()
+	return []Statement{
+		NewComment(" MarshalText implements encoding.TextMarshaler."),
+		NewFunc(
+			NewFuncReceiver(recv, e.typeName),
+			NewFuncSignature("MarshalText").AddReturnTypes("[]byte", "error"),
+			NewIf(
+				"!"+recv+".IsValid()",
+				NewReturnStatement().AddReturnStatements(
+					NewRawStatement("nil"),
+					NewCall("fmt.Errorf", NewRawStatement(fmt.Sprintf("%q", "invalid "+e.typeName+": %d")), NewRawStatement(recv)),
+				),
+			),
+			NewReturnStatement().AddReturnStatements(
+				NewCall("[]byte", NewCall(recv+".String")),
+				NewRawStatement("nil"),
+			),
+		),
+	}
+}
+
+func (e *Enum) unmarshalTextFunc() []Statement {
+	recv := e.receiverName()
+	return []Statement{
+		NewComment(" UnmarshalText implements encoding.TextUnmarshaler."),
+		NewFunc(
+			NewFuncReceiver(recv, "*"+e.typeName),
+			NewFuncSignature("UnmarshalText").AddParameters(NewFuncParameter("text", "[]byte")).AddReturnTypes("error"),
+			NewShortVarDecl([]string{"parsed", "err"}, NewCall("Parse"+e.typeName, NewRawStatement("string(text)"))),
+			NewIf("err != nil", NewReturnStatement("err")),
+			NewAssign([]string{"*" + recv}, "=", NewRawStatement("parsed")),
+			NewReturnStatement("nil"),
+		),
+	}
+}
+
+func (e *Enum) isValidFunc() []Statement {
+	recv := e.receiverName()
+
+	var statements []Statement
+	switch {
+	case e.bitFlags:
+		cond := fmt.Sprintf("%s&^(%s) == 0", recv, strings.Join(e.valueNames(), " | "))
+		if len(e.values) <= 0 {
+			cond = recv + " == 0"
+		}
+		if e.unknownValue != nil {
+			cond = fmt.Sprintf("%s != %s && %s", recv, e.unknownValue.name, cond)
+		}
+		statements = []Statement{NewReturnStatement(cond)}
+	case len(e.values) > 0:
+		statements = []Statement{
+			NewSwitch(recv).AddCase(NewTypeCase(e.valueNames(), NewReturnStatement("true"))),
+			NewReturnStatement("false"),
+		}
+	default:
+		statements = []Statement{NewReturnStatement("false")}
+	}
+
+	return []Statement{
+		NewCommentf(" IsValid reports whether the value is a valid %s.", e.typeName),
+		NewFunc(
+			NewFuncReceiver(recv, e.typeName),
+			NewFuncSignature("IsValid").AddReturnTypes("bool"),
+			statements...,
+		),
+	}
+}
+
+func (e *Enum) valuesFunc() []Statement {
+	funcName := e.typeName + "Values"
+	return []Statement{
+		NewCommentf(" %s returns all values of %s.", funcName, e.typeName),
+		NewFunc(
+			nil,
+			NewFuncSignature(funcName).AddReturnTypes("[]"+e.typeName),
+			NewReturnStatement(fmt.Sprintf("[]%s{%s}", e.typeName, strings.Join(e.valueNames(), ", "))),
+		),
+	}
+}
+
+// allValues returns the values including the unknown sentinel.
+func (e *Enum) allValues() []*EnumValue {
+	if e.unknownValue == nil {
+		return e.values
+	}
+	return append([]*EnumValue{e.unknownValue}, e.values...)
+}
+
+func (e *Enum) validate() []error {
+	var errs []error
+	if e.typeName == "" {
+		errs = append(errs, errmsg.EnumTypeNameIsEmptyError(e.caller))
+	}
+	errs = appendIdentifierError(errs, e.typeName, e.caller)
+
+	names := map[string]bool{}
+	stringNames := map[string]bool{}
+	for i, v := range e.allValues() {
+		caller := e.caller
+		if e.unknownValue != nil {
+			i--
+		}
+		if i >= 0 && i < len(e.valuesCallers) {
+			caller = e.valuesCallers[i]
+		}
+
+		if v == nil || v.name == "" {
+			errs = append(errs, errmsg.EnumValueNameIsEmptyError(caller))
+			continue
+		}
+		errs = appendIdentifierError(errs, v.name, caller)
+
+		if names[v.name] {
+			errs = append(errs, errmsg.EnumValueIsDuplicatedError(v.name, caller))
+		}
+		names[v.name] = true
+		if stringNames[v.stringRepresentation()] {
+			errs = append(errs, errmsg.EnumValueIsDuplicatedError(v.stringRepresentation(), caller))
+		}
+		stringNames[v.stringRepresentation()] = true
+	}
+
+	return errs
+}
+
+func (e *Enum) childStatements() []Statement {
+	if len(e.validate()) > 0 {
+		return nil
+	}
+
+	return flattenStatementGroups(e.statementGroups())
+}
+
+func (e *Enum) pathSegment() errorPathSegment {
+	return errorPathSegment{name: namedPathSegment("Enum", e.typeName), caller: e.caller}
+}
+
+func (e *Enum) composesStatements() {}
@@ -0,0 +1,42 @@
+package generator
+
+import (
+	"fmt"
+	"log"
+)
+
+func ExampleEnum_Generate() {
+	generator := NewEnum(
+		"Color",
+		NewEnumValue("ColorRed").StringName("red"),
+		NewEnumValue("ColorGreen").StringName("green"),
+	).UnknownValue(NewEnumValue("ColorUnknown").StringName("unknown"))
+
+	generated, err := NewRoot(
+		NewPackage("mypkg"),
+		NewImport("fmt"),
+		generator,
+	).Gofmt().Generate(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(generated)
+}
+
+func ExampleEnum_BitFlags() {
+	generator := NewEnum(
+		"Perm",
+		NewEnumValue("PermRead").StringName("read"),
+		NewEnumValue("PermWrite").StringName("write"),
+	).BitFlags(true)
+
+	generated, err := NewRoot(
+		NewPackage("mypkg"),
+		NewImport("fmt", "strings"),
+		generator,
+	).Gofmt().Generate(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(generated)
+}
@@ -0,0 +1,221 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldGenerateEnum(t *testing.T) {
+	expected := `// Color is an enum.
+type Color int
+
+const (
+	Red Color = iota
+	Green
+)
+
+// String returns the string representation of Color.
+func (c Color) String() string {
+	switch c {
+	case Red:
+		return "Red"
+	case Green:
+		return "green"
+	}
+	return fmt.Sprintf("Color(%d)", c)
+}
+
+// ParseColor parses the string representation of Color.
+func ParseColor(s string) (Color, error) {
+	switch s {
+	case "Red":
+		return Red, nil
+	case "green":
+		return Green, nil
+	}
+	return 0, fmt.Errorf("invalid Color: %q", s)
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (c Color) MarshalText() ([]byte, error) {
+	if !c.IsValid() {
+		return nil, fmt.Errorf("invalid Color: %d", c)
+	}
+	return []byte(c.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (c *Color) UnmarshalText(text []byte) error {
+	parsed, err := ParseColor(string(text))
+	if err != nil {
+		return err
+	}
+	*c = parsed
+	return nil
+}
+
+// IsValid reports whether the value is a valid Color.
+func (c Color) IsValid() bool {
+	switch c {
+	case Red, Green:
+		return true
+	}
+	return false
+}
+
+// ColorValues returns all values of Color.
+func ColorValues() []Color {
+	return []Color{Red, Green}
+}
+`
+	generated, err := NewEnum("Color", NewEnumValue("Red")).
+		AddValues(NewEnumValue("Green").StringName("green")).
+		Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, generated)
+}
+
+func TestShouldGenerateEnumWithExplicitValues(t *testing.T) {
+	generated, err := NewEnum("Status").
+		Values(
+			NewEnumValue("StatusOK").Value("200"),
+			NewEnumValue("StatusCreated"),
+			NewEnumValue("StatusNotFound").Value("404"),
+		).
+		UnknownValue(NewEnumValue("StatusUnknown").StringName("unknown")).
+		UnderlyingType("uint16").
+		Generate(0)
+	assert.NoError(t, err)
+	assert.Contains(t, generated, `type Status uint16
+
+const (
+	StatusUnknown Status = 0
+	StatusOK Status = 200
+	StatusCreated Status = StatusOK + 1
+	StatusNotFound Status = 404
+)
+`)
+	assert.Contains(t, generated, "\treturn StatusUnknown, fmt.Errorf(\"invalid Status: %q\", s)\n")
+	assert.Contains(t, generated, "\treturn []Status{StatusOK, StatusCreated, StatusNotFound}\n")
+}
+
+func TestShouldGenerateEnumOfBitFlags(t *testing.T) {
+	generated, err := NewEnum(
+		"Perm",
+		NewEnumValue("PermRead").StringName("read"),
+		NewEnumValue("PermWrite").StringName("write"),
+	).BitFlags(true).Generate(0)
+	assert.NoError(t, err)
+	assert.Contains(t, generated, `const (
+	PermRead Perm = 1 << 0
+	PermWrite Perm = 1 << 1
+)
+`)
+	assert.Contains(t, generated, "\treturn p&^(PermRead | PermWrite) == 0\n")
+}
+
+func TestShouldGeneratedEnumWork(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this test builds the generated code")
+	}
+
+	enumCode, err := NewRoot(
+		NewPackage("enums"),
+		NewImport("fmt", "strings"),
+		NewNewline(),
+		NewEnum(
+			"Color",
+			NewEnumValue("Red").StringName("red"),
+			NewEnumValue("Green").StringName("green"),
+		).UnknownValue(NewEnumValue("ColorUnknown").StringName("unknown")),
+		NewNewline(),
+		NewEnum(
+			"Perm",
+			NewEnumValue("Read").StringName("read"),
+			NewEnumValue("Write").StringName("write"),
+			NewEnumValue("ReadWrite").StringName("rw").Value("Read | Write"),
+			NewEnumValue("Exec").StringName("exec"),
+		).UnknownValue(NewEnumValue("PermNone").StringName("none")).BitFlags(true),
+	).Gofmt().EnableTypeChecking("").Generate(0)
+	assert.NoError(t, err)
+
+	testCode := `package enums
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestEnum(t *testing.T) {
+	if Green.String() != "green" || Color(100).String() != "Color(100)" || ColorUnknown.String() != "unknown" {
+		t.Fatal("String")
+	}
+	if c, err := ParseColor("red"); err != nil || c != Red {
+		t.Fatal("ParseColor")
+	}
+	if c, err := ParseColor("blue"); err == nil || c != ColorUnknown {
+		t.Fatal("ParseColor error")
+	}
+	if ColorUnknown.IsValid() || !Red.IsValid() || len(ColorValues()) != 2 {
+		t.Fatal("IsValid")
+	}
+
+	b, err := json.Marshal(map[string]Color{"c": Green})
+	if err != nil || string(b) != ` + "`" + `{"c":"green"}` + "`" + ` {
+		t.Fatal("json.Marshal", string(b), err)
+	}
+	var m map[string]Color
+	if err := json.Unmarshal(b, &m); err != nil || m["c"] != Green {
+		t.Fatal("json.Unmarshal")
+	}
+	if _, err := json.Marshal(ColorUnknown); err == nil {
+		t.Fatal("json.Marshal invalid")
+	}
+
+	if (Read|Exec).String() != "read|exec" || ReadWrite.String() != "rw" || (Read|Write|Exec).String() != "read|write|exec" {
+		t.Fatal("Perm String", (Read | Exec).String(), (Read | Write | Exec).String())
+	}
+	if p, err := ParsePerm("read|exec"); err != nil || p != Read|Exec {
+		t.Fatal("ParsePerm")
+	}
+	if _, err := ParsePerm("read|foo"); err == nil {
+		t.Fatal("ParsePerm error")
+	}
+	if PermNone.IsValid() || !(Read | Exec).IsValid() || Perm(16).IsValid() || Perm(16).String() != "Perm(16)" {
+		t.Fatal("Perm IsValid")
+	}
+}
+`
+
+	runGoCommandsOnGeneratedPackage(t, map[string]string{
+		"enums.go":      enumCode,
+		"enums_test.go": testCode,
+	}, []string{"vet", "."}, []string{"test", "."})
+}
+
+func TestShouldRaiseErrorWhenEnumIsInvalid(t *testing.T) {
+	_, err := NewEnum("", NewEnumValue("A")).Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.EnumTypeNameIsEmptyError("").Error(), " ")[0],
+	), err.Error())
+
+	_, err = NewEnum("E", NewEnumValue("")).Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.EnumValueNameIsEmptyError("").Error(), " ")[0],
+	), err.Error())
+
+	_, err = NewEnum("E", NewEnumValue("A"), NewEnumValue("B").StringName("A")).Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.EnumValueIsDuplicatedError("", "").Error(), " ")[0],
+	), err.Error())
+
+	_, err = NewEnum("E", NewEnumValue("A")).UnknownValue(NewEnumValue("A").StringName("unknown")).Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.EnumValueIsDuplicatedError("", "").Error(), " ")[0],
+	), err.Error())
+}
@@ -1,11 +1,20 @@
 package generator
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
 
 // For represents a code generator for `for` block.
+// It supports the bare form (i.e. `for {`), the condition form (i.e. `for cond {`) and the three-clause form (i.e. `for init; cond; post {`).
 type For struct {
-	condition  string
-	statements []Statement
+	initStatement Statement
+	condition     string
+	postStatement Statement
+	statements    []Statement
+	caller        string
 }
 
 // NewFor returns a new `For`.
@@ -13,6 +22,19 @@ func NewFor(condition string, statements ...Statement) *For {
 	return &For{
 		condition:  condition,
 		statements: statements,
+		caller:     fetchClientCallerLine(),
+	}
+}
+
+// NewForClause returns a new `For` for the three-clause form (i.e. `for init; cond; post {`).
+// `init` and `post` can be nil.
+func NewForClause(init Statement, condition string, post Statement, statements ...Statement) *For {
+	return &For{
+		initStatement: init,
+		condition:     condition,
+		postStatement: post,
+		statements:    statements,
+		caller:        fetchClientCallerLine(),
 	}
 }
 
@@ -20,8 +42,11 @@ func NewFor(condition string, statements ...Statement) *For {
 // This method returns a *new* `For`; it means this method acts as immutable.
 func (fg *For) AddStatements(statements ...Statement) *For {
 	return &For{
-		condition:  fg.condition,
-		statements: append(fg.statements, statements...),
+		initStatement: fg.initStatement,
+		condition:     fg.condition,
+		postStatement: fg.postStatement,
+		statements:    append(fg.statements, statements...),
+		caller:        fg.caller,
 	}
 }
 
@@ -29,16 +54,57 @@ func (fg *For) AddStatements(statements ...Statement) *For {
 // This method returns a *new* `For`; it means this method acts as immutable.
 func (fg *For) Statements(statements ...Statement) *For {
 	return &For{
-		condition:  fg.condition,
-		statements: statements,
+		initStatement: fg.initStatement,
+		condition:     fg.condition,
+		postStatement: fg.postStatement,
+		statements:    statements,
+		caller:        fg.caller,
+	}
+}
+
+// Init sets an init statement of the three-clause form to `For`.
+// This method returns a *new* `For`; it means this method acts as immutable.
+func (fg *For) Init(init Statement) *For {
+	return &For{
+		initStatement: init,
+		condition:     fg.condition,
+		postStatement: fg.postStatement,
+		statements:    fg.statements,
+		caller:        fg.caller,
+	}
+}
+
+// Post sets a post statement of the three-clause form to `For`.
+// This method returns a *new* `For`; it means this method acts as immutable.
+func (fg *For) Post(post Statement) *For {
+	return &For{
+		initStatement: fg.initStatement,
+		condition:     fg.condition,
+		postStatement: post,
+		statements:    fg.statements,
+		caller:        fg.caller,
 	}
 }
 
 // Generate generates a `for` block as golang code.
-func (fg *For) Generate(indentLevel int) (string, error) {
+func (fg *For) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, fg.pathSegment())
+
+	if err := firstError(fg.validate()); err != nil {
+		return "", err
+	}
+
 	indent := BuildIndent(indentLevel)
 
 	cond := fg.condition
+	if fg.initStatement != nil || fg.postStatement != nil {
+		var err error
+		cond, err = fg.generateClauses()
+		if err != nil {
+			return "", err
+		}
+	}
+
 	stmt := fmt.Sprintf("%sfor %s", indent, cond)
 	if cond != "" {
 		stmt += " "
@@ -57,3 +123,47 @@ func (fg *For) Generate(indentLevel int) (string, error) {
 
 	return stmt, nil
 }
+
+func (fg *For) generateClauses() (string, error) {
+	init := ""
+	if fg.initStatement != nil {
+		var err error
+		init, err = generateSimpleStatement(fg.initStatement)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	post := ""
+	if fg.postStatement != nil {
+		var err error
+		post, err = generateSimpleStatement(fg.postStatement)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return strings.TrimSpace(fmt.Sprintf("%s; %s; %s", init, fg.condition, post)), nil
+}
+
+func (fg *For) validate() []error {
+	if assign, ok := fg.postStatement.(*Assign); ok && assign.operator == ":=" {
+		return []error{errmsg.ForPostStatementIsShortVarDeclError(fg.caller)}
+	}
+	return nil
+}
+
+func (fg *For) childStatements() []Statement {
+	var children []Statement
+	if fg.initStatement != nil {
+		children = append(children, fg.initStatement)
+	}
+	if fg.postStatement != nil {
+		children = append(children, fg.postStatement)
+	}
+	return append(children, fg.statements...)
+}
+
+func (fg *For) pathSegment() errorPathSegment {
+	return errorPathSegment{name: "For", caller: fg.caller}
+}
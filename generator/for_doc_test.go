@@ -17,3 +17,17 @@ func ExampleFor_Generate() {
 	}
 	fmt.Println(generated)
 }
+
+func ExampleNewForClause() {
+	generator := NewForClause(
+		NewShortVarDecl([]string{"i"}, NewRawStatement("0")),
+		"i < foo",
+		NewIncrement("i"),
+	).AddStatements(NewRawStatement(`fmt.Printf("%d", i)`))
+
+	generated, err := generator.Generate(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(generated)
+}
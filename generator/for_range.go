@@ -0,0 +1,135 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
+
+// ForRange represents a code generator for `for` block with `range` clause.
+//
+// example:
+//
+//	for k, v := range m {
+//	  // do something
+//	}
+type ForRange struct {
+	key        string
+	value      string
+	expression string
+	define     bool
+	statements []Statement
+	caller     string
+}
+
+// NewForRange returns a new `ForRange`.
+//
+// `key` and `value` are optional; if both of them are empty, it generates `for range expression`.
+// If only `value` is specified, the key is generated as a blank identifier (i.e. `for _, v := range expression`).
+func NewForRange(key string, value string, expression string, statements ...Statement) *ForRange {
+	return &ForRange{
+		key:        key,
+		value:      value,
+		expression: expression,
+		define:     true,
+		statements: statements,
+		caller:     fetchClientCallerLine(),
+	}
+}
+
+// AddStatements adds statements for `for` block to `ForRange`. This does *not* set, just add.
+// This method returns a *new* `ForRange`; it means this method acts as immutable.
+func (fr *ForRange) AddStatements(statements ...Statement) *ForRange {
+	return &ForRange{
+		key:        fr.key,
+		value:      fr.value,
+		expression: fr.expression,
+		define:     fr.define,
+		statements: append(fr.statements, statements...),
+		caller:     fr.caller,
+	}
+}
+
+// Statements sets statements for `for` block to `ForRange`. This does *not* add, just set.
+// This method returns a *new* `ForRange`; it means this method acts as immutable.
+func (fr *ForRange) Statements(statements ...Statement) *ForRange {
+	return &ForRange{
+		key:        fr.key,
+		value:      fr.value,
+		expression: fr.expression,
+		define:     fr.define,
+		statements: statements,
+		caller:     fr.caller,
+	}
+}
+
+// WithDefine specifies whether the iteration variables are declared by `:=` or assigned by `=`.
+// Default value is `true`, so this method might be used when you want to assign to existing variables.
+// This method returns a *new* `ForRange`; it means this method acts as immutable.
+func (fr *ForRange) WithDefine(define bool) *ForRange {
+	return &ForRange{
+		key:        fr.key,
+		value:      fr.value,
+		expression: fr.expression,
+		define:     define,
+		statements: fr.statements,
+		caller:     fr.caller,
+	}
+}
+
+// Generate generates a `for` block with `range` clause as golang code.
+func (fr *ForRange) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, fr.pathSegment())
+
+	if err := firstError(fr.validate()); err != nil {
+		return "", err
+	}
+
+	indent := BuildIndent(indentLevel)
+
+	vars := fr.key
+	if fr.value != "" {
+		key := fr.key
+		if key == "" {
+			key = "_"
+		}
+		vars = key + ", " + fr.value
+	}
+
+	stmt := indent + "for "
+	if vars != "" {
+		op := ":="
+		if !fr.define {
+			op = "="
+		}
+		stmt += vars + " " + op + " "
+	}
+	stmt += fmt.Sprintf("range %s {\n", fr.expression)
+
+	nextIndentLevel := indentLevel + 1
+	for _, c := range fr.statements {
+		gen, err := c.Generate(nextIndentLevel)
+		if err != nil {
+			return "", err
+		}
+		stmt += gen
+	}
+	stmt += fmt.Sprintf("%s}\n", indent)
+
+	return stmt, nil
+}
+
+func (fr *ForRange) validate() []error {
+	if fr.expression == "" {
+		return []error{errmsg.ForRangeExpressionIsEmptyError(fr.caller)}
+	}
+	return nil
+}
+
+func (fr *ForRange) childStatements() []Statement {
+	return fr.statements
+}
+
+func (fr *ForRange) pathSegment() errorPathSegment {
+	return errorPathSegment{name: "ForRange", caller: fr.caller}
+}
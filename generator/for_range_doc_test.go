@@ -0,0 +1,19 @@
+package generator
+
+import (
+	"fmt"
+	"log"
+)
+
+func ExampleForRange_Generate() {
+	generator := NewForRange(
+		"k", "v", "m",
+		NewComment(" do something"),
+	).AddStatements(NewRawStatement(`fmt.Println(k, v)`))
+
+	generated, err := generator.Generate(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(generated)
+}
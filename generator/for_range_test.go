@@ -0,0 +1,107 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldGenerateForRangeCode(t *testing.T) {
+	generator := NewForRange(
+		"k", "v", "m",
+		NewComment(" do something"),
+		NewRawStatement(`fmt.Println(k, v)`),
+	)
+
+	{
+		expected := `for k, v := range m {
+	// do something
+	fmt.Println(k, v)
+}
+`
+		gen, err := generator.Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, gen)
+	}
+
+	{
+		expected := `		for k, v := range m {
+			// do something
+			fmt.Println(k, v)
+		}
+`
+		gen, err := generator.Generate(2)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, gen)
+	}
+
+	{
+		expected := `for k, v = range m {
+	//modified
+}
+`
+		gen, err := generator.WithDefine(false).Statements(NewComment("modified")).Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, gen)
+	}
+}
+
+func TestShouldGenerateForRangeCodeWithVariations(t *testing.T) {
+	{
+		gen, err := NewForRange("k", "", "m").Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "for k := range m {\n}\n", gen)
+	}
+
+	{
+		gen, err := NewForRange("", "v", "s").Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "for _, v := range s {\n}\n", gen)
+	}
+
+	{
+		gen, err := NewForRange("", "", "ch").Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "for range ch {\n}\n", gen)
+	}
+
+	{
+		gen, err := NewForRange("i", "", "10").Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "for i := range 10 {\n}\n", gen)
+	}
+}
+
+func TestShouldGenerateForRangeCodeWithExpandingMethod(t *testing.T) {
+	generator := NewForRange("_", "v", "s").
+		AddStatements(NewComment(" XXX: test test")).
+		AddStatements(NewRawStatement(`fmt.Println(v)`))
+
+	expected := `for _, v := range s {
+	// XXX: test test
+	fmt.Println(v)
+}
+`
+	gen, err := generator.Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+}
+
+func TestShouldGenerateForRangeCodeRaisesError(t *testing.T) {
+	{
+		_, err := NewForRange("k", "v", "").Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.ForRangeExpressionIsEmptyError("").Error(), " ")[0],
+		), err.Error())
+	}
+
+	{
+		_, err := NewForRange("k", "v", "m", NewFunc(nil, NewFuncSignature(""))).Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.FuncNameIsEmptyError("").Error(), " ")[0],
+		), err.Error())
+	}
+}
@@ -79,3 +79,100 @@ func TestShouldGenerateForCodeGiveUpWhenStatementRaisesError(t *testing.T) {
 		`^\`+strings.Split(errmsg.FuncNameIsEmptyError("").Error(), " ")[0],
 	), err.Error())
 }
+
+func TestShouldGenerateForCodeWithoutCondition(t *testing.T) {
+	generator := NewFor("", NewRawStatement("break"))
+
+	expected := `for {
+	break
+}
+`
+	gen, err := generator.Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+}
+
+func TestShouldGenerateForClauseCode(t *testing.T) {
+	generator := NewForClause(
+		NewShortVarDecl([]string{"i"}, NewRawStatement("0")),
+		"i < n",
+		NewIncrement("i"),
+		NewRawStatement(`fmt.Printf("%d", i)`),
+	)
+
+	{
+		expected := `for i := 0; i < n; i++ {
+	fmt.Printf("%d", i)
+}
+`
+		gen, err := generator.Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, gen)
+	}
+
+	{
+		expected := `		for i := 0; i < n; i++ {
+			fmt.Printf("%d", i)
+		}
+`
+		gen, err := generator.Generate(2)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, gen)
+	}
+
+	{
+		expected := `for i := 0; i < n; i += 2 {
+	fmt.Printf("%d", i)
+}
+`
+		gen, err := generator.Post(NewAssign([]string{"i"}, "+=", NewRawStatement("2"))).Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, gen)
+	}
+
+	{
+		expected := `for ; i < n; i++ {
+	fmt.Printf("%d", i)
+}
+`
+		gen, err := generator.Init(nil).Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, gen)
+	}
+
+	{
+		expected := `for i < n {
+}
+`
+		gen, err := generator.Init(nil).Post(nil).Statements().Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, gen)
+	}
+}
+
+func TestShouldGenerateForClauseCodeRaisesError(t *testing.T) {
+	{
+		_, err := NewForClause(
+			nil,
+			"i < n",
+			NewShortVarDecl([]string{"i"}, NewRawStatement("0")),
+		).Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.ForPostStatementIsShortVarDeclError("").Error(), " ")[0],
+		), err.Error())
+	}
+
+	{
+		_, err := NewForClause(NewIncrement(""), "i < n", nil).Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.IncDecTargetIsEmptyError("").Error(), " ")[0],
+		), err.Error())
+	}
+
+	{
+		_, err := NewForClause(nil, "i < n", NewIncrement("")).Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.IncDecTargetIsEmptyError("").Error(), " ")[0],
+		), err.Error())
+	}
+}
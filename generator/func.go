@@ -29,6 +29,7 @@ func (fg *Func) AddStatements(statements ...Statement) *Func {
 		funcReceiver:  fg.funcReceiver,
 		funcSignature: fg.funcSignature,
 		statements:    append(fg.statements, statements...),
+		caller:        fg.caller,
 	}
 }
 
@@ -39,11 +40,14 @@ func (fg *Func) Statements(statements ...Statement) *Func {
 		funcReceiver:  fg.funcReceiver,
 		funcSignature: fg.funcSignature,
 		statements:    statements,
+		caller:        fg.caller,
 	}
 }
 
 // Generate generates a func block as golang code.
-func (fg *Func) Generate(indentLevel int) (string, error) {
+func (fg *Func) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, fg.pathSegment())
+
 	indent := BuildIndent(indentLevel)
 
 	stmt := indent + "func "
@@ -60,8 +64,8 @@ func (fg *Func) Generate(indentLevel int) (string, error) {
 		stmt += receiver + " "
 	}
 
-	if fg.funcSignature == nil {
-		return "", errmsg.FuncSignatureIsNilError(fg.caller)
+	if err := firstError(fg.validate()); err != nil {
+		return "", err
 	}
 	sig, err := fg.funcSignature.Generate(0)
 	if err != nil {
@@ -82,3 +86,37 @@ func (fg *Func) Generate(indentLevel int) (string, error) {
 
 	return stmt, nil
 }
+
+func (fg *Func) validate() []error {
+	var errs []error
+	if fg.funcSignature == nil {
+		errs = append(errs, errmsg.FuncSignatureIsNilError(fg.caller))
+	}
+	return append(errs, validateLabels(fg.statements)...)
+}
+
+func (fg *Func) warnings() []error {
+	if fg.funcSignature == nil || (fg.funcReceiver != nil && fg.funcReceiver.name != "") {
+		// method name doesn't shadow anything
+		return nil
+	}
+	return appendShadowingWarning(nil, fg.funcSignature.funcName, fg.funcSignature.funcNameCaller)
+}
+
+func (fg *Func) childStatements() []Statement {
+	var children []Statement
+	if fg.funcReceiver != nil {
+		children = append(children, fg.funcReceiver)
+	}
+	if fg.funcSignature != nil {
+		children = append(children, fg.funcSignature)
+	}
+	return append(children, fg.statements...)
+}
+
+func (fg *Func) pathSegment() errorPathSegment {
+	if fg.funcSignature == nil {
+		return errorPathSegment{name: "Func", caller: fg.caller}
+	}
+	return errorPathSegment{name: namedPathSegment("Func", fg.funcSignature.funcName), caller: fg.caller}
+}
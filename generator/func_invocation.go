@@ -10,6 +10,7 @@ import (
 type FuncInvocation struct {
 	parameters []string
 	callers    []string
+	caller     string
 }
 
 // NewFuncInvocation returns a new `FuncInvocation`.
@@ -17,6 +18,7 @@ func NewFuncInvocation(parameters ...string) *FuncInvocation {
 	return &FuncInvocation{
 		parameters: parameters,
 		callers:    fetchClientCallerLineAsSlice(len(parameters)),
+		caller:     fetchClientCallerLine(),
 	}
 }
 
@@ -26,6 +28,7 @@ func (fig *FuncInvocation) AddParameters(parameters ...string) *FuncInvocation {
 	return &FuncInvocation{
 		parameters: append(fig.parameters, parameters...),
 		callers:    append(fig.callers, fetchClientCallerLineAsSlice(len(parameters))...),
+		caller:     fig.caller,
 	}
 }
 
@@ -35,16 +38,31 @@ func (fig *FuncInvocation) Parameters(parameters ...string) *FuncInvocation {
 	return &FuncInvocation{
 		parameters: parameters,
 		callers:    fetchClientCallerLineAsSlice(len(parameters)),
+		caller:     fig.caller,
 	}
 }
 
 // Generate generates the func invocation as golang code.
-func (fig *FuncInvocation) Generate(indentLevel int) (string, error) {
+func (fig *FuncInvocation) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, fig.pathSegment())
+
+	if err := firstError(fig.validate()); err != nil {
+		return "", err
+	}
+
+	return "(" + strings.Join(fig.parameters, ", ") + ")", nil
+}
+
+func (fig *FuncInvocation) validate() []error {
+	var errs []error
 	for i, param := range fig.parameters {
 		if param == "" {
-			return "", errmsg.FuncInvocationParameterIsEmptyError(fig.callers[i])
+			errs = append(errs, errmsg.FuncInvocationParameterIsEmptyError(fig.callers[i]))
 		}
 	}
+	return errs
+}
 
-	return "(" + strings.Join(fig.parameters, ", ") + ")", nil
+func (fig *FuncInvocation) pathSegment() errorPathSegment {
+	return errorPathSegment{name: "FuncInvocation", caller: fig.caller}
 }
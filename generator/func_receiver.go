@@ -23,7 +23,9 @@ func NewFuncReceiver(name string, typ string) *FuncReceiver {
 }
 
 // Generate generates a receiver of the func as golang code.
-func (f *FuncReceiver) Generate(indentLevel int) (string, error) {
+func (f *FuncReceiver) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, f.pathSegment())
+
 	name := f.name
 	typ := f.typ
 
@@ -31,13 +33,33 @@ func (f *FuncReceiver) Generate(indentLevel int) (string, error) {
 		return "", nil
 	}
 
-	if name == "" {
-		return "", errmsg.FuncReceiverNameIsEmptyError(f.caller)
+	if err := firstError(f.validate()); err != nil {
+		return "", err
 	}
 
-	if typ == "" {
-		return "", errmsg.FuncReceiverTypeIsEmptyError(f.caller)
+	return fmt.Sprintf("(%s %s)", name, typ), nil
+}
+
+func (f *FuncReceiver) validate() []error {
+	if f.typ == "" && f.name == "" {
+		return nil
 	}
 
-	return fmt.Sprintf("(%s %s)", name, typ), nil
+	var errs []error
+	if f.name == "" {
+		errs = append(errs, errmsg.FuncReceiverNameIsEmptyError(f.caller))
+	}
+	errs = appendIdentifierError(errs, f.name, f.caller)
+	if f.typ == "" {
+		errs = append(errs, errmsg.FuncReceiverTypeIsEmptyError(f.caller))
+	}
+	return errs
+}
+
+func (f *FuncReceiver) warnings() []error {
+	return appendShadowingWarning(nil, f.name, f.caller)
+}
+
+func (f *FuncReceiver) pathSegment() errorPathSegment {
+	return errorPathSegment{name: "FuncReceiver", caller: f.caller}
 }
@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/moznion/gowrtr/internal/errmsg"
@@ -152,31 +153,22 @@ func (f *FuncSignature) ReturnTypeStatements(returnTypes ...*FuncReturnType) *Fu
 }
 
 // Generate generates a signature of the func as golang code.
-func (f *FuncSignature) Generate(indentLevel int) (string, error) {
-	if f.funcName == "" {
-		return "", errmsg.FuncNameIsEmptyError(f.funcNameCaller)
+func (f *FuncSignature) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, f.pathSegment())
+
+	if err := firstError(f.validate()); err != nil {
+		return "", err
 	}
 
 	stmt := f.funcName
 
 	typeBoundaries := []int{}
-	typeExisted := true
-	typeMissingCaller := ""
 	for i, param := range f.funcParameters {
-		if param.name == "" {
-			return "", errmsg.FuncParameterNameIsEmptyErr(f.paramCallers[i])
-		}
-
-		typeExisted = param.typ != ""
-		if typeExisted {
+		if param.typ != "" {
 			typeBoundaries = append(typeBoundaries, i)
 		}
 	}
 
-	if !typeExisted {
-		return "", errmsg.LastFuncParameterTypeIsEmptyErr(typeMissingCaller)
-	}
-
 	stmt += "("
 
 	groups := make([]string, len(typeBoundaries))
@@ -223,21 +215,85 @@ func (f *FuncSignature) Generate(indentLevel int) (string, error) {
 		}
 		stmt += openingLit + retType + closingLit
 	default:
-		namedRetTypeAppeared := false
 		retTypes := make([]string, len(returnTypes))
 		for i, r := range returnTypes {
+			retTypes[i], _ = r.Generate(0)
+		}
+		stmt += " (" + strings.Join(retTypes, ", ") + ")"
+	}
+	return stmt, nil
+}
+
+func (f *FuncSignature) validate() []error {
+	var errs []error
+	if f.funcName == "" {
+		errs = append(errs, errmsg.FuncNameIsEmptyError(f.funcNameCaller))
+	}
+	errs = appendIdentifierError(errs, f.funcName, f.funcNameCaller)
+
+	typeExisted := true
+	typeMissingCaller := ""
+	for i, param := range f.funcParameters {
+		if param.name == "" {
+			errs = append(errs, errmsg.FuncParameterNameIsEmptyErr(f.paramCallers[i]))
+		}
+		errs = appendIdentifierError(errs, param.name, f.paramCallers[i])
+
+		typeExisted = param.typ != ""
+		if !typeExisted {
+			typeMissingCaller = f.paramCallers[i]
+		}
+	}
+	if !typeExisted {
+		errs = append(errs, errmsg.LastFuncParameterTypeIsEmptyErr(typeMissingCaller))
+	}
+
+	if len(f.returnTypes) > 1 {
+		namedRetTypeAppeared := false
+		for i, r := range f.returnTypes {
 			retType, _ := r.Generate(0)
-			retTypes[i] = retType
 
 			isNamedRetType := strings.Contains(retType, " ")
 			if !namedRetTypeAppeared {
 				namedRetTypeAppeared = isNamedRetType
 			}
 			if namedRetTypeAppeared && !isNamedRetType {
-				return "", errmsg.UnnamedReturnTypeAppearsAfterNamedReturnTypeError(f.returnTypesCallers[i])
+				errs = append(errs, errmsg.UnnamedReturnTypeAppearsAfterNamedReturnTypeError(f.returnTypesCallers[i]))
 			}
 		}
-		stmt += " (" + strings.Join(retTypes, ", ") + ")"
 	}
-	return stmt, nil
+
+	return errs
+}
+
+func (f *FuncSignature) warnings() []error {
+	var warnings []error
+	for i, param := range f.funcParameters {
+		warnings = appendShadowingWarning(warnings, param.name, f.paramCallers[i])
+	}
+	return warnings
+}
+
+func (f *FuncSignature) pathSegment() errorPathSegment {
+	return errorPathSegment{name: namedPathSegment("FuncSignature", f.funcName), caller: f.funcNameCaller}
+}
+
+// expandedParameters returns the parameters that each has its own type; the grouped parameters (e.g. `a, b int`) are expanded.
+// The parameters that cannot be referred (i.e. `_`) are named `arg0`, `arg1` and so on, by the position.
+func (f *FuncSignature) expandedParameters() []*FuncParameter {
+	params := make([]*FuncParameter, len(f.funcParameters))
+	typ := ""
+	for i := len(f.funcParameters) - 1; i >= 0; i-- {
+		param := f.funcParameters[i]
+		if param.typ != "" {
+			typ = param.typ
+		}
+
+		name := param.name
+		if name == "" || name == "_" {
+			name = fmt.Sprintf("arg%d", i)
+		}
+		params[i] = NewFuncParameter(name, typ)
+	}
+	return params
 }
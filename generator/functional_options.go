@@ -0,0 +1,188 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FunctionalOptions represents a code generator for the functional options of the struct.
+// It generates the option type, `WithXxx()` func for each field and `NewXxx(opts ...Option)` constructor.
+//
+// The tag of each field can control the constructor:
+//
+//   - `default:"value"`: the constructor sets the default value; the value of the string field is quoted, and the others are used as expressions.
+//   - `required:"true"`: the constructor returns an error if the field is not set (i.e. it is still the zero value).
+//
+// If there is a required field, the constructor returns `(*Xxx, error)` and the generated code depends on `errors` package
+// (and `reflect` package for the required field of the defined type, e.g. `time.Duration`); please add the imports, or enable `goimports`.
+type FunctionalOptions struct {
+	structure      *Struct
+	optionTypeName string
+	caller         string
+}
+
+// NewFunctionalOptions returns a new `FunctionalOptions` for the struct.
+// This doesn't generate the struct itself, so please generate that separately.
+func NewFunctionalOptions(structure *Struct) *FunctionalOptions {
+	return &FunctionalOptions{
+		structure: structure,
+		caller:    fetchClientCallerLine(),
+	}
+}
+
+// OptionTypeName sets the name of the option type. By default, it is the struct name with `Option` suffix (e.g. `ServerOption`).
+// This method returns a *new* `FunctionalOptions`; it means this method acts as immutable.
+func (o *FunctionalOptions) OptionTypeName(name string) *FunctionalOptions {
+	return &FunctionalOptions{
+		structure:      o.structure,
+		optionTypeName: name,
+		caller:         o.caller,
+	}
+}
+
+// Generate generates the functional options as golang code.
+func (o *FunctionalOptions) Generate(indentLevel int) (generated string, err error) {
+	defer annotateComposedErrorPath(&err, o.pathSegment())
+
+	if err := firstError(o.validate()); err != nil {
+		return "", err
+	}
+
+	return generateStatementGroups(o.statementGroups(), indentLevel)
+}
+
+func (o *FunctionalOptions) statementGroups() [][]Statement {
+	structName := o.structure.name
+	optionType := o.optionTypeName
+	if optionType == "" {
+		optionType = structName + "Option"
+	}
+	recv := structVariableName(o.structure)
+
+	groups := [][]Statement{
+		{
+			NewCommentf(" %s is a functional option for %s.", optionType, structName),
+			NewRawStatementf("type %s func(*%s)", optionType, structName),
+		},
+	}
+
+	for _, field := range o.structure.fields {
+		funcName := "With" + ToExportedName(field.name)
+		param := ToUnexportedName(field.name)
+
+		groups = append(groups, []Statement{
+			NewCommentf(" %s sets %s of %s.", funcName, field.name, structName),
+			NewFunc(
+				nil,
+				NewFuncSignature(funcName).AddParameters(NewFuncParameter(param, field.typ)).AddReturnTypes(optionType),
+				NewReturnStatement().AddReturnStatements(
+					NewAnonymousFunc(
+						false,
+						NewAnonymousFuncSignature().AddParameters(NewFuncParameter(recv, "*"+structName)),
+						NewAssign([]string{recv + "." + field.name}, "=", NewRawStatement(param)),
+					),
+				),
+			),
+		})
+	}
+
+	constructorName := "New" + ToExportedName(structName)
+	requiredChecks := requiredFieldChecks(o.structure, func(field *StructField) string {
+		return recv + "." + field.name
+	})
+
+	sig := NewFuncSignature(constructorName).AddParameters(NewFuncParameter("opts", "..."+optionType))
+	var returnStatement Statement
+	if len(requiredChecks) > 0 {
+		sig = sig.AddReturnTypes("*"+structName, "error")
+		returnStatement = NewReturnStatement(recv, "nil")
+	} else {
+		sig = sig.AddReturnTypes("*" + structName)
+		returnStatement = NewReturnStatement(recv)
+	}
+
+	statements := []Statement{
+		NewShortVarDecl([]string{recv}, structLiteralWithDefaults(o.structure)),
+		NewForRange("_", "opt", "opts", NewRawStatementf("opt(%s)", recv)),
+	}
+	statements = append(statements, requiredChecks...)
+	statements = append(statements, returnStatement)
+
+	groups = append(groups, []Statement{
+		NewCommentf(" %s returns a new %s with the options.", constructorName, structName),
+		NewFunc(nil, sig, statements...),
+	})
+
+	return groups
+}
+
+func (o *FunctionalOptions) validate() []error {
+	return appendIdentifierError(validateDerivedStruct(o.structure, o.caller), o.optionTypeName, o.caller)
+}
+
+func (o *FunctionalOptions) childStatements() []Statement {
+	if len(o.validate()) > 0 {
+		return nil
+	}
+	return flattenStatementGroups(o.statementGroups())
+}
+
+func (o *FunctionalOptions) pathSegment() errorPathSegment {
+	return errorPathSegment{name: namedPathSegment("FunctionalOptions", derivedStructName(o.structure)), caller: o.caller}
+}
+
+func (o *FunctionalOptions) composesStatements() {}
+
+// structVariableName returns the name of the variable for the struct (e.g. `s` for `Server`).
+// It avoids the conflict with the parameter name of a field, that is derived by `ToUnexportedName()`.
+func structVariableName(structure *Struct) string {
+	name := strings.ToLower(string([]rune(structure.name)[0]))
+	for _, field := range structure.fields {
+		if ToUnexportedName(field.name) == name {
+			return name + "_"
+		}
+	}
+	return name
+}
+
+// structLiteralWithDefaults returns the composite literal of the pointer of the struct that has the default values of the tags.
+func structLiteralWithDefaults(structure *Struct) *CompositeLiteral {
+	literal := NewCompositeLiteral("&" + structure.name)
+	for _, field := range structure.fields {
+		if value, ok := field.defaultValue(); ok {
+			literal = literal.AddField(field.name, NewRawStatement(value))
+		}
+	}
+	return literal
+}
+
+// requiredFieldChecks returns the statements that return `nil` and an error if a required field is the zero value.
+// `fieldExpr` returns the expression to refer the field.
+func requiredFieldChecks(structure *Struct, fieldExpr func(field *StructField) string) []Statement {
+	var checks []Statement
+	for _, field := range structure.fields {
+		if !field.isRequired() {
+			continue
+		}
+		checks = append(checks, NewIf(
+			zeroCheckOf(fieldExpr(field), field.typ),
+			NewReturnStatement().AddReturnStatements(
+				NewRawStatement("nil"),
+				NewCall("errors.New", NewRawStatement(fmt.Sprintf("%q", structure.name+": "+field.name+" is required"))),
+			),
+		))
+	}
+	return checks
+}
+
+// zeroCheckOf returns the condition that `expr` of `typ` is the zero value.
+// The defined type (e.g. `Tags` of `[]string`) is checked by `reflect`, since it may not be comparable.
+func zeroCheckOf(expr string, typ string) string {
+	if strings.HasPrefix(typ, "[]") || strings.HasPrefix(typ, "map[") {
+		return fmt.Sprintf("len(%s) == 0", expr)
+	}
+	if zero := zeroValueOf(typ); !strings.HasPrefix(zero, "*new(") {
+		return fmt.Sprintf("%s == %s", expr, zero)
+	}
+	return fmt.Sprintf("reflect.ValueOf(%s).IsZero()", expr)
+}
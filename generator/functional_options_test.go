@@ -0,0 +1,221 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldGenerateFunctionalOptions(t *testing.T) {
+	structure := NewStruct("Server").
+		AddField("Host", "string", `required:"true"`).
+		AddField("Port", "int", `default:"8080"`).
+		AddField("Timeout", "time.Duration")
+
+	expected := `// ServerOption is a functional option for Server.
+type ServerOption func(*Server)
+
+// WithHost sets Host of Server.
+func WithHost(host string) ServerOption {
+	return func(s *Server) {
+		s.Host = host
+	}
+}
+
+// WithPort sets Port of Server.
+func WithPort(port int) ServerOption {
+	return func(s *Server) {
+		s.Port = port
+	}
+}
+
+// WithTimeout sets Timeout of Server.
+func WithTimeout(timeout time.Duration) ServerOption {
+	return func(s *Server) {
+		s.Timeout = timeout
+	}
+}
+
+// NewServer returns a new Server with the options.
+func NewServer(opts ...ServerOption) (*Server, error) {
+	s := &Server{
+		Port: 8080,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.Host == "" {
+		return nil, errors.New("Server: Host is required")
+	}
+	return s, nil
+}
+`
+
+	gen, err := NewFunctionalOptions(structure).Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+}
+
+func TestShouldGenerateFunctionalOptionsWithoutRequiredField(t *testing.T) {
+	structure := NewStruct("Config").
+		AddField("Name", "string", `default:"default"`).
+		AddField("C", "[]string")
+
+	expected := `// Opt is a functional option for Config.
+type Opt func(*Config)
+
+// WithName sets Name of Config.
+func WithName(name string) Opt {
+	return func(c_ *Config) {
+		c_.Name = name
+	}
+}
+
+// WithC sets C of Config.
+func WithC(c []string) Opt {
+	return func(c_ *Config) {
+		c_.C = c
+	}
+}
+
+// NewConfig returns a new Config with the options.
+func NewConfig(opts ...Opt) *Config {
+	c_ := &Config{
+		Name: "default",
+	}
+	for _, opt := range opts {
+		opt(c_)
+	}
+	return c_
+}
+`
+
+	gen, err := NewFunctionalOptions(structure).OptionTypeName("Opt").Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+}
+
+func TestShouldGenerateRequiredChecksOfNonComparableTypes(t *testing.T) {
+	structure := NewStruct("Router").
+		AddField("Routes", "[]string", `required:"true"`).
+		AddField("Headers", "map[string]string", `required:"true"`).
+		AddField("Fallback", "func()", `required:"true"`).
+		AddField("Handlers", "Handlers", `required:"true"`)
+
+	gen, err := NewFunctionalOptions(structure).Generate(0)
+	assert.NoError(t, err)
+	assert.Contains(t, gen, `	if len(r.Routes) == 0 {
+		return nil, errors.New("Router: Routes is required")
+	}
+	if len(r.Headers) == 0 {
+		return nil, errors.New("Router: Headers is required")
+	}
+	if r.Fallback == nil {
+		return nil, errors.New("Router: Fallback is required")
+	}
+	if reflect.ValueOf(r.Handlers).IsZero() {
+		return nil, errors.New("Router: Handlers is required")
+	}
+`)
+
+	if testing.Short() {
+		return
+	}
+	code, err := NewRoot(
+		NewPackage("router"),
+		NewImport("errors", "reflect"),
+		NewNewline(),
+		NewRawStatement("type Handlers []func()"),
+		NewNewline(),
+		structure,
+		NewNewline(),
+		NewFunctionalOptions(structure),
+		NewNewline(),
+		NewBuilder(structure),
+	).Gofmt().Generate(0)
+	assert.NoError(t, err)
+	runGoCommandsOnGeneratedPackage(t, map[string]string{"router.go": code}, []string{"vet", "."})
+}
+
+func TestShouldRaiseErrorWhenFunctionalOptionsIsInvalid(t *testing.T) {
+	_, err := NewFunctionalOptions(nil).Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.DerivedStructIsNilError("").Error(), " ")[0],
+	), err.Error())
+
+	_, err = NewFunctionalOptions(NewStruct("Server").AddField("Host", "")).Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.StructFieldTypeIsEmptyErr("").Error(), " ")[0],
+	), err.Error())
+
+	_, err = NewFunctionalOptions(NewStruct("Server")).OptionTypeName("server-option").Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.IdentifierIsInvalidError("", "").Error(), " ")[0],
+	), err.Error())
+}
+
+func TestShouldGeneratedFunctionalOptionsAndBuilderWork(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this test builds the generated code")
+	}
+
+	structure := NewStruct("Server").
+		AddField("Host", "string", `required:"true"`).
+		AddField("Port", "int", `default:"8080"`).
+		AddField("Tags", "[]string")
+
+	code, err := NewRoot(
+		NewPackage("server"),
+		NewImport("errors"),
+		NewNewline(),
+		structure,
+		NewNewline(),
+		NewFunctionalOptions(structure),
+		NewNewline(),
+		NewBuilder(structure),
+	).Gofmt().EnableTypeChecking("").Generate(0)
+	assert.NoError(t, err)
+
+	testCode, err := NewRoot(
+		NewPackage("server"),
+		NewImport("testing"),
+		NewNewline(),
+		NewFunc(
+			nil,
+			NewFuncSignature("TestServer").AddParameters(NewFuncParameter("t", "*testing.T")),
+			NewIf(
+				"_, err := NewServer(); err == nil",
+				NewRawStatement(`t.Fatal("required field is not checked")`),
+			),
+			NewShortVarDecl([]string{"s", "err"}, NewRawStatement(`NewServer(WithHost("localhost"))`)),
+			NewIf(
+				`err != nil || s.Host != "localhost" || s.Port != 8080`,
+				NewRawStatement(`t.Fatalf("unexpected server: %v, %v", s, err)`),
+			),
+			NewShortVarDecl([]string{"base"}, NewRawStatement(`NewServerBuilder().Host("localhost")`)),
+			NewShortVarDecl([]string{"derived"}, NewRawStatement(`base.Port(80).Tags([]string{"a"})`)),
+			NewIf(
+				`s, err := base.Build(); err != nil || s.Port != 8080 || s.Tags != nil`,
+				NewRawStatement(`t.Fatalf("builder is mutated: %v, %v", s, err)`),
+			),
+			NewIf(
+				`s, err := derived.Build(); err != nil || s.Port != 80 || len(s.Tags) != 1`,
+				NewRawStatement(`t.Fatalf("unexpected server: %v, %v", s, err)`),
+			),
+			NewIf(
+				"_, err := NewServerBuilder().Build(); err == nil",
+				NewRawStatement(`t.Fatal("required field is not checked")`),
+			),
+		),
+	).Gofmt().Generate(0)
+	assert.NoError(t, err)
+
+	runGoCommandsOnGeneratedPackage(t, map[string]string{
+		"server.go":      code,
+		"server_test.go": testCode,
+	}, []string{"vet", "."}, []string{"test", "."})
+}
@@ -0,0 +1,319 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
+
+// ErrorKind represents the kind of the error that is raised by generators.
+type ErrorKind int
+
+const (
+	// ErrorKindUnknown is the kind of the error that is not raised by gowrtr itself (e.g. I/O error).
+	ErrorKindUnknown ErrorKind = iota
+	// ErrorKindValidation is the kind of the error that is raised by the validation of generators.
+	ErrorKindValidation
+	// ErrorKindCodeFormatter is the kind of the error that is raised by the code formatter (i.e. `gofmt` and `goimports`).
+	ErrorKindCodeFormatter
+	// ErrorKindTypeCheck is the kind of the error that is raised by the type checking of the generated code.
+	ErrorKindTypeCheck
+	// ErrorKindWarning is the kind of the warning that is reported by `Root.Warnings()`.
+	// This never causes the failure of code generation.
+	ErrorKindWarning
+)
+
+// String returns the name of the error kind.
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorKindValidation:
+		return "validation"
+	case ErrorKindCodeFormatter:
+		return "code formatter"
+	case ErrorKindTypeCheck:
+		return "type check"
+	case ErrorKindWarning:
+		return "warning"
+	default:
+		return "unknown"
+	}
+}
+
+var (
+	// ErrValidation is a sentinel error to check whether the error is raised by the validation of generators through `errors.Is()`.
+	ErrValidation = errors.New("gowrtr: validation error")
+	// ErrCodeFormatter is a sentinel error to check whether the error is raised by the code formatter through `errors.Is()`.
+	ErrCodeFormatter = errors.New("gowrtr: code formatter error")
+	// ErrTypeCheck is a sentinel error to check whether the error is raised by the type checking through `errors.Is()`.
+	ErrTypeCheck = errors.New("gowrtr: type check error")
+	// ErrWarning is a sentinel error to check whether the error is a warning through `errors.Is()`.
+	ErrWarning = errors.New("gowrtr: warning")
+)
+
+// GeneratorError represents an error that is raised on code generation phase.
+// This error holds the nesting path of the generators, from the outermost one to the one that raises the error,
+// so it shows where the error occurs even if the generator is deeply nested.
+//
+// This error also holds the stable error code (e.g. `GOWRTR-14`), the kind and the caller location of the error,
+// so the client can inspect the error without parsing the message:
+//
+//	var genErr *generator.GeneratorError
+//	if errors.As(err, &genErr) && genErr.Code() == "GOWRTR-14" {
+//		...
+//	}
+type GeneratorError struct {
+	err    error
+	code   string
+	kind   ErrorKind
+	caller string
+	path   []errorPathSegment
+}
+
+func newGeneratorError(err error) *GeneratorError {
+	// the error of the code formatter doesn't unwrap to the error of gowrtr, but to that of the command
+	var msgErr *errmsg.Error
+	var fmtErr *FormatterError
+	if errors.As(err, &fmtErr) {
+		errors.As(fmtErr.err, &msgErr)
+	} else {
+		errors.As(err, &msgErr)
+	}
+
+	genErr := &GeneratorError{
+		err:  err,
+		kind: ErrorKindUnknown,
+	}
+	if msgErr == nil {
+		// not raised by gowrtr
+		return genErr
+	}
+
+	genErr.code = msgErr.Code()
+	genErr.caller = msgErr.Caller()
+	switch errmsg.IdentifyErrs(msgErr) {
+	case errmsg.CodeFormatterErrorType:
+		genErr.kind = ErrorKindCodeFormatter
+	case errmsg.PredeclaredIdentifierIsShadowedWarningType:
+		genErr.kind = ErrorKindWarning
+	case errmsg.TypeCheckErrorType:
+		genErr.kind = ErrorKindTypeCheck
+	default:
+		genErr.kind = ErrorKindValidation
+	}
+	return genErr
+}
+
+// Error returns the error message with the nesting path.
+func (e *GeneratorError) Error() string {
+	return fmt.Sprintf("%s [path: %s]", e.err.Error(), e.Path())
+}
+
+// Message returns the original error message without the nesting path.
+func (e *GeneratorError) Message() string {
+	return e.err.Error()
+}
+
+// Code returns the stable error code (e.g. `GOWRTR-14`).
+// This returns empty string if the error is not raised by gowrtr itself.
+func (e *GeneratorError) Code() string {
+	return e.code
+}
+
+// Kind returns the kind of the error.
+func (e *GeneratorError) Kind() ErrorKind {
+	return e.kind
+}
+
+// Caller returns the location (i.e. file name and the line number) of the client code that causes the error.
+// This returns empty string if the location is unknown.
+func (e *GeneratorError) Caller() string {
+	return e.caller
+}
+
+// Path returns the nesting path of the generators that raise the error.
+// e.g. `Root > Func "Handle" (a.go:10) > If (a.go:14) > Case (a.go:20)`
+func (e *GeneratorError) Path() string {
+	return strings.Join(pathSegmentStrings(e.path), " > ")
+}
+
+// PathSegments returns each segment of the nesting path, from the outermost one.
+func (e *GeneratorError) PathSegments() []string {
+	return pathSegmentStrings(e.path)
+}
+
+// Is reports whether the error matches with `target`.
+// This matches with `ErrValidation`, `ErrCodeFormatter`, `ErrTypeCheck` and `ErrWarning` according to the kind,
+// and also matches with another `*GeneratorError` that has the same error code.
+func (e *GeneratorError) Is(target error) bool {
+	switch target {
+	case ErrValidation:
+		return e.kind == ErrorKindValidation
+	case ErrCodeFormatter:
+		return e.kind == ErrorKindCodeFormatter
+	case ErrTypeCheck:
+		return e.kind == ErrorKindTypeCheck
+	case ErrWarning:
+		return e.kind == ErrorKindWarning
+	}
+
+	if t, ok := target.(*GeneratorError); ok {
+		return e.code != "" && e.code == t.code
+	}
+	return false
+}
+
+// Unwrap returns the original error.
+func (e *GeneratorError) Unwrap() error {
+	return e.err
+}
+
+// Cause returns the original error. This is for the compatibility with `github.com/pkg/errors`.
+func (e *GeneratorError) Cause() error {
+	return e.err
+}
+
+var formatterErrorPositionRe = regexp.MustCompile(`(?m)^<standard input>:(\d+):\d+:`)
+
+// FormatterError represents an error that is raised by the code formatter (i.e. `gofmt` and `goimports`).
+// This error is wrapped by `*GeneratorError`, so it can be retrieved through `errors.As()`.
+type FormatterError struct {
+	err           error
+	command       string
+	stderr        string
+	lineNumber    int
+	offendingLine string
+	caller        string
+	cause         error
+}
+
+func newFormatterError(command string, stderr string, cause error, generatedCode string) *FormatterError {
+	lineNumber := 0
+	offendingLine := ""
+	if m := formatterErrorPositionRe.FindStringSubmatch(stderr); m != nil {
+		lineNumber, _ = strconv.Atoi(m[1])
+		lines := strings.Split(generatedCode, "\n")
+		if lineNumber >= 1 && lineNumber <= len(lines) {
+			offendingLine = lines[lineNumber-1]
+		}
+	}
+
+	return &FormatterError{
+		err:           errmsg.CodeFormatterError(command, stderr, cause),
+		command:       command,
+		stderr:        stderr,
+		lineNumber:    lineNumber,
+		offendingLine: offendingLine,
+		cause:         cause,
+	}
+}
+
+// Error returns the error message.
+func (e *FormatterError) Error() string {
+	return e.err.Error()
+}
+
+// Command returns the command line of the code formatter.
+func (e *FormatterError) Command() string {
+	return e.command
+}
+
+// Stderr returns the output of the code formatter to stderr.
+func (e *FormatterError) Stderr() string {
+	return e.stderr
+}
+
+// LineNumber returns the 1-origin line number of the generated code that the code formatter complains.
+// This returns 0 if the line number is unknown.
+func (e *FormatterError) LineNumber() int {
+	return e.lineNumber
+}
+
+// OffendingLine returns the line of the generated code that the code formatter complains.
+// This returns empty string if the line is unknown.
+func (e *FormatterError) OffendingLine() string {
+	return e.offendingLine
+}
+
+// Caller returns the location of the client code that creates the generator that produces the offending line.
+// This returns empty string if the location is unknown.
+func (e *FormatterError) Caller() string {
+	return e.caller
+}
+
+// Unwrap returns the error of the code formatter command (e.g. `*exec.ExitError`).
+func (e *FormatterError) Unwrap() error {
+	return e.cause
+}
+
+// annotateErrorPath prepends the path segment of the generator to the error that is pointed by `err`.
+// This function is supposed to be deferred at the top of each `Generate()`.
+func annotateErrorPath(err *error, segment errorPathSegment) {
+	if *err == nil {
+		return
+	}
+	if _, ok := (*err).(*ValidationErrors); ok {
+		// each error already has its own path
+		return
+	}
+
+	genErr, ok := (*err).(*GeneratorError)
+	if !ok {
+		genErr = newGeneratorError(*err)
+	}
+
+	*err = &GeneratorError{
+		err:    genErr.err,
+		code:   genErr.code,
+		kind:   genErr.kind,
+		caller: genErr.caller,
+		path:   append([]errorPathSegment{segment}, genErr.path...),
+	}
+}
+
+// annotateComposedErrorPath is `annotateErrorPath()` for `statementComposer`.
+// The composer builds its statements on demand (e.g. in `Generate()`), so their callers point at the code that triggers it;
+// the error takes the caller of the composer instead.
+func annotateComposedErrorPath(err *error, segment errorPathSegment) {
+	annotateErrorPath(err, segment)
+
+	genErr, ok := (*err).(*GeneratorError)
+	if !ok {
+		return
+	}
+	if genErr.caller != "" {
+		genErr.caller = segment.caller
+	}
+	for i := 1; i < len(genErr.path); i++ {
+		genErr.path[i].caller = segment.caller
+	}
+}
+
+// errorPathSegment represents a segment of the nesting path of the generators.
+type errorPathSegment struct {
+	name   string
+	caller string
+}
+
+// String returns the segment as string (e.g. `If (a.go:14)`).
+func (s errorPathSegment) String() string {
+	if s.caller == "" {
+		return s.name
+	}
+	return s.name + " (" + s.caller + ")"
+}
+
+func pathSegmentStrings(segments []errorPathSegment) []string {
+	strs := make([]string, len(segments))
+	for i, segment := range segments {
+		strs[i] = segment.String()
+	}
+	return strs
+}
+
+func namedPathSegment(kind string, name string) string {
+	return fmt.Sprintf("%s %q", kind, name)
+}
@@ -0,0 +1,246 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldGeneratorErrorHaveNestingPath(t *testing.T) {
+	generator := NewRoot(
+		NewPackage("mypkg"),
+		NewFunc(
+			nil,
+			NewFuncSignature("Handle"),
+			NewIf("x > 0",
+				NewSwitch("x").AddCase(NewCase("")),
+			),
+		),
+	)
+
+	_, err := generator.Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.CaseConditionIsEmptyError("").Error(), " ")[0],
+	), err.Error())
+
+	var genErr *GeneratorError
+	assert.True(t, errors.As(err, &genErr))
+
+	segments := genErr.PathSegments()
+	assert.Len(t, segments, 5)
+	assert.Equal(t, "Root", segments[0])
+	assert.Regexp(t, regexp.MustCompile(`^Func "Handle" \(.+:\d+\)$`), segments[1])
+	assert.Regexp(t, regexp.MustCompile(`^If \(.+:\d+\)$`), segments[2])
+	assert.Regexp(t, regexp.MustCompile(`^Switch \(.+:\d+\)$`), segments[3])
+	assert.Regexp(t, regexp.MustCompile(`^Case \(.+:\d+\)$`), segments[4])
+
+	assert.Equal(t, strings.Join(segments, " > "), genErr.Path())
+	assert.True(t, strings.HasSuffix(err.Error(), " [path: "+genErr.Path()+"]"))
+
+	assert.True(t, strings.HasPrefix(errors.Unwrap(err).Error(), "[GOWRTR-"))
+	assert.Equal(t, errors.Unwrap(err), genErr.Cause())
+}
+
+func TestShouldGeneratorErrorHaveNestingPathOfExpressions(t *testing.T) {
+	_, err := NewReturnStatement().AddReturnStatements(
+		NewCall("f", NewCompositeLiteral("&Struct").AddField("foo", NewCall(""))),
+	).Generate(0)
+
+	var genErr *GeneratorError
+	assert.True(t, errors.As(err, &genErr))
+
+	segments := genErr.PathSegments()
+	assert.Len(t, segments, 4)
+	assert.Regexp(t, regexp.MustCompile(`^ReturnStatement `), segments[0])
+	assert.Regexp(t, regexp.MustCompile(`^Call "f" `), segments[1])
+	assert.Regexp(t, regexp.MustCompile(`^CompositeLiteral "&Struct" `), segments[2])
+	assert.Regexp(t, regexp.MustCompile(`^Call "" `), segments[3])
+}
+
+func TestShouldKeepCallerThroughImmutableCopies(t *testing.T) {
+	emptyCaller := "(caused at )"
+
+	for _, generator := range []Statement{
+		NewFunc(nil, nil).AddStatements(NewComment("foo")),
+		NewFunc(nil, nil).Statements(NewComment("foo")),
+		NewCase("").AddStatements(NewComment("foo")),
+		NewCase("").Statements(NewComment("foo")),
+		NewElseIf("").AddStatements(NewComment("foo")),
+		NewIf("").Init(nil).AddStatements(NewComment("foo")),
+		NewFuncSignature("f").AddParameters(NewFuncParameter("a", "")),
+		NewForRange("k", "v", "").AddStatements(NewComment("foo")),
+		NewReturnStatement("foo").AddReturnItems("", ""),
+	} {
+		_, err := generator.Generate(0)
+		assert.Error(t, err)
+		assert.NotContains(t, err.Error(), emptyCaller)
+	}
+}
+
+func TestShouldKeepCallerOfComposerThroughImmutableCopies(t *testing.T) {
+	// the parameter type is missing, so the composed func signature raises the error
+	iface := NewInterface("Getter", NewFuncSignature("Get").AddParameters(NewFuncParameter("key", "")))
+
+	mock := NewMock("GetterMock", iface).InterfaceType("Getter")
+	stub := NewInterfaceStub("getterStub", iface).ReceiverName("s").ReturnZeroValues(true)
+
+	for _, composer := range []struct {
+		generator Statement
+		caller    string
+	}{
+		{mock, mock.caller},
+		{stub, stub.caller},
+		{NewRoot(mock), mock.caller},
+	} {
+		assert.Regexp(t, regexp.MustCompile(`:\d+$`), composer.caller)
+
+		var err error
+		inAnotherGoroutine(func() {
+			_, err = composer.generator.Generate(0)
+		})
+		var genErr *GeneratorError
+		assert.True(t, errors.As(err, &genErr))
+		assert.Equal(t, composer.caller, genErr.Caller())
+
+		segments := genErr.PathSegments()
+		if _, ok := composer.generator.(*Root); ok {
+			segments = segments[1:]
+		}
+		assert.True(t, len(segments) > 1)
+		for _, segment := range segments {
+			assert.True(t, strings.HasSuffix(segment, " ("+composer.caller+")"), segment)
+		}
+	}
+
+	var err error
+	inAnotherGoroutine(func() {
+		err = NewRoot(mock).Validate()
+	})
+	var validationErrs *ValidationErrors
+	assert.True(t, errors.As(err, &validationErrs))
+	for _, e := range validationErrs.Errors() {
+		var genErr *GeneratorError
+		assert.True(t, errors.As(e, &genErr))
+		assert.Equal(t, mock.caller, genErr.Caller())
+		for _, segment := range genErr.PathSegments()[1:] {
+			assert.True(t, strings.HasSuffix(segment, " ("+mock.caller+")"), segment)
+		}
+	}
+}
+
+func TestShouldGeneratorErrorBeInspectable(t *testing.T) {
+	_, err := NewRoot(
+		NewFunc(nil, NewFuncSignature("f"), NewIf("")),
+	).Generate(0)
+
+	var genErr *GeneratorError
+	assert.True(t, errors.As(err, &genErr))
+	assert.Equal(t, "GOWRTR-15", genErr.Code())
+	assert.Equal(t, ErrorKindValidation, genErr.Kind())
+	assert.Equal(t, "validation", genErr.Kind().String())
+	assert.Regexp(t, regexp.MustCompile(`^.+:\d+$`), genErr.Caller())
+	assert.Equal(t, errmsg.IfConditionIsEmptyError(genErr.Caller()).Error(), genErr.Message())
+
+	assert.True(t, errors.Is(err, ErrValidation))
+	assert.False(t, errors.Is(err, ErrCodeFormatter))
+
+	_, sameCodeErr := NewIf("").Generate(0)
+	assert.True(t, errors.Is(err, sameCodeErr))
+	_, anotherCodeErr := NewCase("").Generate(0)
+	assert.False(t, errors.Is(err, anotherCodeErr))
+
+	var formatterErr *FormatterError
+	assert.False(t, errors.As(err, &formatterErr))
+}
+
+func TestShouldGeneratorErrorHaveUnknownKindForForeignError(t *testing.T) {
+	cause := errors.New("foreign")
+	err := func() (err error) {
+		defer annotateErrorPath(&err, errorPathSegment{name: "Root"})
+		return cause
+	}()
+
+	var genErr *GeneratorError
+	assert.True(t, errors.As(err, &genErr))
+	assert.Equal(t, "", genErr.Code())
+	assert.Equal(t, ErrorKindUnknown, genErr.Kind())
+	assert.Equal(t, "", genErr.Caller())
+	assert.True(t, errors.Is(err, cause))
+	assert.False(t, errors.Is(err, ErrValidation))
+}
+
+func TestShouldGeneratorErrorNotParseMessageOfForeignError(t *testing.T) {
+	err := func() (err error) {
+		defer annotateErrorPath(&err, errorPathSegment{name: "Root"})
+		return errors.New("[GOWRTR-15] it looks like the error of gowrtr (caused at main.go:10)")
+	}()
+
+	var genErr *GeneratorError
+	assert.True(t, errors.As(err, &genErr))
+	assert.Equal(t, "", genErr.Code())
+	assert.Equal(t, ErrorKindUnknown, genErr.Kind())
+	assert.Equal(t, "", genErr.Caller())
+}
+
+func TestShouldGeneratorErrorHaveCodeAndCallerOfWrappedError(t *testing.T) {
+	err := func() (err error) {
+		defer annotateErrorPath(&err, errorPathSegment{name: "Root"})
+		return fmt.Errorf("failed to generate: %w", errmsg.IfConditionIsEmptyError("main.go:10"))
+	}()
+
+	var genErr *GeneratorError
+	assert.True(t, errors.As(err, &genErr))
+	assert.Equal(t, "GOWRTR-15", genErr.Code())
+	assert.Equal(t, ErrorKindValidation, genErr.Kind())
+	assert.Equal(t, "main.go:10", genErr.Caller())
+}
+
+func TestShouldIdentifyErrsByErrorCode(t *testing.T) {
+	assert.Equal(t, errmsg.IfConditionIsEmptyErrorType, errmsg.IdentifyErrs(errmsg.IfConditionIsEmptyError("main.go:10")))
+	assert.Equal(t, errmsg.IfConditionIsEmptyErrorType, errmsg.IdentifyErrs(fmt.Errorf("failed: %w", errmsg.IfConditionIsEmptyError("main.go:10"))))
+	assert.Equal(t, errmsg.CaseConditionIsEmptyErrorType, errmsg.IdentifyErrs(errmsg.CaseConditionIsEmptyErrorWrap("main.go:10", errmsg.IfConditionIsEmptyError("main.go:10"))))
+	assert.Equal(t, errmsg.ErrsUnknownType, errmsg.IdentifyErrs(errors.New("[GOWRTR-15] it looks like the error of gowrtr (caused at main.go:10)")))
+}
+
+func TestShouldFormatterErrorExposeStderrAndOffendingLine(t *testing.T) {
+	_, err := NewRoot(
+		NewPackage("mypkg"),
+		NewNewline(),
+		NewRawStatement("func f() {"),
+		NewRawStatement("\tx := := 1"),
+		NewRawStatement("}"),
+	).Gofmt().Generate(0)
+
+	var genErr *GeneratorError
+	assert.True(t, errors.As(err, &genErr))
+	assert.Equal(t, "GOWRTR-13", genErr.Code())
+	assert.Equal(t, ErrorKindCodeFormatter, genErr.Kind())
+	assert.True(t, errors.Is(err, ErrCodeFormatter))
+	assert.False(t, errors.Is(err, ErrValidation))
+
+	var formatterErr *FormatterError
+	assert.True(t, errors.As(err, &formatterErr))
+	assert.Equal(t, "gofmt", formatterErr.Command())
+	assert.Regexp(t, regexp.MustCompile(`^<standard input>:4:`), formatterErr.Stderr())
+	assert.Equal(t, 4, formatterErr.LineNumber())
+	assert.Equal(t, "\tx := := 1", formatterErr.OffendingLine())
+
+	var exitErr *exec.ExitError
+	assert.True(t, errors.As(err, &exitErr))
+}
+
+func TestShouldMessagesOfValidationErrorHaveCaller(t *testing.T) {
+	formatterErrMsg := errmsg.CodeFormatterError("", "", nil).Error()
+	for _, msg := range errmsg.ListErrs() {
+		if strings.HasPrefix(formatterErrMsg, strings.Split(msg, " ")[0]+" ") {
+			continue
+		}
+		assert.True(t, strings.HasSuffix(msg, "(caused at %s)"), msg)
+	}
+}
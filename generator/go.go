@@ -0,0 +1,60 @@
+package generator
+
+import (
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
+
+// Go represents a code generator for `go` statement.
+//
+// example:
+// go worker(ch)
+type Go struct {
+	statement Statement
+	caller    string
+}
+
+// NewGo returns a new `Go`.
+// `statement` is generated as the function call to run as a goroutine; it can receive a nested `Statement` like `AnonymousFunc` with invocation.
+func NewGo(statement Statement) *Go {
+	return &Go{
+		statement: statement,
+		caller:    fetchClientCallerLine(),
+	}
+}
+
+// Generate generates `go` statement as golang code.
+func (g *Go) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, g.pathSegment())
+
+	if err := firstError(g.validate()); err != nil {
+		return "", err
+	}
+
+	call, err := generateCallExpression(g.statement, indentLevel)
+	if err != nil {
+		return "", err
+	}
+	if call == "" {
+		return "", errmsg.GoStatementIsEmptyError(g.caller)
+	}
+
+	return BuildIndent(indentLevel) + "go " + call + "\n", nil
+}
+
+func (g *Go) validate() []error {
+	if g.statement == nil {
+		return []error{errmsg.GoStatementIsEmptyError(g.caller)}
+	}
+	return nil
+}
+
+func (g *Go) childStatements() []Statement {
+	if g.statement == nil {
+		return nil
+	}
+	return []Statement{g.statement}
+}
+
+func (g *Go) pathSegment() errorPathSegment {
+	return errorPathSegment{name: "Go", caller: g.caller}
+}
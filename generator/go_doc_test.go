@@ -0,0 +1,16 @@
+package generator
+
+import (
+	"fmt"
+	"log"
+)
+
+func ExampleGo_Generate() {
+	generator := NewGo(NewRawStatement("worker(ch)"))
+
+	generated, err := generator.Generate(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(generated)
+}
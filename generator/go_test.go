@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldGenerateGo(t *testing.T) {
+	{
+		gen, err := NewGo(NewRawStatement("worker(ch)")).Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "go worker(ch)\n", gen)
+	}
+
+	{
+		generator := NewGo(
+			NewAnonymousFunc(
+				false,
+				NewAnonymousFuncSignature().AddParameters(NewFuncParameter("ch", "chan int")),
+				NewRawStatement("ch <- 1"),
+			).Invocation(NewFuncInvocation("ch")),
+		)
+		expected := `	go func(ch chan int) {
+		ch <- 1
+	}(ch)
+`
+		gen, err := generator.Generate(1)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, gen)
+	}
+}
+
+func TestShouldGenerateGoRaisesError(t *testing.T) {
+	{
+		_, err := NewGo(nil).Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.GoStatementIsEmptyError("").Error(), " ")[0],
+		), err.Error())
+	}
+
+	{
+		_, err := NewGo(NewAnonymousFunc(false, nil)).Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.AnonymousFuncSignatureIsNilError("").Error(), " ")[0],
+		), err.Error())
+	}
+}
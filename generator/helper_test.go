@@ -0,0 +1,30 @@
+package generator
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// runGoCommandsOnGeneratedPackage writes the generated files as a standalone module and runs the go commands on that.
+func runGoCommandsOnGeneratedPackage(t *testing.T, files map[string]string, commands ...[]string) {
+	dir, err := ioutil.TempDir("", "gowrtr-generated")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, "go.mod"), []byte("module generated\n\ngo 1.14\n"), 0644))
+	for name, code := range files {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(code), 0644))
+	}
+
+	for _, args := range commands {
+		cmd := exec.Command("go", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		assert.NoError(t, err, string(out))
+	}
+}
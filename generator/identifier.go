@@ -0,0 +1,190 @@
+package generator
+
+import (
+	"go/token"
+	"go/types"
+	"strings"
+	"unicode"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
+
+// commonInitialisms is the set of initialisms that are written in all capitals in golang (e.g. `ID`, `URL` and `HTTP`).
+var commonInitialisms = map[string]bool{
+	"ACL":   true,
+	"API":   true,
+	"ASCII": true,
+	"CPU":   true,
+	"CSS":   true,
+	"DNS":   true,
+	"EOF":   true,
+	"GUID":  true,
+	"HTML":  true,
+	"HTTP":  true,
+	"HTTPS": true,
+	"ID":    true,
+	"IP":    true,
+	"JSON":  true,
+	"LHS":   true,
+	"QPS":   true,
+	"RAM":   true,
+	"RHS":   true,
+	"RPC":   true,
+	"SLA":   true,
+	"SMTP":  true,
+	"SQL":   true,
+	"SSH":   true,
+	"TCP":   true,
+	"TLS":   true,
+	"TTL":   true,
+	"UDP":   true,
+	"UI":    true,
+	"UID":   true,
+	"UUID":  true,
+	"URI":   true,
+	"URL":   true,
+	"UTF8":  true,
+	"VM":    true,
+	"XML":   true,
+	"XMPP":  true,
+	"XSRF":  true,
+	"XSS":   true,
+}
+
+// validateIdentifier checks that `name` is a valid identifier of golang; i.e. it consists of unicode letters, digits and underscores,
+// it doesn't begin with a digit and it isn't a keyword.
+// Empty name is not inspected, because each generator raises its own error for that.
+func validateIdentifier(name string, caller string) error {
+	if name == "" {
+		return nil
+	}
+	if token.IsKeyword(name) {
+		return errmsg.IdentifierIsKeywordError(name, caller)
+	}
+	if !token.IsIdentifier(name) {
+		return errmsg.IdentifierIsInvalidError(name, caller)
+	}
+	return nil
+}
+
+// appendIdentifierError appends the error of `validateIdentifier()` to `errs` if the name is invalid.
+func appendIdentifierError(errs []error, name string, caller string) []error {
+	if err := validateIdentifier(name, caller); err != nil {
+		return append(errs, err)
+	}
+	return errs
+}
+
+// appendShadowingWarning appends the warning to `warnings` if `name` shadows the predeclared identifier (e.g. `string` and `len`).
+func appendShadowingWarning(warnings []error, name string, caller string) []error {
+	if name == "" || name == "_" {
+		return warnings
+	}
+	if types.Universe.Lookup(name) != nil {
+		return append(warnings, errmsg.PredeclaredIdentifierIsShadowedWarning(name, caller))
+	}
+	return warnings
+}
+
+// ToExportedName converts an arbitrary string (e.g. a name in OpenAPI or SQL schema) into a valid exported identifier of golang.
+// Each word is capitalized and the common initialisms are written in all capitals.
+//
+// e.g.
+//
+//	"user_id"     => "UserID"
+//	"my-field"    => "MyField"
+//	"http server" => "HTTPServer"
+//	"123abc"      => "X123abc"
+func ToExportedName(s string) string {
+	name := ""
+	for _, word := range splitWords(s) {
+		if upper := strings.ToUpper(word); commonInitialisms[upper] {
+			name += upper
+			continue
+		}
+		name += capitalize(word)
+	}
+
+	if name == "" {
+		return "X"
+	}
+	if first := []rune(name)[0]; !unicode.IsUpper(first) {
+		// e.g. it begins with a digit, or a letter that has no case
+		name = "X" + name
+	}
+	return name
+}
+
+// ToUnexportedName converts an arbitrary string (e.g. a name in OpenAPI or SQL schema) into a valid unexported identifier of golang.
+// The first word is written in all lower case and the rest are same as `ToExportedName()`.
+// If the result is a keyword, an underscore is appended to that.
+//
+// e.g.
+//
+//	"UserID"   => "userID"
+//	"URL path" => "urlPath"
+//	"type"     => "type_"
+func ToUnexportedName(s string) string {
+	words := splitWords(s)
+	if len(words) <= 0 {
+		return "x"
+	}
+
+	name := strings.ToLower(words[0])
+	for _, word := range words[1:] {
+		if upper := strings.ToUpper(word); commonInitialisms[upper] {
+			name += upper
+			continue
+		}
+		name += capitalize(word)
+	}
+
+	if first := []rune(name)[0]; !unicode.IsLetter(first) {
+		name = "x" + name
+	}
+	if token.IsKeyword(name) {
+		name += "_"
+	}
+	return name
+}
+
+// splitWords splits the string into the words by the characters that are not allowed in an identifier and the boundaries of camel case.
+// e.g. `HTTPServer_name` => `HTTP`, `Server`, `name`
+func splitWords(s string) []string {
+	var words []string
+	var word []rune
+
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = nil
+		}
+	}
+
+	runes := []rune(s)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+
+		if len(word) > 0 && unicode.IsUpper(r) {
+			prev := word[len(word)-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower) {
+				// e.g. `fooBar` => `foo`, `Bar` and `HTTPServer` => `HTTP`, `Server`
+				flush()
+			}
+		}
+		word = append(word, r)
+	}
+	flush()
+
+	return words
+}
+
+func capitalize(word string) string {
+	runes := []rune(strings.ToLower(word))
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
@@ -0,0 +1,15 @@
+package generator
+
+import (
+	"fmt"
+)
+
+func ExampleToExportedName() {
+	fmt.Println(ToExportedName("user_id"))
+	fmt.Println(ToExportedName("http-server"))
+}
+
+func ExampleToUnexportedName() {
+	fmt.Println(ToUnexportedName("UserID"))
+	fmt.Println(ToUnexportedName("type"))
+}
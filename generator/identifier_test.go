@@ -0,0 +1,122 @@
+package generator
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldRaiseErrorWhenIdentifierIsInvalid(t *testing.T) {
+	invalidErrPrefix := `^\` + strings.Split(errmsg.IdentifierIsInvalidError("", "").Error(), " ")[0]
+	keywordErrPrefix := `^\` + strings.Split(errmsg.IdentifierIsKeywordError("", "").Error(), " ")[0]
+
+	for _, c := range []struct {
+		generator Statement
+		errPrefix string
+	}{
+		{NewFuncSignature("my-func"), invalidErrPrefix},
+		{NewFuncSignature("type"), keywordErrPrefix},
+		{NewFuncSignature("f").AddParameters(NewFuncParameter("123abc", "string")), invalidErrPrefix},
+		{NewFuncSignature("f").AddParameters(NewFuncParameter("func", "string")), keywordErrPrefix},
+		{NewAnonymousFuncSignature().AddParameters(NewFuncParameter("my param", "string")), invalidErrPrefix},
+		{NewStruct("My.Struct"), invalidErrPrefix},
+		{NewStruct("MyStruct").AddField("my-field", "string"), invalidErrPrefix},
+		{NewStruct("MyStruct").AddField("range", "string"), keywordErrPrefix},
+		{NewFuncReceiver("1m", "*MyStruct"), invalidErrPrefix},
+		{NewPackage("my.pkg"), invalidErrPrefix},
+		{NewPackage("package"), keywordErrPrefix},
+	} {
+		_, err := c.generator.Generate(0)
+		assert.Regexp(t, regexp.MustCompile(c.errPrefix), err.Error())
+	}
+}
+
+func TestShouldAcceptUnicodeIdentifier(t *testing.T) {
+	for _, generator := range []Statement{
+		NewFuncSignature("名前").AddParameters(NewFuncParameter("_", "string"), NewFuncParameter("ÿ", "int")),
+		NewStruct("Straße").AddField("Größe", "int"),
+		NewFuncReceiver("_", "*MyStruct"),
+		NewPackage("mypkg"),
+	} {
+		_, err := generator.Generate(0)
+		assert.NoError(t, err)
+	}
+}
+
+func TestShouldReportWarningsOfShadowing(t *testing.T) {
+	root := NewRoot(
+		NewPackage("mypkg"),
+		NewStruct("error"),
+		NewFunc(
+			NewFuncReceiver("len", "*MyStruct"),
+			NewFuncSignature("string").AddParameters(NewFuncParameter("int", "string")),
+		),
+		NewFunc(
+			nil,
+			NewFuncSignature("append"),
+			NewAnonymousFunc(false, NewAnonymousFuncSignature().AddParameters(NewFuncParameter("nil", "string"))),
+		),
+		NewFunc(nil, NewFuncSignature("myFunc").AddParameters(NewFuncParameter("_", "string"))),
+	)
+
+	assert.NoError(t, root.Validate())
+
+	warnings := root.Warnings()
+	names := []string{}
+	for _, w := range warnings {
+		var genErr *GeneratorError
+		assert.True(t, errors.As(w, &genErr))
+		assert.Equal(t, ErrorKindWarning, genErr.Kind())
+		assert.True(t, errors.Is(w, ErrWarning))
+		assert.False(t, errors.Is(w, ErrValidation))
+		names = append(names, strings.Split(genErr.Message(), "'")[1])
+	}
+	assert.Equal(t, []string{"error", "len", "int", "append", "nil"}, names)
+}
+
+func TestShouldConvertToExportedName(t *testing.T) {
+	for input, expected := range map[string]string{
+		"user_id":          "UserID",
+		"userId":           "UserID",
+		"my-field":         "MyField",
+		"http server":      "HTTPServer",
+		"HTTPServer":       "HTTPServer",
+		"api_url":          "APIURL",
+		"utf8String":       "UTF8String",
+		"123abc":           "X123abc",
+		"type":             "Type",
+		"名前":               "X名前",
+		"straße":           "Straße",
+		"":                 "X",
+		"--":               "X",
+		"XMLHttpRequest":   "XMLHTTPRequest",
+		"already_Exported": "AlreadyExported",
+	} {
+		actual := ToExportedName(input)
+		assert.Equal(t, expected, actual, input)
+		assert.NoError(t, validateIdentifier(actual, ""))
+	}
+}
+
+func TestShouldConvertToUnexportedName(t *testing.T) {
+	for input, expected := range map[string]string{
+		"UserID":      "userID",
+		"URL path":    "urlPath",
+		"HTTPServer":  "httpServer",
+		"my-field":    "myField",
+		"type":        "type_",
+		"Func":        "func_",
+		"123abc":      "x123abc",
+		"名前":          "名前",
+		"":            "x",
+		"user_api_id": "userAPIID",
+	} {
+		actual := ToUnexportedName(input)
+		assert.Equal(t, expected, actual, input)
+		assert.NoError(t, validateIdentifier(actual, ""))
+	}
+}
@@ -2,17 +2,19 @@ package generator
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/moznion/gowrtr/internal/errmsg"
 )
 
 // If represents a code generator for `if`, `else-if` and `else` block.
 type If struct {
-	condition    string
-	statements   []Statement
-	elseIfBlocks []*ElseIf
-	elseBlock    *Else
-	caller       string
+	initStatement Statement
+	condition     string
+	statements    []Statement
+	elseIfBlocks  []*ElseIf
+	elseBlock     *Else
+	caller        string
 }
 
 // NewIf returns a new `If`.
@@ -24,15 +26,29 @@ func NewIf(condition string, statements ...Statement) *If {
 	}
 }
 
+// Init sets an init statement to `If` (e.g. `err := f()` of `if err := f(); err != nil {`).
+// This method returns a *new* `If`; it means this method acts as immutable.
+func (ig *If) Init(init Statement) *If {
+	return &If{
+		initStatement: init,
+		condition:     ig.condition,
+		statements:    ig.statements,
+		elseIfBlocks:  ig.elseIfBlocks,
+		elseBlock:     ig.elseBlock,
+		caller:        ig.caller,
+	}
+}
+
 // AddStatements adds statements for `if` block to `If`. This does *not* set, just add.
 // This method returns a *new* `If`; it means this method acts as immutable.
 func (ig *If) AddStatements(statements ...Statement) *If {
 	return &If{
-		condition:    ig.condition,
-		statements:   append(ig.statements, statements...),
-		elseIfBlocks: ig.elseIfBlocks,
-		elseBlock:    ig.elseBlock,
-		caller:       ig.caller,
+		initStatement: ig.initStatement,
+		condition:     ig.condition,
+		statements:    append(ig.statements, statements...),
+		elseIfBlocks:  ig.elseIfBlocks,
+		elseBlock:     ig.elseBlock,
+		caller:        ig.caller,
 	}
 }
 
@@ -40,11 +56,12 @@ func (ig *If) AddStatements(statements ...Statement) *If {
 // This method returns a *new* `If`; it means this method acts as immutable.
 func (ig *If) Statements(statements ...Statement) *If {
 	return &If{
-		condition:    ig.condition,
-		statements:   statements,
-		elseIfBlocks: ig.elseIfBlocks,
-		elseBlock:    ig.elseBlock,
-		caller:       ig.caller,
+		initStatement: ig.initStatement,
+		condition:     ig.condition,
+		statements:    statements,
+		elseIfBlocks:  ig.elseIfBlocks,
+		elseBlock:     ig.elseBlock,
+		caller:        ig.caller,
 	}
 }
 
@@ -52,11 +69,12 @@ func (ig *If) Statements(statements ...Statement) *If {
 // This method returns a *new* `If`; it means this method acts as immutable.
 func (ig *If) AddElseIf(blocks ...*ElseIf) *If {
 	return &If{
-		condition:    ig.condition,
-		statements:   ig.statements,
-		elseIfBlocks: append(ig.elseIfBlocks, blocks...),
-		elseBlock:    ig.elseBlock,
-		caller:       ig.caller,
+		initStatement: ig.initStatement,
+		condition:     ig.condition,
+		statements:    ig.statements,
+		elseIfBlocks:  append(ig.elseIfBlocks, blocks...),
+		elseBlock:     ig.elseBlock,
+		caller:        ig.caller,
 	}
 }
 
@@ -64,11 +82,12 @@ func (ig *If) AddElseIf(blocks ...*ElseIf) *If {
 // This method returns a *new* `If`; it means this method acts as immutable.
 func (ig *If) ElseIf(blocks ...*ElseIf) *If {
 	return &If{
-		condition:    ig.condition,
-		statements:   ig.statements,
-		elseIfBlocks: blocks,
-		elseBlock:    ig.elseBlock,
-		caller:       ig.caller,
+		initStatement: ig.initStatement,
+		condition:     ig.condition,
+		statements:    ig.statements,
+		elseIfBlocks:  blocks,
+		elseBlock:     ig.elseBlock,
+		caller:        ig.caller,
 	}
 }
 
@@ -76,32 +95,37 @@ func (ig *If) ElseIf(blocks ...*ElseIf) *If {
 // This method returns a *new* `If`; it means this method acts as immutable.
 func (ig *If) Else(block *Else) *If {
 	return &If{
-		condition:    ig.condition,
-		statements:   ig.statements,
-		elseIfBlocks: ig.elseIfBlocks,
-		elseBlock:    block,
-		caller:       ig.caller,
+		initStatement: ig.initStatement,
+		condition:     ig.condition,
+		statements:    ig.statements,
+		elseIfBlocks:  ig.elseIfBlocks,
+		elseBlock:     block,
+		caller:        ig.caller,
 	}
 }
 
 // Generate generates `if` block as golang code.
-func (ig *If) Generate(indentLevel int) (string, error) {
+func (ig *If) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, ig.pathSegment())
+
+	if err := firstError(ig.validate()); err != nil {
+		return "", err
+	}
+
 	indent := BuildIndent(indentLevel)
 
-	if ig.condition == "" {
-		return "", errmsg.IfConditionIsEmptyError(ig.caller)
+	header, err := generateIfHeader(ig.initStatement, ig.condition)
+	if err != nil {
+		return "", err
 	}
 
-	stmt := fmt.Sprintf("%sif %s {\n", indent, ig.condition)
+	stmt := fmt.Sprintf("%sif %s {\n", indent, header)
 
-	nextIndentLevel := indentLevel + 1
-	for _, c := range ig.statements {
-		gen, err := c.Generate(nextIndentLevel)
-		if err != nil {
-			return "", err
-		}
-		stmt += gen
+	body, err := generateBlockBody(ig.statements, indentLevel+1)
+	if err != nil {
+		return "", err
 	}
+	stmt += body
 
 	stmt += fmt.Sprintf("%s}", indent)
 
@@ -129,3 +153,62 @@ func (ig *If) Generate(indentLevel int) (string, error) {
 
 	return stmt, nil
 }
+
+// generateIfHeader generates the part between `if` and `{`.
+func generateIfHeader(init Statement, condition string) (string, error) {
+	if init == nil {
+		return condition, nil
+	}
+
+	gen, err := generateSimpleStatement(init)
+	if err != nil {
+		return "", err
+	}
+	return gen + "; " + condition, nil
+}
+
+// generateBlockBody generates statements of the block. The result always ends with a newline (if it is not empty)
+// so that the closing brace of the block begins on its own line.
+func generateBlockBody(statements []Statement, indentLevel int) (string, error) {
+	body := ""
+	for _, c := range statements {
+		gen, err := c.Generate(indentLevel)
+		if err != nil {
+			return "", err
+		}
+		body += gen
+	}
+
+	if body != "" && !strings.HasSuffix(body, "\n") {
+		body += "\n"
+	}
+	return body, nil
+}
+
+func (ig *If) validate() []error {
+	if ig.condition == "" {
+		return []error{errmsg.IfConditionIsEmptyError(ig.caller)}
+	}
+	return nil
+}
+
+func (ig *If) childStatements() []Statement {
+	var children []Statement
+	if ig.initStatement != nil {
+		children = append(children, ig.initStatement)
+	}
+	children = append(children, ig.statements...)
+	for _, elseIfBlock := range ig.elseIfBlocks {
+		if elseIfBlock != nil {
+			children = append(children, elseIfBlock)
+		}
+	}
+	if ig.elseBlock != nil {
+		children = append(children, ig.elseBlock)
+	}
+	return children
+}
+
+func (ig *If) pathSegment() errorPathSegment {
+	return errorPathSegment{name: "If", caller: ig.caller}
+}
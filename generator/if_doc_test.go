@@ -22,3 +22,16 @@ func ExampleIf_Generate() {
 	}
 	fmt.Println(generated)
 }
+
+func ExampleIf_Init() {
+	generator := NewIf(
+		"err != nil",
+		NewReturnStatement("err"),
+	).Init(NewShortVarDecl([]string{"err"}, NewRawStatement("f()")))
+
+	generated, err := generator.Generate(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(generated)
+}
@@ -176,3 +176,71 @@ func TestShouldGenerateIfElseRaisesError(t *testing.T) {
 		`^\`+strings.Split(errmsg.FuncNameIsEmptyError("").Error(), " ")[0],
 	), err.Error())
 }
+
+func TestShouldGenerateIfCodeWithInitStatement(t *testing.T) {
+	generator := NewIf("err != nil", NewReturnStatement("err")).
+		Init(NewShortVarDecl([]string{"err"}, NewRawStatement("f()"))).
+		AddElseIf(
+			NewElseIf("v > 0", NewReturnStatement("nil")).
+				Init(NewShortVarDecl([]string{"v"}, NewRawStatement("g()"))),
+		)
+
+	expected := `if err := f(); err != nil {
+	return err
+} else if v := g(); v > 0 {
+	return nil
+}
+`
+	gen, err := generator.Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+}
+
+func TestShouldGenerateIfCodeWithEmptyStatements(t *testing.T) {
+	generator := NewIf("i == 0").
+		AddElseIf(NewElseIf("i < 0")).
+		Else(NewElse())
+
+	expected := `	if i == 0 {
+	} else if i < 0 {
+	} else {
+	}
+`
+	gen, err := generator.Generate(1)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+}
+
+func TestShouldGenerateIfCodeWhenStatementDoesNotEndWithNewline(t *testing.T) {
+	generator := NewIf("i == 0", NewRawStatement("a()").WithNewline(false)).
+		AddElseIf(NewElseIf("i < 0", NewRawStatement("b()").WithNewline(false))).
+		Else(NewElse(NewRawStatement("c()").WithNewline(false)))
+
+	expected := `if i == 0 {
+	a()
+} else if i < 0 {
+	b()
+} else {
+	c()
+}
+`
+	gen, err := generator.Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+}
+
+func TestShouldGenerateIfRaisesErrorWhenInitStatementRaisesError(t *testing.T) {
+	{
+		_, err := NewIf("i > 0").Init(NewIncrement("")).Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.IncDecTargetIsEmptyError("").Error(), " ")[0],
+		), err.Error())
+	}
+
+	{
+		_, err := NewIf("i > 0").AddElseIf(NewElseIf("i < 0").Init(NewIncrement(""))).Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.IncDecTargetIsEmptyError("").Error(), " ")[0],
+		), err.Error())
+	}
+}
@@ -6,13 +6,15 @@ import (
 
 // Import represents a code generator for `import` statement.
 type Import struct {
-	names []string
+	names  []string
+	caller string
 }
 
 // NewImport returns a new `Import`.
 func NewImport(names ...string) *Import {
 	return &Import{
-		names: names,
+		names:  names,
+		caller: fetchClientCallerLine(),
 	}
 }
 
@@ -20,7 +22,8 @@ func NewImport(names ...string) *Import {
 // This method returns a *new* `Import`; it means this method acts as immutable.
 func (ig *Import) AddImports(imps ...string) *Import {
 	return &Import{
-		names: append(ig.names, imps...),
+		names:  append(ig.names, imps...),
+		caller: ig.caller,
 	}
 }
 
@@ -28,12 +31,15 @@ func (ig *Import) AddImports(imps ...string) *Import {
 // This method returns a *new* `Import`; it means this method acts as immutable.
 func (ig *Import) Imports(imps ...string) *Import {
 	return &Import{
-		names: imps,
+		names:  imps,
+		caller: ig.caller,
 	}
 }
 
 // Generate generates `import` statement as golang code.
-func (ig *Import) Generate(indentLevel int) (string, error) {
+func (ig *Import) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, ig.pathSegment())
+
 	if len(ig.names) <= 0 {
 		return "", nil
 	}
@@ -50,3 +56,7 @@ func (ig *Import) Generate(indentLevel int) (string, error) {
 
 	return stmt, nil
 }
+
+func (ig *Import) pathSegment() errorPathSegment {
+	return errorPathSegment{name: "Import", caller: ig.caller}
+}
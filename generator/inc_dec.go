@@ -0,0 +1,53 @@
+package generator
+
+import (
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
+
+// IncDec represents a code generator for increment and decrement statement (i.e. `x++` and `x--`).
+type IncDec struct {
+	target   string
+	operator string
+	caller   string
+}
+
+// NewIncrement returns a new `IncDec` that generates `target++`.
+func NewIncrement(target string) *IncDec {
+	return &IncDec{
+		target:   target,
+		operator: "++",
+		caller:   fetchClientCallerLine(),
+	}
+}
+
+// NewDecrement returns a new `IncDec` that generates `target--`.
+func NewDecrement(target string) *IncDec {
+	return &IncDec{
+		target:   target,
+		operator: "--",
+		caller:   fetchClientCallerLine(),
+	}
+}
+
+// Generate generates an increment or decrement statement as golang code.
+func (i *IncDec) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, i.pathSegment())
+
+	if err := firstError(i.validate()); err != nil {
+		return "", err
+	}
+
+	indent := BuildIndent(indentLevel)
+	return indent + i.target + i.operator + "\n", nil
+}
+
+func (i *IncDec) validate() []error {
+	if i.target == "" {
+		return []error{errmsg.IncDecTargetIsEmptyError(i.caller)}
+	}
+	return nil
+}
+
+func (i *IncDec) pathSegment() errorPathSegment {
+	return errorPathSegment{name: "IncDec", caller: i.caller}
+}
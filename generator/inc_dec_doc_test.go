@@ -0,0 +1,16 @@
+package generator
+
+import (
+	"fmt"
+	"log"
+)
+
+func ExampleIncDec_Generate() {
+	generator := NewIncrement("i")
+
+	generated, err := generator.Generate(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(generated)
+}
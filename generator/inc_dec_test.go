@@ -0,0 +1,31 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldGenerateIncDec(t *testing.T) {
+	{
+		gen, err := NewIncrement("i").Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "i++\n", gen)
+	}
+
+	{
+		gen, err := NewDecrement("s.count").Generate(2)
+		assert.NoError(t, err)
+		assert.Equal(t, "\t\ts.count--\n", gen)
+	}
+}
+
+func TestShouldGenerateIncDecRaisesError(t *testing.T) {
+	_, err := NewIncrement("").Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.IncDecTargetIsEmptyError("").Error(), " ")[0],
+	), err.Error())
+}
@@ -43,9 +43,11 @@ func (ig *Interface) Signatures(sig ...*FuncSignature) *Interface {
 }
 
 // Generate generates `interface` block as golang code.
-func (ig *Interface) Generate(indentLevel int) (string, error) {
-	if ig.name == "" {
-		return "", errmsg.InterfaceNameIsEmptyError(ig.caller)
+func (ig *Interface) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, ig.pathSegment())
+
+	if err := firstError(ig.validate()); err != nil {
+		return "", err
 	}
 
 	indent := BuildIndent(indentLevel)
@@ -63,3 +65,24 @@ func (ig *Interface) Generate(indentLevel int) (string, error) {
 
 	return stmt, nil
 }
+
+func (ig *Interface) validate() []error {
+	if ig.name == "" {
+		return []error{errmsg.InterfaceNameIsEmptyError(ig.caller)}
+	}
+	return nil
+}
+
+func (ig *Interface) childStatements() []Statement {
+	children := make([]Statement, 0, len(ig.funcSignatures))
+	for _, sig := range ig.funcSignatures {
+		if sig != nil {
+			children = append(children, sig)
+		}
+	}
+	return children
+}
+
+func (ig *Interface) pathSegment() errorPathSegment {
+	return errorPathSegment{name: namedPathSegment("Interface", ig.name), caller: ig.caller}
+}
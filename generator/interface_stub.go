@@ -0,0 +1,233 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
+
+// InterfaceStub represents a code generator for the stub implementation of the interface;
+// it generates a struct and the methods that implement the interface, like the `impl` tool.
+//
+// Each method panics with "not implemented" by default, or returns the zero values if `ReturnZeroValues()` is enabled.
+type InterfaceStub struct {
+	typeName           string
+	iface              *Interface
+	receiverName       string
+	valueReceiver      bool
+	zeroValueReturning bool
+	existingType       bool
+	implementedMethods []string
+	caller             string
+}
+
+// NewInterfaceStub returns a new `InterfaceStub` that implements `iface` by the type named `typeName`.
+// The interface can be loaded from a real package; please see also `LoadInterface()`.
+func NewInterfaceStub(typeName string, iface *Interface) *InterfaceStub {
+	return &InterfaceStub{
+		typeName: typeName,
+		iface:    iface,
+		caller:   fetchClientCallerLine(),
+	}
+}
+
+// ReceiverName sets the name of the receiver of each method.
+// By default, it is the lower case of the first letter of the type name.
+// This method returns a *new* `InterfaceStub`; it means this method acts as immutable.
+func (s *InterfaceStub) ReceiverName(name string) *InterfaceStub {
+	return &InterfaceStub{
+		typeName:           s.typeName,
+		iface:              s.iface,
+		receiverName:       name,
+		valueReceiver:      s.valueReceiver,
+		zeroValueReturning: s.zeroValueReturning,
+		existingType:       s.existingType,
+		implementedMethods: s.implementedMethods,
+		caller:             s.caller,
+	}
+}
+
+// ValueReceiver makes each method have a value receiver instead of a pointer receiver.
+// This method returns a *new* `InterfaceStub`; it means this method acts as immutable.
+func (s *InterfaceStub) ValueReceiver(enabled bool) *InterfaceStub {
+	return &InterfaceStub{
+		typeName:           s.typeName,
+		iface:              s.iface,
+		receiverName:       s.receiverName,
+		valueReceiver:      enabled,
+		zeroValueReturning: s.zeroValueReturning,
+		existingType:       s.existingType,
+		implementedMethods: s.implementedMethods,
+		caller:             s.caller,
+	}
+}
+
+// ReturnZeroValues makes each method return the zero values instead of panicking.
+// This method returns a *new* `InterfaceStub`; it means this method acts as immutable.
+func (s *InterfaceStub) ReturnZeroValues(enabled bool) *InterfaceStub {
+	return &InterfaceStub{
+		typeName:           s.typeName,
+		iface:              s.iface,
+		receiverName:       s.receiverName,
+		valueReceiver:      s.valueReceiver,
+		zeroValueReturning: enabled,
+		existingType:       s.existingType,
+		implementedMethods: s.implementedMethods,
+		caller:             s.caller,
+	}
+}
+
+// ExistingType makes the stub for the type that already exists; it doesn't generate the struct,
+// and it skips the methods named `implementedMethods` because they are already implemented.
+// The method names of the existing type can be loaded by `LoadMethodNames()`.
+// This method returns a *new* `InterfaceStub`; it means this method acts as immutable.
+func (s *InterfaceStub) ExistingType(implementedMethods ...string) *InterfaceStub {
+	return &InterfaceStub{
+		typeName:           s.typeName,
+		iface:              s.iface,
+		receiverName:       s.receiverName,
+		valueReceiver:      s.valueReceiver,
+		zeroValueReturning: s.zeroValueReturning,
+		existingType:       true,
+		implementedMethods: implementedMethods,
+		caller:             s.caller,
+	}
+}
+
+// Generate generates the stub implementation of the interface as golang code.
+func (s *InterfaceStub) Generate(indentLevel int) (generated string, err error) {
+	defer annotateComposedErrorPath(&err, s.pathSegment())
+
+	if err := firstError(s.validate()); err != nil {
+		return "", err
+	}
+
+	stmt := ""
+	for i, statement := range s.statements() {
+		if i > 0 {
+			stmt += "\n"
+		}
+		gen, err := statement.Generate(indentLevel)
+		if err != nil {
+			return "", err
+		}
+		stmt += gen
+	}
+	return stmt, nil
+}
+
+// statements builds the struct and the methods by the generators.
+func (s *InterfaceStub) statements() []Statement {
+	implemented := map[string]bool{}
+	for _, name := range s.implementedMethods {
+		implemented[name] = true
+	}
+
+	var statements []Statement
+	if !s.existingType {
+		statements = append(statements, NewStruct(s.typeName))
+	}
+
+	for _, sig := range s.iface.funcSignatures {
+		if sig == nil || implemented[sig.funcName] {
+			continue
+		}
+
+		receiverType := "*" + s.typeName
+		if s.valueReceiver {
+			receiverType = s.typeName
+		}
+
+		statements = append(
+			statements,
+			NewFunc(NewFuncReceiver(s.stubReceiverName(sig), receiverType), sig, s.stubBody(sig)...),
+		)
+	}
+
+	return statements
+}
+
+// stubReceiverName returns the receiver name that doesn't conflict with the parameter names.
+func (s *InterfaceStub) stubReceiverName(sig *FuncSignature) string {
+	name := s.receiverName
+	if name == "" {
+		name = strings.ToLower(string([]rune(s.typeName)[0]))
+	}
+
+	for _, param := range sig.funcParameters {
+		if param.name == name {
+			return "_"
+		}
+	}
+	for _, ret := range sig.returnTypes {
+		if ret.name == name {
+			return "_"
+		}
+	}
+	return name
+}
+
+func (s *InterfaceStub) stubBody(sig *FuncSignature) []Statement {
+	if !s.zeroValueReturning {
+		return []Statement{NewRawStatement(`panic("not implemented")`)}
+	}
+
+	if len(sig.returnTypes) <= 0 {
+		return nil
+	}
+	zeroValues := make([]string, len(sig.returnTypes))
+	for i, ret := range sig.returnTypes {
+		zeroValues[i] = zeroValueOf(ret.typ)
+	}
+	return []Statement{NewReturnStatement(zeroValues...)}
+}
+
+// zeroValueOf returns the expression of the zero value of the type.
+// The type is given as string, so the underlying type of a defined type is unknown; `*new(T)` is used for that.
+func zeroValueOf(typ string) string {
+	switch typ {
+	case "bool":
+		return "false"
+	case "string":
+		return `""`
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64", "uintptr",
+		"byte", "rune", "float32", "float64", "complex64", "complex128":
+		return "0"
+	case "error", "any":
+		return "nil"
+	}
+
+	for _, prefix := range []string{"*", "[]", "map[", "chan ", "chan<-", "<-chan", "func(", "interface{", "interface {"} {
+		if strings.HasPrefix(typ, prefix) {
+			return "nil"
+		}
+	}
+	return "*new(" + typ + ")"
+}
+
+func (s *InterfaceStub) validate() []error {
+	var errs []error
+	if s.typeName == "" {
+		errs = append(errs, errmsg.StubTypeNameIsEmptyError(s.caller))
+	}
+	errs = appendIdentifierError(errs, s.typeName, s.caller)
+	if s.iface == nil {
+		errs = append(errs, errmsg.StubInterfaceIsNilError(s.caller))
+	}
+	errs = appendIdentifierError(errs, s.receiverName, s.caller)
+	return errs
+}
+
+func (s *InterfaceStub) childStatements() []Statement {
+	if s.typeName == "" || s.iface == nil {
+		return nil
+	}
+	return s.statements()
+}
+
+func (s *InterfaceStub) pathSegment() errorPathSegment {
+	return errorPathSegment{name: namedPathSegment("InterfaceStub", s.typeName), caller: s.caller}
+}
+
+func (s *InterfaceStub) composesStatements() {}
@@ -0,0 +1,39 @@
+package generator
+
+import (
+	"fmt"
+	"log"
+)
+
+func ExampleInterfaceStub_Generate() {
+	iface := NewInterface(
+		"Store",
+		NewFuncSignature("Get").
+			AddParameters(NewFuncParameter("key", "string")).
+			AddReturnTypes("[]byte", "error"),
+	)
+
+	generator := NewInterfaceStub("memoryStore", iface).ReturnZeroValues(true)
+
+	generated, err := generator.Generate(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(generated)
+}
+
+func ExampleLoadInterface() {
+	iface, err := LoadInterface("", "io", "ReadCloser")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	generated, err := NewRoot(
+		NewPackage("mypkg"),
+		NewInterfaceStub("nopReadCloser", iface),
+	).Gofmt().Generate(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(generated)
+}
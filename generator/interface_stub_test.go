@@ -0,0 +1,133 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldGenerateInterfaceStub(t *testing.T) {
+	iface := NewInterface(
+		"Store",
+		NewFuncSignature("Get").
+			AddParameters(NewFuncParameter("key", "string")).
+			AddReturnTypes("[]byte", "error"),
+		NewFuncSignature("Close"),
+	)
+
+	expected := `type memoryStore struct {
+}
+
+func (m *memoryStore) Get(key string) ([]byte, error) {
+	panic("not implemented")
+}
+
+func (m *memoryStore) Close() {
+	panic("not implemented")
+}
+`
+	generated, err := NewInterfaceStub("memoryStore", iface).Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, generated)
+}
+
+func TestShouldGenerateInterfaceStubWithZeroValues(t *testing.T) {
+	iface := NewInterface(
+		"Store",
+		NewFuncSignature("Get").
+			AddParameters(NewFuncParameter("m", "string")).
+			AddReturnTypes("[]byte", "bool", "int64", "string", "Item", "map[string]int", "error"),
+		NewFuncSignature("Close"),
+	)
+
+	expected := `type memoryStore struct {
+}
+
+func (_ memoryStore) Get(m string) ([]byte, bool, int64, string, Item, map[string]int, error) {
+	return nil, false, 0, "", *new(Item), nil, nil
+}
+
+func (m memoryStore) Close() {
+}
+`
+	generated, err := NewInterfaceStub("memoryStore", iface).
+		ValueReceiver(true).
+		ReturnZeroValues(true).
+		Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, generated)
+}
+
+func TestShouldGenerateInterfaceStubForExistingType(t *testing.T) {
+	iface := NewInterface(
+		"Store",
+		NewFuncSignature("Get").
+			AddParameters(NewFuncParameter("key", "string")).
+			AddReturnTypes("[]byte", "error"),
+		NewFuncSignature("Close"),
+	)
+
+	expected := `func (store *memoryStore) Get(key string) ([]byte, error) {
+	panic("not implemented")
+}
+`
+	generated, err := NewInterfaceStub("memoryStore", iface).
+		ReceiverName("store").
+		ExistingType("Close").
+		Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, generated)
+}
+
+func TestShouldGenerateInterfaceStubThatPassesTypeChecking(t *testing.T) {
+	iface, err := LoadInterface("", "./testdata/stubtarget", "Store")
+	assert.NoError(t, err)
+
+	generated, err := NewRoot(
+		NewPackage("mypkg"),
+		NewImport("github.com/moznion/gowrtr/generator/testdata/stubtarget"),
+		NewInterfaceStub("store", iface),
+		NewRawStatement("var _ stubtarget.Store = &store{}"),
+	).Gofmt().EnableTypeChecking("").Generate(0)
+	assert.NoError(t, err)
+	assert.Contains(t, generated, "func (s *store) Keys(\n\tprefix string,\n\tlimit ...int,\n) []string {")
+	assert.Contains(t, generated, "func (s *store) Close() error {")
+}
+
+func TestShouldGenerateInterfaceStubSkipImplementedMethods(t *testing.T) {
+	iface, err := LoadInterface("", "./testdata/stubtarget", "Store")
+	assert.NoError(t, err)
+	implemented, err := LoadMethodNames("", "./testdata/stubtarget", "PartialStore")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Close"}, implemented)
+
+	generated, err := NewInterfaceStub("PartialStore", iface).ExistingType(implemented...).Generate(0)
+	assert.NoError(t, err)
+	assert.NotContains(t, generated, "type PartialStore struct")
+	assert.NotContains(t, generated, "Close()")
+	assert.Contains(t, generated, "func (p *PartialStore) Get(key string) (value []byte, err error) {")
+}
+
+func TestShouldRaiseErrorWhenInterfaceStubIsInvalid(t *testing.T) {
+	_, err := NewInterfaceStub("", NewInterface("Store")).Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.StubTypeNameIsEmptyError("").Error(), " ")[0],
+	), err.Error())
+
+	_, err = NewInterfaceStub("store", nil).Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.StubInterfaceIsNilError("").Error(), " ")[0],
+	), err.Error())
+
+	_, err = NewInterfaceStub("store", NewInterface("Store", NewFuncSignature(""))).Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.FuncNameIsEmptyError("").Error(), " ")[0],
+	), err.Error())
+
+	err = NewRoot(NewInterfaceStub("type", nil)).Validate()
+	assert.Len(t, err.(*ValidationErrors).Errors(), 2)
+}
@@ -0,0 +1,240 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
+
+type jsonSampleKind int
+
+const (
+	jsonSampleUnknown jsonSampleKind = iota
+	jsonSampleBool
+	jsonSampleInteger
+	jsonSampleNumber
+	jsonSampleString
+	jsonSampleArray
+	jsonSampleObject
+	jsonSampleMixed
+)
+
+// jsonSample is the merged shape of the values that appear at the same position of the samples.
+type jsonSample struct {
+	kind     jsonSampleKind
+	nullable bool
+
+	// for object; the properties are kept in the order of appearance
+	properties   []string
+	propSamples  map[string]*jsonSample
+	propCounts   map[string]int
+	objectsCount int
+
+	// for array
+	items *jsonSample
+}
+
+func (s *jsonSample) merge(kind jsonSampleKind) {
+	switch {
+	case s.kind == jsonSampleUnknown || s.kind == kind:
+		s.kind = kind
+	case (s.kind == jsonSampleInteger && kind == jsonSampleNumber) || (s.kind == jsonSampleNumber && kind == jsonSampleInteger):
+		s.kind = jsonSampleNumber
+	default:
+		s.kind = jsonSampleMixed
+	}
+}
+
+// mergeSample merges the other sample into the sample.
+func (s *jsonSample) mergeSample(other *jsonSample) {
+	s.nullable = s.nullable || other.nullable
+	if other.kind != jsonSampleUnknown {
+		s.merge(other.kind)
+	}
+
+	s.objectsCount += other.objectsCount
+	for _, prop := range other.properties {
+		if s.propSamples == nil {
+			s.propSamples = map[string]*jsonSample{}
+			s.propCounts = map[string]int{}
+		}
+		if _, ok := s.propSamples[prop]; !ok {
+			s.properties = append(s.properties, prop)
+			s.propSamples[prop] = &jsonSample{}
+		}
+		s.propCounts[prop] += other.propCounts[prop]
+		s.propSamples[prop].mergeSample(other.propSamples[prop])
+	}
+
+	if other.items != nil {
+		if s.items == nil {
+			s.items = &jsonSample{}
+		}
+		s.items.mergeSample(other.items)
+	}
+}
+
+// add merges the next value of the decoder into the sample.
+func (s *jsonSample) add(dec *json.Decoder) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	switch v := tok.(type) {
+	case nil:
+		s.nullable = true
+	case bool:
+		s.merge(jsonSampleBool)
+	case string:
+		s.merge(jsonSampleString)
+	case json.Number:
+		if strings.ContainsAny(v.String(), ".eE") {
+			s.merge(jsonSampleNumber)
+		} else {
+			s.merge(jsonSampleInteger)
+		}
+	case json.Delim:
+		if v == '[' {
+			s.merge(jsonSampleArray)
+			for dec.More() {
+				if s.items == nil {
+					s.items = &jsonSample{}
+				}
+				if err := s.items.add(dec); err != nil {
+					return err
+				}
+			}
+			_, err := dec.Token()
+			return err
+		}
+
+		s.merge(jsonSampleObject)
+		s.objectsCount++
+		if s.propSamples == nil {
+			s.propSamples = map[string]*jsonSample{}
+			s.propCounts = map[string]int{}
+		}
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			prop := tok.(string)
+			if _, ok := s.propSamples[prop]; !ok {
+				s.properties = append(s.properties, prop)
+				s.propSamples[prop] = &jsonSample{}
+			}
+			s.propCounts[prop]++
+			if err := s.propSamples[prop].add(dec); err != nil {
+				return err
+			}
+		}
+		_, err := dec.Token()
+		return err
+	}
+	return nil
+}
+
+// NewJSONTypesFromSamples returns a new `JSONTypes` that is inferred from the JSON samples. Each sample must be an object,
+// or an array of objects; the elements of such array are treated as the samples.
+// The samples are merged, and the types are inferred as the following:
+//
+//   - the property that appears in all of the samples is required, and the others are tagged with `omitempty`
+//   - the property that is `null` in any sample is a pointer, unless the type is nilable already
+//   - the number is `int64` if all of the values are integers, otherwise `float64`
+//   - the nested object is a named struct, that is named by the parent type and the property (e.g. `UserAddress`);
+//     the optional one is a pointer as well as the schema mode, so that it is omitted by `omitempty`
+//   - the value that has the different types in the samples (and the empty array) is `interface{}`
+func NewJSONTypesFromSamples(rootTypeName string, samples ...[]byte) (*JSONTypes, error) {
+	return newJSONTypesFromSamples(rootTypeName, samples, fetchClientCallerLine())
+}
+
+func newJSONTypesFromSamples(rootTypeName string, samples [][]byte, caller string) (*JSONTypes, error) {
+	root := &jsonSample{}
+	for i, sample := range samples {
+		dec := json.NewDecoder(bytes.NewReader(sample))
+		dec.UseNumber()
+
+		var parsed jsonSample
+		if err := parsed.add(dec); err != nil {
+			return nil, errmsg.JSONSampleIsInvalidError(i, err.Error(), caller)
+		}
+		if _, err := dec.Token(); err == nil {
+			return nil, errmsg.JSONSampleIsInvalidError(i, "it has the extra data after the value", caller)
+		}
+
+		switch {
+		case parsed.kind == jsonSampleObject && !parsed.nullable:
+			root.mergeSample(&parsed)
+		case parsed.kind == jsonSampleArray && parsed.items != nil && parsed.items.kind == jsonSampleObject && !parsed.items.nullable:
+			root.mergeSample(parsed.items)
+		default:
+			return nil, errmsg.JSONSampleIsInvalidError(i, "it must be an object or an array of objects", caller)
+		}
+	}
+
+	inferrer := &jsonSampleInferrer{
+		names: jsonTypeNames{rootTypeName: true},
+	}
+	inferrer.declareStruct(rootTypeName, root)
+
+	return &JSONTypes{
+		rootTypeName: rootTypeName,
+		decls:        inferrer.decls,
+		caller:       caller,
+	}, nil
+}
+
+// jsonSampleInferrer converts the merged sample into the declarations of the types.
+type jsonSampleInferrer struct {
+	names jsonTypeNames
+	decls []*jsonTypeDecl
+}
+
+func (i *jsonSampleInferrer) declareStruct(name string, s *jsonSample) {
+	decl := &jsonTypeDecl{kind: jsonTypeDeclStruct, name: name}
+	i.decls = append(i.decls, decl)
+
+	used := map[string]bool{}
+	for _, prop := range s.properties {
+		fieldName := uniqueFieldName(prop, used)
+		propSample := s.propSamples[prop]
+		typ, nilable := i.typeOf(propSample, name+fieldName)
+		required := s.propCounts[prop] == s.objectsCount
+		if propSample.nullable || (propSample.kind == jsonSampleObject && !required) {
+			typ = pointerTypeOf(typ, nilable)
+		}
+		decl.fields = append(decl.fields, jsonField(fieldName, typ, prop, required, ""))
+	}
+}
+
+// typeOf returns the golang type of the sample and whether the zero value of that is nil.
+func (i *jsonSampleInferrer) typeOf(s *jsonSample, hint string) (string, bool) {
+	switch s.kind {
+	case jsonSampleBool:
+		return "bool", false
+	case jsonSampleInteger:
+		return "int64", false
+	case jsonSampleNumber:
+		return "float64", false
+	case jsonSampleString:
+		return "string", false
+	case jsonSampleArray:
+		if s.items == nil {
+			return "[]interface{}", true
+		}
+		typ, nilable := i.typeOf(s.items, singularName(hint))
+		if s.items.nullable {
+			typ = pointerTypeOf(typ, nilable)
+		}
+		return "[]" + typ, true
+	case jsonSampleObject:
+		name := i.names.reserve(hint)
+		i.declareStruct(name, s)
+		return name, false
+	}
+	return "interface{}", true
+}
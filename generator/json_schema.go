@@ -0,0 +1,507 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
+
+// jsonSchema is a subset of JSON Schema that is used to derive the golang types.
+type jsonSchema struct {
+	Ref                  string               `json:"$ref"`
+	Type                 jsonSchemaTypes      `json:"type"`
+	Format               string               `json:"format"`
+	Title                string               `json:"title"`
+	Description          string               `json:"description"`
+	Properties           jsonSchemaProperties `json:"properties"`
+	Required             []string             `json:"required"`
+	Items                *jsonSchema          `json:"items"`
+	AdditionalProperties json.RawMessage      `json:"additionalProperties"`
+	Enum                 []json.RawMessage    `json:"enum"`
+	OneOf                []*jsonSchema        `json:"oneOf"`
+	AnyOf                []*jsonSchema        `json:"anyOf"`
+	AllOf                []*jsonSchema        `json:"allOf"`
+	Nullable             bool                 `json:"nullable"`
+	Definitions          jsonSchemaProperties `json:"definitions"`
+	Defs                 jsonSchemaProperties `json:"$defs"`
+}
+
+// jsonSchemaTypes is the value of `type`; it is either a string or an array of strings.
+type jsonSchemaTypes []string
+
+func (t *jsonSchemaTypes) UnmarshalJSON(data []byte) error {
+	var typ string
+	if err := json.Unmarshal(data, &typ); err == nil {
+		*t = jsonSchemaTypes{typ}
+		return nil
+	}
+
+	var types []string
+	if err := json.Unmarshal(data, &types); err != nil {
+		return fmt.Errorf("type must be a string or an array of strings: %s", data)
+	}
+	*t = types
+	return nil
+}
+
+// jsonSchemaProperty is a named schema of `properties` and `definitions`.
+type jsonSchemaProperty struct {
+	name   string
+	schema *jsonSchema
+}
+
+// jsonSchemaProperties is an object of the named schemas; it keeps the order of the document, so the generated code is deterministic.
+type jsonSchemaProperties []*jsonSchemaProperty
+
+func (p *jsonSchemaProperties) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return fmt.Errorf("properties and definitions must be an object: %s", data)
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		var schema jsonSchema
+		if err := dec.Decode(&schema); err != nil {
+			return err
+		}
+		*p = append(*p, &jsonSchemaProperty{name: tok.(string), schema: &schema})
+	}
+	return nil
+}
+
+// types returns the types except `null`.
+func (s *jsonSchema) types() []string {
+	var types []string
+	for _, typ := range s.Type {
+		if typ != "null" {
+			types = append(types, typ)
+		}
+	}
+	return types
+}
+
+func (s *jsonSchema) isNullable() bool {
+	return s.Nullable || len(s.types()) < len(s.Type)
+}
+
+func (s *jsonSchema) variants() []*jsonSchema {
+	return append(append([]*jsonSchema{}, s.OneOf...), s.AnyOf...)
+}
+
+// hasProperties reports whether the schema is an object that has the properties, that is derived as a struct.
+func (s *jsonSchema) hasProperties() bool {
+	types := s.types()
+	return len(s.Properties) > 0 && (len(types) <= 0 || (len(types) == 1 && types[0] == "object"))
+}
+
+// declKind returns the kind of the declaration when the schema is declared as a named type.
+func (s *jsonSchema) declKind() jsonTypeDeclKind {
+	switch {
+	case len(s.variants()) > 0:
+		return jsonTypeDeclUnion
+	case len(s.Enum) > 0:
+		return jsonTypeDeclEnum
+	case s.hasProperties() || len(s.AllOf) > 0:
+		return jsonTypeDeclStruct
+	}
+	return jsonTypeDeclDefined
+}
+
+// isInterface reports whether the type that is derived from the schema is an interface, that cannot be a variant of the union.
+func (s *jsonSchema) isInterface() bool {
+	switch s.declKind() {
+	case jsonTypeDeclUnion:
+		return true
+	case jsonTypeDeclDefined:
+		types := s.types()
+		return s.Ref == "" && (len(types) != 1 || types[0] == "null")
+	}
+	return false
+}
+
+// isNilable reports whether the zero value of the type that is derived from the schema is nil (e.g. slice and map).
+func (s *jsonSchema) isNilable() bool {
+	switch s.declKind() {
+	case jsonTypeDeclDefined:
+		if s.Ref != "" {
+			return false
+		}
+		types := s.types()
+		return len(types) != 1 || types[0] == "array" || types[0] == "object"
+	}
+	return false
+}
+
+// jsonSchemaConverter converts the schema into the declarations of the types.
+type jsonSchemaConverter struct {
+	names       jsonTypeNames
+	decls       []*jsonTypeDecl
+	refs        map[string]*jsonTypeDecl
+	refSchemas  map[string]*jsonSchema
+	definitions []*jsonSchemaProperty
+	declaring   map[*jsonTypeDecl]bool
+	caller      string
+}
+
+func newJSONSchemaConverter(caller string) *jsonSchemaConverter {
+	return &jsonSchemaConverter{
+		names:      jsonTypeNames{},
+		refs:       map[string]*jsonTypeDecl{},
+		refSchemas: map[string]*jsonSchema{},
+		declaring:  map[*jsonTypeDecl]bool{},
+		caller:     caller,
+	}
+}
+
+// register registers the named type of the schema that `ref` refers to (e.g. `#/definitions/User`).
+// The type is declared by `declareDefinitions()`.
+func (c *jsonSchemaConverter) register(ref string, name string, s *jsonSchema) {
+	c.refs[ref] = &jsonTypeDecl{name: name, nilable: s.isNilable()}
+	c.refSchemas[ref] = s
+	c.definitions = append(c.definitions, &jsonSchemaProperty{name: ref, schema: s})
+}
+
+// declareDefinitions declares the registered types in the order of the registration.
+func (c *jsonSchemaConverter) declareDefinitions() error {
+	for _, def := range c.definitions {
+		if err := c.declare(c.refs[def.name], def.schema); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewJSONTypesFromSchema returns a new `JSONTypes` that is derived from the JSON Schema document.
+// The root schema is generated as `rootTypeName`, and the following constructs are supported:
+//
+//   - an object that has `properties` is a struct; the `description` is the doc comment, and the optional property (that is not in `required`)
+//     is tagged with `omitempty`; the optional struct (and union) property and the recursive `$ref` to the struct are pointers
+//   - `string`, `integer`, `number`, `boolean` and `array` are the corresponding golang types, and `date-time` format is `time.Time`
+//   - an object without `properties` is a map, and the value type is derived from `additionalProperties`
+//   - `enum` of strings (or integers) is a defined type with the typed constants
+//   - `oneOf` and `anyOf` are a struct that holds one of the variants in `Value`; the variant types implement the interface that has the marker method,
+//     and `UnmarshalJSON()` picks the first variant that the JSON is decoded into without the unknown fields
+//   - `allOf` is a struct that has the properties of all of the schemas
+//   - the nullable value (i.e. `"type": ["string", "null"]` or `"nullable": true`) is a pointer, unless the type is nilable already
+//   - each schema of `definitions` and `$defs` is a named type, and `$ref` refers to that
+//
+// The nested object is generated as a named type, that is named by the parent type and the property (e.g. `UserAddress`).
+func NewJSONTypesFromSchema(rootTypeName string, schema []byte) (*JSONTypes, error) {
+	return newJSONTypesFromSchema(rootTypeName, schema, fetchClientCallerLine())
+}
+
+func newJSONTypesFromSchema(rootTypeName string, schema []byte, caller string) (*JSONTypes, error) {
+	var root jsonSchema
+	if err := json.Unmarshal(schema, &root); err != nil {
+		return nil, errmsg.JSONSchemaIsInvalidError(err.Error(), caller)
+	}
+
+	c := newJSONSchemaConverter(caller)
+	c.names[rootTypeName] = true
+	c.register("#", rootTypeName, &root)
+	for _, def := range root.Definitions {
+		c.register("#/definitions/"+def.name, c.names.reserve(def.name), def.schema)
+	}
+	for _, def := range root.Defs {
+		c.register("#/$defs/"+def.name, c.names.reserve(def.name), def.schema)
+	}
+	if err := c.declareDefinitions(); err != nil {
+		return nil, err
+	}
+
+	return &JSONTypes{
+		rootTypeName: rootTypeName,
+		decls:        c.decls,
+		caller:       caller,
+	}, nil
+}
+
+// lookupRef returns the declaration and the schema that `$ref` refers to.
+func (c *jsonSchemaConverter) lookupRef(ref string) (*jsonTypeDecl, *jsonSchema, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, nil, errmsg.JSONSchemaRefIsNotSupportedError(ref, c.caller)
+	}
+
+	// unescape JSON pointer
+	key := strings.NewReplacer("~1", "/", "~0", "~").Replace(ref)
+	decl, ok := c.refs[key]
+	if !ok {
+		return nil, nil, errmsg.JSONSchemaRefIsNotFoundError(ref, c.caller)
+	}
+	return decl, c.refSchemas[key], nil
+}
+
+// declare fills the declaration by the schema, and appends that to the declarations.
+func (c *jsonSchemaConverter) declare(decl *jsonTypeDecl, s *jsonSchema) error {
+	c.decls = append(c.decls, decl)
+	decl.kind = s.declKind()
+	decl.description = s.Description
+
+	switch decl.kind {
+	case jsonTypeDeclUnion:
+		return c.declareUnion(decl, s)
+	case jsonTypeDeclEnum:
+		return c.declareEnum(decl, s)
+	case jsonTypeDeclStruct:
+		merged, err := c.mergeAllOf(s, 0)
+		if err != nil {
+			return err
+		}
+		return c.declareStruct(decl, merged)
+	}
+
+	typ, _, err := c.typeOf(s, decl.name)
+	if err != nil {
+		return err
+	}
+	decl.typ = typ
+	return nil
+}
+
+func (c *jsonSchemaConverter) declareStruct(decl *jsonTypeDecl, s *jsonSchema) error {
+	c.declaring[decl] = true
+	defer delete(c.declaring, decl)
+
+	required := map[string]bool{}
+	for _, name := range s.Required {
+		required[name] = true
+	}
+
+	used := map[string]bool{}
+	for _, prop := range s.Properties {
+		fieldName := uniqueFieldName(prop.name, used)
+		typ, nilable, err := c.typeOf(prop.schema, decl.name+fieldName)
+		if err != nil {
+			return err
+		}
+		if isStruct, recursive := c.structOf(prop.schema); prop.schema.isNullable() || recursive || (isStruct && !required[prop.name]) {
+			typ = pointerTypeOf(typ, nilable)
+		}
+		decl.fields = append(decl.fields, jsonField(fieldName, typ, prop.name, required[prop.name], prop.schema.Description))
+	}
+	return nil
+}
+
+// structOf reports whether the type of the schema is a struct (including the wrapper of the union), and whether that struct is being declared,
+// i.e. the field of that type must be a pointer to avoid the invalid recursive type.
+func (c *jsonSchemaConverter) structOf(s *jsonSchema) (bool, bool) {
+	if s.Ref == "" {
+		return isJSONStructKind(s.declKind()), false
+	}
+	decl, refed, err := c.lookupRef(s.Ref)
+	if err != nil {
+		return false, false
+	}
+	return isJSONStructKind(refed.declKind()), c.declaring[decl]
+}
+
+func isJSONStructKind(kind jsonTypeDeclKind) bool {
+	return kind == jsonTypeDeclStruct || kind == jsonTypeDeclUnion
+}
+
+func (c *jsonSchemaConverter) declareEnum(decl *jsonTypeDecl, s *jsonSchema) error {
+	var values []interface{}
+	for _, raw := range s.Enum {
+		dec := json.NewDecoder(bytes.NewReader(raw))
+		dec.UseNumber()
+		var value interface{}
+		if err := dec.Decode(&value); err != nil {
+			return errmsg.JSONSchemaIsInvalidError(err.Error(), c.caller)
+		}
+		if value != nil {
+			values = append(values, value)
+		}
+	}
+
+	if len(values) <= 0 {
+		return errmsg.JSONSchemaEnumIsNotSupportedError(decl.name, c.caller)
+	}
+	for _, value := range values {
+		switch v := value.(type) {
+		case string:
+			if decl.typ == "int64" {
+				return errmsg.JSONSchemaEnumIsNotSupportedError(decl.name, c.caller)
+			}
+			decl.typ = "string"
+			decl.enumValues = append(decl.enumValues, &jsonEnumValue{
+				name:  c.names.reserve(enumConstName(decl.name, v)),
+				value: strconv.Quote(v),
+			})
+		case json.Number:
+			if _, err := v.Int64(); err != nil || decl.typ == "string" {
+				return errmsg.JSONSchemaEnumIsNotSupportedError(decl.name, c.caller)
+			}
+			decl.typ = "int64"
+			decl.enumValues = append(decl.enumValues, &jsonEnumValue{
+				name:  c.names.reserve(enumConstName(decl.name, v.String())),
+				value: v.String(),
+			})
+		default:
+			return errmsg.JSONSchemaEnumIsNotSupportedError(decl.name, c.caller)
+		}
+	}
+	return nil
+}
+
+func (c *jsonSchemaConverter) declareUnion(decl *jsonTypeDecl, s *jsonSchema) error {
+	decl.variantType = c.names.reserve(decl.name + "Variant")
+	for _, variant := range s.variants() {
+		if variant.Ref != "" {
+			variantDecl, variantSchema, err := c.lookupRef(variant.Ref)
+			if err != nil {
+				return err
+			}
+			if variantSchema.isInterface() {
+				return errmsg.JSONSchemaOneOfVariantIsNotSupportedError(variantDecl.name, c.caller)
+			}
+			decl.variants = append(decl.variants, variantDecl.name)
+			continue
+		}
+
+		types := variant.types()
+		if len(types) <= 0 && len(variant.Type) > 0 {
+			// `null` is nil of the interface
+			continue
+		}
+
+		hint := variant.Title
+		if hint == "" {
+			hint = decl.name + " " + strings.Join(types, " or ")
+		}
+		variantDecl := &jsonTypeDecl{name: c.names.reserve(hint), nilable: variant.isNilable()}
+		if variant.isInterface() {
+			return errmsg.JSONSchemaOneOfVariantIsNotSupportedError(variantDecl.name, c.caller)
+		}
+		if err := c.declare(variantDecl, variant); err != nil {
+			return err
+		}
+		decl.variants = append(decl.variants, variantDecl.name)
+	}
+	return nil
+}
+
+// mergeAllOf returns the object schema that has the properties of the schema itself and all of the schemas of `allOf`.
+func (c *jsonSchemaConverter) mergeAllOf(s *jsonSchema, depth int) (*jsonSchema, error) {
+	if depth > len(c.refs) {
+		return nil, errmsg.JSONSchemaIsInvalidError("allOf refers to itself circularly", c.caller)
+	}
+
+	merged := &jsonSchema{Description: s.Description}
+	schemas := append([]*jsonSchema{{Properties: s.Properties, Required: s.Required}}, s.AllOf...)
+	for _, schema := range schemas {
+		if schema.Ref != "" {
+			_, refed, err := c.lookupRef(schema.Ref)
+			if err != nil {
+				return nil, err
+			}
+			schema = refed
+		}
+		if len(schema.AllOf) > 0 {
+			var err error
+			if schema, err = c.mergeAllOf(schema, depth+1); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, prop := range schema.Properties {
+			replaced := false
+			for i, p := range merged.Properties {
+				if p.name == prop.name {
+					merged.Properties[i] = prop
+					replaced = true
+				}
+			}
+			if !replaced {
+				merged.Properties = append(merged.Properties, prop)
+			}
+		}
+		merged.Required = append(merged.Required, schema.Required...)
+	}
+	return merged, nil
+}
+
+// typeOf returns the golang type of the schema and whether the zero value of that is nil.
+// The named type (e.g. the struct of the nested object) is declared with the name that is based on `hint`.
+func (c *jsonSchemaConverter) typeOf(s *jsonSchema, hint string) (string, bool, error) {
+	if s.Ref != "" {
+		decl, _, err := c.lookupRef(s.Ref)
+		if err != nil {
+			return "", false, err
+		}
+		return decl.name, decl.nilable, nil
+	}
+
+	if kind := s.declKind(); kind != jsonTypeDeclDefined {
+		decl := &jsonTypeDecl{name: c.names.reserve(hint), nilable: s.isNilable()}
+		if err := c.declare(decl, s); err != nil {
+			return "", false, err
+		}
+		return decl.name, decl.nilable, nil
+	}
+
+	types := s.types()
+	if len(types) != 1 {
+		return "interface{}", true, nil
+	}
+
+	switch types[0] {
+	case "string":
+		if s.Format == "date-time" {
+			return "time.Time", false, nil
+		}
+		return "string", false, nil
+	case "integer":
+		if s.Format == "int32" {
+			return "int32", false, nil
+		}
+		return "int64", false, nil
+	case "number":
+		if s.Format == "float" {
+			return "float32", false, nil
+		}
+		return "float64", false, nil
+	case "boolean":
+		return "bool", false, nil
+	case "array":
+		if s.Items == nil {
+			return "[]interface{}", true, nil
+		}
+		typ, nilable, err := c.typeOf(s.Items, singularName(hint))
+		if err != nil {
+			return "", false, err
+		}
+		if s.Items.isNullable() {
+			typ = pointerTypeOf(typ, nilable)
+		}
+		return "[]" + typ, true, nil
+	case "object":
+		valueType := "interface{}"
+		if raw := bytes.TrimSpace(s.AdditionalProperties); len(raw) > 0 && raw[0] == '{' {
+			var value jsonSchema
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return "", false, errmsg.JSONSchemaIsInvalidError(err.Error(), c.caller)
+			}
+			typ, nilable, err := c.typeOf(&value, hint+"Value")
+			if err != nil {
+				return "", false, err
+			}
+			if value.isNullable() {
+				typ = pointerTypeOf(typ, nilable)
+			}
+			valueType = typ
+		}
+		return "map[string]" + valueType, true, nil
+	case "null":
+		return "interface{}", true, nil
+	}
+	return "", false, errmsg.JSONSchemaIsInvalidError(fmt.Sprintf("unknown type '%s'", types[0]), c.caller)
+}
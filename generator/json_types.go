@@ -0,0 +1,326 @@
+package generator
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
+
+// JSONTypes represents a code generator for the golang types that are derived from a JSON Schema document or JSON samples.
+// Please see `NewJSONTypesFromSchema()` and `NewJSONTypesFromSamples()` for the details of each mode.
+//
+// The types are generated by the generators of this library (e.g. `Struct` and `Const`), and each field has the `json` tag.
+// The generated code may depend on `time` package (for `date-time` format), and `bytes`, `encoding/json` and `fmt` packages
+// (for `oneOf` and `anyOf`); please add the imports, or enable `goimports`.
+type JSONTypes struct {
+	rootTypeName string
+	decls        []*jsonTypeDecl
+	caller       string
+}
+
+type jsonTypeDeclKind int
+
+const (
+	jsonTypeDeclStruct jsonTypeDeclKind = iota
+	jsonTypeDeclDefined
+	jsonTypeDeclEnum
+	jsonTypeDeclUnion
+)
+
+// jsonTypeDecl is a declaration of the type that is derived from JSON; it is converted into the statements by `JSONTypes`.
+type jsonTypeDecl struct {
+	kind        jsonTypeDeclKind
+	name        string
+	description string
+	nilable     bool
+
+	// for struct
+	fields []*StructField
+
+	// for defined type and enum; the underlying type
+	typ        string
+	enumValues []*jsonEnumValue
+
+	// for union; the names of the variant types and the interface that they implement
+	variants    []string
+	variantType string
+}
+
+type jsonEnumValue struct {
+	name  string
+	value string
+}
+
+// jsonTypeNames is a registry of the names of the package-level identifiers, to keep them unique.
+type jsonTypeNames map[string]bool
+
+// reserve returns the unique name that is based on `name` and reserves that (e.g. `User`, `User2`, `User3`...).
+func (n jsonTypeNames) reserve(name string) string {
+	name = ToExportedName(name)
+	unique := name
+	for i := 2; n[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	n[unique] = true
+	return unique
+}
+
+// LoadJSONTypesFromSchema reads the JSON Schema document from the local file, and returns a new `JSONTypes`.
+// Please see `NewJSONTypesFromSchema()` for the details.
+func LoadJSONTypesFromSchema(rootTypeName string, path string) (*JSONTypes, error) {
+	caller := fetchClientCallerLine()
+
+	schema, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errmsg.JSONFileLoadingError(path, err.Error(), caller)
+	}
+	return newJSONTypesFromSchema(rootTypeName, schema, caller)
+}
+
+// LoadJSONTypesFromSamples reads the JSON samples from the local files, and returns a new `JSONTypes`.
+// Please see `NewJSONTypesFromSamples()` for the details.
+func LoadJSONTypesFromSamples(rootTypeName string, paths ...string) (*JSONTypes, error) {
+	caller := fetchClientCallerLine()
+
+	samples := make([][]byte, len(paths))
+	for i, path := range paths {
+		sample, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, errmsg.JSONFileLoadingError(path, err.Error(), caller)
+		}
+		samples[i] = sample
+	}
+	return newJSONTypesFromSamples(rootTypeName, samples, caller)
+}
+
+// Generate generates the types as golang code.
+func (j *JSONTypes) Generate(indentLevel int) (generated string, err error) {
+	defer annotateComposedErrorPath(&err, j.pathSegment())
+
+	if err := firstError(j.validate()); err != nil {
+		return "", err
+	}
+
+	return generateStatementGroups(j.statementGroups(), indentLevel)
+}
+
+// statementGroups builds the types by the generators. Each group is a declaration with its doc comment.
+func (j *JSONTypes) statementGroups() [][]Statement {
+	return jsonTypeDeclStatementGroups(j.decls)
+}
+
+func jsonTypeDeclStatementGroups(decls []*jsonTypeDecl) [][]Statement {
+	var groups [][]Statement
+	for _, decl := range decls {
+		var comments []Statement
+		if decl.description != "" {
+			for _, line := range strings.Split(strings.TrimSpace(decl.description), "\n") {
+				comments = append(comments, NewComment(" "+line))
+			}
+		}
+
+		switch decl.kind {
+		case jsonTypeDeclStruct:
+			groups = append(groups, append(comments, NewStruct(decl.name).AddFields(decl.fields...)))
+		case jsonTypeDeclDefined:
+			groups = append(groups, append(comments, NewRawStatementf("type %s %s", decl.name, decl.typ)))
+		case jsonTypeDeclEnum:
+			specs := make([]*ConstSpec, len(decl.enumValues))
+			for i, v := range decl.enumValues {
+				specs[i] = NewConstSpec([]string{v.name}, decl.name, NewRawStatement(v.value))
+			}
+			groups = append(groups,
+				append(comments, NewRawStatementf("type %s %s", decl.name, decl.typ)),
+				[]Statement{NewConst(specs...)},
+			)
+		case jsonTypeDeclUnion:
+			groups = append(groups, jsonUnionStatementGroups(decl, comments)...)
+		}
+	}
+	return groups
+}
+
+// jsonUnionStatementGroups returns the wrapper struct of the union, that holds one of the variants in `Value`.
+// `encoding/json` cannot unmarshal into an interface, so the wrapper picks the variant by `UnmarshalJSON()`.
+func jsonUnionStatementGroups(decl *jsonTypeDecl, comments []Statement) [][]Statement {
+	if len(comments) <= 0 {
+		comments = []Statement{NewCommentf(" %s holds one of the variants in Value: %s.", decl.name, strings.Join(decl.variants, ", "))}
+	}
+
+	marker := "is" + decl.name
+	groups := [][]Statement{
+		append(comments, NewStruct(decl.name).AddField("Value", decl.variantType)),
+		{
+			NewCommentf(" %s is implemented by the variants of %s.", decl.variantType, decl.name),
+			NewInterface(decl.variantType, NewFuncSignature(marker)),
+		},
+	}
+	for _, variant := range decl.variants {
+		recv := strings.ToLower(string([]rune(variant)[0]))
+		groups = append(groups, []Statement{
+			NewFunc(NewFuncReceiver(recv, variant), NewFuncSignature(marker)),
+		})
+	}
+
+	recv := strings.ToLower(string([]rune(decl.name)[0]))
+	unmarshal := []Statement{
+		NewIf(`string(data) == "null"`,
+			NewAssign([]string{recv + ".Value"}, "=", NewRawStatement("nil")),
+			NewReturnStatement("nil"),
+		),
+		NewNewline(),
+		NewComment(" the object must not have the unknown fields, so that it is decoded into the variant that has the same properties"),
+		NewShortVarDecl([]string{"decode"}, NewAnonymousFunc(
+			false,
+			NewAnonymousFuncSignature().AddParameters(NewFuncParameter("variant", "interface{}")).AddReturnTypes("bool"),
+			NewShortVarDecl([]string{"dec"}, NewCall("json.NewDecoder", NewCall("bytes.NewReader", NewRawStatement("data")))),
+			NewRawStatement("dec.DisallowUnknownFields()"),
+			NewReturnStatement("dec.Decode(variant) == nil"),
+		)),
+		NewNewline(),
+	}
+	for i, variant := range decl.variants {
+		v := "v" + strconv.Itoa(i+1)
+		unmarshal = append(unmarshal,
+			NewVar([]string{v}, variant),
+			NewIf(fmt.Sprintf("decode(&%s)", v),
+				NewAssign([]string{recv + ".Value"}, "=", NewRawStatement(v)),
+				NewReturnStatement("nil"),
+			),
+		)
+	}
+	unmarshal = append(unmarshal, NewReturnStatement().AddReturnStatements(
+		NewCall("fmt.Errorf", NewRawStatementf("%q", "JSON doesn't match any variant of "+decl.name+": %s"), NewRawStatement("data")),
+	))
+
+	return append(groups,
+		[]Statement{
+			NewComment(" MarshalJSON encodes the variant."),
+			NewFunc(
+				NewFuncReceiver(recv, decl.name),
+				NewFuncSignature("MarshalJSON").AddReturnTypes("[]byte", "error"),
+				NewReturnStatement().AddReturnStatements(NewCall("json.Marshal", NewRawStatement(recv+".Value"))),
+			),
+		},
+		[]Statement{
+			NewComment(" UnmarshalJSON decodes the JSON into the first variant that accepts it; null is decoded into nil."),
+			NewFunc(
+				NewFuncReceiver(recv, "*"+decl.name),
+				NewFuncSignature("UnmarshalJSON").AddParameters(NewFuncParameter("data", "[]byte")).AddReturnTypes("error"),
+				unmarshal...,
+			),
+		},
+	)
+}
+
+func (j *JSONTypes) validate() []error {
+	var errs []error
+	if j.rootTypeName == "" {
+		errs = append(errs, errmsg.JSONTypesRootTypeNameIsEmptyError(j.caller))
+	}
+	return appendIdentifierError(errs, j.rootTypeName, j.caller)
+}
+
+func (j *JSONTypes) childStatements() []Statement {
+	if len(j.validate()) > 0 {
+		return nil
+	}
+	return flattenStatementGroups(j.statementGroups())
+}
+
+func (j *JSONTypes) pathSegment() errorPathSegment {
+	return errorPathSegment{name: namedPathSegment("JSONTypes", j.rootTypeName), caller: j.caller}
+}
+
+func (j *JSONTypes) composesStatements() {}
+
+// jsonTypeDeclsUseUnion reports whether the declarations have the union, that requires the imports of `bytes`, `encoding/json` and `fmt` packages.
+func jsonTypeDeclsUseUnion(decls []*jsonTypeDecl) bool {
+	for _, decl := range decls {
+		if decl.kind == jsonTypeDeclUnion {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonTypeDeclsUseTime reports whether the declarations refer to `time.Time`, that requires the import of `time` package.
+func jsonTypeDeclsUseTime(decls []*jsonTypeDecl) bool {
+	for _, decl := range decls {
+		if strings.Contains(decl.typ, "time.Time") {
+			return true
+		}
+		for _, field := range decl.fields {
+			if strings.Contains(field.typ, "time.Time") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// jsonField returns the struct field for the JSON property; the optional property is tagged with `omitempty`.
+func jsonField(name string, typ string, property string, required bool, description string) *StructField {
+	tag := NewStructTag().Add("json", property)
+	if !required {
+		tag = tag.Add("json", property, "omitempty")
+	}
+
+	field := NewStructField(name, typ).Tag(tag)
+	if description = strings.TrimSpace(description); description != "" {
+		field = field.Comment(" " + strings.Replace(description, "\n", "\n ", -1))
+	}
+	return field
+}
+
+// uniqueFieldName returns the exported field name for the JSON property that doesn't conflict with the other fields.
+func uniqueFieldName(property string, used map[string]bool) string {
+	name := ToExportedName(property)
+	unique := name
+	for i := 2; used[unique]; i++ {
+		unique = name + strconv.Itoa(i)
+	}
+	used[unique] = true
+	return unique
+}
+
+// singularName returns the name of the element for the name of the array (e.g. `Users` => `User`).
+func singularName(name string) string {
+	if singular := singularize(name); singular != name {
+		return singular
+	}
+	return name + "Item"
+}
+
+// singularize returns the singular form of the plural noun; it returns the name as it is if that is not plural.
+func singularize(name string) string {
+	switch {
+	case strings.HasSuffix(name, "ies") && len(name) > 3:
+		return name[:len(name)-3] + "y"
+	case strings.HasSuffix(name, "sses") || strings.HasSuffix(name, "xes") || strings.HasSuffix(name, "ches") || strings.HasSuffix(name, "shes"):
+		return name[:len(name)-2]
+	case strings.HasSuffix(name, "s") && !strings.HasSuffix(name, "ss") && len(name) > 1:
+		return name[:len(name)-1]
+	}
+	return name
+}
+
+// pointerTypeOf returns the pointer type of `typ` for the nullable value, unless the zero value of `typ` is already nil.
+func pointerTypeOf(typ string, nilable bool) string {
+	if nilable {
+		return typ
+	}
+	return "*" + typ
+}
+
+// enumConstName returns the name of the constant of the enum value (e.g. `Status` and `in-progress` => `StatusInProgress`).
+func enumConstName(typeName string, value string) string {
+	if value != "" && (unicode.IsDigit([]rune(value)[0]) || value[0] == '-') {
+		return typeName + strings.Replace(value, "-", "Minus", 1)
+	}
+	return typeName + ToExportedName(value)
+}
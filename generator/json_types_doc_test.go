@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"fmt"
+	"log"
+)
+
+func ExampleNewJSONTypesFromSchema() {
+	jsonTypes, err := NewJSONTypesFromSchema("User", []byte(`{
+		"description": "User is a user of the service.",
+		"type": "object",
+		"required": ["id"],
+		"properties": {
+			"id": {"type": "integer"},
+			"nickname": {"type": ["string", "null"], "description": "Nickname is shown instead of the name."},
+			"role": {"type": "string", "enum": ["admin", "member"]}
+		}
+	}`))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	generated, err := NewRoot(
+		NewPackage("mypkg"),
+		jsonTypes,
+	).Gofmt().Generate(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(generated)
+}
+
+func ExampleNewJSONTypesFromSamples() {
+	jsonTypes, err := NewJSONTypesFromSamples(
+		"Event",
+		[]byte(`{"type": "click", "x": 10, "y": 20}`),
+		[]byte(`{"type": "scroll", "delta": 1.5}`),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	generated, err := NewRoot(
+		NewPackage("mypkg"),
+		jsonTypes,
+	).Gofmt().Generate(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(generated)
+}
@@ -0,0 +1,404 @@
+package generator
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const userJSONSchema = `{
+  "description": "User is a user of the service.",
+  "type": "object",
+  "required": ["id", "name"],
+  "properties": {
+    "id": {"type": "integer", "description": "ID is the identifier of the user.\nIt is unique."},
+    "name": {"type": "string"},
+    "email": {"type": ["string", "null"]},
+    "status": {"$ref": "#/definitions/status"},
+    "address": {"type": "object", "properties": {"zip_code": {"type": "string"}}},
+    "tags": {"type": "array", "items": {"type": "string"}},
+    "attrs": {"type": "object", "additionalProperties": {"type": "integer"}},
+    "pet": {"$ref": "#/$defs/pet"},
+    "created_at": {"type": "string", "format": "date-time"},
+    "parent": {"$ref": "#", "nullable": true}
+  },
+  "definitions": {
+    "status": {"description": "Status is the status of the user.", "type": "string", "enum": ["active", "in-progress"]}
+  },
+  "$defs": {
+    "pet": {"oneOf": [{"$ref": "#/$defs/dog"}, {"type": "string"}, {"type": "null"}]},
+    "dog": {"allOf": [{"$ref": "#/$defs/animal"}, {"properties": {"bark": {"type": "boolean"}}, "required": ["bark"]}]},
+    "animal": {"type": "object", "properties": {"name": {"type": "string"}}}
+  }
+}`
+
+func TestShouldGenerateJSONTypesFromSchema(t *testing.T) {
+	jsonTypes, err := NewJSONTypesFromSchema("User", []byte(userJSONSchema))
+	assert.NoError(t, err)
+
+	expected := "// User is a user of the service.\n" +
+		"type User struct {\n" +
+		"	// ID is the identifier of the user.\n" +
+		"	// It is unique.\n" +
+		"	ID int64 `json:\"id\"`\n" +
+		"	Name string `json:\"name\"`\n" +
+		"	Email *string `json:\"email,omitempty\"`\n" +
+		"	Status Status `json:\"status,omitempty\"`\n" +
+		"	Address *UserAddress `json:\"address,omitempty\"`\n" +
+		"	Tags []string `json:\"tags,omitempty\"`\n" +
+		"	Attrs map[string]int64 `json:\"attrs,omitempty\"`\n" +
+		"	Pet *Pet `json:\"pet,omitempty\"`\n" +
+		"	CreatedAt time.Time `json:\"created_at,omitempty\"`\n" +
+		"	Parent *User `json:\"parent,omitempty\"`\n" +
+		"}\n" +
+		"\n" +
+		"type UserAddress struct {\n" +
+		"	ZipCode string `json:\"zip_code,omitempty\"`\n" +
+		"}\n" +
+		"\n" +
+		"// Status is the status of the user.\n" +
+		"type Status string\n" +
+		"\n" +
+		"const (\n" +
+		"	StatusActive Status = \"active\"\n" +
+		"	StatusInProgress Status = \"in-progress\"\n" +
+		")\n" +
+		"\n" +
+		"// Pet holds one of the variants in Value: Dog, PetString.\n" +
+		"type Pet struct {\n" +
+		"	Value PetVariant\n" +
+		"}\n" +
+		"\n" +
+		"// PetVariant is implemented by the variants of Pet.\n" +
+		"type PetVariant interface {\n" +
+		"	isPet()\n" +
+		"}\n" +
+		"\n" +
+		"func (d Dog) isPet() {\n" +
+		"}\n" +
+		"\n" +
+		"func (p PetString) isPet() {\n" +
+		"}\n" +
+		"\n" +
+		"// MarshalJSON encodes the variant.\n" +
+		"func (p Pet) MarshalJSON() ([]byte, error) {\n" +
+		"	return json.Marshal(p.Value)\n" +
+		"}\n" +
+		"\n" +
+		"// UnmarshalJSON decodes the JSON into the first variant that accepts it; null is decoded into nil.\n" +
+		"func (p *Pet) UnmarshalJSON(data []byte) error {\n" +
+		"	if string(data) == \"null\" {\n" +
+		"		p.Value = nil\n" +
+		"		return nil\n" +
+		"	}\n" +
+		"\n" +
+		"	// the object must not have the unknown fields, so that it is decoded into the variant that has the same properties\n" +
+		"	decode := func(variant interface{}) bool {\n" +
+		"		dec := json.NewDecoder(bytes.NewReader(data))\n" +
+		"		dec.DisallowUnknownFields()\n" +
+		"		return dec.Decode(variant) == nil\n" +
+		"	}\n" +
+		"\n" +
+		"	var v1 Dog\n" +
+		"	if decode(&v1) {\n" +
+		"		p.Value = v1\n" +
+		"		return nil\n" +
+		"	}\n" +
+		"	var v2 PetString\n" +
+		"	if decode(&v2) {\n" +
+		"		p.Value = v2\n" +
+		"		return nil\n" +
+		"	}\n" +
+		"	return fmt.Errorf(\"JSON doesn't match any variant of Pet: %s\", data)\n" +
+		"}\n" +
+		"\n" +
+		"type PetString string\n" +
+		"\n" +
+		"type Dog struct {\n" +
+		"	Name string `json:\"name,omitempty\"`\n" +
+		"	Bark bool `json:\"bark\"`\n" +
+		"}\n" +
+		"\n" +
+		"type Animal struct {\n" +
+		"	Name string `json:\"name,omitempty\"`\n" +
+		"}\n"
+
+	gen, err := jsonTypes.Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+}
+
+func TestShouldGenerateJSONTypesFromSchemaWithIntegerEnumAndNonObjectRoot(t *testing.T) {
+	jsonTypes, err := NewJSONTypesFromSchema("Priorities", []byte(`{
+		"type": "array",
+		"items": {"type": "integer", "enum": [1, 2, -1]}
+	}`))
+	assert.NoError(t, err)
+
+	expected := `type Priorities []Priority
+
+type Priority int64
+
+const (
+	Priority1 Priority = 1
+	Priority2 Priority = 2
+	PriorityMinus1 Priority = -1
+)
+`
+	gen, err := jsonTypes.Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+}
+
+func TestShouldGenerateJSONTypesFromSchemaWithUniqueNames(t *testing.T) {
+	jsonTypes, err := NewJSONTypesFromSchema("Order", []byte(`{
+		"properties": {
+			"user_id": {"type": "integer"},
+			"userId": {"type": "integer"},
+			"item": {"properties": {"name": {"type": "string"}}},
+			"items": {"type": "array", "items": {"properties": {"price": {"type": "number"}}}}
+		}
+	}`))
+	assert.NoError(t, err)
+
+	expected := "type Order struct {\n" +
+		"	UserID int64 `json:\"user_id,omitempty\"`\n" +
+		"	UserID2 int64 `json:\"userId,omitempty\"`\n" +
+		"	Item *OrderItem `json:\"item,omitempty\"`\n" +
+		"	Items []OrderItem2 `json:\"items,omitempty\"`\n" +
+		"}\n" +
+		"\n" +
+		"type OrderItem struct {\n" +
+		"	Name string `json:\"name,omitempty\"`\n" +
+		"}\n" +
+		"\n" +
+		"type OrderItem2 struct {\n" +
+		"	Price float64 `json:\"price,omitempty\"`\n" +
+		"}\n"
+
+	gen, err := jsonTypes.Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+}
+
+func TestShouldGenerateJSONTypesFromRecursiveSchema(t *testing.T) {
+	jsonTypes, err := NewJSONTypesFromSchema("Tree", []byte(`{
+  "type": "object",
+  "required": ["root"],
+  "properties": {
+    "root": {"$ref": "#/definitions/node"}
+  },
+  "definitions": {
+    "node": {
+      "type": "object",
+      "required": ["value", "parent"],
+      "properties": {
+        "value": {"type": "integer"},
+        "parent": {"$ref": "#/definitions/node"},
+        "children": {"type": "array", "items": {"$ref": "#/definitions/node"}},
+        "meta": {"type": "object", "required": ["owner"], "properties": {"owner": {"$ref": "#/definitions/node"}}}
+      }
+    }
+  }
+}`))
+	assert.NoError(t, err)
+
+	gen, err := jsonTypes.Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, "type Tree struct {\n"+
+		"	Root Node `json:\"root\"`\n"+
+		"}\n"+
+		"\n"+
+		"type Node struct {\n"+
+		"	Value int64 `json:\"value\"`\n"+
+		"	Parent *Node `json:\"parent\"`\n"+
+		"	Children []Node `json:\"children,omitempty\"`\n"+
+		"	Meta *NodeMeta `json:\"meta,omitempty\"`\n"+
+		"}\n"+
+		"\n"+
+		"type NodeMeta struct {\n"+
+		"	Owner *Node `json:\"owner\"`\n"+
+		"}\n", gen)
+
+	if testing.Short() {
+		return
+	}
+	code, err := NewRoot(NewPackage("tree"), NewNewline(), jsonTypes).Gofmt().Generate(0)
+	assert.NoError(t, err)
+	runGoCommandsOnGeneratedPackage(t, map[string]string{"tree.go": code}, []string{"vet", "."})
+}
+
+func TestShouldNewJSONTypesFromSchemaRaiseError(t *testing.T) {
+	for _, tc := range []struct {
+		schema   string
+		expected error
+	}{
+		{`{"type": `, errmsg.JSONSchemaIsInvalidError("", "")},
+		{`{"type": 1}`, errmsg.JSONSchemaIsInvalidError("", "")},
+		{`{"type": "strings"}`, errmsg.JSONSchemaIsInvalidError("", "")},
+		{`{"properties": {"a": {"$ref": "other.json#/definitions/a"}}}`, errmsg.JSONSchemaRefIsNotSupportedError("", "")},
+		{`{"properties": {"a": {"$ref": "#/definitions/a"}}}`, errmsg.JSONSchemaRefIsNotFoundError("", "")},
+		{`{"properties": {"a": {"enum": ["a", 1]}}}`, errmsg.JSONSchemaEnumIsNotSupportedError("", "")},
+		{`{"properties": {"a": {"enum": [true]}}}`, errmsg.JSONSchemaEnumIsNotSupportedError("", "")},
+		{`{"properties": {"a": {"oneOf": [{"oneOf": [{"type": "string"}]}]}}}`, errmsg.JSONSchemaOneOfVariantIsNotSupportedError("", "")},
+		{`{"properties": {"a": {"oneOf": [{}]}}}`, errmsg.JSONSchemaOneOfVariantIsNotSupportedError("", "")},
+	} {
+		_, err := NewJSONTypesFromSchema("Root", []byte(tc.schema))
+		assert.Error(t, err, tc.schema)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(tc.expected.Error(), " ")[0],
+		), err.Error(), tc.schema)
+	}
+}
+
+func TestShouldGenerateJSONTypesFromSamples(t *testing.T) {
+	jsonTypes, err := NewJSONTypesFromSamples(
+		"User",
+		[]byte(`{"id": 1, "name": "a", "score": 1, "tags": [], "address": {"city": "x"}, "company": {"name": "c"}, "items": [{"n": 1}, {"n": 2.5}]}`),
+		[]byte(`[{"id": 2, "name": null, "score": 1.5, "address": {"city": "y"}, "x": true}]`),
+	)
+	assert.NoError(t, err)
+
+	expected := "type User struct {\n" +
+		"	ID int64 `json:\"id\"`\n" +
+		"	Name *string `json:\"name\"`\n" +
+		"	Score float64 `json:\"score\"`\n" +
+		"	Tags []interface{} `json:\"tags,omitempty\"`\n" +
+		"	Address UserAddress `json:\"address\"`\n" +
+		"	Company *UserCompany `json:\"company,omitempty\"`\n" +
+		"	Items []UserItem `json:\"items,omitempty\"`\n" +
+		"	X bool `json:\"x,omitempty\"`\n" +
+		"}\n" +
+		"\n" +
+		"type UserAddress struct {\n" +
+		"	City string `json:\"city\"`\n" +
+		"}\n" +
+		"\n" +
+		"type UserCompany struct {\n" +
+		"	Name string `json:\"name\"`\n" +
+		"}\n" +
+		"\n" +
+		"type UserItem struct {\n" +
+		"	N float64 `json:\"n\"`\n" +
+		"}\n"
+
+	gen, err := jsonTypes.Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+}
+
+func TestShouldNewJSONTypesFromSamplesRaiseError(t *testing.T) {
+	for _, sample := range []string{`{"a": `, `{"a": 1} {}`, `1`, `[1]`, `null`} {
+		_, err := NewJSONTypesFromSamples("Root", []byte(`{}`), []byte(sample))
+		assert.Error(t, err, sample)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.JSONSampleIsInvalidError(0, "", "").Error(), " ")[0],
+		), err.Error(), sample)
+	}
+}
+
+func TestShouldJSONTypesRaiseErrorWhenRootTypeNameIsInvalid(t *testing.T) {
+	jsonTypes, err := NewJSONTypesFromSamples("", []byte(`{}`))
+	assert.NoError(t, err)
+	_, err = jsonTypes.Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.JSONTypesRootTypeNameIsEmptyError("").Error(), " ")[0],
+	), err.Error())
+
+	jsonTypes, err = NewJSONTypesFromSchema("type", []byte(`{}`))
+	assert.NoError(t, err)
+	_, err = jsonTypes.Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.IdentifierIsKeywordError("", "").Error(), " ")[0],
+	), err.Error())
+}
+
+func TestShouldLoadJSONTypesFromFiles(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gowrtr-json")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	schemaPath := filepath.Join(dir, "schema.json")
+	samplePath := filepath.Join(dir, "sample.json")
+	assert.NoError(t, ioutil.WriteFile(schemaPath, []byte(`{"properties": {"id": {"type": "integer"}}, "required": ["id"]}`), 0644))
+	assert.NoError(t, ioutil.WriteFile(samplePath, []byte(`{"id": 1}`), 0644))
+
+	expected := "type User struct {\n" +
+		"	ID int64 `json:\"id\"`\n" +
+		"}\n"
+
+	jsonTypes, err := LoadJSONTypesFromSchema("User", schemaPath)
+	assert.NoError(t, err)
+	gen, err := jsonTypes.Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+
+	jsonTypes, err = LoadJSONTypesFromSamples("User", samplePath)
+	assert.NoError(t, err)
+	gen, err = jsonTypes.Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+
+	errPattern := regexp.MustCompile(`^\` + strings.Split(errmsg.JSONFileLoadingError("", "", "").Error(), " ")[0])
+	_, err = LoadJSONTypesFromSchema("User", filepath.Join(dir, "missing.json"))
+	assert.Regexp(t, errPattern, err.Error())
+	_, err = LoadJSONTypesFromSamples("User", samplePath, filepath.Join(dir, "missing.json"))
+	assert.Regexp(t, errPattern, err.Error())
+}
+
+func TestShouldGeneratedJSONTypesRoundTripJSON(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this test builds the generated code")
+	}
+
+	jsonTypes, err := NewJSONTypesFromSchema("User", []byte(userJSONSchema))
+	assert.NoError(t, err)
+
+	code, err := NewRoot(
+		NewPackage("user"),
+		NewImport("bytes", "encoding/json", "fmt", "time"),
+		NewNewline(),
+		jsonTypes,
+	).Gofmt().Generate(0)
+	assert.NoError(t, err)
+
+	testCode, err := NewRoot(
+		NewPackage("user"),
+		NewImport("encoding/json", "testing"),
+		NewNewline(),
+		NewFunc(
+			nil,
+			NewFuncSignature("TestUser").AddParameters(NewFuncParameter("t", "*testing.T")),
+			NewShortVarDecl([]string{"input"}, NewRawStatement("`"+`{"id":1,"name":"a","email":null,"status":"in-progress","address":{"zip_code":"123"},"pet":{"name":"pochi","bark":true},"created_at":"2020-01-02T03:04:05Z","parent":{"id":2,"name":"b","pet":"tama"}}`+"`")),
+			NewVar([]string{"u"}, "User"),
+			NewIf("err := json.Unmarshal([]byte(input), &u); err != nil", NewRawStatement("t.Fatal(err)")),
+			NewIf(
+				`u.ID != 1 || u.Email != nil || u.Status != StatusInProgress || u.Address.ZipCode != "123" || u.CreatedAt.Year() != 2020 || u.Parent.Name != "b"`,
+				NewRawStatement(`t.Fatalf("unexpected user: %+v", u)`),
+			),
+			NewIf(
+				`u.Pet.Value != (Dog{Name: "pochi", Bark: true}) || u.Parent.Pet.Value != PetString("tama")`,
+				NewRawStatement(`t.Fatalf("unexpected pets: %+v, %+v", u.Pet, u.Parent.Pet)`),
+			),
+			NewShortVarDecl([]string{"out", "err"}, NewRawStatement("json.Marshal(u.Pet)")),
+			NewIf("err != nil || string(out) != `{\"name\":\"pochi\",\"bark\":true}`", NewRawStatement(`t.Fatalf("unexpected json: %s", out)`)),
+			NewIf(
+				"err := json.Unmarshal([]byte(`{\"pet\":{\"name\":\"pochi\",\"color\":\"white\"}}`), &u); err == nil",
+				NewRawStatement(`t.Fatalf("unexpected pet: %+v", u.Pet)`),
+			),
+		),
+	).Gofmt().Generate(0)
+	assert.NoError(t, err)
+
+	runGoCommandsOnGeneratedPackage(t, map[string]string{
+		"user.go":      code,
+		"user_test.go": testCode,
+	}, []string{"vet", "."}, []string{"test", "."})
+}
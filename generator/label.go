@@ -0,0 +1,135 @@
+package generator
+
+import (
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
+
+// Label represents a code generator for label of labeled statement.
+// The statement that follows this is the labeled statement.
+//
+// example:
+//
+//	outer:
+//		for {
+//			break outer
+//		}
+type Label struct {
+	name   string
+	caller string
+}
+
+// NewLabel returns a new `Label`.
+func NewLabel(name string) *Label {
+	return &Label{
+		name:   name,
+		caller: fetchClientCallerLine(),
+	}
+}
+
+// Generate generates a label as golang code.
+// A label is outdented by one level, as well as gofmt does.
+func (l *Label) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, l.pathSegment())
+
+	if err := firstError(l.validate()); err != nil {
+		return "", err
+	}
+
+	if indentLevel > 0 {
+		indentLevel--
+	}
+	return BuildIndent(indentLevel) + l.name + ":\n", nil
+}
+
+// validateLabels checks that each label is defined only once and every label referenced by branch statements is defined in the func body.
+// It also checks that each label is followed by a statement, and `goto` doesn't jump into a block (i.e. the label is in the block of the `goto` or an enclosing one).
+// Nested funcs are not inspected, because they have their own label scope.
+func validateLabels(statements []Statement) []error {
+	var errs []error
+	definedBlocks := map[string]int{}
+	var branches []*Branch
+	branchScopes := map[*Branch][]int{}
+	blocks := 0
+
+	var walk func(statements []Statement, scope []int)
+	walk = func(statements []Statement, scope []int) {
+		block := blocks
+		blocks++
+		scope = append(scope[:len(scope):len(scope)], block)
+
+		for i, statement := range statements {
+			switch s := statement.(type) {
+			case *Label:
+				if s.name == "" {
+					continue
+				}
+				if _, ok := definedBlocks[s.name]; ok {
+					errs = append(errs, errmsg.LabelIsDuplicatedError(s.name, s.caller))
+					continue
+				}
+				definedBlocks[s.name] = block
+				if !isFollowedByStatement(statements[i+1:]) {
+					errs = append(errs, errmsg.LabelIsNotFollowedByStatementError(s.name, s.caller))
+				}
+			case *Branch:
+				if s.label != "" {
+					branches = append(branches, s)
+					branchScopes[s] = scope
+				}
+			case *Func, *AnonymousFunc:
+				// NOP: it has its own label scope
+			case statementContainer:
+				walk(s.childStatements(), scope)
+			}
+		}
+	}
+	walk(statements, nil)
+
+	for _, branch := range branches {
+		block, ok := definedBlocks[branch.label]
+		if !ok {
+			errs = append(errs, errmsg.LabelIsNotDefinedError(branch.label, branch.caller))
+			continue
+		}
+		if branch.keyword == "goto" && !containsBlock(branchScopes[branch], block) {
+			errs = append(errs, errmsg.GotoJumpsIntoBlockError(branch.label, branch.caller))
+		}
+	}
+
+	return errs
+}
+
+// isFollowedByStatement reports whether the statements that follow the label have a statement of the same block;
+// the newlines and the comments are not statements, and the else clauses of `If` belong to the other blocks.
+func isFollowedByStatement(following []Statement) bool {
+	for _, statement := range following {
+		switch statement.(type) {
+		case nil, *Newline, *Comment:
+			continue
+		case *ElseIf, *Else:
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+func containsBlock(scope []int, block int) bool {
+	for _, b := range scope {
+		if b == block {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *Label) validate() []error {
+	if l.name == "" {
+		return []error{errmsg.LabelNameIsEmptyError(l.caller)}
+	}
+	return nil
+}
+
+func (l *Label) pathSegment() errorPathSegment {
+	return errorPathSegment{name: namedPathSegment("Label", l.name), caller: l.caller}
+}
@@ -0,0 +1,172 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldGenerateLabel(t *testing.T) {
+	{
+		gen, err := NewLabel("outer").Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "outer:\n", gen)
+	}
+
+	{
+		gen, err := NewLabel("outer").Generate(2)
+		assert.NoError(t, err)
+		assert.Equal(t, "\touter:\n", gen)
+	}
+}
+
+func TestShouldGenerateLabelRaisesError(t *testing.T) {
+	_, err := NewLabel("").Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.LabelNameIsEmptyError("").Error(), " ")[0],
+	), err.Error())
+}
+
+func TestShouldGenerateFuncWithLabels(t *testing.T) {
+	generator := NewFunc(
+		nil,
+		NewFuncSignature("f"),
+		NewLabel("outer"),
+		NewForRange("_", "row", "rows",
+			NewForRange("_", "v", "row",
+				NewIf("v < 0", NewContinue("outer")),
+				NewIf("v == 0", NewBreak("outer")),
+				NewIf("v > 100", NewGoto("end")),
+			),
+		),
+		NewLabel("end"),
+		NewReturnStatement(),
+	)
+
+	expected := `func f() {
+outer:
+	for _, row := range rows {
+		for _, v := range row {
+			if v < 0 {
+				continue outer
+			}
+			if v == 0 {
+				break outer
+			}
+			if v > 100 {
+				goto end
+			}
+		}
+	}
+end:
+	return
+}
+`
+	gen, err := generator.Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+}
+
+func TestShouldGenerateFuncRaisesErrorWhenLabelIsNotDefined(t *testing.T) {
+	{
+		_, err := NewFunc(
+			nil,
+			NewFuncSignature("f"),
+			NewSwitch("x").AddCase(NewCase("1", NewBreak("outer"))),
+		).Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.LabelIsNotDefinedError("", "").Error(), " ")[0],
+		), err.Error())
+	}
+
+	{
+		// a label in nested anonymous func is out of scope
+		_, err := NewFunc(
+			nil,
+			NewFuncSignature("f"),
+			NewGoto("end"),
+			NewAnonymousFunc(false, NewAnonymousFuncSignature(), NewLabel("end")).Invocation(NewFuncInvocation()),
+		).Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.LabelIsNotDefinedError("", "").Error(), " ")[0],
+		), err.Error())
+	}
+
+	{
+		_, err := NewAnonymousFunc(
+			false,
+			NewAnonymousFuncSignature(),
+			NewSelect(NewSelectDefaultCase(NewBreak("loop"))),
+		).Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.LabelIsNotDefinedError("", "").Error(), " ")[0],
+		), err.Error())
+	}
+}
+
+func TestShouldGenerateFuncRaisesErrorWhenGotoJumpsIntoBlock(t *testing.T) {
+	{
+		_, err := NewFunc(
+			nil,
+			NewFuncSignature("f"),
+			NewGoto("inner"),
+			NewIf("x", NewLabel("inner"), NewRawStatement("x = false")),
+		).Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.GotoJumpsIntoBlockError("", "").Error(), " ")[0],
+		), err.Error())
+	}
+
+	{
+		// the label in the sibling block is not reachable either
+		_, err := NewFunc(
+			nil,
+			NewFuncSignature("f"),
+			NewCodeBlock(NewGoto("sibling")),
+			NewCodeBlock(NewLabel("sibling"), NewRawStatement("x++")),
+		).Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.GotoJumpsIntoBlockError("", "").Error(), " ")[0],
+		), err.Error())
+	}
+
+	{
+		// goto the label in the enclosing block, and break the label of the enclosing statement
+		_, err := NewFunc(
+			nil,
+			NewFuncSignature("f"),
+			NewLabel("outer"),
+			NewFor("", NewIf("x", NewGoto("outer")), NewBreak("outer")),
+		).Generate(0)
+		assert.NoError(t, err)
+	}
+}
+
+func TestShouldGenerateFuncRaisesErrorWhenLabelIsNotFollowedByStatement(t *testing.T) {
+	for _, generator := range []Statement{
+		NewFunc(nil, NewFuncSignature("f"), NewGoto("end"), NewLabel("end")),
+		NewFunc(nil, NewFuncSignature("f"), NewGoto("end"), NewLabel("end"), NewNewline(), NewComment(" end")),
+		NewFunc(nil, NewFuncSignature("f"), NewIf("x", NewLabel("L")).Else(NewElse(NewGoto("L")))),
+		NewAnonymousFunc(false, NewAnonymousFuncSignature(), NewCodeBlock(NewLabel("L"))),
+	} {
+		_, err := generator.Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.LabelIsNotFollowedByStatementError("", "").Error(), " ")[0],
+		), err.Error())
+	}
+}
+
+func TestShouldGenerateFuncRaisesErrorWhenLabelIsDuplicated(t *testing.T) {
+	_, err := NewFunc(
+		nil,
+		NewFuncSignature("f"),
+		NewLabel("L"),
+		NewCodeBlock(NewLabel("L")),
+	).Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.LabelIsDuplicatedError("", "").Error(), " ")[0],
+	), err.Error())
+}
@@ -0,0 +1,303 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
+
+// Mock represents a code generator for the mock implementation of the interface.
+//
+// The mock has a `XxxFunc` field for each method `Xxx`; the method calls that function, so each test can set the behavior.
+// The mock also records the arguments of each call; they can be retrieved by `XxxCalls()` and `XxxCallCount()`.
+// The records are held by the nested struct in `calls` field, so they don't conflict with the methods of the interface.
+// The other fields and methods of the mock still can conflict with them (e.g. the interface has both of `Get` and `GetCalls`); it raises an error.
+// The recording is guarded by `sync.Mutex`, so the mock can be used from multiple goroutines.
+//
+// The generated code depends on `sync` package; please add the import, or enable `goimports`.
+type Mock struct {
+	typeName      string
+	iface         *Interface
+	interfaceType string
+	caller        string
+}
+
+// NewMock returns a new `Mock` that implements `iface` by the type named `typeName`.
+// The interface can be loaded from a real package; please see also `LoadInterface()`.
+func NewMock(typeName string, iface *Interface) *Mock {
+	return &Mock{
+		typeName: typeName,
+		iface:    iface,
+		caller:   fetchClientCallerLine(),
+	}
+}
+
+// InterfaceType sets the type of the interface that is used in the compile-time assertion (i.e. `var _ Iface = (*Mock)(nil)`).
+// By default, it is the name of the interface; please set the qualified one (e.g. `io.Reader`) if the interface belongs to another package.
+// This method returns a *new* `Mock`; it means this method acts as immutable.
+func (m *Mock) InterfaceType(typ string) *Mock {
+	return &Mock{
+		typeName:      m.typeName,
+		iface:         m.iface,
+		interfaceType: typ,
+		caller:        m.caller,
+	}
+}
+
+// Generate generates the mock implementation of the interface as golang code.
+func (m *Mock) Generate(indentLevel int) (generated string, err error) {
+	defer annotateComposedErrorPath(&err, m.pathSegment())
+
+	if err := firstError(m.validate()); err != nil {
+		return "", err
+	}
+
+	return generateStatementGroups(m.statementGroups(), indentLevel)
+}
+
+// mockMethod holds the names that are derived from a method of the interface.
+type mockMethod struct {
+	sig        *FuncSignature
+	params     []*FuncParameter
+	funcField  string
+	callType   string
+	callFields []string
+	receiver   string
+}
+
+func (m *Mock) mockMethods() []*mockMethod {
+	methods := make([]*mockMethod, 0, len(m.iface.funcSignatures))
+	for _, sig := range m.iface.funcSignatures {
+		if sig == nil {
+			continue
+		}
+
+		params := sig.expandedParameters()
+		callFields := make([]string, len(params))
+		receiver := "mock"
+		for i, param := range params {
+			callFields[i] = ToExportedName(param.name)
+			if param.name == receiver {
+				receiver += "_"
+			}
+		}
+
+		methods = append(methods, &mockMethod{
+			sig:        sig,
+			params:     params,
+			funcField:  ToExportedName(sig.funcName) + "Func",
+			callType:   m.typeName + ToExportedName(sig.funcName) + "Call",
+			callFields: callFields,
+			receiver:   receiver,
+		})
+	}
+	return methods
+}
+
+// statementGroups builds the mock by the generators. Each group is a declaration with its doc comment.
+func (m *Mock) statementGroups() [][]Statement {
+	ifaceType := m.interfaceType
+	if ifaceType == "" {
+		ifaceType = m.iface.name
+	}
+
+	methods := m.mockMethods()
+
+	mockStruct := NewStruct(m.typeName)
+	for _, method := range methods {
+		mockStruct = mockStruct.AddField(method.funcField, funcTypeOf(method.params, method.sig.returnTypes))
+	}
+	mockStruct = mockStruct.AddField("mu", "sync.Mutex")
+	mockStruct = mockStruct.AddField("calls", m.callsType())
+
+	// the records are named after the methods as they are, so they are unique in the struct
+	callsStruct := NewStruct(m.callsType())
+	for _, method := range methods {
+		callsStruct = callsStruct.AddField(method.sig.funcName, "[]"+method.callType)
+	}
+
+	groups := [][]Statement{
+		{
+			NewCommentf(" %s is a mock implementation of %s.", m.typeName, ifaceType),
+			mockStruct,
+		},
+		{
+			NewCommentf(" %s holds the arguments of the calls of %s.", m.callsType(), m.typeName),
+			callsStruct,
+		},
+	}
+
+	for _, method := range methods {
+		groups = append(groups, m.methodStatementGroups(method)...)
+	}
+
+	groups = append(groups, []Statement{
+		NewVar([]string{"_"}, ifaceType, NewRawStatementf("(*%s)(nil)", m.typeName)),
+	})
+
+	return groups
+}
+
+// callsType returns the name of the struct that holds the records of the calls.
+func (m *Mock) callsType() string {
+	return ToUnexportedName(m.typeName) + "Calls"
+}
+
+func (m *Mock) methodStatementGroups(method *mockMethod) [][]Statement {
+	records := "calls." + method.sig.funcName
+	callStruct := NewStruct(method.callType)
+	callLiteral := NewCompositeLiteral(method.callType)
+	args := make([]string, len(method.params))
+	for i, param := range method.params {
+		typ := param.typ
+		args[i] = param.name
+		if strings.HasPrefix(typ, "...") {
+			typ = "[]" + strings.TrimPrefix(typ, "...")
+			args[i] += "..."
+		}
+		callStruct = callStruct.AddField(method.callFields[i], typ)
+		callLiteral = callLiteral.AddField(method.callFields[i], NewRawStatement(param.name))
+	}
+
+	recv := method.receiver
+	invocation := NewRawStatementf("%s.%s(%s)", recv, method.funcField, strings.Join(args, ", "))
+	var invoke Statement = invocation
+	if len(method.sig.returnTypes) > 0 {
+		invoke = NewReturnStatement().AddReturnStatements(invocation)
+	}
+
+	sig := NewFuncSignature(method.sig.funcName).
+		Parameters(method.params...).
+		ReturnTypeStatements(method.sig.returnTypes...)
+
+	return [][]Statement{
+		{
+			NewCommentf(" %s records the arguments of a call of %s.", method.callType, method.sig.funcName),
+			callStruct,
+		},
+		{
+			NewCommentf(" %s calls %s.", method.sig.funcName, method.funcField),
+			NewFunc(
+				NewFuncReceiver(recv, "*"+m.typeName),
+				sig,
+				NewRawStatementf("%s.mu.Lock()", recv),
+				NewAssign(
+					[]string{recv + "." + records},
+					"=",
+					NewCall("append", NewRawStatement(recv+"."+records), callLiteral),
+				),
+				NewRawStatementf("%s.mu.Unlock()", recv),
+				NewIf(
+					recv+"."+method.funcField+" == nil",
+					NewRawStatementf(`panic("%s.%s is not set")`, m.typeName, method.funcField),
+				),
+				invoke,
+			),
+		},
+		{
+			NewCommentf(" %sCalls returns the arguments of the calls of %s.", method.sig.funcName, method.sig.funcName),
+			NewFunc(
+				NewFuncReceiver("mock", "*"+m.typeName),
+				NewFuncSignature(method.sig.funcName+"Calls").AddReturnTypes("[]"+method.callType),
+				NewRawStatement("mock.mu.Lock()"),
+				NewDefer(NewRawStatement("mock.mu.Unlock()")),
+				NewReturnStatement().AddReturnStatements(
+					NewCall("append", NewRawStatementf("[]%s{}", method.callType), NewRawStatement("mock."+records)).
+						WithSpread(true),
+				),
+			),
+		},
+		{
+			NewCommentf(" %sCallCount returns the number of the calls of %s.", method.sig.funcName, method.sig.funcName),
+			NewFunc(
+				NewFuncReceiver("mock", "*"+m.typeName),
+				NewFuncSignature(method.sig.funcName+"CallCount").AddReturnTypes("int"),
+				NewRawStatement("mock.mu.Lock()"),
+				NewDefer(NewRawStatement("mock.mu.Unlock()")),
+				NewReturnStatement().AddReturnStatements(NewCall("len", NewRawStatement("mock."+records))),
+			),
+		},
+	}
+}
+
+// funcTypeOf returns the func type (e.g. `func(key string) ([]byte, error)`) of the parameters and the return types.
+func funcTypeOf(params []*FuncParameter, returnTypes []*FuncReturnType) string {
+	paramTypes := make([]string, len(params))
+	for i, param := range params {
+		paramTypes[i] = param.typ
+	}
+
+	retTypes := make([]string, len(returnTypes))
+	for i, ret := range returnTypes {
+		retTypes[i] = ret.typ
+	}
+
+	typ := "func(" + strings.Join(paramTypes, ", ") + ")"
+	switch len(retTypes) {
+	case 0:
+		// NOP
+	case 1:
+		typ += " " + retTypes[0]
+	default:
+		typ += " (" + strings.Join(retTypes, ", ") + ")"
+	}
+	return typ
+}
+
+func (m *Mock) validate() []error {
+	var errs []error
+	if m.typeName == "" {
+		errs = append(errs, errmsg.MockTypeNameIsEmptyError(m.caller))
+	}
+	errs = appendIdentifierError(errs, m.typeName, m.caller)
+	if m.iface == nil {
+		errs = append(errs, errmsg.MockInterfaceIsNilError(m.caller))
+	}
+	if m.typeName != "" && m.iface != nil {
+		errs = append(errs, m.validateMemberNames()...)
+	}
+	return errs
+}
+
+// validateMemberNames checks that the generated fields, methods and types don't conflict with each other.
+// The fields and the methods of the mock share the namespace; the types share that of the package.
+func (m *Mock) validateMemberNames() []error {
+	members := map[string]bool{"mu": true, "calls": true}
+	types := map[string]bool{m.callsType(): true}
+
+	var errs []error
+	declare := func(declared map[string]bool, name string) {
+		if declared[name] {
+			errs = append(errs, errmsg.MockMemberNameConflictsError(name, m.typeName, m.caller))
+			return
+		}
+		declared[name] = true
+	}
+
+	methods := m.mockMethods()
+	for _, method := range methods {
+		declare(members, method.funcField)
+		declare(types, method.callType)
+	}
+	for _, method := range methods {
+		name := method.sig.funcName
+		declare(members, name)
+		declare(members, name+"Calls")
+		declare(members, name+"CallCount")
+	}
+	return errs
+}
+
+func (m *Mock) childStatements() []Statement {
+	if m.typeName == "" || m.iface == nil {
+		return nil
+	}
+
+	return flattenStatementGroups(m.statementGroups())
+}
+
+func (m *Mock) pathSegment() errorPathSegment {
+	return errorPathSegment{name: namedPathSegment("Mock", m.typeName), caller: m.caller}
+}
+
+func (m *Mock) composesStatements() {}
@@ -0,0 +1,25 @@
+package generator
+
+import (
+	"fmt"
+	"log"
+)
+
+func ExampleMock_Generate() {
+	iface := NewInterface(
+		"Store",
+		NewFuncSignature("Get").
+			AddParameters(NewFuncParameter("key", "string")).
+			AddReturnTypes("[]byte", "error"),
+	)
+
+	generated, err := NewRoot(
+		NewPackage("mypkg"),
+		NewImport("sync"),
+		NewMock("StoreMock", iface),
+	).Gofmt().Generate(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(generated)
+}
@@ -0,0 +1,225 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldGenerateMock(t *testing.T) {
+	iface := NewInterface(
+		"Getter",
+		NewFuncSignature("Get").
+			AddParameters(NewFuncParameter("key", "string")).
+			AddReturnTypes("[]byte", "error"),
+	)
+
+	expected := `// GetterMock is a mock implementation of Getter.
+type GetterMock struct {
+	GetFunc func(string) ([]byte, error)
+	mu sync.Mutex
+	calls getterMockCalls
+}
+
+// getterMockCalls holds the arguments of the calls of GetterMock.
+type getterMockCalls struct {
+	Get []GetterMockGetCall
+}
+
+// GetterMockGetCall records the arguments of a call of Get.
+type GetterMockGetCall struct {
+	Key string
+}
+
+// Get calls GetFunc.
+func (mock *GetterMock) Get(key string) ([]byte, error) {
+	mock.mu.Lock()
+	mock.calls.Get = append(mock.calls.Get, GetterMockGetCall{
+		Key: key,
+	})
+	mock.mu.Unlock()
+	if mock.GetFunc == nil {
+		panic("GetterMock.GetFunc is not set")
+	}
+	return mock.GetFunc(key)
+}
+
+// GetCalls returns the arguments of the calls of Get.
+func (mock *GetterMock) GetCalls() []GetterMockGetCall {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	return append([]GetterMockGetCall{}, mock.calls.Get...)
+}
+
+// GetCallCount returns the number of the calls of Get.
+func (mock *GetterMock) GetCallCount() int {
+	mock.mu.Lock()
+	defer mock.mu.Unlock()
+	return len(mock.calls.Get)
+}
+
+var _ Getter = (*GetterMock)(nil)
+`
+	generated, err := NewMock("GetterMock", iface).Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, generated)
+}
+
+func TestShouldGenerateMockWithoutConflictingReceiver(t *testing.T) {
+	iface := NewInterface(
+		"Notifier",
+		NewFuncSignature("Notify").AddParameters(
+			NewFuncParameter("mock", ""),
+			NewFuncParameter("_", "string"),
+			NewFuncParameter("opts", "...int"),
+		),
+	)
+
+	generated, err := NewMock("NotifierMock", iface).InterfaceType("notify.Notifier").Generate(0)
+	assert.NoError(t, err)
+	assert.Contains(t, generated, "func (mock_ *NotifierMock) Notify(\n\tmock string,\n\targ1 string,\n\topts ...int,\n) {\n")
+	assert.Contains(t, generated, "\tmock_.NotifyFunc(mock, arg1, opts...)\n")
+	assert.Contains(t, generated, "\tMock string\n\tArg1 string\n\tOpts []int\n")
+	assert.Contains(t, generated, "\tNotifyFunc func(string, string, ...int)\n")
+	assert.Contains(t, generated, "var _ notify.Notifier = (*NotifierMock)(nil)\n")
+}
+
+func TestShouldGenerateMockOfUnexportedMethod(t *testing.T) {
+	iface := NewInterface(
+		"getter",
+		NewFuncSignature("get").
+			AddParameters(NewFuncParameter("key", "string")).
+			AddReturnTypes("[]byte", "error"),
+		NewFuncSignature("Get").
+			AddParameters(NewFuncParameter("key", "string")).
+			AddReturnTypes("[]byte", "error"),
+	)
+
+	generated, err := NewMock("getterMock", iface).Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.MockMemberNameConflictsError("", "", "").Error(), " ")[0],
+	), err.Error())
+	assert.Empty(t, generated)
+
+	iface = NewInterface(
+		"getter",
+		NewFuncSignature("get").
+			AddParameters(NewFuncParameter("key", "string")).
+			AddReturnTypes("[]byte", "error"),
+	)
+
+	generated, err = NewMock("getterMock", iface).Generate(0)
+	assert.NoError(t, err)
+	assert.Contains(t, generated, "\tcalls getterMockCalls\n")
+	assert.Contains(t, generated, "type getterMockCalls struct {\n\tget []getterMockGetCall\n}\n")
+	assert.Contains(t, generated, "func (mock *getterMock) getCalls() []getterMockGetCall {\n")
+	assert.Contains(t, generated, "func (mock *getterMock) getCallCount() int {\n")
+
+	_, err = NewRoot(
+		NewPackage("mockpkg"),
+		NewImport("sync"),
+		NewNewline(),
+		iface,
+		NewNewline(),
+		NewMock("getterMock", iface),
+	).EnableTypeChecking("").Generate(0)
+	assert.NoError(t, err)
+}
+
+func TestShouldRaiseErrorWhenMockMembersConflict(t *testing.T) {
+	for _, iface := range []*Interface{
+		NewInterface("Getter", NewFuncSignature("Get"), NewFuncSignature("GetCalls")),
+		NewInterface("Getter", NewFuncSignature("Get"), NewFuncSignature("GetCallCount")),
+		NewInterface("Getter", NewFuncSignature("Get"), NewFuncSignature("GetFunc")),
+		NewInterface("Getter", NewFuncSignature("calls")),
+		NewInterface("Getter", NewFuncSignature("mu")),
+	} {
+		_, err := NewMock("GetterMock", iface).Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.MockMemberNameConflictsError("", "", "").Error(), " ")[0],
+		), err.Error())
+	}
+}
+
+func TestShouldRaiseErrorWhenMockIsInvalid(t *testing.T) {
+	_, err := NewMock("", NewInterface("Getter")).Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.MockTypeNameIsEmptyError("").Error(), " ")[0],
+	), err.Error())
+
+	_, err = NewMock("GetterMock", nil).Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.MockInterfaceIsNilError("").Error(), " ")[0],
+	), err.Error())
+}
+
+func TestShouldGeneratedMockPassVetAndRaceDetector(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this test builds the generated code")
+	}
+
+	iface, err := LoadInterface("", "./testdata/stubtarget", "Store")
+	assert.NoError(t, err)
+
+	mockCode, err := NewRoot(
+		NewPackage("mockpkg"),
+		NewImport("sync"),
+		NewNewline(),
+		iface,
+		NewNewline(),
+		NewMock("StoreMock", iface),
+	).Gofmt().EnableTypeChecking("").Generate(0)
+	assert.NoError(t, err)
+
+	testCode, err := NewRoot(
+		NewPackage("mockpkg"),
+		NewImport("sync", "testing"),
+		NewNewline(),
+		NewFunc(
+			nil,
+			NewFuncSignature("TestStoreMock").AddParameters(NewFuncParameter("t", "*testing.T")),
+			NewShortVarDecl([]string{"mock"}, NewRawStatement("&StoreMock{}")),
+			NewAssign([]string{"mock.GetFunc"}, "=", NewAnonymousFunc(
+				false,
+				NewAnonymousFuncSignature().
+					AddParameters(NewFuncParameter("key", "string")).
+					AddReturnTypes("[]byte", "error"),
+				NewReturnStatement("[]byte(key)", "nil"),
+			)),
+			NewVar([]string{"s"}, "Store", NewRawStatement("mock")),
+			NewVar([]string{"wg"}, "sync.WaitGroup"),
+			NewForClause(
+				NewShortVarDecl([]string{"i"}, NewRawStatement("0")),
+				"i < 10",
+				NewIncrement("i"),
+				NewRawStatement("wg.Add(1)"),
+				NewGo(NewAnonymousFunc(
+					false,
+					NewAnonymousFuncSignature(),
+					NewDefer(NewRawStatement("wg.Done()")),
+					NewRawStatement(`_, _ = s.Get("key")`),
+					NewRawStatement("_ = mock.GetCallCount()"),
+				).Invocation(NewFuncInvocation())),
+			),
+			NewRawStatement("wg.Wait()"),
+			NewIf(
+				"n := mock.GetCallCount(); n != 10",
+				NewRawStatement(`t.Fatalf("unexpected call count: %d", n)`),
+			),
+			NewIf(
+				`calls := mock.GetCalls(); calls[0].Key != "key"`,
+				NewRawStatement(`t.Fatalf("unexpected call: %v", calls[0])`),
+			),
+		),
+	).Gofmt().Generate(0)
+	assert.NoError(t, err)
+
+	runGoCommandsOnGeneratedPackage(t, map[string]string{
+		"mock.go":      mockCode,
+		"mock_test.go": testCode,
+	}, []string{"vet", "."}, []string{"test", "-race", "."})
+}
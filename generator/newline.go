@@ -2,11 +2,14 @@ package generator
 
 // Newline represents a code generator for newline character.
 type Newline struct {
+	caller string
 }
 
 // NewNewline returns a new `Newline`.
 func NewNewline() *Newline {
-	return &Newline{}
+	return &Newline{
+		caller: fetchClientCallerLine(),
+	}
 }
 
 // Generate generates a newline statement as golang code.
@@ -0,0 +1,1079 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
+
+// OpenAPI represents a code generator for the golang package that is derived from an OpenAPI 3 document.
+// The package consists of the following files, and they are generated by the generators of this library:
+//
+//   - `models.go`: the types of the schemas (like `JSONTypes`), the parameters struct of each operation (`XxxParams`) and `HTTPError`
+//   - `server.go`: `Server` interface that has a method for each operation, and `NewServerHandler(Server)` that returns `http.Handler`;
+//     the handler routes the request to `Server` with decoding the path/query/header parameters and the JSON body
+//   - `client.go`: `Client` that calls each operation by `http.Client`
+//
+// Each operation is named by `operationId` (or the method and the path if it is not specified), and the method of `Server` and `Client`
+// is like `GetUser(ctx context.Context, params GetUserParams, body *NewUser) (*User, error)`; the params, the body and the result are omitted
+// if the operation doesn't have them. The result is the JSON body of the first 2xx response.
+// The error of `Server` is responded with the status code of `*HTTPError` (or 500 for the other errors),
+// and `Client` returns `*HTTPError` for the non-2xx response.
+type OpenAPI struct {
+	decls      []*jsonTypeDecl
+	operations []*openAPIOperation
+}
+
+type openAPIOperation struct {
+	name          string
+	method        string
+	path          string
+	summary       string
+	paramsType    string
+	params        []*openAPIParam
+	bodyType      string
+	bodyNilable   bool
+	bodyOptional  bool
+	resultType    string
+	resultNilable bool
+	status        int
+}
+
+type openAPIParam struct {
+	name      string
+	in        string
+	fieldName string
+	typ       string
+	baseType  string
+	array     bool
+	required  bool
+	// the index of the path segment for the path parameter
+	segment int
+}
+
+// the documents of OpenAPI
+
+type openAPIDocument struct {
+	OpenAPI    string       `json:"openapi"`
+	Paths      openAPIPaths `json:"paths"`
+	Components struct {
+		Schemas       jsonSchemaProperties           `json:"schemas"`
+		Parameters    map[string]*openAPIParameter   `json:"parameters"`
+		RequestBodies map[string]*openAPIRequestBody `json:"requestBodies"`
+		Responses     map[string]*openAPIResponse    `json:"responses"`
+	} `json:"components"`
+}
+
+type openAPIPathItem struct {
+	path       string
+	Parameters []*openAPIParameter     `json:"parameters"`
+	Get        *openAPIOperationObject `json:"get"`
+	Put        *openAPIOperationObject `json:"put"`
+	Post       *openAPIOperationObject `json:"post"`
+	Delete     *openAPIOperationObject `json:"delete"`
+	Options    *openAPIOperationObject `json:"options"`
+	Head       *openAPIOperationObject `json:"head"`
+	Patch      *openAPIOperationObject `json:"patch"`
+	Trace      *openAPIOperationObject `json:"trace"`
+}
+
+// openAPIPaths is the paths object; it keeps the order of the document, so the generated code is deterministic.
+type openAPIPaths []*openAPIPathItem
+
+func (p *openAPIPaths) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return fmt.Errorf("paths must be an object: %s", data)
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+
+		var item openAPIPathItem
+		if err := dec.Decode(&item); err != nil {
+			return err
+		}
+		item.path = tok.(string)
+		*p = append(*p, &item)
+	}
+	return nil
+}
+
+type openAPIOperationObject struct {
+	OperationID string                      `json:"operationId"`
+	Summary     string                      `json:"summary"`
+	Parameters  []*openAPIParameter         `json:"parameters"`
+	RequestBody *openAPIRequestBody         `json:"requestBody"`
+	Responses   map[string]*openAPIResponse `json:"responses"`
+}
+
+type openAPIParameter struct {
+	Ref      string      `json:"$ref"`
+	Name     string      `json:"name"`
+	In       string      `json:"in"`
+	Required bool        `json:"required"`
+	Schema   *jsonSchema `json:"schema"`
+}
+
+type openAPIRequestBody struct {
+	Ref      string                       `json:"$ref"`
+	Required bool                         `json:"required"`
+	Content  map[string]*openAPIMediaType `json:"content"`
+}
+
+type openAPIResponse struct {
+	Ref     string                       `json:"$ref"`
+	Content map[string]*openAPIMediaType `json:"content"`
+}
+
+type openAPIMediaType struct {
+	Schema *jsonSchema `json:"schema"`
+}
+
+// LoadOpenAPI reads the OpenAPI 3 document (YAML or JSON) from the local file, and returns a new `OpenAPI`.
+func LoadOpenAPI(path string) (*OpenAPI, error) {
+	caller := fetchClientCallerLine()
+
+	spec, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errmsg.OpenAPILoadingError(path, err.Error(), caller)
+	}
+	return newOpenAPI(spec, caller)
+}
+
+// NewOpenAPI returns a new `OpenAPI` from the OpenAPI 3 document; it can be written in YAML or JSON.
+// Please see also `OpenAPI` for the generated code.
+func NewOpenAPI(spec []byte) (*OpenAPI, error) {
+	return newOpenAPI(spec, fetchClientCallerLine())
+}
+
+func newOpenAPI(spec []byte, caller string) (*OpenAPI, error) {
+	converted, err := yamlToJSON(spec)
+	if err != nil {
+		return nil, errmsg.OpenAPIIsInvalidError(err.Error(), caller)
+	}
+
+	var doc openAPIDocument
+	if err := json.Unmarshal(converted, &doc); err != nil {
+		return nil, errmsg.OpenAPIIsInvalidError(err.Error(), caller)
+	}
+	if !strings.HasPrefix(doc.OpenAPI, "3.") {
+		return nil, errmsg.OpenAPIIsInvalidError(fmt.Sprintf("openapi must be 3.x, but it gets '%s'", doc.OpenAPI), caller)
+	}
+
+	c := newJSONSchemaConverter(caller)
+	for _, name := range []string{"HTTPError", "Server", "NewServerHandler", "Client", "NewClient"} {
+		c.names[name] = true
+	}
+	for _, schema := range doc.Components.Schemas {
+		c.register("#/components/schemas/"+schema.name, c.names.reserve(schema.name), schema.schema)
+	}
+	if err := c.declareDefinitions(); err != nil {
+		return nil, err
+	}
+
+	var operations []*openAPIOperation
+	names := map[string]bool{}
+	for _, item := range doc.Paths {
+		for _, method := range []struct {
+			name      string
+			operation *openAPIOperationObject
+		}{
+			{http.MethodGet, item.Get},
+			{http.MethodPut, item.Put},
+			{http.MethodPost, item.Post},
+			{http.MethodDelete, item.Delete},
+			{http.MethodOptions, item.Options},
+			{http.MethodHead, item.Head},
+			{http.MethodPatch, item.Patch},
+			{http.MethodTrace, item.Trace},
+		} {
+			if method.operation == nil {
+				continue
+			}
+
+			op, err := newOpenAPIOperation(c, &doc, item, method.name, method.operation)
+			if err != nil {
+				return nil, err
+			}
+			if names[op.name] {
+				return nil, errmsg.OpenAPIOperationIsDuplicatedError(op.name, caller)
+			}
+			names[op.name] = true
+			operations = append(operations, op)
+		}
+	}
+
+	return &OpenAPI{
+		decls:      c.decls,
+		operations: operations,
+	}, nil
+}
+
+func newOpenAPIOperation(c *jsonSchemaConverter, doc *openAPIDocument, item *openAPIPathItem, method string, obj *openAPIOperationObject) (*openAPIOperation, error) {
+	name := obj.OperationID
+	if name == "" {
+		name = strings.ToLower(method) + " " + item.path
+	}
+
+	op := &openAPIOperation{
+		name:    ToExportedName(name),
+		method:  method,
+		path:    item.path,
+		summary: obj.Summary,
+	}
+
+	params, err := mergeOpenAPIParameters(c, doc, item.Parameters, obj.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	if len(params) > 0 {
+		op.paramsType = c.names.reserve(op.name + "Params")
+	}
+
+	pathParams := map[string]int{}
+	for i, segment := range strings.Split(strings.Trim(item.path, "/"), "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			pathParams[segment[1:len(segment)-1]] = i
+		} else if strings.ContainsAny(segment, "{}") {
+			return nil, errmsg.OpenAPIIsInvalidError(fmt.Sprintf("path parameter must be a whole segment, but it gets '%s'", item.path), c.caller)
+		}
+	}
+
+	used := map[string]bool{}
+	for _, p := range params {
+		param, err := newOpenAPIParam(c, op, p, used)
+		if err != nil {
+			return nil, err
+		}
+		if param.in == "path" {
+			i, ok := pathParams[param.name]
+			if !ok {
+				return nil, errmsg.OpenAPIIsInvalidError(fmt.Sprintf("path parameter '%s' is not in the path '%s'", param.name, item.path), c.caller)
+			}
+			param.segment = i
+			delete(pathParams, param.name)
+		}
+		op.params = append(op.params, param)
+	}
+	if len(pathParams) > 0 {
+		undefined := make([]string, 0, len(pathParams))
+		for name := range pathParams {
+			undefined = append(undefined, name)
+		}
+		sort.Strings(undefined)
+		return nil, errmsg.OpenAPIIsInvalidError(fmt.Sprintf("path parameter '%s' of the path '%s' is not defined", undefined[0], item.path), c.caller)
+	}
+
+	if body := obj.RequestBody; body != nil {
+		if body.Ref != "" {
+			body = doc.Components.RequestBodies[strings.TrimPrefix(body.Ref, "#/components/requestBodies/")]
+			if body == nil {
+				return nil, errmsg.JSONSchemaRefIsNotFoundError(obj.RequestBody.Ref, c.caller)
+			}
+		}
+		schema, err := jsonContentSchema(c, op, body.Content)
+		if err != nil {
+			return nil, err
+		}
+		if schema != nil {
+			typ, nilable, err := c.typeOf(schema, op.name+"Request")
+			if err != nil {
+				return nil, err
+			}
+			op.bodyType = pointerTypeOf(typ, nilable)
+			op.bodyNilable = nilable
+			op.bodyOptional = !body.Required
+		}
+	}
+
+	statuses := make([]string, 0, len(obj.Responses))
+	for status := range obj.Responses {
+		if strings.HasPrefix(status, "2") {
+			statuses = append(statuses, status)
+		}
+	}
+	sort.Strings(statuses)
+	op.status = http.StatusOK
+	if len(statuses) > 0 {
+		if status, err := strconv.Atoi(statuses[0]); err == nil {
+			op.status = status
+		}
+
+		resp := obj.Responses[statuses[0]]
+		if resp.Ref != "" {
+			resp = doc.Components.Responses[strings.TrimPrefix(resp.Ref, "#/components/responses/")]
+			if resp == nil {
+				return nil, errmsg.JSONSchemaRefIsNotFoundError(obj.Responses[statuses[0]].Ref, c.caller)
+			}
+		}
+		schema, err := jsonContentSchema(c, op, resp.Content)
+		if err != nil {
+			return nil, err
+		}
+		if schema != nil {
+			typ, nilable, err := c.typeOf(schema, op.name+"Response")
+			if err != nil {
+				return nil, err
+			}
+			op.resultType = pointerTypeOf(typ, nilable)
+			op.resultNilable = nilable
+		}
+	}
+
+	return op, nil
+}
+
+// mergeOpenAPIParameters resolves `$ref` of the parameters, and overrides the parameters of the path by the ones of the operation.
+func mergeOpenAPIParameters(c *jsonSchemaConverter, doc *openAPIDocument, pathParams []*openAPIParameter, opParams []*openAPIParameter) ([]*openAPIParameter, error) {
+	var params []*openAPIParameter
+	for _, p := range append(append([]*openAPIParameter{}, pathParams...), opParams...) {
+		if p.Ref != "" {
+			ref := p.Ref
+			if p = doc.Components.Parameters[strings.TrimPrefix(ref, "#/components/parameters/")]; p == nil {
+				return nil, errmsg.JSONSchemaRefIsNotFoundError(ref, c.caller)
+			}
+		}
+
+		replaced := false
+		for i, param := range params {
+			if param.Name == p.Name && param.In == p.In {
+				params[i] = p
+				replaced = true
+			}
+		}
+		if !replaced {
+			params = append(params, p)
+		}
+	}
+	return params, nil
+}
+
+func newOpenAPIParam(c *jsonSchemaConverter, op *openAPIOperation, p *openAPIParameter, used map[string]bool) (*openAPIParam, error) {
+	param := &openAPIParam{
+		name:      p.Name,
+		in:        p.In,
+		fieldName: uniqueFieldName(p.Name, used),
+		required:  p.Required || p.In == "path",
+	}
+	if p.Schema == nil || (p.In != "path" && p.In != "query" && p.In != "header") {
+		return nil, errmsg.OpenAPIParameterIsNotSupportedError(p.Name, op.name, c.caller)
+	}
+
+	schema, err := c.resolve(p.Schema)
+	if err != nil {
+		return nil, err
+	}
+	if types := schema.types(); len(types) == 1 && types[0] == "array" && p.In == "query" && schema.Items != nil {
+		param.array = true
+		if schema, err = c.resolve(schema.Items); err != nil {
+			return nil, err
+		}
+	}
+	param.baseType = primitiveTypeOf(schema)
+	if param.baseType == "" {
+		return nil, errmsg.OpenAPIParameterIsNotSupportedError(p.Name, op.name, c.caller)
+	}
+
+	typ, nilable, err := c.typeOf(p.Schema, op.paramsType+param.fieldName)
+	if err != nil {
+		return nil, err
+	}
+	// the parameter is passed as it is, so `date-time` is not parsed
+	param.typ = strings.Replace(typ, "time.Time", "string", 1)
+	if !param.required {
+		param.typ = pointerTypeOf(param.typ, nilable)
+	}
+	return param, nil
+}
+
+// jsonContentSchema returns the schema of JSON content; it returns nil if there is no content.
+func jsonContentSchema(c *jsonSchemaConverter, op *openAPIOperation, content map[string]*openAPIMediaType) (*jsonSchema, error) {
+	if len(content) <= 0 {
+		return nil, nil
+	}
+
+	contentTypes := make([]string, 0, len(content))
+	for contentType, media := range content {
+		if contentType == "application/json" || strings.HasSuffix(contentType, "+json") {
+			if media.Schema == nil {
+				return &jsonSchema{}, nil
+			}
+			return media.Schema, nil
+		}
+		contentTypes = append(contentTypes, contentType)
+	}
+	sort.Strings(contentTypes)
+	return nil, errmsg.OpenAPIContentTypeIsNotSupportedError(contentTypes[0], op.name, c.caller)
+}
+
+// resolve returns the schema that `$ref` of the schema refers to.
+func (c *jsonSchemaConverter) resolve(s *jsonSchema) (*jsonSchema, error) {
+	for i := 0; s.Ref != ""; i++ {
+		if i > len(c.refs) {
+			return nil, errmsg.JSONSchemaIsInvalidError(fmt.Sprintf("$ref '%s' refers to itself circularly", s.Ref), c.caller)
+		}
+		_, refSchema, err := c.lookupRef(s.Ref)
+		if err != nil {
+			return nil, err
+		}
+		s = refSchema
+	}
+	return s, nil
+}
+
+// primitiveTypeOf returns the golang type of the primitive schema; it returns empty string if the schema is not primitive.
+func primitiveTypeOf(s *jsonSchema) string {
+	types := s.types()
+	if len(types) != 1 {
+		return ""
+	}
+	switch types[0] {
+	case "string":
+		return "string"
+	case "integer":
+		if s.Format == "int32" {
+			return "int32"
+		}
+		return "int64"
+	case "number":
+		if s.Format == "float" {
+			return "float32"
+		}
+		return "float64"
+	case "boolean":
+		return "bool"
+	}
+	return ""
+}
+
+// Roots returns `Root` of each file of the package; the key is the file name (i.e. `models.go`, `server.go` and `client.go`).
+// Please see also `Files()`.
+func (o *OpenAPI) Roots(packageName string) map[string]*Root {
+	return map[string]*Root{
+		"models.go": NewRoot(o.modelsStatements(packageName)...),
+		"server.go": NewRoot(o.serverStatements(packageName)...),
+		"client.go": NewRoot(o.clientStatements(packageName)...),
+	}
+}
+
+// Files generates the files of the package with `gofmt`; the key is the file name.
+func (o *OpenAPI) Files(packageName string) (map[string]string, error) {
+	files := map[string]string{}
+	for name, root := range o.Roots(packageName) {
+		generated, err := root.Gofmt().Generate(0)
+		if err != nil {
+			return nil, err
+		}
+		files[name] = generated
+	}
+	return files, nil
+}
+
+// statementsWithImports returns the statements of the file, that begins with the package and the imports.
+func statementsWithImports(packageName string, imports []string, groups [][]Statement) []Statement {
+	sort.Strings(imports)
+	statements := []Statement{NewPackage(packageName)}
+	if len(imports) > 0 {
+		statements = append(statements, NewImport(imports...))
+	}
+	for _, group := range groups {
+		statements = append(statements, NewNewline())
+		statements = append(statements, group...)
+	}
+	return statements
+}
+
+func (o *OpenAPI) usesStrconv() bool {
+	for _, op := range o.operations {
+		for _, param := range op.params {
+			if param.baseType != "string" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (o *OpenAPI) modelsStatements(packageName string) []Statement {
+	groups := jsonTypeDeclStatementGroups(o.decls)
+
+	for _, op := range o.operations {
+		if op.paramsType == "" {
+			continue
+		}
+		fields := make([]*StructField, len(op.params))
+		for i, param := range op.params {
+			fields[i] = NewStructField(param.fieldName, param.typ).Comment(fmt.Sprintf(" %s is the %s parameter `%s`.", param.fieldName, param.in, param.name))
+		}
+		groups = append(groups, []Statement{
+			NewCommentf(" %s is the parameters of %s.", op.paramsType, op.name),
+			NewStruct(op.paramsType).AddFields(fields...),
+		})
+	}
+
+	groups = append(groups,
+		[]Statement{
+			NewComment(" HTTPError is the error that has the status code of HTTP."),
+			NewComment(" The server responds it with the status code, and the client returns it for the non-2xx response."),
+			NewStruct("HTTPError").AddField("StatusCode", "int").AddField("Message", "string"),
+		},
+		[]Statement{
+			NewComment(" Error returns the status and the message."),
+			NewFunc(
+				NewFuncReceiver("e", "*HTTPError"),
+				NewFuncSignature("Error").AddReturnTypes("string"),
+				NewReturnStatement(`http.StatusText(e.StatusCode) + ": " + e.Message`),
+			),
+		},
+	)
+
+	imports := []string{"net/http"}
+	if jsonTypeDeclsUseTime(o.decls) {
+		imports = append(imports, "time")
+	}
+	if jsonTypeDeclsUseUnion(o.decls) {
+		imports = append(imports, "bytes", "encoding/json", "fmt")
+	}
+	return statementsWithImports(packageName, imports, groups)
+}
+
+// signature returns the signature of the method of `Server` and `Client` for the operation.
+func (op *openAPIOperation) signature() *FuncSignature {
+	sig := NewFuncSignature(op.name).AddParameters(NewFuncParameter("ctx", "context.Context"))
+	if op.paramsType != "" {
+		sig = sig.AddParameters(NewFuncParameter("params", op.paramsType))
+	}
+	if op.bodyType != "" {
+		sig = sig.AddParameters(NewFuncParameter("body", op.bodyType))
+	}
+	if op.resultType != "" {
+		sig = sig.AddReturnTypes(op.resultType)
+	}
+	return sig.AddReturnTypes("error")
+}
+
+func (op *openAPIOperation) comment() Statement {
+	if op.summary != "" {
+		return NewCommentf(" %s calls %s %s; %s", op.name, op.method, op.path, op.summary)
+	}
+	return NewCommentf(" %s calls %s %s.", op.name, op.method, op.path)
+}
+
+func httpMethodConst(method string) string {
+	return "http.Method" + capitalize(method)
+}
+
+func (o *OpenAPI) serverStatements(packageName string) []Statement {
+	sigs := make([]*FuncSignature, len(o.operations))
+	for i, op := range o.operations {
+		sigs[i] = op.signature()
+	}
+
+	// the route that has less path parameters is prior to the others, to match the fixed path (e.g. `/users/me` rather than `/users/{id}`)
+	routes := make([]*openAPIOperation, len(o.operations))
+	copy(routes, o.operations)
+	sort.SliceStable(routes, func(i, j int) bool {
+		return routes[i].pathParamsCount() < routes[j].pathParamsCount()
+	})
+
+	router := NewSwitch("")
+	for _, op := range routes {
+		router = router.AddCase(NewCase(
+			"r.Method == "+httpMethodConst(op.method)+" && "+pathConditionOf(op.path),
+			NewRawStatementf("h.handle%s(w, r, segments)", op.name),
+		))
+	}
+	// the request that matches the path of the operations but not the method is 405 Method Not Allowed
+	seen := map[string]bool{}
+	for _, op := range routes {
+		if seen[op.path] {
+			continue
+		}
+		seen[op.path] = true
+
+		var methods []string
+		allowed := map[string]bool{}
+		for _, other := range routes {
+			if openAPIPathMatches(other.path, op.path) && !allowed[other.method] {
+				allowed[other.method] = true
+				methods = append(methods, other.method)
+			}
+		}
+		router = router.AddCase(NewCase(
+			pathConditionOf(op.path),
+			NewRawStatementf(`w.Header().Set("Allow", %s)`, strconv.Quote(strings.Join(methods, ", "))),
+			NewRawStatement(`writeError(w, &HTTPError{StatusCode: http.StatusMethodNotAllowed, Message: r.Method + " is not allowed"})`),
+		))
+	}
+	router = router.Default(NewDefaultCase(NewRawStatement("http.NotFound(w, r)")))
+
+	groups := [][]Statement{
+		{
+			NewComment(" Server is the interface of the handlers of the API; please serve the implementation by NewServerHandler()."),
+			NewInterface("Server", sigs...),
+		},
+		{
+			NewComment(" NewServerHandler returns http.Handler that routes the requests to the server."),
+			NewFunc(
+				nil,
+				NewFuncSignature("NewServerHandler").AddParameters(NewFuncParameter("server", "Server")).AddReturnTypes("http.Handler"),
+				NewReturnStatement("&serverHandler{server: server}"),
+			),
+		},
+		{
+			NewStruct("serverHandler").AddField("server", "Server"),
+		},
+		{
+			NewComment(" ServeHTTP routes the request to the handler of the operation."),
+			NewFunc(
+				NewFuncReceiver("h", "*serverHandler"),
+				NewFuncSignature("ServeHTTP").AddParameters(
+					NewFuncParameter("w", "http.ResponseWriter"),
+					NewFuncParameter("r", "*http.Request"),
+				),
+				// split the escaped path, since the path parameter may contain the escaped slash (i.e. `%2F`)
+				NewShortVarDecl([]string{"segments"}, NewRawStatement(`strings.Split(strings.Trim(r.URL.EscapedPath(), "/"), "/")`)),
+				NewForRange("i", "segment", "segments",
+					NewShortVarDecl([]string{"unescaped", "err"}, NewRawStatement("url.PathUnescape(segment)")),
+					NewIf("err != nil", badRequest(`"invalid path: " + err.Error()`)...),
+					NewAssign([]string{"segments[i]"}, "=", NewRawStatement("unescaped")),
+				),
+				router,
+			),
+		},
+	}
+
+	usesIO := false
+	for _, op := range o.operations {
+		groups = append(groups, []Statement{op.handlerFunc()})
+		usesIO = usesIO || (op.bodyType != "" && op.bodyOptional)
+	}
+
+	groups = append(groups,
+		[]Statement{
+			NewFunc(
+				nil,
+				NewFuncSignature("writeJSON").AddParameters(
+					NewFuncParameter("w", "http.ResponseWriter"),
+					NewFuncParameter("statusCode", "int"),
+					NewFuncParameter("v", "interface{}"),
+				),
+				NewRawStatement(`w.Header().Set("Content-Type", "application/json")`),
+				NewRawStatement("w.WriteHeader(statusCode)"),
+				NewRawStatement("_ = json.NewEncoder(w).Encode(v)"),
+			),
+		},
+		[]Statement{
+			NewFunc(
+				nil,
+				NewFuncSignature("writeError").AddParameters(
+					NewFuncParameter("w", "http.ResponseWriter"),
+					NewFuncParameter("err", "error"),
+				),
+				NewVar([]string{"httpErr"}, "*HTTPError"),
+				NewIf(
+					"!errors.As(err, &httpErr)",
+					NewRawStatement("httpErr = &HTTPError{StatusCode: http.StatusInternalServerError, Message: err.Error()}"),
+				),
+				NewRawStatement("http.Error(w, httpErr.Message, httpErr.StatusCode)"),
+			),
+		},
+	)
+
+	imports := []string{"context", "encoding/json", "errors", "net/http", "net/url", "strings"}
+	if o.usesStrconv() {
+		imports = append(imports, "strconv")
+	}
+	if usesIO {
+		imports = append(imports, "io")
+	}
+	return statementsWithImports(packageName, imports, groups)
+}
+
+// pathConditionOf returns the condition that the segments of the request path match the path of the operation.
+func pathConditionOf(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	conditions := []string{"len(segments) == " + strconv.Itoa(len(segments))}
+	for i, segment := range segments {
+		if !strings.HasPrefix(segment, "{") {
+			conditions = append(conditions, fmt.Sprintf("segments[%d] == %s", i, strconv.Quote(segment)))
+		}
+	}
+	return strings.Join(conditions, " && ")
+}
+
+// openAPIPathMatches reports whether the request path of `path` can be routed to `pattern`,
+// i.e. each fixed segment of `pattern` is the same as that of `path`.
+func openAPIPathMatches(pattern string, path string) bool {
+	patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(patternSegments) != len(pathSegments) {
+		return false
+	}
+	for i, segment := range patternSegments {
+		if !strings.HasPrefix(segment, "{") && segment != pathSegments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (op *openAPIOperation) pathParamsCount() int {
+	return strings.Count(op.path, "{")
+}
+
+// badRequest returns the statements to respond 400 Bad Request.
+func badRequest(message string) []Statement {
+	return []Statement{
+		NewRawStatementf("writeError(w, &HTTPError{StatusCode: http.StatusBadRequest, Message: %s})", message),
+		NewReturnStatement(),
+	}
+}
+
+// handlerFunc returns the method of the server handler that decodes the request and calls the operation of `Server`.
+func (op *openAPIOperation) handlerFunc() Statement {
+	var statements []Statement
+	for _, param := range op.params {
+		if param.in == "query" {
+			statements = append(statements, NewShortVarDecl([]string{"query"}, NewRawStatement("r.URL.Query()")))
+			break
+		}
+	}
+	if op.paramsType != "" {
+		statements = append(statements, NewVar([]string{"params"}, op.paramsType))
+	}
+	for _, param := range op.params {
+		statements = append(statements, param.decodeStatement())
+	}
+
+	args := []string{"r.Context()"}
+	if op.paramsType != "" {
+		args = append(args, "params")
+	}
+	if op.bodyType != "" {
+		bodyType := op.bodyType
+		arg := "body"
+		if !op.bodyNilable {
+			bodyType = strings.TrimPrefix(bodyType, "*")
+			arg = "&body"
+		}
+		cond := "err != nil"
+		if op.bodyOptional {
+			cond += " && err != io.EOF"
+		}
+		statements = append(statements,
+			NewVar([]string{"body"}, bodyType),
+			NewIf(cond, badRequest(`"invalid body: " + err.Error()`)...).
+				Init(NewShortVarDecl([]string{"err"}, NewRawStatement("json.NewDecoder(r.Body).Decode(&body)"))),
+		)
+		args = append(args, arg)
+	}
+
+	call := fmt.Sprintf("h.server.%s(%s)", op.name, strings.Join(args, ", "))
+	if op.resultType != "" {
+		statements = append(statements,
+			NewShortVarDecl([]string{"result", "err"}, NewRawStatement(call)),
+			NewIf("err != nil", NewRawStatement("writeError(w, err)"), NewReturnStatement()),
+			NewRawStatementf("writeJSON(w, %d, result)", op.status),
+		)
+	} else {
+		statements = append(statements,
+			NewIf("err != nil", NewRawStatement("writeError(w, err)"), NewReturnStatement()).
+				Init(NewShortVarDecl([]string{"err"}, NewRawStatement(call))),
+			NewRawStatementf("w.WriteHeader(%d)", op.status),
+		)
+	}
+
+	return NewFunc(
+		NewFuncReceiver("h", "*serverHandler"),
+		NewFuncSignature("handle"+op.name).AddParameters(
+			NewFuncParameter("w", "http.ResponseWriter"),
+			NewFuncParameter("r", "*http.Request"),
+			NewFuncParameter("segments", "[]string"),
+		),
+		statements...,
+	)
+}
+
+// decodeStatement returns the statement that decodes the parameter into the field of the params.
+func (param *openAPIParam) decodeStatement() Statement {
+	field := "params." + param.fieldName
+	itemType := strings.TrimPrefix(strings.TrimPrefix(param.typ, "*"), "[]")
+
+	var parse []Statement
+	parsed := "value"
+	if call, parsedType := parseCallOf(param.baseType, "value"); call != "" {
+		parsed = "parsed"
+		parse = append(parse,
+			NewShortVarDecl([]string{"parsed", "err"}, NewRawStatement(call)),
+			NewIf("err != nil", badRequest(strconv.Quote("invalid parameter: "+param.name))...),
+		)
+		if itemType != parsedType {
+			parsed = itemType + "(" + parsed + ")"
+		}
+	} else if itemType != "string" {
+		parsed = itemType + "(" + parsed + ")"
+	}
+
+	var decode *If
+	switch {
+	case param.array:
+		decode = NewIf("len(values) > 0", NewForRange("_", "value", "values",
+			append(parse, NewAssign([]string{field}, "=", NewRawStatementf("append(%s, %s)", field, parsed)))...,
+		)).Init(NewShortVarDecl([]string{"values"}, NewRawStatement(fmt.Sprintf("query[%s]", strconv.Quote(param.name)))))
+	case param.required:
+		decode = NewIf(`value != ""`, append(parse, NewAssign([]string{field}, "=", NewRawStatement(parsed)))...).
+			Init(NewShortVarDecl([]string{"value"}, NewRawStatement(param.source())))
+	default:
+		if parsed != "value" && parsed != "parsed" {
+			parse = append(parse, NewShortVarDecl([]string{"converted"}, NewRawStatement(parsed)))
+			parsed = "converted"
+		}
+		decode = NewIf(`value != ""`, append(parse, NewAssign([]string{field}, "=", NewRawStatement("&"+parsed)))...).
+			Init(NewShortVarDecl([]string{"value"}, NewRawStatement(param.source())))
+	}
+
+	if param.required {
+		decode = decode.Else(NewElse(badRequest(strconv.Quote("missing parameter: " + param.name))...))
+	}
+	return decode
+}
+
+// source returns the expression of the raw value of the parameter in the request.
+func (param *openAPIParam) source() string {
+	switch param.in {
+	case "path":
+		return fmt.Sprintf("segments[%d]", param.segment)
+	case "header":
+		return fmt.Sprintf("r.Header.Get(%s)", strconv.Quote(param.name))
+	}
+	return fmt.Sprintf("query.Get(%s)", strconv.Quote(param.name))
+}
+
+// parseCallOf returns the call expression that parses the string into the primitive type, and the type of the parsed value.
+// It returns empty string for string type.
+func parseCallOf(baseType string, value string) (string, string) {
+	switch baseType {
+	case "int64":
+		return fmt.Sprintf("strconv.ParseInt(%s, 10, 64)", value), "int64"
+	case "int32":
+		return fmt.Sprintf("strconv.ParseInt(%s, 10, 32)", value), "int64"
+	case "float64":
+		return fmt.Sprintf("strconv.ParseFloat(%s, 64)", value), "float64"
+	case "float32":
+		return fmt.Sprintf("strconv.ParseFloat(%s, 32)", value), "float64"
+	case "bool":
+		return fmt.Sprintf("strconv.ParseBool(%s)", value), "bool"
+	}
+	return "", "string"
+}
+
+// formatCallOf returns the expression that formats the value of `typ` (that is based on the primitive type) into string.
+func formatCallOf(baseType string, typ string, value string) string {
+	switch baseType {
+	case "int64", "int32":
+		if typ != "int64" {
+			value = "int64(" + value + ")"
+		}
+		return fmt.Sprintf("strconv.FormatInt(%s, 10)", value)
+	case "float64", "float32":
+		if typ != "float64" {
+			value = "float64(" + value + ")"
+		}
+		bitSize := 64
+		if baseType == "float32" {
+			bitSize = 32
+		}
+		return fmt.Sprintf("strconv.FormatFloat(%s, 'g', -1, %d)", value, bitSize)
+	case "bool":
+		if typ != "bool" {
+			value = "bool(" + value + ")"
+		}
+		return fmt.Sprintf("strconv.FormatBool(%s)", value)
+	}
+	if typ != "string" {
+		return "string(" + value + ")"
+	}
+	return value
+}
+
+func (o *OpenAPI) clientStatements(packageName string) []Statement {
+	groups := [][]Statement{
+		{
+			NewComment(" Client is the client of the API."),
+			NewStruct("Client").
+				AddFields(
+					NewStructField("BaseURL", "string").Comment(" BaseURL is the URL that the path of each operation is appended to (e.g. https://api.example.com/v1)."),
+					NewStructField("HTTPClient", "*http.Client"),
+				),
+		},
+		{
+			NewComment(" NewClient returns a new Client with http.DefaultClient."),
+			NewFunc(
+				nil,
+				NewFuncSignature("NewClient").AddParameters(NewFuncParameter("baseURL", "string")).AddReturnTypes("*Client"),
+				NewReturnStatement(`&Client{BaseURL: strings.TrimRight(baseURL, "/"), HTTPClient: http.DefaultClient}`),
+			),
+		},
+	}
+
+	for _, op := range o.operations {
+		groups = append(groups, []Statement{op.comment(), op.clientFunc()})
+	}
+
+	groups = append(groups, []Statement{
+		NewFunc(
+			NewFuncReceiver("c", "*Client"),
+			NewFuncSignature("do").
+				AddParameters(
+					NewFuncParameter("ctx", "context.Context"),
+					NewFuncParameter("method", "string"),
+					NewFuncParameter("path", "string"),
+					NewFuncParameter("query", "url.Values"),
+					NewFuncParameter("header", "http.Header"),
+					NewFuncParameter("body", "interface{}"),
+					NewFuncParameter("result", "interface{}"),
+				).
+				AddReturnTypes("error"),
+			NewVar([]string{"reqBody"}, "io.Reader"),
+			NewIf("body != nil",
+				NewShortVarDecl([]string{"encoded", "err"}, NewRawStatement("json.Marshal(body)")),
+				NewIf("err != nil", NewReturnStatement("err")),
+				NewAssign([]string{"reqBody"}, "=", NewRawStatement("bytes.NewReader(encoded)")),
+			),
+			NewShortVarDecl([]string{"u"}, NewRawStatement("c.BaseURL + path")),
+			NewIf("len(query) > 0", NewAssign([]string{"u"}, "+=", NewRawStatement(`"?" + query.Encode()`))),
+			NewShortVarDecl([]string{"req", "err"}, NewRawStatement("http.NewRequest(method, u, reqBody)")),
+			NewIf("err != nil", NewReturnStatement("err")),
+			NewAssign([]string{"req"}, "=", NewRawStatement("req.WithContext(ctx)")),
+			NewForRange("key", "values", "header", NewAssign([]string{"req.Header[key]"}, "=", NewRawStatement("values"))),
+			NewIf("body != nil", NewRawStatement(`req.Header.Set("Content-Type", "application/json")`)),
+			NewNewline(),
+			NewShortVarDecl([]string{"resp", "err"}, NewRawStatement("c.HTTPClient.Do(req)")),
+			NewIf("err != nil", NewReturnStatement("err")),
+			NewRawStatement("defer resp.Body.Close()"),
+			NewNewline(),
+			NewIf("resp.StatusCode < 200 || resp.StatusCode >= 300",
+				NewShortVarDecl([]string{"message", "_"}, NewRawStatement("ioutil.ReadAll(resp.Body)")),
+				NewReturnStatement("&HTTPError{StatusCode: resp.StatusCode, Message: strings.TrimSpace(string(message))}"),
+			),
+			NewIf("result == nil", NewReturnStatement("nil")),
+			NewReturnStatement("json.NewDecoder(resp.Body).Decode(result)"),
+		),
+	})
+
+	imports := []string{"bytes", "context", "encoding/json", "io", "io/ioutil", "net/http", "net/url", "strings"}
+	if o.usesStrconv() {
+		imports = append(imports, "strconv")
+	}
+	return statementsWithImports(packageName, imports, groups)
+}
+
+// clientFunc returns the method of `Client` that calls the operation.
+func (op *openAPIOperation) clientFunc() Statement {
+	var pathExprs []string
+	literal := ""
+	for _, segment := range strings.Split(op.path, "/") {
+		if !strings.HasPrefix(segment, "{") {
+			literal += segment + "/"
+			continue
+		}
+		for _, param := range op.params {
+			if param.in == "path" && "{"+param.name+"}" == segment {
+				pathExprs = append(pathExprs,
+					strconv.Quote(literal),
+					"url.PathEscape("+formatCallOf(param.baseType, param.typ, "params."+param.fieldName)+")",
+				)
+			}
+		}
+		literal = "/"
+	}
+	if literal = strings.TrimSuffix(literal, "/"); literal != "" || len(pathExprs) <= 0 {
+		pathExprs = append(pathExprs, strconv.Quote(literal))
+	}
+
+	statements := []Statement{
+		NewShortVarDecl([]string{"path"}, NewRawStatement(strings.Join(pathExprs, " + "))),
+	}
+
+	query, header := "nil", "nil"
+	for _, in := range []string{"query", "header"} {
+		var encodes []Statement
+		for _, param := range op.params {
+			if param.in == in {
+				encodes = append(encodes, param.encodeStatement())
+			}
+		}
+		if len(encodes) <= 0 {
+			continue
+		}
+		if in == "query" {
+			query = "query"
+			statements = append(statements, NewShortVarDecl([]string{"query"}, NewRawStatement("url.Values{}")))
+		} else {
+			header = "header"
+			statements = append(statements, NewShortVarDecl([]string{"header"}, NewRawStatement("http.Header{}")))
+		}
+		statements = append(statements, encodes...)
+	}
+
+	body := "nil"
+	if op.bodyType != "" {
+		body = "body"
+	}
+	call := fmt.Sprintf("c.do(ctx, %s, path, %s, %s, %s, %%s)", httpMethodConst(op.method), query, header, body)
+
+	switch {
+	case op.resultType == "":
+		statements = append(statements, NewReturnStatement(fmt.Sprintf(call, "nil")))
+	case op.resultNilable:
+		statements = append(statements,
+			NewVar([]string{"result"}, op.resultType),
+			NewIf("err != nil", NewReturnStatement("nil", "err")).
+				Init(NewShortVarDecl([]string{"err"}, NewRawStatement(fmt.Sprintf(call, "&result")))),
+			NewReturnStatement("result", "nil"),
+		)
+	default:
+		statements = append(statements,
+			NewVar([]string{"result"}, strings.TrimPrefix(op.resultType, "*")),
+			NewIf("err != nil", NewReturnStatement("nil", "err")).
+				Init(NewShortVarDecl([]string{"err"}, NewRawStatement(fmt.Sprintf(call, "&result")))),
+			NewReturnStatement("&result", "nil"),
+		)
+	}
+
+	return NewFunc(NewFuncReceiver("c", "*Client"), op.signature(), statements...)
+}
+
+// encodeStatement returns the statement that encodes the field of the params into the query or the header.
+func (param *openAPIParam) encodeStatement() Statement {
+	target := "query"
+	if param.in == "header" {
+		target = "header"
+	}
+	field := "params." + param.fieldName
+	itemType := strings.TrimPrefix(strings.TrimPrefix(param.typ, "*"), "[]")
+
+	switch {
+	case param.array:
+		return NewForRange("_", "value", field,
+			NewRawStatementf("%s.Add(%s, %s)", target, strconv.Quote(param.name), formatCallOf(param.baseType, itemType, "value")),
+		)
+	case param.required:
+		return NewRawStatementf("%s.Set(%s, %s)", target, strconv.Quote(param.name), formatCallOf(param.baseType, itemType, field))
+	}
+	return NewIf(field+" != nil",
+		NewRawStatementf("%s.Set(%s, %s)", target, strconv.Quote(param.name), formatCallOf(param.baseType, itemType, "*"+field)),
+	)
+}
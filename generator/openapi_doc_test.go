@@ -0,0 +1,41 @@
+package generator
+
+import (
+	"fmt"
+	"log"
+)
+
+func ExampleNewOpenAPI() {
+	openAPI, err := NewOpenAPI([]byte(`
+openapi: 3.0.3
+paths:
+  /users/{id}:
+    get:
+      operationId: getUser
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: integer}}
+      responses:
+        '200':
+          description: the user
+          content:
+            application/json:
+              schema: {$ref: '#/components/schemas/User'}
+components:
+  schemas:
+    User:
+      type: object
+      properties:
+        name: {type: string}
+`))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	files, err := openAPI.Files("users")
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, name := range []string{"models.go", "server.go", "client.go"} {
+		fmt.Println(files[name])
+	}
+}
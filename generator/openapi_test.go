@@ -0,0 +1,317 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldGenerateOpenAPIFiles(t *testing.T) {
+	openAPI, err := NewOpenAPI([]byte(`
+openapi: 3.0.3
+paths:
+  /pets/{id}:
+    get:
+      operationId: getPet
+      summary: returns the pet.
+      parameters:
+        - {name: id, in: path, required: true, schema: {type: integer}}
+        - {name: fields, in: query, schema: {type: array, items: {type: string}}}
+      responses:
+        '200':
+          description: ok
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [name]
+                properties:
+                  name: {type: string}
+`))
+	assert.NoError(t, err)
+
+	files, err := openAPI.Files("pets")
+	assert.NoError(t, err)
+	assert.Len(t, files, 3)
+
+	assert.Equal(t, "package pets\n"+
+		"\n"+
+		"import (\n"+
+		"\t\"net/http\"\n"+
+		")\n"+
+		"\n"+
+		"type GetPetResponse struct {\n"+
+		"\tName string `json:\"name\"`\n"+
+		"}\n"+
+		"\n"+
+		"// GetPetParams is the parameters of GetPet.\n"+
+		"type GetPetParams struct {\n"+
+		"\t// ID is the path parameter `id`.\n"+
+		"\tID int64\n"+
+		"\t// Fields is the query parameter `fields`.\n"+
+		"\tFields []string\n"+
+		"}\n"+
+		"\n"+
+		"// HTTPError is the error that has the status code of HTTP.\n"+
+		"// The server responds it with the status code, and the client returns it for the non-2xx response.\n"+
+		"type HTTPError struct {\n"+
+		"\tStatusCode int\n"+
+		"\tMessage    string\n"+
+		"}\n"+
+		"\n"+
+		"// Error returns the status and the message.\n"+
+		"func (e *HTTPError) Error() string {\n"+
+		"\treturn http.StatusText(e.StatusCode) + \": \" + e.Message\n"+
+		"}\n", files["models.go"])
+
+	assert.Contains(t, files["server.go"], `	case r.Method == http.MethodGet && len(segments) == 2 && segments[0] == "pets":
+		h.handleGetPet(w, r, segments)
+	case len(segments) == 2 && segments[0] == "pets":
+		w.Header().Set("Allow", "GET")
+		writeError(w, &HTTPError{StatusCode: http.StatusMethodNotAllowed, Message: r.Method + " is not allowed"})
+	default:
+		http.NotFound(w, r)
+`)
+	assert.Contains(t, files["server.go"], `	if values := query["fields"]; len(values) > 0 {
+		for _, value := range values {
+			params.Fields = append(params.Fields, value)
+		}
+	}
+`)
+	assert.Contains(t, files["client.go"], `// GetPet calls GET /pets/{id}; returns the pet.
+func (c *Client) GetPet(
+	ctx context.Context,
+	params GetPetParams,
+) (*GetPetResponse, error) {
+	path := "/pets/" + url.PathEscape(strconv.FormatInt(params.ID, 10))
+	query := url.Values{}
+	for _, value := range params.Fields {
+		query.Add("fields", value)
+	}
+`)
+}
+
+func TestShouldNewOpenAPIRaiseError(t *testing.T) {
+	for _, tc := range []struct {
+		spec     string
+		expected error
+	}{
+		{"openapi: [3.0", errmsg.OpenAPIIsInvalidError("", "")},
+		{"openapi: 2.0\npaths: {}", errmsg.OpenAPIIsInvalidError("", "")},
+		{"openapi: 3.0.0\npaths: []", errmsg.OpenAPIIsInvalidError("", "")},
+		{
+			"openapi: 3.0.0\npaths:\n  /a:\n    get: {operationId: op, responses: {}}\n  /b:\n    get: {operationId: op, responses: {}}",
+			errmsg.OpenAPIOperationIsDuplicatedError("", ""),
+		},
+		{
+			"openapi: 3.0.0\npaths:\n  /a:\n    get: {parameters: [{name: c, in: cookie, schema: {type: string}}], responses: {}}",
+			errmsg.OpenAPIParameterIsNotSupportedError("", "", ""),
+		},
+		{
+			"openapi: 3.0.0\npaths:\n  /a:\n    get: {parameters: [{name: o, in: query, schema: {type: object}}], responses: {}}",
+			errmsg.OpenAPIParameterIsNotSupportedError("", "", ""),
+		},
+		{
+			"openapi: 3.0.0\npaths:\n  /a:\n    get: {parameters: [{name: h, in: header, schema: {type: array, items: {type: string}}}], responses: {}}",
+			errmsg.OpenAPIParameterIsNotSupportedError("", "", ""),
+		},
+		{
+			"openapi: 3.0.0\npaths:\n  /a:\n    post: {requestBody: {content: {text/plain: {}}}, responses: {}}",
+			errmsg.OpenAPIContentTypeIsNotSupportedError("", "", ""),
+		},
+		{
+			"openapi: 3.0.0\npaths:\n  /a/{id}:\n    get: {responses: {}}",
+			errmsg.OpenAPIIsInvalidError("", ""),
+		},
+		{
+			"openapi: 3.0.0\npaths:\n  /a:\n    get: {parameters: [{name: id, in: path, schema: {type: string}}], responses: {}}",
+			errmsg.OpenAPIIsInvalidError("", ""),
+		},
+		{
+			"openapi: 3.0.0\npaths:\n  /a/{id}.json:\n    get: {parameters: [{name: id, in: path, schema: {type: string}}], responses: {}}",
+			errmsg.OpenAPIIsInvalidError("", ""),
+		},
+		{
+			"openapi: 3.0.0\npaths:\n  /a:\n    get: {parameters: [{$ref: '#/components/parameters/p'}], responses: {}}",
+			errmsg.JSONSchemaRefIsNotFoundError("", ""),
+		},
+		{
+			"openapi: 3.0.0\npaths:\n  /a:\n    get: {responses: {'200': {content: {application/json: {schema: {$ref: '#/components/schemas/s'}}}}}}",
+			errmsg.JSONSchemaRefIsNotFoundError("", ""),
+		},
+	} {
+		_, err := NewOpenAPI([]byte(tc.spec))
+		assert.Error(t, err, tc.spec)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(tc.expected.Error(), " ")[0],
+		), err.Error(), tc.spec)
+	}
+}
+
+func TestShouldLoadOpenAPIRaiseErrorWhenFileIsMissing(t *testing.T) {
+	_, err := LoadOpenAPI("./testdata/openapi/missing.yaml")
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.OpenAPILoadingError("", "", "").Error(), " ")[0],
+	), err.Error())
+}
+
+const openAPIServerAndClientTestCode = `package users
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type server struct{}
+
+func (s *server) ListUsers(ctx context.Context, params ListUsersParams) ([]User, error) {
+	if params.Limit == nil || *params.Limit != 10 || params.Status == nil || *params.Status != StatusActive || len(params.Tags) != 2 {
+		return nil, &HTTPError{StatusCode: http.StatusBadRequest, Message: "unexpected params"}
+	}
+	return []User{{ID: 1, Name: "a", Status: StatusActive}}, nil
+}
+
+func (s *server) CreateUser(ctx context.Context, body *NewUser) (*User, error) {
+	return &User{ID: 2, Name: body.Name}, nil
+}
+
+func (s *server) GetUsersMe(ctx context.Context) (*User, error) {
+	return &User{Name: "me"}, nil
+}
+
+func (s *server) GetUser(ctx context.Context, params GetUserParams) (*User, error) {
+	if params.ID == 404 {
+		return nil, &HTTPError{StatusCode: http.StatusNotFound, Message: "not found"}
+	}
+	if params.XRequestID == nil || *params.XRequestID != "req" || !params.Verbose {
+		return nil, errors.New("unexpected params")
+	}
+	return &User{ID: params.ID}, nil
+}
+
+func (s *server) UpdateUser(ctx context.Context, params UpdateUserParams, body *NewUser) error {
+	if body.Name != "" {
+		return &HTTPError{StatusCode: http.StatusConflict, Message: "name cannot be changed"}
+	}
+	return nil
+}
+
+func (s *server) DeleteUser(ctx context.Context, params DeleteUserParams) error {
+	return nil
+}
+
+func (s *server) GetUserFile(ctx context.Context, params GetUserFileParams) (*GetUserFileResponse, error) {
+	if params.ID != 3 {
+		return nil, errors.New("unexpected params")
+	}
+	return &GetUserFileResponse{Name: params.Name}, nil
+}
+
+func TestServerAndClient(t *testing.T) {
+	ts := httptest.NewServer(NewServerHandler(&server{}))
+	defer ts.Close()
+
+	client := NewClient(ts.URL)
+	ctx := context.Background()
+
+	limit := int32(10)
+	status := StatusActive
+	users, err := client.ListUsers(ctx, ListUsersParams{Limit: &limit, Status: &status, Tags: []string{"a", "b"}})
+	if err != nil || len(users) != 1 || users[0].Name != "a" || users[0].Status != StatusActive {
+		t.Fatalf("unexpected result: %v, %v", users, err)
+	}
+
+	created, err := client.CreateUser(ctx, &NewUser{Name: "b"})
+	if err != nil || created.ID != 2 || created.Name != "b" {
+		t.Fatalf("unexpected result: %v, %v", created, err)
+	}
+
+	me, err := client.GetUsersMe(ctx)
+	if err != nil || me.Name != "me" {
+		t.Fatalf("unexpected result: %v, %v", me, err)
+	}
+
+	requestID := "req"
+	user, err := client.GetUser(ctx, GetUserParams{ID: 3, XRequestID: &requestID, Verbose: true})
+	if err != nil || user.ID != 3 {
+		t.Fatalf("unexpected result: %v, %v", user, err)
+	}
+
+	var httpErr *HTTPError
+	_, err = client.GetUser(ctx, GetUserParams{ID: 404, XRequestID: &requestID, Verbose: true})
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusNotFound || httpErr.Message != "not found" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	_, err = client.GetUser(ctx, GetUserParams{ID: 3, Verbose: true})
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusInternalServerError {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := client.UpdateUser(ctx, UpdateUserParams{ID: 3}, &NewUser{}); err != nil {
+		t.Fatal(err)
+	}
+	err = client.UpdateUser(ctx, UpdateUserParams{ID: 3}, &NewUser{Name: "c"})
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusConflict {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := client.DeleteUser(ctx, DeleteUserParams{ID: 3}); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := client.GetUserFile(ctx, GetUserFileParams{ID: 3, Name: "a/b c%"})
+	if err != nil || file.Name != "a/b c%" {
+		t.Fatalf("unexpected result: %v, %v", file, err)
+	}
+
+	for _, c := range []struct {
+		method   string
+		path     string
+		expected int
+		allow    string
+	}{
+		{http.MethodGet, "/users/abc?verbose=true", http.StatusBadRequest, ""},
+		{http.MethodGet, "/users/3", http.StatusBadRequest, ""},
+		{http.MethodGet, "/unknown", http.StatusNotFound, ""},
+		{http.MethodGet, "/users/3/files/a/b", http.StatusNotFound, ""},
+		{http.MethodPatch, "/users", http.StatusMethodNotAllowed, "GET, POST"},
+		{http.MethodPost, "/users/me", http.StatusMethodNotAllowed, "GET, PUT, DELETE"},
+		{http.MethodPost, "/users/3/files/a%2Fb", http.StatusMethodNotAllowed, "GET"},
+	} {
+		req, err := http.NewRequest(c.method, ts.URL+c.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != c.expected || resp.Header.Get("Allow") != c.allow {
+			t.Fatalf("unexpected response of %s %s: %d (Allow: %s)", c.method, c.path, resp.StatusCode, resp.Header.Get("Allow"))
+		}
+	}
+}
+`
+
+func TestShouldGeneratedOpenAPIServerAndClientWork(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this test builds the generated code")
+	}
+
+	openAPI, err := LoadOpenAPI("./testdata/openapi/users.yaml")
+	assert.NoError(t, err)
+
+	files, err := openAPI.Files("users")
+	assert.NoError(t, err)
+
+	files["users_test.go"] = openAPIServerAndClientTestCode
+	runGoCommandsOnGeneratedPackage(t, files, []string{"vet", "."}, []string{"test", "."})
+}
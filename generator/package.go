@@ -4,18 +4,37 @@ import "fmt"
 
 // Package represents a code generator for `package` statement.
 type Package struct {
-	name string
+	name   string
+	caller string
 }
 
 // NewPackage returns a new `Package`.
 func NewPackage(packageName string) *Package {
 	return &Package{
-		name: packageName,
+		name:   packageName,
+		caller: fetchClientCallerLine(),
 	}
 }
 
 // Generate generates a package statement.
-func (pg *Package) Generate(indentLevel int) (string, error) {
+func (pg *Package) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, pg.pathSegment())
+
+	if err := firstError(pg.validate()); err != nil {
+		return "", err
+	}
+
 	indent := BuildIndent(indentLevel)
 	return fmt.Sprintf("%spackage %s\n", indent, pg.name), nil
 }
+
+func (pg *Package) validate() []error {
+	if err := validateIdentifier(pg.name, pg.caller); err != nil {
+		return []error{err}
+	}
+	return nil
+}
+
+func (pg *Package) pathSegment() errorPathSegment {
+	return errorPathSegment{name: namedPathSegment("Package", pg.name), caller: pg.caller}
+}
@@ -0,0 +1,363 @@
+package generator
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
+
+// Proto represents a code generator for the package of a proto file, without `protoc` and the plugins.
+// The messages become the structs, the enums become `Enum`, and each service becomes the interfaces and the skeletons of
+// the client and the server that don't depend on any transport; please see `NewProto()` for the details.
+type Proto struct {
+	file *protoFile
+}
+
+// LoadProto reads the proto file from the local file, and returns a new `Proto`.
+// Please see `NewProto()` for the details.
+func LoadProto(path string) (*Proto, error) {
+	caller := fetchClientCallerLine()
+
+	src, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errmsg.ProtoLoadingError(path, err.Error(), caller)
+	}
+	return newProto(string(src), caller)
+}
+
+// NewProto returns a new `Proto` from the proto file (proto2 or proto3). The options and the imports are ignored; the types of
+// the imported files and the streaming rpc are not supported. The code is generated as the following:
+//
+//   - the message is the struct that has the `json` tag of the JSON mapping of proto3 (e.g. `user_id` => `userId`);
+//     the nested type is named by the parent (e.g. `User.Address` => `UserAddress`)
+//   - the field of a message is a pointer, and the scalar field that has the presence (i.e. `optional`, and the member of `oneof`) too
+//   - the enum is `Enum` of `int32`, that is marshaled as the name of the value; the prefix of the value that is same as the enum
+//     is trimmed from the name of the constant (e.g. `STATUS_ACTIVE` of `Status` => `StatusActive`)
+//   - the service is `XxxServer` and `XxxClient` interfaces. `XxxHandlers()` adapts the server to the map of `Handler` by the full
+//     names of the methods (e.g. `/pkg.Greeter/SayHello`), and `NewXxxClient()` makes the client that calls `Invoker`;
+//     so the transport implements `Invoker` for the client, and dispatches the requests to `Handler` for the server
+func NewProto(src string) (*Proto, error) {
+	return newProto(src, fetchClientCallerLine())
+}
+
+func newProto(src string, caller string) (*Proto, error) {
+	file, err := parseProto(src)
+	if err != nil {
+		return nil, errmsg.ProtoIsInvalidError(err.Error(), caller)
+	}
+
+	names := jsonTypeNames{"Invoker": true, "Handler": true, "ErrUnimplemented": true}
+	types := map[string]*protoType{}
+	for _, typ := range file.types {
+		parentGoName := ""
+		if i := strings.LastIndex(typ.fullName, "."); i >= 0 {
+			if parent := types[typ.fullName[:i]]; parent != nil {
+				parentGoName = parent.goName
+			}
+		}
+		typ.goName = names.reserve(parentGoName + ToExportedName(typ.name))
+		types[typ.fullName] = typ
+	}
+
+	resolve := func(ref *protoTypeRef) (*protoType, error) {
+		if strings.HasPrefix(ref.name, ".") {
+			if typ := types[ref.name[1:]]; typ != nil {
+				return typ, nil
+			}
+			return nil, errmsg.ProtoTypeIsNotFoundError(ref.name, ref.line, ref.column, caller)
+		}
+		// search from the innermost scope
+		for scope := ref.scope; ; scope = scope[:strings.LastIndex(scope, ".")] {
+			if typ := types[joinProtoName(scope, ref.name)]; typ != nil {
+				return typ, nil
+			}
+			if !strings.Contains(scope, ".") {
+				if typ := types[ref.name]; typ != nil {
+					return typ, nil
+				}
+				return nil, errmsg.ProtoTypeIsNotFoundError(ref.name, ref.line, ref.column, caller)
+			}
+		}
+	}
+
+	proto3 := file.syntax == "proto3"
+	for _, typ := range file.types {
+		used := map[string]bool{}
+		for _, field := range typ.fields {
+			if err := field.resolve(resolve, proto3, used); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for _, service := range file.services {
+		for _, rpc := range service.rpcs {
+			if rpc.streaming {
+				return nil, errmsg.ProtoStreamingIsNotSupportedError(service.name+"."+rpc.name, caller)
+			}
+			for _, ref := range []*protoTypeRef{rpc.request, rpc.response} {
+				typ, err := resolve(ref)
+				if err != nil {
+					return nil, err
+				}
+				if typ.enum {
+					return nil, errmsg.ProtoIsInvalidError(fmt.Sprintf("line %d, column %d: type of the rpc must be a message, but %s is an enum", ref.line, ref.column, ref.name), caller)
+				}
+				ref.goType = typ.goName
+			}
+		}
+	}
+
+	return &Proto{
+		file: file,
+	}, nil
+}
+
+// resolve resolves the type of the field into the golang type.
+func (f *protoField) resolve(resolve func(ref *protoTypeRef) (*protoType, error), proto3 bool, used map[string]bool) error {
+	f.fieldName = uniqueFieldName(f.name, used)
+
+	typ := protoScalarTypes[f.typ.name]
+	message := false
+	if typ == "" {
+		resolved, err := resolve(f.typ)
+		if err != nil {
+			return err
+		}
+		typ = resolved.goName
+		message = !resolved.enum
+	}
+	if message {
+		typ = "*" + typ
+	}
+
+	switch {
+	case f.mapKey != "":
+		f.goType = "map[" + f.mapKey + "]" + typ
+	case f.label == "repeated":
+		f.goType = "[]" + typ
+	case !message && typ != "[]byte" && (f.oneof != "" || f.label == "optional" || (!proto3 && f.label != "required")):
+		// the scalar that has the presence
+		f.goType = "*" + typ
+	default:
+		f.goType = typ
+	}
+	return nil
+}
+
+// Roots returns `Root` of each file of the package; the key is the file name (i.e. `messages.go`, and `services.go` if the proto
+// has the services). Please see also `Files()`.
+func (p *Proto) Roots(packageName string) map[string]*Root {
+	roots := map[string]*Root{
+		"messages.go": NewRoot(p.messagesStatements(packageName)...),
+	}
+	if len(p.file.services) > 0 {
+		roots["services.go"] = NewRoot(p.servicesStatements(packageName)...)
+	}
+	return roots
+}
+
+// Files generates the files of the package with `gofmt`; the key is the file name.
+func (p *Proto) Files(packageName string) (map[string]string, error) {
+	files := map[string]string{}
+	for name, root := range p.Roots(packageName) {
+		generated, err := root.Gofmt().Generate(0)
+		if err != nil {
+			return nil, err
+		}
+		files[name] = generated
+	}
+	return files, nil
+}
+
+// protoComments returns the comments of the proto as the doc comment. If the comment begins with the name in the proto
+// (e.g. `Sender is...` of `User.Sender`), the name is replaced by the name in golang.
+func protoComments(comment string, name string, goName string) []Statement {
+	if strings.HasPrefix(comment, name+" ") {
+		comment = goName + comment[len(name):]
+	}
+
+	var comments []Statement
+	if comment != "" {
+		for _, line := range strings.Split(comment, "\n") {
+			comments = append(comments, NewComment(" "+line))
+		}
+	}
+	return comments
+}
+
+func (p *Proto) messagesStatements(packageName string) []Statement {
+	var groups [][]Statement
+	usesEnum := false
+	for _, typ := range p.file.types {
+		if typ.enum {
+			usesEnum = true
+			groups = append(groups, []Statement{protoEnum(typ)})
+			continue
+		}
+
+		fields := make([]*StructField, len(typ.fields))
+		for i, field := range typ.fields {
+			fields[i] = NewStructField(field.fieldName, field.goType).Tag(NewStructTag().Add("json", field.jsonName, "omitempty"))
+
+			comment := field.comment
+			if field.oneof != "" {
+				comment = strings.TrimSpace(comment + fmt.Sprintf("\nIt is a member of oneof `%s`.", field.oneof))
+			}
+			if comment != "" {
+				fields[i] = fields[i].Comment(" " + strings.Replace(comment, "\n", "\n ", -1))
+			}
+		}
+		groups = append(groups, append(protoComments(typ.comment, typ.name, typ.goName), NewStruct(typ.goName).AddFields(fields...)))
+	}
+
+	var imports []string
+	if usesEnum {
+		imports = append(imports, "fmt")
+	}
+	return statementsWithImports(packageName, imports, groups)
+}
+
+// protoEnum returns `Enum` of the proto enum; the aliases (i.e. the values that have the same number) are omitted.
+func protoEnum(typ *protoType) *Enum {
+	prefix := strings.ToUpper(strings.Join(splitWords(typ.name), "_")) + "_"
+
+	numbers := map[int]bool{}
+	var values []*EnumValue
+	for _, v := range typ.values {
+		if numbers[v.number] {
+			continue
+		}
+		numbers[v.number] = true
+
+		name := v.name
+		if trimmed := strings.TrimPrefix(name, prefix); trimmed != "" && trimmed != name {
+			name = trimmed
+		}
+		values = append(values, NewEnumValue(typ.goName+ToExportedName(name)).StringName(v.name).Value(strconv.Itoa(v.number)))
+	}
+	return NewEnum(typ.goName, values...).UnderlyingType("int32")
+}
+
+func (p *Proto) servicesStatements(packageName string) []Statement {
+	handlerSig := NewAnonymousFuncSignature().
+		AddParameters(NewFuncParameter("ctx", "context.Context"), NewFuncParameter("decode", "func(req interface{}) error")).
+		AddReturnTypes("interface{}", "error")
+
+	groups := [][]Statement{
+		{
+			NewComment(" Invoker is the transport of the clients. It sends the request to the method (e.g. `/pkg.Service/Method`),"),
+			NewComment(" and decodes the response into resp."),
+			NewInterface("Invoker",
+				NewFuncSignature("Invoke").AddParameters(
+					NewFuncParameter("ctx", "context.Context"),
+					NewFuncParameter("method", "string"),
+					NewFuncParameter("req", "interface{}"),
+					NewFuncParameter("resp", "interface{}"),
+				).AddReturnTypes("error"),
+			),
+		},
+		{
+			NewComment(" Handler handles the request of the method; decode decodes the request into the given value."),
+			NewRawStatementf("type Handler func(ctx context.Context, decode func(req interface{}) error) (interface{}, error)"),
+		},
+		{
+			NewComment(" ErrUnimplemented is the error of the method that is not implemented by the server."),
+			NewVar([]string{"ErrUnimplemented"}, "", NewRawStatement(`errors.New("unimplemented")`)),
+		},
+	}
+
+	for _, service := range p.file.services {
+		goName := ToExportedName(service.name)
+		serverName := goName + "Server"
+		clientName := goName + "Client"
+		clientImplName := ToUnexportedName(clientName)
+		unimplementedName := "Unimplemented" + serverName
+
+		var specs []*ConstSpec
+		var sigs []*FuncSignature
+		handlers := NewCompositeLiteral("map[string]Handler")
+		var unimplementedFuncs, clientFuncs [][]Statement
+		for _, rpc := range service.rpcs {
+			methodConst := goName + ToExportedName(rpc.name) + "Method"
+			specs = append(specs, NewConstSpec([]string{methodConst}, "", NewRawStatement(strconv.Quote("/"+service.fullName+"/"+rpc.name))))
+
+			sig := NewFuncSignature(ToExportedName(rpc.name)).
+				AddParameters(NewFuncParameter("ctx", "context.Context"), NewFuncParameter("req", "*"+rpc.request.goType)).
+				AddReturnTypes("*"+rpc.response.goType, "error")
+			sigs = append(sigs, sig)
+
+			handlers = handlers.AddField(methodConst, NewAnonymousFunc(false, handlerSig,
+				NewShortVarDecl([]string{"req"}, NewRawStatementf("&%s{}", rpc.request.goType)),
+				NewIf("err != nil", NewReturnStatement("nil", "err")).
+					Init(NewShortVarDecl([]string{"err"}, NewRawStatement("decode(req)"))),
+				NewReturnStatement(fmt.Sprintf("srv.%s(ctx, req)", ToExportedName(rpc.name))),
+			))
+
+			unimplementedFuncs = append(unimplementedFuncs, []Statement{
+				NewCommentf(" %s returns ErrUnimplemented.", ToExportedName(rpc.name)),
+				NewFunc(NewFuncReceiver("s", unimplementedName), sig,
+					NewReturnStatement("nil", fmt.Sprintf(`fmt.Errorf("method %s is %%w", ErrUnimplemented)`, rpc.name)),
+				),
+			})
+
+			clientFuncs = append(clientFuncs, append(protoComments(rpc.comment, rpc.name, ToExportedName(rpc.name)),
+				NewFunc(NewFuncReceiver("c", "*"+clientImplName), sig,
+					NewShortVarDecl([]string{"resp"}, NewRawStatementf("&%s{}", rpc.response.goType)),
+					NewIf("err != nil", NewReturnStatement("nil", "err")).
+						Init(NewShortVarDecl([]string{"err"}, NewRawStatementf("c.invoker.Invoke(ctx, %s, req, resp)", methodConst))),
+					NewReturnStatement("resp", "nil"),
+				),
+			))
+		}
+
+		if len(specs) > 0 {
+			groups = append(groups, []Statement{
+				NewCommentf(" The full names of the methods of the service `%s`.", service.fullName),
+				NewConst(specs...),
+			})
+		}
+		groups = append(groups,
+			append(
+				append(protoComments(service.comment, service.name, goName), NewCommentf(" %s is the server API of the service `%s`.", serverName, service.fullName)),
+				NewInterface(serverName, sigs...),
+			),
+			[]Statement{
+				NewCommentf(" %s is the server that returns ErrUnimplemented for all of the methods; embed it to keep the", unimplementedName),
+				NewComment(" compatibility when the methods are added to the service."),
+				NewStruct(unimplementedName),
+			},
+		)
+		groups = append(groups, unimplementedFuncs...)
+		groups = append(groups,
+			[]Statement{
+				NewCommentf(" %sHandlers returns the handlers of the methods of the server by the full names of them.", goName),
+				NewFunc(
+					nil,
+					NewFuncSignature(goName+"Handlers").AddParameters(NewFuncParameter("srv", serverName)).AddReturnTypes("map[string]Handler"),
+					NewReturnStatement().AddReturnStatements(handlers),
+				),
+			},
+			append(
+				append(protoComments(service.comment, service.name, goName), NewCommentf(" %s is the client API of the service `%s`.", clientName, service.fullName)),
+				NewInterface(clientName, sigs...),
+			),
+			[]Statement{
+				NewStruct(clientImplName).AddField("invoker", "Invoker"),
+			},
+			[]Statement{
+				NewCommentf(" New%s returns a new %s that sends the requests by the invoker.", clientName, clientName),
+				NewFunc(
+					nil,
+					NewFuncSignature("New"+clientName).AddParameters(NewFuncParameter("invoker", "Invoker")).AddReturnTypes(clientName),
+					NewReturnStatement(fmt.Sprintf("&%s{invoker: invoker}", clientImplName)),
+				),
+			},
+		)
+		groups = append(groups, clientFuncs...)
+	}
+
+	return statementsWithImports(packageName, []string{"context", "errors", "fmt"}, groups)
+}
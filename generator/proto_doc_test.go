@@ -0,0 +1,36 @@
+package generator
+
+import (
+	"fmt"
+	"log"
+)
+
+func ExampleNewProto() {
+	proto, err := NewProto(`
+syntax = "proto3";
+package helloworld;
+
+message HelloRequest {
+  string name = 1;
+}
+
+message HelloReply {
+  string message = 1;
+}
+
+service Greeter {
+  rpc SayHello (HelloRequest) returns (HelloReply);
+}
+`)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	files, err := proto.Files("helloworld")
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, name := range []string{"messages.go", "services.go"} {
+		fmt.Println(files[name])
+	}
+}
@@ -0,0 +1,670 @@
+package generator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type protoTokenKind int
+
+const (
+	protoTokenIdent protoTokenKind = iota // identifier, keyword or number
+	protoTokenString
+	protoTokenSymbol
+)
+
+type protoToken struct {
+	kind    protoTokenKind
+	text    string
+	line    int
+	column  int
+	comment string // the leading comment
+}
+
+func (t *protoToken) position() string {
+	return fmt.Sprintf("line %d, column %d", t.line, t.column)
+}
+
+// tokenizeProto splits the proto into the tokens. The comment just before the token (without the blank line between them)
+// is kept as the leading comment of that token, and the trailing comment of the line is dropped.
+func tokenizeProto(src string) ([]*protoToken, error) {
+	var tokens []*protoToken
+	var comments []string
+	line, lineStart := 1, 0
+	commentEndLine := 0
+	lastTokenLine := 0
+
+	for i := 0; i < len(src); {
+		c := src[i]
+		column := i - lineStart + 1
+		switch {
+		case c == '\n':
+			line++
+			i++
+			lineStart = i
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f':
+			i++
+		case strings.HasPrefix(src[i:], "//"), strings.HasPrefix(src[i:], "/*"):
+			var text string
+			startLine := line
+			if src[i+1] == '/' {
+				end := strings.IndexByte(src[i:], '\n')
+				if end < 0 {
+					end = len(src) - i
+				}
+				text = src[i+2 : i+end]
+				i += end
+			} else {
+				end := strings.Index(src[i+2:], "*/")
+				if end < 0 {
+					return nil, fmt.Errorf("line %d, column %d: comment is not terminated", line, column)
+				}
+				text = src[i+2 : i+2+end]
+				for j := i; j < i+2+end; j++ {
+					if src[j] == '\n' {
+						line++
+						lineStart = j + 1
+					}
+				}
+				i += end + 4
+			}
+
+			if startLine == lastTokenLine {
+				// the trailing comment of the previous token
+				continue
+			}
+			if len(comments) > 0 && startLine > commentEndLine+1 {
+				comments = nil
+			}
+			for _, l := range strings.Split(text, "\n") {
+				comments = append(comments, strings.TrimRight(strings.TrimPrefix(strings.TrimSpace(l), "*"), " "))
+			}
+			commentEndLine = line
+		default:
+			token := &protoToken{line: line, column: column}
+			switch {
+			case c == '"' || c == '\'':
+				var value strings.Builder
+				for i++; ; i++ {
+					if i >= len(src) || src[i] == '\n' {
+						return nil, fmt.Errorf("line %d, column %d: string is not terminated", line, column)
+					}
+					if src[i] == c {
+						break
+					}
+					if src[i] == '\\' && i+1 < len(src) {
+						i++
+					}
+					value.WriteByte(src[i])
+				}
+				i++
+				token.kind = protoTokenString
+				token.text = value.String()
+			case isProtoIdentByte(c):
+				start := i
+				for i < len(src) && isProtoIdentByte(src[i]) {
+					i++
+				}
+				token.kind = protoTokenIdent
+				token.text = src[start:i]
+			default:
+				token.kind = protoTokenSymbol
+				token.text = string(c)
+				i++
+			}
+
+			if len(comments) > 0 && line <= commentEndLine+1 {
+				token.comment = strings.TrimSpace(strings.Join(comments, "\n"))
+			}
+			comments = nil
+			lastTokenLine = line
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+func isProtoIdentByte(c byte) bool {
+	return c == '_' || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+// protoFile is the parsed proto file.
+type protoFile struct {
+	syntax   string
+	pkg      string
+	types    []*protoType // the messages and the enums in the order of the declaration; the nested ones follow the parent
+	services []*protoService
+}
+
+type protoType struct {
+	enum     bool
+	name     string
+	fullName string // e.g. `pkg.Outer.Inner`
+	comment  string
+
+	fields []*protoField     // for message
+	values []*protoEnumValue // for enum
+
+	// the name of the type in golang
+	goName string
+}
+
+type protoField struct {
+	label    string // `repeated`, `optional`, `required` or empty
+	typ      *protoTypeRef
+	mapKey   string
+	name     string
+	comment  string
+	oneof    string
+	jsonName string
+
+	// the field of the struct in golang
+	fieldName string
+	goType    string
+}
+
+type protoTypeRef struct {
+	name   string
+	scope  string
+	line   int
+	column int
+
+	// the name of the message in golang, for the rpc
+	goType string
+}
+
+type protoEnumValue struct {
+	name   string
+	number int
+}
+
+type protoService struct {
+	name     string
+	fullName string
+	comment  string
+	rpcs     []*protoRPC
+}
+
+type protoRPC struct {
+	name     string
+	comment  string
+	request  *protoTypeRef
+	response *protoTypeRef
+	// the streaming rpc is parsed, but it is not supported by the generator
+	streaming bool
+}
+
+// protoParser is a recursive descent parser of the proto file.
+type protoParser struct {
+	tokens []*protoToken
+	pos    int
+	file   *protoFile
+}
+
+// parseProto parses the proto file; the options are ignored.
+func parseProto(src string) (*protoFile, error) {
+	tokens, err := tokenizeProto(src)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &protoParser{tokens: tokens, file: &protoFile{syntax: "proto2"}}
+	for !p.eof() {
+		t := p.next()
+		if t.kind == protoTokenString {
+			return nil, fmt.Errorf("%s: unexpected \"%s\"", t.position(), t.text)
+		}
+		switch t.text {
+		case "syntax":
+			if err := p.expect("="); err != nil {
+				return nil, err
+			}
+			syntax, err := p.expectKind(protoTokenString, "syntax")
+			if err != nil {
+				return nil, err
+			}
+			p.file.syntax = syntax.text
+			if err := p.expect(";"); err != nil {
+				return nil, err
+			}
+		case "package":
+			pkg, err := p.fullIdent()
+			if err != nil {
+				return nil, err
+			}
+			p.file.pkg = pkg
+			if err := p.expect(";"); err != nil {
+				return nil, err
+			}
+		case "import", "option":
+			if err := p.skipStatement(); err != nil {
+				return nil, err
+			}
+		case "message":
+			if err := p.message(t, p.file.pkg); err != nil {
+				return nil, err
+			}
+		case "enum":
+			if err := p.enum(t, p.file.pkg); err != nil {
+				return nil, err
+			}
+		case "service":
+			if err := p.service(t); err != nil {
+				return nil, err
+			}
+		case ";":
+		default:
+			return nil, fmt.Errorf("%s: unexpected %s", t.position(), t.text)
+		}
+	}
+	return p.file, nil
+}
+
+func (p *protoParser) eof() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *protoParser) next() *protoToken {
+	if p.eof() {
+		last := &protoToken{line: 1, column: 1}
+		if len(p.tokens) > 0 {
+			last = p.tokens[len(p.tokens)-1]
+		}
+		return &protoToken{kind: protoTokenSymbol, line: last.line, column: last.column}
+	}
+	t := p.tokens[p.pos]
+	p.pos++
+	return t
+}
+
+func (p *protoParser) peek() *protoToken {
+	if p.eof() {
+		return &protoToken{kind: protoTokenSymbol}
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *protoParser) expect(text string) error {
+	if t := p.next(); t.text != text || t.kind == protoTokenString {
+		return unexpectedProtoToken(t, "'"+text+"'")
+	}
+	return nil
+}
+
+func (p *protoParser) expectKind(kind protoTokenKind, what string) (*protoToken, error) {
+	t := p.next()
+	if t.kind != kind || t.text == "" {
+		return nil, unexpectedProtoToken(t, what)
+	}
+	return t, nil
+}
+
+func unexpectedProtoToken(t *protoToken, expected string) error {
+	if t.text == "" {
+		return fmt.Errorf("%s: %s is expected, but it reaches the end", t.position(), expected)
+	}
+	return fmt.Errorf("%s: %s is expected, but it gets %s", t.position(), expected, t.text)
+}
+
+// fullIdent parses the dot-separated identifier (e.g. `foo.bar.Baz` and `.foo.Bar`).
+func (p *protoParser) fullIdent() (string, error) {
+	name := ""
+	if p.peek().text == "." {
+		name = p.next().text
+	}
+	for {
+		t, err := p.expectKind(protoTokenIdent, "identifier")
+		if err != nil {
+			return "", err
+		}
+		name += t.text
+		if p.peek().text != "." {
+			return name, nil
+		}
+		name += p.next().text
+	}
+}
+
+// skipStatement skips the tokens until the end of the statement, e.g. `option (foo) = { bar: 1 };`.
+func (p *protoParser) skipStatement() error {
+	depth := 0
+	for !p.eof() {
+		t := p.next()
+		if t.kind != protoTokenSymbol {
+			continue
+		}
+		switch t.text {
+		case "{", "[", "(", "<":
+			depth++
+		case "}", "]", ")", ">":
+			depth--
+		case ";":
+			if depth <= 0 {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("%s: ';' is expected, but it reaches the end", p.next().position())
+}
+
+// skipFieldOptions skips the options of the field, e.g. `[deprecated = true]`.
+func (p *protoParser) skipFieldOptions() error {
+	if p.peek().text != "[" {
+		return nil
+	}
+	for depth := 0; !p.eof(); {
+		switch p.next().text {
+		case "[":
+			depth++
+		case "]":
+			if depth--; depth == 0 {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("%s: ']' is expected, but it reaches the end", p.next().position())
+}
+
+func (p *protoParser) typeRef(scope string) (*protoTypeRef, error) {
+	t := p.peek()
+	name, err := p.fullIdent()
+	if err != nil {
+		return nil, err
+	}
+	return &protoTypeRef{name: name, scope: scope, line: t.line, column: t.column}, nil
+}
+
+func (p *protoParser) message(keyword *protoToken, scope string) error {
+	name, err := p.expectKind(protoTokenIdent, "name of the message")
+	if err != nil {
+		return err
+	}
+	typ := &protoType{name: name.text, fullName: joinProtoName(scope, name.text), comment: keyword.comment}
+	p.file.types = append(p.file.types, typ)
+
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	return p.messageBody(typ, "")
+}
+
+// messageBody parses the body of the message, or the body of the oneof if `oneof` is not empty.
+func (p *protoParser) messageBody(typ *protoType, oneof string) error {
+	for {
+		t := p.peek()
+		switch {
+		case p.eof():
+			return fmt.Errorf("%s: '}' is expected, but it reaches the end", p.next().position())
+		case t.text == "}" && t.kind == protoTokenSymbol:
+			p.next()
+			return nil
+		case t.text == ";" && t.kind == protoTokenSymbol:
+			p.next()
+		case oneof == "" && t.text == "message":
+			if err := p.message(p.next(), typ.fullName); err != nil {
+				return err
+			}
+		case oneof == "" && t.text == "enum":
+			if err := p.enum(p.next(), typ.fullName); err != nil {
+				return err
+			}
+		case oneof == "" && t.text == "oneof":
+			p.next()
+			name, err := p.expectKind(protoTokenIdent, "name of the oneof")
+			if err != nil {
+				return err
+			}
+			if err := p.expect("{"); err != nil {
+				return err
+			}
+			if err := p.messageBody(typ, name.text); err != nil {
+				return err
+			}
+		case t.text == "option" || t.text == "reserved" || t.text == "extensions":
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		case t.text == "extend" || t.text == "group":
+			return fmt.Errorf("%s: %s is not supported", t.position(), t.text)
+		default:
+			field, err := p.field(typ.fullName, oneof)
+			if err != nil {
+				return err
+			}
+			typ.fields = append(typ.fields, field)
+		}
+	}
+}
+
+func (p *protoParser) field(scope string, oneof string) (*protoField, error) {
+	first := p.peek()
+	field := &protoField{comment: first.comment, oneof: oneof}
+	if oneof == "" && (first.text == "repeated" || first.text == "optional" || first.text == "required") {
+		field.label = p.next().text
+	}
+
+	if p.peek().text == "map" && len(p.tokens) > p.pos+1 && p.tokens[p.pos+1].text == "<" {
+		p.next()
+		p.next()
+		key, err := p.expectKind(protoTokenIdent, "key type of the map")
+		if err != nil {
+			return nil, err
+		}
+		if protoScalarTypes[key.text] == "" || key.text == "double" || key.text == "float" || key.text == "bytes" {
+			return nil, fmt.Errorf("%s: key type of the map must be an integer, bool or string, but it gets %s", key.position(), key.text)
+		}
+		field.mapKey = protoScalarTypes[key.text]
+		if err := p.expect(","); err != nil {
+			return nil, err
+		}
+		if field.typ, err = p.typeRef(scope); err != nil {
+			return nil, err
+		}
+		if err := p.expect(">"); err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		if field.typ, err = p.typeRef(scope); err != nil {
+			return nil, err
+		}
+	}
+
+	name, err := p.expectKind(protoTokenIdent, "name of the field")
+	if err != nil {
+		return nil, err
+	}
+	field.name = name.text
+	field.jsonName = protoJSONName(name.text)
+
+	if err := p.expect("="); err != nil {
+		return nil, err
+	}
+	if _, err := p.expectKind(protoTokenIdent, "number of the field"); err != nil {
+		return nil, err
+	}
+	if err := p.skipFieldOptions(); err != nil {
+		return nil, err
+	}
+	return field, p.expect(";")
+}
+
+func (p *protoParser) enum(keyword *protoToken, scope string) error {
+	name, err := p.expectKind(protoTokenIdent, "name of the enum")
+	if err != nil {
+		return err
+	}
+	typ := &protoType{enum: true, name: name.text, fullName: joinProtoName(scope, name.text), comment: keyword.comment}
+	p.file.types = append(p.file.types, typ)
+
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for {
+		t := p.next()
+		switch {
+		case t.text == "":
+			return unexpectedProtoToken(t, "'}'")
+		case t.text == "}" && t.kind == protoTokenSymbol:
+			if len(typ.values) <= 0 {
+				return fmt.Errorf("%s: enum %s must have a value at least", name.position(), name.text)
+			}
+			return nil
+		case t.text == ";" && t.kind == protoTokenSymbol:
+		case t.text == "option" || t.text == "reserved":
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		case t.kind == protoTokenIdent:
+			if err := p.expect("="); err != nil {
+				return err
+			}
+			sign := ""
+			if p.peek().text == "-" {
+				sign = p.next().text
+			}
+			num, err := p.expectKind(protoTokenIdent, "number of the enum value")
+			if err != nil {
+				return err
+			}
+			number, err := strconv.ParseInt(sign+num.text, 0, 32)
+			if err != nil {
+				return fmt.Errorf("%s: number of the enum value must be an int32, but it gets %s", num.position(), sign+num.text)
+			}
+			typ.values = append(typ.values, &protoEnumValue{name: t.text, number: int(number)})
+			if err := p.skipFieldOptions(); err != nil {
+				return err
+			}
+			if err := p.expect(";"); err != nil {
+				return err
+			}
+		default:
+			return unexpectedProtoToken(t, "enum value")
+		}
+	}
+}
+
+func (p *protoParser) service(keyword *protoToken) error {
+	name, err := p.expectKind(protoTokenIdent, "name of the service")
+	if err != nil {
+		return err
+	}
+	service := &protoService{name: name.text, fullName: joinProtoName(p.file.pkg, name.text), comment: keyword.comment}
+	p.file.services = append(p.file.services, service)
+
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for {
+		t := p.next()
+		switch {
+		case t.text == "":
+			return unexpectedProtoToken(t, "'}'")
+		case t.text == "}" && t.kind == protoTokenSymbol:
+			return nil
+		case t.text == ";" && t.kind == protoTokenSymbol:
+		case t.text == "option":
+			if err := p.skipStatement(); err != nil {
+				return err
+			}
+		case t.text == "rpc":
+			rpc, err := p.rpc(t)
+			if err != nil {
+				return err
+			}
+			service.rpcs = append(service.rpcs, rpc)
+		default:
+			return unexpectedProtoToken(t, "rpc")
+		}
+	}
+}
+
+func (p *protoParser) rpc(keyword *protoToken) (*protoRPC, error) {
+	name, err := p.expectKind(protoTokenIdent, "name of the rpc")
+	if err != nil {
+		return nil, err
+	}
+	rpc := &protoRPC{name: name.text, comment: keyword.comment}
+
+	for i, ref := range []**protoTypeRef{&rpc.request, &rpc.response} {
+		if i > 0 {
+			if err := p.expect("returns"); err != nil {
+				return nil, err
+			}
+		}
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		if p.peek().text == "stream" && len(p.tokens) > p.pos+1 && p.tokens[p.pos+1].text != ")" {
+			p.next()
+			rpc.streaming = true
+		}
+		if *ref, err = p.typeRef(p.file.pkg); err != nil {
+			return nil, err
+		}
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+	}
+
+	if p.peek().text == "{" {
+		p.next()
+		for p.peek().text != "}" {
+			if p.eof() {
+				return nil, unexpectedProtoToken(p.next(), "'}'")
+			}
+			if err := p.skipStatement(); err != nil {
+				return nil, err
+			}
+		}
+		p.next()
+	} else if err := p.expect(";"); err != nil {
+		return nil, err
+	}
+	return rpc, nil
+}
+
+func joinProtoName(scope string, name string) string {
+	if scope == "" {
+		return name
+	}
+	return scope + "." + name
+}
+
+// protoJSONName returns the name of the field in the JSON mapping of proto3 (e.g. `user_id` => `userId`).
+func protoJSONName(name string) string {
+	var b strings.Builder
+	upper := false
+	for _, c := range name {
+		if c == '_' {
+			upper = true
+			continue
+		}
+		if upper && 'a' <= c && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+		upper = false
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// protoScalarTypes is the golang types of the scalar types of protobuf.
+var protoScalarTypes = map[string]string{
+	"double":   "float64",
+	"float":    "float32",
+	"int32":    "int32",
+	"int64":    "int64",
+	"uint32":   "uint32",
+	"uint64":   "uint64",
+	"sint32":   "int32",
+	"sint64":   "int64",
+	"fixed32":  "uint32",
+	"fixed64":  "uint64",
+	"sfixed32": "int32",
+	"sfixed64": "int64",
+	"bool":     "bool",
+	"string":   "string",
+	"bytes":    "[]byte",
+}
@@ -0,0 +1,270 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldGenerateProtoFiles(t *testing.T) {
+	proto, err := NewProto(`
+syntax = "proto3";
+package pets.v1;
+
+// Pet is a pet.
+message Pet {
+  int64 id = 1;
+  // pet_name is the name.
+  string pet_name = 2;
+  optional double weight = 3;
+  repeated Toy toys = 4;
+  map<string, Toy> favorites = 5;
+
+  message Toy {
+    bytes image = 1;
+  }
+}
+`)
+	assert.NoError(t, err)
+
+	files, err := proto.Files("pets")
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+
+	assert.Equal(t, "package pets\n"+
+		"\n"+
+		"// Pet is a pet.\n"+
+		"type Pet struct {\n"+
+		"\tID int64 `json:\"id,omitempty\"`\n"+
+		"\t// pet_name is the name.\n"+
+		"\tPetName   string             `json:\"petName,omitempty\"`\n"+
+		"\tWeight    *float64           `json:\"weight,omitempty\"`\n"+
+		"\tToys      []*PetToy          `json:\"toys,omitempty\"`\n"+
+		"\tFavorites map[string]*PetToy `json:\"favorites,omitempty\"`\n"+
+		"}\n"+
+		"\n"+
+		"type PetToy struct {\n"+
+		"\tImage []byte `json:\"image,omitempty\"`\n"+
+		"}\n", files["messages.go"])
+}
+
+func TestShouldGenerateProtoServices(t *testing.T) {
+	proto, err := LoadProto("./testdata/proto/greeter.proto")
+	assert.NoError(t, err)
+
+	files, err := proto.Files("greeter")
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+
+	messages := files["messages.go"]
+	for _, expected := range []string{
+		"// HelloRequestSender is the sender of the greeting.\ntype HelloRequestSender struct {\n",
+		"\tTimes  *int32              `json:\"times,omitempty\"`\n",
+		"\t// It is a member of oneof `greeting`.\n\tText *string `json:\"text,omitempty\"`\n",
+		"\tStatusSuspended   Status = 2\n",
+		"\t\treturn \"STATUS_SUSPENDED\"\n",
+		"\tHelloRequestSenderKindBot         HelloRequestSenderKind = 2\n",
+		"\tSenders []*HelloRequestSender `json:\"senders,omitempty\"`\n",
+	} {
+		assert.Contains(t, messages, expected)
+	}
+	assert.NotContains(t, messages, "trailing comment")
+
+	services := files["services.go"]
+	for _, expected := range []string{
+		"\tGreeterSayHelloMethod   = \"/example.greeter.Greeter/SayHello\"\n",
+		"// Greeter greets the users.\n// GreeterServer is the server API of the service `example.greeter.Greeter`.\ntype GreeterServer interface {\n",
+		"\treturn nil, fmt.Errorf(\"method SayGoodbye is %w\", ErrUnimplemented)\n",
+		"func GreeterHandlers(srv GreeterServer) map[string]Handler {\n",
+		"\t\t\treturn srv.SayHello(ctx, req)\n",
+		"func NewGreeterClient(invoker Invoker) GreeterClient {\n",
+		"// SayHello greets the user.\nfunc (c *greeterClient) SayHello(\n",
+		"\tif err := c.invoker.Invoke(ctx, GreeterSayHelloMethod, req, resp); err != nil {\n",
+	} {
+		assert.Contains(t, services, expected)
+	}
+}
+
+func TestShouldResolveProto2Fields(t *testing.T) {
+	proto, err := NewProto(`
+package a;
+message Outer {
+  message Inner { optional int32 n = 1; }
+  required string name = 1;
+  optional Inner inner = 2;
+  optional .a.Outer.Inner absolute = 3;
+  optional Outer.Inner relative = 4;
+  repeated int32 numbers = 5 [packed = true];
+}
+`)
+	assert.NoError(t, err)
+
+	outer := proto.file.types[0]
+	var types []string
+	for _, field := range outer.fields {
+		types = append(types, field.goType)
+	}
+	assert.Equal(t, []string{"string", "*OuterInner", "*OuterInner", "*OuterInner", "[]int32"}, types)
+	assert.Equal(t, "*int32", proto.file.types[1].fields[0].goType)
+}
+
+func TestShouldTokenizeProtoWithLeadingComments(t *testing.T) {
+	tokens, err := tokenizeProto("// dropped\n\n// a\n// b\nmessage /* c */ M {} // trailing\n/*\n * d\n */\nenum")
+	assert.NoError(t, err)
+
+	var texts, comments []string
+	for _, token := range tokens {
+		texts = append(texts, token.text)
+		comments = append(comments, token.comment)
+	}
+	assert.Equal(t, []string{"message", "M", "{", "}", "enum"}, texts)
+	assert.Equal(t, []string{"a\nb", "", "", "", "d"}, comments)
+	assert.Equal(t, 9, tokens[4].line)
+}
+
+func TestShouldNewProtoRaiseError(t *testing.T) {
+	for _, tc := range []struct {
+		src      string
+		expected error
+	}{
+		{`message M { string name = 1 }`, errmsg.ProtoIsInvalidError("", "")},
+		{`message M { string name = 1;`, errmsg.ProtoIsInvalidError("", "")},
+		{`message M { map<bytes, string> m = 1; }`, errmsg.ProtoIsInvalidError("", "")},
+		{`message M { group G = 1 {} }`, errmsg.ProtoIsInvalidError("", "")},
+		{`enum E {}`, errmsg.ProtoIsInvalidError("", "")},
+		{`enum E { A = 99999999999; }`, errmsg.ProtoIsInvalidError("", "")},
+		{`/* comment`, errmsg.ProtoIsInvalidError("", "")},
+		{`syntax = "proto3`, errmsg.ProtoIsInvalidError("", "")},
+		{`foo bar;`, errmsg.ProtoIsInvalidError("", "")},
+		{`message M { Unknown u = 1; }`, errmsg.ProtoTypeIsNotFoundError("", 0, 0, "")},
+		{`message M { google.protobuf.Timestamp t = 1; }`, errmsg.ProtoTypeIsNotFoundError("", 0, 0, "")},
+		{`message M {} service S { rpc R (M) returns (Unknown); }`, errmsg.ProtoTypeIsNotFoundError("", 0, 0, "")},
+		{`enum E { A = 0; } service S { rpc R (E) returns (E); }`, errmsg.ProtoIsInvalidError("", "")},
+		{`message M {} service S { rpc R (stream M) returns (M); }`, errmsg.ProtoStreamingIsNotSupportedError("", "")},
+	} {
+		_, err := NewProto(tc.src)
+		assert.Error(t, err, tc.src)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(tc.expected.Error(), " ")[0],
+		), err.Error(), tc.src)
+	}
+}
+
+func TestShouldProtoErrorHavePosition(t *testing.T) {
+	_, err := NewProto("syntax = \"proto3\";\n\nmessage M {\n  Unknown u = 1;\n}\n")
+	assert.Contains(t, err.Error(), "type 'Unknown' at line 4, column 3 is not found")
+
+	_, err = NewProto("message M {\n  string name = ;\n}\n")
+	assert.Contains(t, err.Error(), "line 2, column 17: number of the field is expected, but it gets ;")
+}
+
+func TestShouldLoadProtoRaiseErrorWhenFileIsMissing(t *testing.T) {
+	_, err := LoadProto("./testdata/proto/missing.proto")
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.ProtoLoadingError("", "", "").Error(), " ")[0],
+	), err.Error())
+}
+
+const protoServiceTestCode = `package greeter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// loopback is the transport that calls the handlers in-process by JSON.
+type loopback struct {
+	handlers map[string]Handler
+}
+
+func (l *loopback) Invoke(ctx context.Context, method string, req interface{}, resp interface{}) error {
+	handler, ok := l.handlers[method]
+	if !ok {
+		return fmt.Errorf("unknown method %s", method)
+	}
+
+	encoded, err := json.Marshal(req)
+	if err != nil {
+		return err
+	}
+	result, err := handler(ctx, func(v interface{}) error {
+		return json.Unmarshal(encoded, v)
+	})
+	if err != nil {
+		return err
+	}
+
+	encoded, err = json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(encoded, resp)
+}
+
+type server struct {
+	UnimplementedGreeterServer
+}
+
+func (s *server) SayHello(ctx context.Context, req *HelloRequest) (*HelloReply, error) {
+	return &HelloReply{
+		Message: fmt.Sprintf("hello %s x%d %s", req.Name, *req.Times, *req.Text),
+		Senders: []*HelloRequestSender{req.Sender},
+		Status:  req.Status,
+	}, nil
+}
+
+func TestGreeter(t *testing.T) {
+	client := NewGreeterClient(&loopback{handlers: GreeterHandlers(&server{})})
+	ctx := context.Background()
+
+	times := int32(2)
+	text := "hi"
+	reply, err := client.SayHello(ctx, &HelloRequest{
+		Name:   "alice",
+		Times:  &times,
+		Status: StatusActive,
+		Sender: &HelloRequestSender{UserID: "u1", Kind: HelloRequestSenderKindBot},
+		Text:   &text,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply.Message != "hello alice x2 hi" || reply.Status != StatusActive || reply.Senders[0].Kind != HelloRequestSenderKindBot {
+		t.Fatalf("unexpected reply: %#v", reply)
+	}
+
+	if _, err := client.SayGoodbye(ctx, &HelloRequest{}); !errors.Is(err, ErrUnimplemented) {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	encoded, err := json.Marshal(&HelloRequest{Status: StatusActive, Sender: &HelloRequestSender{UserID: "u1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(encoded) != ` + "`" + `{"status":"STATUS_ACTIVE","sender":{"userId":"u1"}}` + "`" + ` {
+		t.Fatalf("unexpected JSON: %s", encoded)
+	}
+}
+`
+
+func TestShouldGeneratedProtoServicesWork(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this test builds the generated code")
+	}
+
+	proto, err := LoadProto("./testdata/proto/greeter.proto")
+	assert.NoError(t, err)
+
+	files, err := proto.Files("greeter")
+	assert.NoError(t, err)
+
+	files["greeter_test.go"] = protoServiceTestCode
+	runGoCommandsOnGeneratedPackage(t, files, []string{"vet", "."}, []string{"test", "."})
+}
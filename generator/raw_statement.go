@@ -7,6 +7,7 @@ import "fmt"
 type RawStatement struct {
 	statement   string
 	withNewline bool
+	caller      string
 }
 
 // NewRawStatement returns a new `RawStatement`.
@@ -14,6 +15,7 @@ func NewRawStatement(stmt string) *RawStatement {
 	return &RawStatement{
 		statement:   stmt,
 		withNewline: true,
+		caller:      fetchClientCallerLine(),
 	}
 }
 
@@ -23,6 +25,7 @@ func NewRawStatementf(stmt string, args ...interface{}) *RawStatement {
 	return &RawStatement{
 		statement:   fmt.Sprintf(stmt, args...),
 		withNewline: true,
+		caller:      fetchClientCallerLine(),
 	}
 }
 
@@ -32,6 +35,7 @@ func (r *RawStatement) WithNewline(with bool) *RawStatement {
 	return &RawStatement{
 		statement:   r.statement,
 		withNewline: with,
+		caller:      r.caller,
 	}
 }
 
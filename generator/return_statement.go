@@ -1,44 +1,124 @@
 package generator
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
 
 // ReturnStatement represents a code generator for `return` statement.
 type ReturnStatement struct {
-	returnItems []string
+	returnItems []Statement
+	caller      string
 }
 
 // NewReturnStatement returns a new `ReturnStatement`.
+// A sole empty item is dropped so that `NewReturnStatement("")` generates a bare `return`.
 func NewReturnStatement(returnItems ...string) *ReturnStatement {
 	return &ReturnStatement{
-		returnItems: returnItems,
+		returnItems: rawReturnItems(returnItems),
+		caller:      fetchClientCallerLine(),
 	}
 }
 
 // AddReturnItems adds return items to `ReturnStatement`. This does *not* set, just add.
+//
+// This method accepts a return item as `string`. If you want to use a nested generator as the item (e.g. `Call`),
+// please consider using `AddReturnStatements()` instead of this.
+//
 // This method returns a *new* `ReturnStatement`; it means this method acts as immutable.
 func (r *ReturnStatement) AddReturnItems(returnItems ...string) *ReturnStatement {
+	return r.AddReturnStatements(rawReturnItems(returnItems)...)
+}
+
+// ReturnItems sets return items to `ReturnStatement`. This does *not* add, just set.
+//
+// This method accepts a return item as `string`. If you want to use a nested generator as the item (e.g. `Call`),
+// please consider using `ReturnStatements()` instead of this.
+//
+// This method returns a *new* `ReturnStatement`; it means this method acts as immutable.
+func (r *ReturnStatement) ReturnItems(returnItems ...string) *ReturnStatement {
+	return r.ReturnStatements(rawReturnItems(returnItems)...)
+}
+
+// AddReturnStatements adds return items as `Statement` to `ReturnStatement`. This does *not* set, just add.
+// This method returns a *new* `ReturnStatement`; it means this method acts as immutable.
+func (r *ReturnStatement) AddReturnStatements(returnItems ...Statement) *ReturnStatement {
 	return &ReturnStatement{
 		returnItems: append(r.returnItems, returnItems...),
+		caller:      r.caller,
 	}
 }
 
-// ReturnItems sets return items to `ReturnStatement`. This does *not* add, just set.
+// ReturnStatements sets return items as `Statement` to `ReturnStatement`. This does *not* add, just set.
 // This method returns a *new* `ReturnStatement`; it means this method acts as immutable.
-func (r *ReturnStatement) ReturnItems(returnItems ...string) *ReturnStatement {
+func (r *ReturnStatement) ReturnStatements(returnItems ...Statement) *ReturnStatement {
 	return &ReturnStatement{
 		returnItems: returnItems,
+		caller:      r.caller,
 	}
 }
 
 // Generate generates `return` statement as golang code.
-func (r *ReturnStatement) Generate(indentLevel int) (string, error) {
+func (r *ReturnStatement) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, r.pathSegment())
+
+	if err := firstError(r.validate()); err != nil {
+		return "", err
+	}
+
 	indent := BuildIndent(indentLevel)
 
+	items := make([]string, len(r.returnItems))
+	for i, item := range r.returnItems {
+		gen, err := item.Generate(indentLevel)
+		if err != nil {
+			return "", err
+		}
+
+		gen = strings.TrimSpace(gen)
+		if gen == "" {
+			return "", errmsg.ReturnItemIsEmptyError(r.caller)
+		}
+		items[i] = gen
+	}
+
 	stmt := indent + "return"
-	if ret := strings.Join(r.returnItems, ", "); ret != "" {
+	if ret := strings.Join(items, ", "); ret != "" {
 		stmt += " " + ret
 	}
 	stmt += "\n"
 
 	return stmt, nil
 }
+
+func (r *ReturnStatement) validate() []error {
+	for _, item := range r.returnItems {
+		if item == nil {
+			return []error{errmsg.ReturnItemIsEmptyError(r.caller)}
+		}
+	}
+	return nil
+}
+
+func (r *ReturnStatement) childStatements() []Statement {
+	return nonNilStatements(r.returnItems)
+}
+
+// rawReturnItems converts the items into `RawStatement`s.
+// A sole empty item is dropped, so that it doesn't add any item (e.g. `ReturnItems("")` generates a bare `return`).
+func rawReturnItems(returnItems []string) []Statement {
+	if len(returnItems) == 1 && strings.TrimSpace(returnItems[0]) == "" {
+		return nil
+	}
+
+	items := make([]Statement, len(returnItems))
+	for i, item := range returnItems {
+		items[i] = NewRawStatement(item)
+	}
+	return items
+}
+
+func (r *ReturnStatement) pathSegment() errorPathSegment {
+	return errorPathSegment{name: "ReturnStatement", caller: r.caller}
+}
@@ -1,8 +1,11 @@
 package generator
 
 import (
+	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/moznion/gowrtr/internal/errmsg"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -22,3 +25,84 @@ func TestShouldGenerateReturnStatement(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "return bar\n", gen)
 }
+
+func TestShouldGenerateReturnStatementWithStatements(t *testing.T) {
+	generator := NewReturnStatement().
+		AddReturnStatements(NewCompositeLiteral("&Struct").AddFieldRaw("foo", 1)).
+		AddReturnItems("nil")
+
+	expected := `	return &Struct{
+		foo: 1,
+	}, nil
+`
+	gen, err := generator.Generate(1)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+
+	generator = generator.ReturnStatements(NewCall("f"))
+	gen, err = generator.Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, "return f()\n", gen)
+}
+
+func TestShouldGenerateBareReturnStatementWithSoleEmptyItem(t *testing.T) {
+	gen, err := NewReturnStatement("").Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, "return\n", gen)
+
+	gen, err = NewReturnStatement("").AddReturnItems("foo").Generate(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "\treturn foo\n", gen)
+
+	gen, err = NewReturnStatement().ReturnItems("").Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, "return\n", gen)
+
+	gen, err = NewReturnStatement("foo").ReturnItems("").Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, "return\n", gen)
+
+	gen, err = NewReturnStatement().AddReturnItems("").Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, "return\n", gen)
+
+	gen, err = NewReturnStatement("foo").AddReturnItems("").Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, "return foo\n", gen)
+}
+
+func TestShouldGenerateReturnStatementRaisesError(t *testing.T) {
+	errPattern := func(err error) *regexp.Regexp {
+		return regexp.MustCompile(`^\` + strings.Split(err.Error(), " ")[0])
+	}
+
+	{
+		_, err := NewReturnStatement("foo", "").Generate(0)
+		assert.Regexp(t, errPattern(errmsg.ReturnItemIsEmptyError("")), err.Error())
+	}
+
+	{
+		_, err := NewReturnStatement("", "").Generate(0)
+		assert.Regexp(t, errPattern(errmsg.ReturnItemIsEmptyError("")), err.Error())
+	}
+
+	{
+		_, err := NewReturnStatement().ReturnItems("foo", "").Generate(0)
+		assert.Regexp(t, errPattern(errmsg.ReturnItemIsEmptyError("")), err.Error())
+	}
+
+	{
+		_, err := NewReturnStatement().AddReturnItems("", "foo").Generate(0)
+		assert.Regexp(t, errPattern(errmsg.ReturnItemIsEmptyError("")), err.Error())
+	}
+
+	{
+		_, err := NewReturnStatement().AddReturnStatements(nil).Generate(0)
+		assert.Regexp(t, errPattern(errmsg.ReturnItemIsEmptyError("")), err.Error())
+	}
+
+	{
+		_, err := NewReturnStatement().AddReturnStatements(NewCall("")).Generate(0)
+		assert.Regexp(t, errPattern(errmsg.CallCalleeIsEmptyError("")), err.Error())
+	}
+}
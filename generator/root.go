@@ -5,8 +5,6 @@ import (
 	"io"
 	"os/exec"
 	"strings"
-
-	"github.com/moznion/gowrtr/internal/errmsg"
 )
 
 // Root is a code generator for the entry point.
@@ -16,12 +14,16 @@ type Root struct {
 	gofmtOptions   []string
 	goimports      bool
 	syntaxChecking bool
+	typeChecking   bool
+	typeCheckDir   string
+	caller         string
 }
 
 // NewRoot generates a new `Root`.
 func NewRoot(statements ...Statement) *Root {
 	return &Root{
 		statements: statements,
+		caller:     fetchClientCallerLine(),
 	}
 }
 
@@ -34,6 +36,9 @@ func (g *Root) AddStatements(statements ...Statement) *Root {
 		gofmtOptions:   g.gofmtOptions,
 		goimports:      g.goimports,
 		syntaxChecking: g.syntaxChecking,
+		typeChecking:   g.typeChecking,
+		typeCheckDir:   g.typeCheckDir,
+		caller:         g.caller,
 	}
 }
 
@@ -46,6 +51,9 @@ func (g *Root) Statements(statements ...Statement) *Root {
 		gofmtOptions:   g.gofmtOptions,
 		goimports:      g.goimports,
 		syntaxChecking: g.syntaxChecking,
+		typeChecking:   g.typeChecking,
+		typeCheckDir:   g.typeCheckDir,
+		caller:         g.caller,
 	}
 }
 
@@ -58,6 +66,9 @@ func (g *Root) Gofmt(gofmtOptions ...string) *Root {
 		gofmtOptions:   gofmtOptions,
 		goimports:      g.goimports,
 		syntaxChecking: g.syntaxChecking,
+		typeChecking:   g.typeChecking,
+		typeCheckDir:   g.typeCheckDir,
+		caller:         g.caller,
 	}
 }
 
@@ -70,6 +81,9 @@ func (g *Root) Goimports() *Root {
 		gofmtOptions:   g.gofmtOptions,
 		goimports:      true,
 		syntaxChecking: g.syntaxChecking,
+		typeChecking:   g.typeChecking,
+		typeCheckDir:   g.typeCheckDir,
+		caller:         g.caller,
 	}
 }
 
@@ -82,45 +96,121 @@ func (g *Root) EnableSyntaxChecking() *Root {
 		gofmtOptions:   g.gofmtOptions,
 		goimports:      g.goimports,
 		syntaxChecking: true,
+		typeChecking:   g.typeChecking,
+		typeCheckDir:   g.typeCheckDir,
+		caller:         g.caller,
+	}
+}
+
+// EnableTypeChecking enables type checking. If this option is enabled, it type-checks the generated code by `go/types`
+// on code generation phase, so the code that refers to undefined identifiers, has mismatched return counts
+// or leaves unused imports is reported as an error.
+//
+// `srcDir` is the directory where the generated code will be placed; the imported packages are resolved from there,
+// so the locally vendored packages are also available. Empty `srcDir` means the current directory.
+// If the error is raised, it is `*ValidationErrors` that contains each diagnostic; each diagnostic points to the generator that produces the line.
+//
+// This method returns a *new* `Root`; it means this method acts as immutable.
+func (g *Root) EnableTypeChecking(srcDir string) *Root {
+	return &Root{
+		statements:     g.statements,
+		gofmt:          g.gofmt,
+		gofmtOptions:   g.gofmtOptions,
+		goimports:      g.goimports,
+		syntaxChecking: g.syntaxChecking,
+		typeChecking:   true,
+		typeCheckDir:   srcDir,
+		caller:         g.caller,
 	}
 }
 
 // Generate generates golang code according to registered statements.
-func (g *Root) Generate(indentLevel int) (string, error) {
+func (g *Root) Generate(indentLevel int) (generated string, err error) {
+	generated, _, err = g.generate(indentLevel)
+	return generated, err
+}
+
+// GenerateWithSourceMap generates golang code according to registered statements, with the source map of the generated code.
+// The source map maps each range of the lines of the generated code to the generator that produces them and its caller location,
+// so the lines of the generated code can be traced back to the client code.
+// The lines are of the final code, i.e. after applying `gofmt` and `goimports`.
+func (g *Root) GenerateWithSourceMap(indentLevel int) (string, *SourceMap, error) {
+	generated, file, err := g.generate(indentLevel)
+	if err != nil {
+		return "", nil, err
+	}
+	return generated, file.sourceMap(), nil
+}
+
+func (g *Root) generate(indentLevel int) (generated string, file *generatedFile, err error) {
+	defer annotateErrorPath(&err, g.pathSegment())
+
+	file, err = g.generateFile(indentLevel)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if g.typeChecking {
+		err := typeCheck(g.typeCheckDir, []*generatedFile{file})
+		if err != nil {
+			return "", nil, err
+		}
+	}
+
+	return file.code, file, nil
+}
+
+// generateFile generates the code with the line ranges of each statement.
+func (g *Root) generateFile(indentLevel int) (*generatedFile, error) {
 	generatedCode := ""
+	lineRanges := make([]statementLineRange, 0, len(g.statements))
 
 	for _, statement := range g.statements {
 		gen, err := statement.Generate(indentLevel)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
+
+		startLine := strings.Count(generatedCode, "\n") + 1
+		lineRanges = append(lineRanges, statementLineRange{
+			statement: statement,
+			startLine: startLine,
+			endLine:   startLine + strings.Count(strings.TrimSuffix(gen, "\n"), "\n"),
+		})
 		generatedCode += gen
 	}
 
+	file := &generatedFile{
+		code:        generatedCode,
+		rawCode:     generatedCode,
+		lineRanges:  lineRanges,
+		rootSegment: g.pathSegment(),
+	}
+
 	if g.syntaxChecking {
-		_, err := g.applyGofmt(generatedCode, "-e")
+		_, err := g.applyGofmt(file.code, "-e")
 		if err != nil {
-			return "", err
+			return nil, file.formatterError(err, file.code)
 		}
 	}
 
 	if g.gofmt {
-		var err error
-		generatedCode, err = g.applyGofmt(generatedCode, g.gofmtOptions...)
+		code, err := g.applyGofmt(file.code, g.gofmtOptions...)
 		if err != nil {
-			return "", err
+			return nil, file.formatterError(err, file.code)
 		}
+		file.code = code
 	}
 
 	if g.goimports {
-		var err error
-		generatedCode, err = g.applyGoimports(generatedCode)
+		code, err := g.applyGoimports(file.code)
 		if err != nil {
-			return "", err
+			return nil, file.formatterError(err, file.code)
 		}
+		file.code = code
 	}
 
-	return generatedCode, nil
+	return file, nil
 }
 
 func (g *Root) applyGofmt(generatedCode string, gofmtOptions ...string) (string, error) {
@@ -142,7 +232,7 @@ func applyCodeFormatter(generatedCode string, formatterCmdName string, formatter
 	err := formatterCmd.Start()
 	if err != nil {
 		cmds := []string{formatterCmdName}
-		return "", errmsg.CodeFormatterError(strings.Join(append(cmds, formatterOpts...), " "), errout.String(), err)
+		return "", newFormatterError(strings.Join(append(cmds, formatterOpts...), " "), errout.String(), err, generatedCode)
 	}
 
 	_, err = io.WriteString(stdinPipe, generatedCode)
@@ -157,8 +247,16 @@ func applyCodeFormatter(generatedCode string, formatterCmdName string, formatter
 	err = formatterCmd.Wait()
 	if err != nil {
 		cmds := []string{formatterCmdName}
-		return "", errmsg.CodeFormatterError(strings.Join(append(cmds, formatterOpts...), " "), errout.String(), err)
+		return "", newFormatterError(strings.Join(append(cmds, formatterOpts...), " "), errout.String(), err, generatedCode)
 	}
 
 	return out.String(), err
 }
+
+func (g *Root) childStatements() []Statement {
+	return g.statements
+}
+
+func (g *Root) pathSegment() errorPathSegment {
+	return errorPathSegment{name: "Root"}
+}
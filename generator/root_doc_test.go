@@ -78,3 +78,70 @@ func ExampleRoot_Generate() {
 	}
 	fmt.Println(generated)
 }
+
+func ExampleRoot_Validate() {
+	generator := NewRoot(
+		NewPackage("mypkg"),
+		NewStruct("MyStruct").
+			AddField("", "string").
+			AddField("Bar", ""),
+		NewFunc(
+			nil,
+			NewFuncSignature("MyFunc"),
+			NewIf(""),
+		),
+	)
+
+	err := generator.Validate()
+	if err != nil {
+		for _, e := range err.(*ValidationErrors).Errors() {
+			fmt.Println(e)
+		}
+	}
+}
+
+func ExampleRoot_EnableTypeChecking() {
+	generator := NewRoot(
+		NewPackage("mypkg"),
+		NewImport("strings"),
+		NewFunc(
+			nil,
+			NewFuncSignature("Upper").
+				AddParameters(NewFuncParameter("s", "string")).
+				AddReturnTypes("string"),
+			NewReturnStatement().AddReturnStatements(NewCall("strings.ToUpper", NewRawStatement("s"))),
+		),
+	).Gofmt().EnableTypeChecking("")
+
+	generated, err := generator.Generate(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(generated)
+}
+
+func ExampleRoot_GenerateWithSourceMap() {
+	generator := NewRoot(
+		NewPackage("mypkg"),
+		NewFunc(
+			nil,
+			NewFuncSignature("Upper").
+				AddParameters(NewFuncParameter("s", "string")).
+				AddReturnTypes("string"),
+			NewReturnStatement().AddReturnStatements(NewCall("strings.ToUpper", NewRawStatement("s"))),
+		),
+	).Gofmt().Goimports()
+
+	generated, sourceMap, err := generator.GenerateWithSourceMap(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(generated)
+
+	for _, entry := range sourceMap.Entries() {
+		fmt.Printf("%d-%d: %s\n", entry.StartLine(), entry.EndLine(), entry.Path())
+	}
+	if entry := sourceMap.Lookup(6); entry != nil {
+		fmt.Printf("line 6 is produced at %s\n", entry.Caller())
+	}
+}
@@ -0,0 +1,96 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
+
+// Select represents a code generator for `select` statement.
+// See also: https://tour.golang.org/concurrency/5
+type Select struct {
+	caseStatements []*SelectCase
+	caller         string
+}
+
+// NewSelect returns a new `Select`.
+func NewSelect(statements ...*SelectCase) *Select {
+	return &Select{
+		caseStatements: statements,
+		caller:         fetchClientCallerLine(),
+	}
+}
+
+// AddCase adds `case` (and `default`) statements to `Select`. This does *not* set, just add.
+// This method returns a *new* `Select`; it means this method acts as immutable.
+func (s *Select) AddCase(statements ...*SelectCase) *Select {
+	return &Select{
+		caseStatements: append(s.caseStatements, statements...),
+		caller:         s.caller,
+	}
+}
+
+// Case sets `case` (and `default`) statements to `Select`. This does *not* add, just set.
+// This method returns a *new* `Select`; it means this method acts as immutable.
+func (s *Select) Case(statements ...*SelectCase) *Select {
+	return &Select{
+		caseStatements: statements,
+		caller:         s.caller,
+	}
+}
+
+// Generate generates `select` statement as golang code.
+func (s *Select) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, s.pathSegment())
+
+	if err := firstError(s.validate()); err != nil {
+		return "", err
+	}
+
+	indent := BuildIndent(indentLevel)
+
+	stmt := fmt.Sprintf("%sselect {\n", indent)
+	for _, statement := range s.caseStatements {
+		if statement == nil {
+			continue
+		}
+
+		gen, err := statement.Generate(indentLevel)
+		if err != nil {
+			return "", err
+		}
+		stmt += gen
+	}
+	stmt += fmt.Sprintf("%s}\n", indent)
+
+	return stmt, nil
+}
+
+func (s *Select) validate() []error {
+	var errs []error
+	defaultCaseAppeared := false
+	for _, statement := range s.caseStatements {
+		if statement == nil || statement.kind != selectDefaultCase {
+			continue
+		}
+		if defaultCaseAppeared {
+			errs = append(errs, errmsg.SelectDefaultCaseIsDuplicatedError(statement.caller))
+		}
+		defaultCaseAppeared = true
+	}
+	return errs
+}
+
+func (s *Select) childStatements() []Statement {
+	children := make([]Statement, 0, len(s.caseStatements))
+	for _, statement := range s.caseStatements {
+		if statement != nil {
+			children = append(children, statement)
+		}
+	}
+	return children
+}
+
+func (s *Select) pathSegment() errorPathSegment {
+	return errorPathSegment{name: "Select", caller: s.caller}
+}
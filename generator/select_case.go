@@ -0,0 +1,170 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
+
+type selectCaseKind int
+
+const (
+	selectRecvCase selectCaseKind = iota
+	selectSendCase
+	selectDefaultCase
+)
+
+// SelectCase represents a code generator for `case` and `default` of `select` statement.
+type SelectCase struct {
+	kind       selectCaseKind
+	variables  []string
+	define     bool
+	channel    string
+	value      string
+	statements []Statement
+	caller     string
+}
+
+// NewSelectRecvCase returns a new `SelectCase` for a receive operation (e.g. `case v, ok := <-ch:`).
+// `variables` is optional and it takes at most two variables; if it is empty, it generates `case <-ch:`.
+func NewSelectRecvCase(variables []string, channel string, statements ...Statement) *SelectCase {
+	return &SelectCase{
+		kind:       selectRecvCase,
+		variables:  variables,
+		define:     true,
+		channel:    channel,
+		statements: statements,
+		caller:     fetchClientCallerLine(),
+	}
+}
+
+// NewSelectSendCase returns a new `SelectCase` for a send operation (e.g. `case ch <- v:`).
+func NewSelectSendCase(channel string, value string, statements ...Statement) *SelectCase {
+	return &SelectCase{
+		kind:       selectSendCase,
+		channel:    channel,
+		value:      value,
+		statements: statements,
+		caller:     fetchClientCallerLine(),
+	}
+}
+
+// NewSelectDefaultCase returns a new `SelectCase` for `default` block of `select`.
+func NewSelectDefaultCase(statements ...Statement) *SelectCase {
+	return &SelectCase{
+		kind:       selectDefaultCase,
+		statements: statements,
+		caller:     fetchClientCallerLine(),
+	}
+}
+
+// AddStatements adds statements to `SelectCase`. This does *not* set, just add.
+// This method returns a *new* `SelectCase`; it means this method acts as immutable.
+func (sc *SelectCase) AddStatements(statements ...Statement) *SelectCase {
+	return &SelectCase{
+		kind:       sc.kind,
+		variables:  sc.variables,
+		define:     sc.define,
+		channel:    sc.channel,
+		value:      sc.value,
+		statements: append(sc.statements, statements...),
+		caller:     sc.caller,
+	}
+}
+
+// Statements sets statements to `SelectCase`. This does *not* add, just set.
+// This method returns a *new* `SelectCase`; it means this method acts as immutable.
+func (sc *SelectCase) Statements(statements ...Statement) *SelectCase {
+	return &SelectCase{
+		kind:       sc.kind,
+		variables:  sc.variables,
+		define:     sc.define,
+		channel:    sc.channel,
+		value:      sc.value,
+		statements: statements,
+		caller:     sc.caller,
+	}
+}
+
+// WithDefine specifies whether the variables of a receive case are declared by `:=` or assigned by `=`.
+// Default value is `true`. This has no effect on send case and default case.
+// This method returns a *new* `SelectCase`; it means this method acts as immutable.
+func (sc *SelectCase) WithDefine(define bool) *SelectCase {
+	return &SelectCase{
+		kind:       sc.kind,
+		variables:  sc.variables,
+		define:     define,
+		channel:    sc.channel,
+		value:      sc.value,
+		statements: sc.statements,
+		caller:     sc.caller,
+	}
+}
+
+// Generate generates `case` or `default` of `select` statement as golang code.
+func (sc *SelectCase) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, sc.pathSegment())
+
+	if err := firstError(sc.validate()); err != nil {
+		return "", err
+	}
+
+	indent := BuildIndent(indentLevel)
+
+	var stmt string
+	switch sc.kind {
+	case selectDefaultCase:
+		stmt = fmt.Sprintf("%sdefault:\n", indent)
+	case selectSendCase:
+		stmt = fmt.Sprintf("%scase %s <- %s:\n", indent, sc.channel, sc.value)
+	default:
+		stmt = indent + "case "
+		if len(sc.variables) > 0 {
+			op := ":="
+			if !sc.define {
+				op = "="
+			}
+			stmt += strings.Join(sc.variables, ", ") + " " + op + " "
+		}
+		stmt += fmt.Sprintf("<-%s:\n", sc.channel)
+	}
+
+	nextIndentLevel := indentLevel + 1
+	for _, statement := range sc.statements {
+		gen, err := statement.Generate(nextIndentLevel)
+		if err != nil {
+			return "", err
+		}
+		stmt += gen
+	}
+
+	return stmt, nil
+}
+
+func (sc *SelectCase) validate() []error {
+	if sc.kind == selectDefaultCase {
+		return nil
+	}
+
+	var errs []error
+	if sc.channel == "" {
+		errs = append(errs, errmsg.SelectCaseChannelIsEmptyError(sc.caller))
+	}
+	if sc.kind == selectSendCase {
+		if sc.value == "" {
+			errs = append(errs, errmsg.SelectSendCaseValueIsEmptyError(sc.caller))
+		}
+	} else if l := len(sc.variables); l > 2 {
+		errs = append(errs, errmsg.SelectRecvCaseVariablesCountError(l, sc.caller))
+	}
+	return errs
+}
+
+func (sc *SelectCase) childStatements() []Statement {
+	return sc.statements
+}
+
+func (sc *SelectCase) pathSegment() errorPathSegment {
+	return errorPathSegment{name: "SelectCase", caller: sc.caller}
+}
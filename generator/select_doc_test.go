@@ -0,0 +1,21 @@
+package generator
+
+import (
+	"fmt"
+	"log"
+)
+
+func ExampleSelect_Generate() {
+	generator := NewSelect(
+		NewSelectRecvCase([]string{"v", "ok"}, "ch", NewRawStatement(`fmt.Println(v, ok)`)),
+		NewSelectSendCase("out", "v"),
+	).AddCase(
+		NewSelectDefaultCase(NewComment(" do nothing")),
+	)
+
+	generated, err := generator.Generate(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(generated)
+}
@@ -0,0 +1,133 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldGenerateSelect(t *testing.T) {
+	generator := NewSelect()
+
+	{
+		gen, err := generator.Generate(0)
+		assert.NoError(t, err)
+		expected := `select {
+}
+`
+		assert.Equal(t, expected, gen)
+	}
+
+	generator = generator.AddCase(
+		NewSelectRecvCase([]string{"v", "ok"}, "ch", NewComment(" received")),
+		nil,
+		NewSelectSendCase("out", "v", NewComment(" sent")),
+		NewSelectRecvCase(nil, "done", NewReturnStatement()),
+		NewSelectDefaultCase(NewComment(" default")),
+	)
+
+	{
+		gen, err := generator.Generate(0)
+		assert.NoError(t, err)
+		expected := `select {
+case v, ok := <-ch:
+	// received
+case out <- v:
+	// sent
+case <-done:
+	return
+default:
+	// default
+}
+`
+		assert.Equal(t, expected, gen)
+	}
+
+	{
+		gen, err := generator.Generate(2)
+		assert.NoError(t, err)
+		expected := `		select {
+		case v, ok := <-ch:
+			// received
+		case out <- v:
+			// sent
+		case <-done:
+			return
+		default:
+			// default
+		}
+`
+		assert.Equal(t, expected, gen)
+	}
+
+	{
+		generator = generator.Case(
+			NewSelectRecvCase([]string{"v"}, "ch").WithDefine(false).Statements(NewComment("modified")),
+		)
+		gen, err := generator.Generate(0)
+		assert.NoError(t, err)
+		expected := `select {
+case v = <-ch:
+	//modified
+}
+`
+		assert.Equal(t, expected, gen)
+	}
+}
+
+func TestShouldGenerateSelectCaseWithExpandingMethod(t *testing.T) {
+	generator := NewSelectDefaultCase().
+		AddStatements(NewComment(" XXX: test test")).
+		AddStatements(NewRawStatement("time.Sleep(time.Second)"))
+
+	expected := `default:
+	// XXX: test test
+	time.Sleep(time.Second)
+`
+	gen, err := generator.Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+}
+
+func TestShouldGenerateSelectRaisesError(t *testing.T) {
+	errPattern := func(err error) *regexp.Regexp {
+		return regexp.MustCompile(`^\` + strings.Split(err.Error(), " ")[0])
+	}
+
+	{
+		_, err := NewSelect(
+			NewSelectDefaultCase(),
+			NewSelectRecvCase(nil, "ch"),
+			NewSelectDefaultCase(),
+		).Generate(0)
+		assert.Regexp(t, errPattern(errmsg.SelectDefaultCaseIsDuplicatedError("")), err.Error())
+	}
+
+	{
+		_, err := NewSelect(NewSelectRecvCase(nil, "")).Generate(0)
+		assert.Regexp(t, errPattern(errmsg.SelectCaseChannelIsEmptyError("")), err.Error())
+	}
+
+	{
+		_, err := NewSelect(NewSelectSendCase("", "v")).Generate(0)
+		assert.Regexp(t, errPattern(errmsg.SelectCaseChannelIsEmptyError("")), err.Error())
+	}
+
+	{
+		_, err := NewSelect(NewSelectSendCase("ch", "")).Generate(0)
+		assert.Regexp(t, errPattern(errmsg.SelectSendCaseValueIsEmptyError("")), err.Error())
+	}
+
+	{
+		_, err := NewSelect(NewSelectRecvCase([]string{"a", "b", "c"}, "ch")).Generate(0)
+		assert.Regexp(t, errPattern(errmsg.SelectRecvCaseVariablesCountError(0, "")), err.Error())
+	}
+
+	{
+		_, err := NewSelect(NewSelectRecvCase(nil, "ch", NewFunc(nil, NewFuncSignature("")))).Generate(0)
+		assert.Regexp(t, errPattern(errmsg.FuncNameIsEmptyError("")), err.Error())
+	}
+}
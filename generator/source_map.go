@@ -0,0 +1,361 @@
+package generator
+
+import (
+	"errors"
+	"go/scanner"
+	"go/token"
+	"strings"
+)
+
+// SourceMap maps the lines of the generated code to the generators that produce them.
+// Please see also `Root#GenerateWithSourceMap()`.
+type SourceMap struct {
+	entries []*SourceMapEntry
+}
+
+// SourceMapEntry represents the range of the lines of the generated code that a generator produces.
+type SourceMapEntry struct {
+	startLine int
+	endLine   int
+	statement Statement
+	caller    string
+	path      []errorPathSegment
+}
+
+// Entries returns all entries of the source map.
+// The entries are ordered by the nesting of the generators; a parent generator comes before its nested generators.
+func (m *SourceMap) Entries() []*SourceMapEntry {
+	return append([]*SourceMapEntry{}, m.entries...)
+}
+
+// Lookup returns the innermost entry that contains the 1-origin line number of the generated code.
+// This returns nil if there is no such entry.
+func (m *SourceMap) Lookup(line int) *SourceMapEntry {
+	var found *SourceMapEntry
+	for _, entry := range m.entries {
+		if entry.startLine <= line && line <= entry.endLine {
+			found = entry
+		}
+	}
+	return found
+}
+
+// StartLine returns the 1-origin line number where the generated code of the generator begins.
+func (e *SourceMapEntry) StartLine() int {
+	return e.startLine
+}
+
+// EndLine returns the 1-origin line number where the generated code of the generator ends.
+func (e *SourceMapEntry) EndLine() int {
+	return e.endLine
+}
+
+// Statement returns the generator that produces the lines.
+func (e *SourceMapEntry) Statement() Statement {
+	return e.statement
+}
+
+// Caller returns the location of the client code that creates the generator.
+func (e *SourceMapEntry) Caller() string {
+	return e.caller
+}
+
+// Path returns the nesting path of the generator, in the same format as `GeneratorError#Path()`.
+func (e *SourceMapEntry) Path() string {
+	return strings.Join(pathSegmentStrings(e.path), " > ")
+}
+
+// PathSegments returns each segment of the nesting path, from the outermost one.
+func (e *SourceMapEntry) PathSegments() []string {
+	return pathSegmentStrings(e.path)
+}
+
+// generatedFile represents the generated code of `Root` with the information to map its lines to the generators.
+type generatedFile struct {
+	name        string
+	code        string
+	rawCode     string
+	lineRanges  []statementLineRange
+	rawSrcMap   *SourceMap
+	rootSegment errorPathSegment
+}
+
+// statementLineRange represents the range of the lines in the raw code (i.e. before applying the code formatters)
+// that the top-level statement produces.
+type statementLineRange struct {
+	statement Statement
+	startLine int
+	endLine   int
+}
+
+// rawSourceMap returns the source map of the raw code. This is built lazily, because it generates the nested generators again.
+func (f *generatedFile) rawSourceMap() *SourceMap {
+	if f.rawSrcMap != nil {
+		return f.rawSrcMap
+	}
+
+	b := &sourceMapBuilder{
+		lines: strings.Split(f.rawCode, "\n"),
+	}
+	rootPath := []errorPathSegment{f.rootSegment}
+	for _, lineRange := range f.lineRanges {
+		b.add(lineRange.statement, lineRange.startLine, lineRange.endLine, rootPath, "", false)
+	}
+
+	f.rawSrcMap = &SourceMap{
+		entries: b.entries,
+	}
+	return f.rawSrcMap
+}
+
+// sourceMap returns the source map of the final code.
+// The lines of the raw code are translated to the final code through the tokens, because the code formatters change the layout.
+func (f *generatedFile) sourceMap() *SourceMap {
+	rawSrcMap := f.rawSourceMap()
+	if f.code == f.rawCode {
+		return rawSrcMap
+	}
+
+	rawTokens := scanTokens(f.rawCode)
+	toFinalOffset := correspondingOffsets(rawTokens, scanTokens(f.code))
+
+	entries := make([]*SourceMapEntry, 0, len(rawSrcMap.entries))
+	for _, entry := range rawSrcMap.entries {
+		startLine, endLine := 0, 0
+		for i, t := range rawTokens {
+			line := lineOf(f.rawCode, t.offset)
+			if line < entry.startLine || line > entry.endLine || toFinalOffset[i] < 0 {
+				continue
+			}
+			finalLine := lineOf(f.code, toFinalOffset[i])
+			if startLine == 0 || finalLine < startLine {
+				startLine = finalLine
+			}
+			if finalLine > endLine {
+				endLine = finalLine
+			}
+		}
+		if startLine == 0 {
+			// e.g. newline; it disappears or is merged by the code formatter
+			continue
+		}
+
+		entries = append(entries, &SourceMapEntry{
+			startLine: startLine,
+			endLine:   endLine,
+			statement: entry.statement,
+			caller:    entry.caller,
+			path:      entry.path,
+		})
+	}
+
+	return &SourceMap{
+		entries: entries,
+	}
+}
+
+// lookupByOffset returns the innermost entry of the raw source map that corresponds to the offset of `code`.
+// `code` is the raw code or the code that is derived from the raw code by the code formatters.
+func (f *generatedFile) lookupByOffset(code string, offset int) *SourceMapEntry {
+	return f.rawSourceMap().Lookup(rawLine(f.rawCode, code, offset))
+}
+
+// formatterError points the error of the code formatter to the generator that produces the line that the code formatter complains.
+// `input` is the code that is given to the code formatter.
+// The root segment of the path is omitted, because `Root#Generate()` annotates the error with that.
+func (f *generatedFile) formatterError(err error, input string) error {
+	var fe *FormatterError
+	if !errors.As(err, &fe) || fe.lineNumber <= 0 {
+		return err
+	}
+
+	entry := f.lookupByOffset(input, offsetOfLine(input, fe.lineNumber))
+	if entry == nil {
+		return err
+	}
+	fe.caller = entry.caller
+
+	genErr := newGeneratorError(fe)
+	genErr.caller = entry.caller
+	genErr.path = append([]errorPathSegment{}, entry.path[1:]...)
+	return genErr
+}
+
+type sourceMapBuilder struct {
+	lines   []string
+	entries []*SourceMapEntry
+}
+
+// add adds the entry of the statement and finds the lines of its nested statements.
+// Each nested statement is generated again, and its first line is searched from the lines of the parent in order.
+// The statement that has no path segment (e.g. `RawStatement`) has no entry; its lines belong to the parent.
+// If `inheritCaller` is true (i.e. the statement is composed by `statementComposer`), the entry and its path segment have the caller of the parent.
+func (b *sourceMapBuilder) add(statement Statement, startLine int, endLine int, parentPath []errorPathSegment, parentCaller string, inheritCaller bool) {
+	path := parentPath
+	caller := parentCaller
+	if s, ok := statement.(interface{ pathSegment() errorPathSegment }); ok {
+		segment := s.pathSegment()
+		if inheritCaller {
+			segment.caller = parentCaller
+		} else {
+			caller = segment.caller
+		}
+		path = append(append([]errorPathSegment{}, parentPath...), segment)
+
+		b.entries = append(b.entries, &SourceMapEntry{
+			startLine: startLine,
+			endLine:   endLine,
+			statement: statement,
+			caller:    caller,
+			path:      path,
+		})
+	}
+
+	c, ok := statement.(statementContainer)
+	if !ok {
+		return
+	}
+	if _, ok := statement.(statementComposer); ok {
+		inheritCaller = true
+	}
+
+	cursor := startLine
+	for _, child := range c.childStatements() {
+		if child == nil {
+			continue
+		}
+
+		gen, err := child.Generate(0)
+		if err != nil {
+			continue
+		}
+		childLines := strings.Split(strings.TrimSuffix(gen, "\n"), "\n")
+		firstLine := strings.TrimSpace(childLines[0])
+
+		found := 0
+		for line := cursor; line <= endLine && line <= len(b.lines); line++ {
+			if firstLine == "" || strings.Contains(b.lines[line-1], firstLine) {
+				found = line
+				break
+			}
+		}
+		if found == 0 {
+			continue
+		}
+
+		childEndLine := found + len(childLines) - 1
+		if childEndLine > endLine {
+			childEndLine = endLine
+		}
+		b.add(child, found, childEndLine, path, caller, inheritCaller)
+
+		cursor = childEndLine
+		if strings.HasSuffix(gen, "\n") {
+			cursor++
+		}
+	}
+}
+
+// rawLine returns the line of the raw code (i.e. before applying the code formatters) that corresponds to the offset of `code`.
+// The code formatters change the layout but rarely change the tokens, so this finds the token at the offset
+// and returns the line of the same token that appears the same number of times in the raw code.
+func rawLine(rawCode string, code string, offset int) int {
+	if code == rawCode {
+		return lineOf(code, offset)
+	}
+
+	tokens := scanTokens(code)
+	target := -1
+	for i, t := range tokens {
+		if t.offset > offset {
+			break
+		}
+		target = i
+	}
+	if target < 0 {
+		return lineOf(code, offset)
+	}
+
+	rawOffset := correspondingOffsets(tokens, scanTokens(rawCode))[target]
+	if rawOffset < 0 {
+		return lineOf(code, offset)
+	}
+	return lineOf(rawCode, rawOffset)
+}
+
+// correspondingOffsets returns the offset of the corresponding token in `to` for each token of `from`, or -1 if there is no such token.
+// The tokens are corresponded by the text and the number of the occurrences.
+func correspondingOffsets(from []scannedToken, to []scannedToken) []int {
+	toOffsets := map[string][]int{}
+	for _, t := range to {
+		toOffsets[t.text] = append(toOffsets[t.text], t.offset)
+	}
+
+	occurrences := map[string]int{}
+	offsets := make([]int, len(from))
+	for i, t := range from {
+		n := occurrences[t.text]
+		occurrences[t.text] = n + 1
+
+		offsets[i] = -1
+		if candidates := toOffsets[t.text]; n < len(candidates) {
+			offsets[i] = candidates[n]
+		}
+	}
+	return offsets
+}
+
+type scannedToken struct {
+	offset int
+	text   string
+}
+
+func scanTokens(code string) []scannedToken {
+	fset := token.NewFileSet()
+	file := fset.AddFile("", fset.Base(), len(code))
+
+	var s scanner.Scanner
+	s.Init(file, []byte(code), nil, scanner.ScanComments)
+
+	var tokens []scannedToken
+	for {
+		pos, tok, lit := s.Scan()
+		if tok == token.EOF {
+			break
+		}
+		if tok == token.SEMICOLON && lit == "\n" {
+			// automatically inserted semicolon depends on the layout
+			continue
+		}
+
+		text := lit
+		if text == "" {
+			text = tok.String()
+		}
+		tokens = append(tokens, scannedToken{
+			offset: file.Offset(pos),
+			text:   text,
+		})
+	}
+	return tokens
+}
+
+func lineOf(code string, offset int) int {
+	if offset > len(code) {
+		offset = len(code)
+	}
+	return strings.Count(code[:offset], "\n") + 1
+}
+
+// offsetOfLine returns the offset of the beginning of the 1-origin line number.
+func offsetOfLine(code string, line int) int {
+	offset := 0
+	for i := 1; i < line; i++ {
+		next := strings.IndexByte(code[offset:], '\n')
+		if next < 0 {
+			return len(code)
+		}
+		offset += next + 1
+	}
+	return offset
+}
@@ -0,0 +1,181 @@
+package generator
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func stripCallers(path string) string {
+	return regexp.MustCompile(` \([^)]+\)`).ReplaceAllString(path, "")
+}
+
+// inAnotherGoroutine runs `f` in another goroutine and waits for it.
+// The statements that are composed there have their own callers that differ from the caller of the composer.
+func inAnotherGoroutine(f func()) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		f()
+	}()
+	<-done
+}
+
+func TestShouldGenerateWithSourceMap(t *testing.T) {
+	generated, sourceMap, err := NewRoot(
+		NewPackage("mypkg"),
+		NewNewline(),
+		NewFunc(
+			nil,
+			NewFuncSignature("Upper").AddParameters(NewFuncParameter("s", "string")).AddReturnTypes("string"),
+			NewIf(`s == ""`, NewReturnStatement(`"empty"`)),
+			NewReturnStatement().AddReturnStatements(NewCall("strings.ToUpper", NewRawStatement("s"))),
+		),
+	).GenerateWithSourceMap(0)
+	assert.NoError(t, err)
+
+	expected := `package mypkg
+
+func Upper(s string) string {
+	if s == "" {
+		return "empty"
+	}
+	return strings.ToUpper(s)
+}
+`
+	assert.Equal(t, expected, generated)
+
+	type entry struct {
+		startLine int
+		endLine   int
+		path      string
+	}
+	entries := []entry{}
+	for _, e := range sourceMap.Entries() {
+		assert.Regexp(t, regexp.MustCompile(`:\d+$`), e.Caller())
+		assert.Equal(t, "Root", e.PathSegments()[0])
+		entries = append(entries, entry{e.StartLine(), e.EndLine(), stripCallers(e.Path())})
+	}
+	assert.Equal(t, []entry{
+		{1, 1, `Root > Package "mypkg"`},
+		{3, 8, `Root > Func "Upper"`},
+		{3, 3, `Root > Func "Upper" > FuncSignature "Upper"`},
+		{4, 6, `Root > Func "Upper" > If`},
+		{5, 5, `Root > Func "Upper" > If > ReturnStatement`},
+		{7, 7, `Root > Func "Upper" > ReturnStatement`},
+		{7, 7, `Root > Func "Upper" > ReturnStatement > Call "strings.ToUpper"`},
+	}, entries)
+
+	assert.Equal(t, `Root > Func "Upper" > If > ReturnStatement`, stripCallers(sourceMap.Lookup(5).Path()))
+	assert.Equal(t, `Root > Func "Upper" > If`, stripCallers(sourceMap.Lookup(6).Path()))
+	assert.Equal(t, `Root > Func "Upper"`, stripCallers(sourceMap.Lookup(8).Path()))
+	assert.Nil(t, sourceMap.Lookup(2))
+	assert.Nil(t, sourceMap.Lookup(100))
+}
+
+func TestShouldGenerateWithSourceMapOfFormattedCode(t *testing.T) {
+	generated, sourceMap, err := NewRoot(
+		NewPackage("mypkg"),
+		NewNewline(),
+		NewNewline(),
+		NewNewline(),
+		NewFunc(
+			nil,
+			NewFuncSignature("Upper").AddParameters(NewFuncParameter("s", "string")).AddReturnTypes("string"),
+			NewReturnStatement().AddReturnStatements(NewCall("strings.ToUpper", NewRawStatement("s"))),
+		),
+	).Gofmt().Goimports().GenerateWithSourceMap(0)
+	assert.NoError(t, err)
+
+	expected := `package mypkg
+
+import "strings"
+
+func Upper(s string) string {
+	return strings.ToUpper(s)
+}
+`
+	assert.Equal(t, expected, generated)
+
+	entry := sourceMap.Lookup(5)
+	assert.Equal(t, `Root > Func "Upper" > FuncSignature "Upper"`, stripCallers(entry.Path()))
+	assert.Equal(t, 5, entry.StartLine())
+	assert.Equal(t, 5, entry.EndLine())
+
+	entry = sourceMap.Lookup(6)
+	assert.Equal(t, `Root > Func "Upper" > ReturnStatement > Call "strings.ToUpper"`, stripCallers(entry.Path()))
+	_, ok := entry.Statement().(*Call)
+	assert.True(t, ok)
+
+	entry = sourceMap.Lookup(7)
+	assert.Equal(t, `Root > Func "Upper"`, stripCallers(entry.Path()))
+	assert.Equal(t, 5, entry.StartLine())
+	assert.Equal(t, 7, entry.EndLine())
+
+	// the import is inserted by goimports, so no generator produces that
+	assert.Nil(t, sourceMap.Lookup(3))
+}
+
+func TestShouldGenerateWithSourceMapRaiseError(t *testing.T) {
+	_, sourceMap, err := NewRoot(NewIf("")).GenerateWithSourceMap(0)
+	assert.Regexp(t, regexp.MustCompile(`^\[GOWRTR-15\]`), err.Error())
+	assert.Nil(t, sourceMap)
+}
+
+func TestShouldFormatterErrorPointToGenerator(t *testing.T) {
+	_, err := NewRoot(
+		NewPackage("mypkg"),
+		NewFunc(nil, NewFuncSignature("F"), NewRawStatement("x := 1 2")),
+	).Gofmt().Generate(0)
+
+	var genErr *GeneratorError
+	assert.True(t, errors.As(err, &genErr))
+	assert.Equal(t, ErrorKindCodeFormatter, genErr.Kind())
+	assert.Regexp(t, regexp.MustCompile(`:\d+$`), genErr.Caller())
+	assert.Equal(t, `Root > Func "F"`, stripCallers(genErr.Path()))
+
+	var formatterErr *FormatterError
+	assert.True(t, errors.As(err, &formatterErr))
+	assert.Equal(t, 3, formatterErr.LineNumber())
+	assert.Equal(t, genErr.Caller(), formatterErr.Caller())
+}
+
+func TestShouldSyntaxCheckingErrorPointToGenerator(t *testing.T) {
+	_, err := NewRoot(
+		NewPackage("mypkg"),
+		NewFunc(
+			nil,
+			NewFuncSignature("F"),
+			NewIf("true", NewRawStatement("x := 1 2")),
+		),
+	).EnableSyntaxChecking().Generate(0)
+
+	var genErr *GeneratorError
+	assert.True(t, errors.As(err, &genErr))
+	assert.Equal(t, ErrorKindCodeFormatter, genErr.Kind())
+	assert.Equal(t, `Root > Func "F" > If`, stripCallers(genErr.Path()))
+}
+
+func TestShouldSourceMapEntriesOfComposedStatementsHaveCallerOfComposer(t *testing.T) {
+	structure := NewStruct("Server").AddField("Host", "string")
+	opts := NewFunctionalOptions(structure)
+
+	var sourceMap *SourceMap
+	inAnotherGoroutine(func() {
+		var err error
+		_, sourceMap, err = NewRoot(NewPackage("mypkg"), opts).GenerateWithSourceMap(0)
+		assert.NoError(t, err)
+	})
+
+	entry := sourceMap.Lookup(8) // s.Host = host
+	if assert.NotNil(t, entry) {
+		assert.Equal(t, `Root > FunctionalOptions "Server" > Func "WithHost" > ReturnStatement > AnonymousFunc > Assign`, stripCallers(entry.Path()))
+		assert.Equal(t, opts.caller, entry.Caller())
+		for _, segment := range entry.PathSegments()[1:] {
+			assert.True(t, strings.HasSuffix(segment, " ("+opts.caller+")"), segment)
+		}
+	}
+}
@@ -0,0 +1,1544 @@
+package generator
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+	"gopkg.in/yaml.v3"
+)
+
+// Spec represents a declarative spec of the generated files, that is written in YAML or JSON.
+// Each file of the spec is a `Root` that is built from the spec; please see `ParseSpec()` for the format.
+type Spec struct {
+	files []*SpecFile
+}
+
+// SpecFile represents a file of `Spec`, that is the pair of the path and the `Root` of the file.
+type SpecFile struct {
+	path string
+	root *Root
+}
+
+// NewSpecFile returns a new `SpecFile`.
+func NewSpecFile(path string, root *Root) *SpecFile {
+	return &SpecFile{
+		path: path,
+		root: root,
+	}
+}
+
+// Path returns the path of the file.
+func (f *SpecFile) Path() string {
+	return f.path
+}
+
+// Root returns the `Root` of the file.
+func (f *SpecFile) Root() *Root {
+	return f.root
+}
+
+// NewSpec returns a new `Spec` that consists of the files. This is the way to export the generator trees into the spec
+// by `YAML()` or `JSON()`.
+func NewSpec(files ...*SpecFile) *Spec {
+	return &Spec{
+		files: files,
+	}
+}
+
+// AddFiles adds the files to `Spec`.
+// This method returns a *new* `Spec`; it means this method acts as immutable.
+func (s *Spec) AddFiles(files ...*SpecFile) *Spec {
+	return &Spec{
+		files: append(s.files, files...),
+	}
+}
+
+// Files returns the files of `Spec` in the order of the spec.
+func (s *Spec) Files() []*SpecFile {
+	return s.files
+}
+
+// Generate generates the code of each file. The result is the map of the path and the generated code.
+func (s *Spec) Generate() (map[string]string, error) {
+	files := make(map[string]string, len(s.files))
+	for _, f := range s.files {
+		generated, err := f.root.Generate(0)
+		if err != nil {
+			return nil, err
+		}
+		files[f.path] = generated
+	}
+	return files, nil
+}
+
+// LoadSpec reads the spec (YAML or JSON) from the local file, and returns a new `Spec`.
+// Please see `ParseSpec()` for the format.
+func LoadSpec(path string) (*Spec, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errmsg.SpecLoadingError(path, err.Error(), fetchClientCallerLine())
+	}
+	return ParseSpec(path, data)
+}
+
+// ParseSpec parses the spec (YAML or JSON), and returns a new `Spec` that holds the generator trees.
+// `name` is the name of the spec (e.g. the file path) that is used in the locations of the errors.
+//
+// The generators that are built from the spec have the location in the spec (e.g. `spec.yaml:12:7`) instead of
+// the caller line of golang, so both of the errors of parsing and generation point to the location in the spec.
+//
+// The spec has `files` that is the mapping of the path and the file; a file has `statements` and the options of `Root`
+// (`gofmt` that is `true` or the list of the options, `goimports`, `syntaxChecking`, and `typeChecking` that is `true` or
+// the source directory):
+//
+//	files:
+//	  user.go:
+//	    gofmt: true
+//	    statements:
+//	      - package: user
+//	      - struct:
+//	          name: User
+//	          fields:
+//	            - {name: ID, type: int64, tag: 'json:"id"'}
+//	      - func:
+//	          receiver: {name: u, type: "*User"}
+//	          name: IsValid
+//	          results: [bool]
+//	          body:
+//	            - return: u.ID > 0
+//
+// A statement is the mapping that has only one key of the kind, or the string that is the raw statement.
+// An expression (e.g. the value of `var` and the argument of `call`) is the same, but the string is the raw expression without the newline.
+// The kinds and the keys are the following:
+//
+//   - `raw`: the string, or `{code, newline}` to control the trailing newline; this is the escape hatch for anything
+//   - `comment`, `newline`, `package`, `import` (the string or the list of the packages), `label`, `inc`, `dec`,
+//     `break` and `continue` (the optional label), `goto`
+//   - `struct`: `{name, fields: [{name, type, tag, comment, embedded}], tagNamings: [{key, strategy: snake|camel}]}`
+//   - `interface`: `{name, methods: [{name, params, results}]}`; `params` is `[{name, type}]` and `results` is the list of
+//     the types or `{name, type}`
+//   - `func`: `{receiver: {name, type}, name, params, results, body}`
+//   - `anonymousFunc`: `{go, params, results, body, invoke}`; `invoke` is the list of the arguments to invoke it immediately
+//   - `call`: `{callee, args, typeArgs, spread, multiline, chain}`; `chain` is the list of the method calls on the result
+//   - `if`: `{init, cond, body, elseIf: [{init, cond, body}], else}`
+//   - `switch`: `{init, cond, cases: [{values, body, fallthrough}], default}`
+//   - `typeSwitch`: `{init, bind, expr, cases: [{types, body}], default}`
+//   - `for`: `{init, cond, post, body}` and `forRange`: `{key, value, expr, define, body}`
+//   - `select`: the list of `{vars, define, chan, value, body}`; it is the send case if it has `value`,
+//     the receive case if it has `chan`, otherwise the default case
+//   - `return`: the list of the expressions; `var`: `{names, type, values}`; `const`: the list of `{names, type, values}`;
+//     `assign`: `{lhs, op, rhs}`
+//   - `block`, `defer` and `go`: the statements of the block, and the call of `defer` and `go`
+//   - `literal`: `{type, fields: [{key, value}]}` and `enum`: `{name, type, values: [{name, string, value}], unknown, bitFlags}`
+//
+// The spec in JSON has the same structure.
+func ParseSpec(name string, data []byte) (*Spec, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, errmsg.SpecIsInvalidError(err.Error(), name)
+	}
+	if len(doc.Content) <= 0 {
+		return nil, errmsg.SpecIsInvalidError("document is empty", name)
+	}
+	return (&specLoader{name: name}).spec(doc.Content[0])
+}
+
+type specLoader struct {
+	name string
+}
+
+var specTagNamingStrategies = map[string]TagNamingStrategy{
+	"snake": SnakeCaseTagNaming,
+	"camel": CamelCaseTagNaming,
+}
+
+func (l *specLoader) position(node *yaml.Node) string {
+	return fmt.Sprintf("%s:%d:%d", l.name, node.Line, node.Column)
+}
+
+func (l *specLoader) errorf(node *yaml.Node, format string, args ...interface{}) error {
+	return errmsg.SpecIsInvalidError(fmt.Sprintf(format, args...), l.position(node))
+}
+
+func (l *specLoader) spec(node *yaml.Node) (*Spec, error) {
+	fields, err := l.mapping(node, "files")
+	if err != nil {
+		return nil, err
+	}
+	filesNode, err := l.required(node, fields, "files")
+	if err != nil {
+		return nil, err
+	}
+	if filesNode.Kind != yaml.MappingNode {
+		return nil, l.errorf(filesNode, "files must be the mapping of the path and the file")
+	}
+
+	files := make([]*SpecFile, 0, len(filesNode.Content)/2)
+	seen := map[string]bool{}
+	for i := 0; i+1 < len(filesNode.Content); i += 2 {
+		path, err := l.str(filesNode.Content[i])
+		if err != nil {
+			return nil, err
+		}
+		if path == "" {
+			return nil, l.errorf(filesNode.Content[i], "path of the file is empty")
+		}
+		if seen[path] {
+			return nil, l.errorf(filesNode.Content[i], "file '%s' is duplicated", path)
+		}
+		seen[path] = true
+
+		root, err := l.root(filesNode.Content[i+1])
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, &SpecFile{
+			path: path,
+			root: root,
+		})
+	}
+	return &Spec{
+		files: files,
+	}, nil
+}
+
+func (l *specLoader) root(node *yaml.Node) (*Root, error) {
+	fields, err := l.mapping(node, "gofmt", "goimports", "syntaxChecking", "typeChecking", "statements")
+	if err != nil {
+		return nil, err
+	}
+
+	gofmt := false
+	var gofmtOptions []string
+	if n := fields["gofmt"]; n != nil {
+		if n.Kind == yaml.SequenceNode {
+			gofmt = true
+			gofmtOptions, err = l.strs(n)
+		} else {
+			gofmt, err = l.boolean(n, false)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	goimports, err := l.boolean(fields["goimports"], false)
+	if err != nil {
+		return nil, err
+	}
+	syntaxChecking, err := l.boolean(fields["syntaxChecking"], false)
+	if err != nil {
+		return nil, err
+	}
+	typeChecking := false
+	typeCheckDir := ""
+	if n := fields["typeChecking"]; n != nil {
+		if n.ShortTag() == "!!bool" {
+			typeChecking, err = l.boolean(n, false)
+		} else {
+			typeChecking = true
+			typeCheckDir, err = l.str(n)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	statements, err := l.statements(fields["statements"])
+	if err != nil {
+		return nil, err
+	}
+
+	return &Root{
+		statements:     statements,
+		gofmt:          gofmt,
+		gofmtOptions:   gofmtOptions,
+		goimports:      goimports,
+		syntaxChecking: syntaxChecking,
+		typeChecking:   typeChecking,
+		typeCheckDir:   typeCheckDir,
+		caller:         l.position(node),
+	}, nil
+}
+
+func (l *specLoader) statements(node *yaml.Node) ([]Statement, error) {
+	items, err := l.sequence(node)
+	if err != nil {
+		return nil, err
+	}
+	statements := make([]Statement, 0, len(items))
+	for _, item := range items {
+		stmt, err := l.statement(item)
+		if err != nil {
+			return nil, err
+		}
+		statements = append(statements, stmt)
+	}
+	return statements, nil
+}
+
+// statement builds the statement; the string is the raw statement that has the trailing newline.
+func (l *specLoader) statement(node *yaml.Node) (Statement, error) {
+	node = resolveYAMLAlias(node)
+	if node.Kind == yaml.ScalarNode {
+		return &RawStatement{
+			statement:   node.Value,
+			withNewline: true,
+			caller:      l.position(node),
+		}, nil
+	}
+	return l.kindedStatement(node)
+}
+
+// expression builds the statement that is used as an expression; the string is the raw expression without the newline.
+func (l *specLoader) expression(node *yaml.Node) (Statement, error) {
+	node = resolveYAMLAlias(node)
+	if node.Kind == yaml.ScalarNode {
+		return &RawStatement{
+			statement: node.Value,
+			caller:    l.position(node),
+		}, nil
+	}
+	return l.kindedStatement(node)
+}
+
+func (l *specLoader) expressions(node *yaml.Node) ([]Statement, error) {
+	if node == nil {
+		return nil, nil
+	}
+	node = resolveYAMLAlias(node)
+	if node.Kind == yaml.ScalarNode {
+		if node.ShortTag() == "!!null" {
+			return nil, nil
+		}
+		expr, err := l.expression(node)
+		if err != nil {
+			return nil, err
+		}
+		return []Statement{expr}, nil
+	}
+
+	items, err := l.sequence(node)
+	if err != nil {
+		return nil, err
+	}
+	exprs := make([]Statement, 0, len(items))
+	for _, item := range items {
+		expr, err := l.expression(item)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+	}
+	return exprs, nil
+}
+
+func (l *specLoader) optionalExpression(node *yaml.Node) (Statement, error) {
+	if node == nil {
+		return nil, nil
+	}
+	return l.expression(node)
+}
+
+func (l *specLoader) kindedStatement(node *yaml.Node) (Statement, error) {
+	if node.Kind != yaml.MappingNode || len(node.Content) != 2 {
+		return nil, l.errorf(node, "statement must be a string, or a mapping that has only one key of the kind (e.g. struct)")
+	}
+
+	key, value := node.Content[0], resolveYAMLAlias(node.Content[1])
+	caller := l.position(key)
+	switch key.Value {
+	case "raw":
+		return l.raw(value, caller)
+	case "comment":
+		comment, err := l.str(value)
+		if err != nil {
+			return nil, err
+		}
+		return &Comment{
+			comment: comment,
+			caller:  caller,
+		}, nil
+	case "newline":
+		return &Newline{
+			caller: caller,
+		}, nil
+	case "package":
+		name, err := l.str(value)
+		if err != nil {
+			return nil, err
+		}
+		return &Package{
+			name:   name,
+			caller: caller,
+		}, nil
+	case "import":
+		names, err := l.strs(value)
+		if err != nil {
+			return nil, err
+		}
+		return &Import{
+			names:  names,
+			caller: caller,
+		}, nil
+	case "struct":
+		return l.structOf(value, caller)
+	case "interface":
+		return l.interfaceOf(value, caller)
+	case "func":
+		return l.funcOf(value, caller)
+	case "anonymousFunc":
+		return l.anonymousFuncOf(value, caller)
+	case "call":
+		return l.callOf(value, caller)
+	case "if":
+		return l.ifOf(value, caller)
+	case "switch":
+		return l.switchOf(value, caller)
+	case "typeSwitch":
+		return l.typeSwitchOf(value, caller)
+	case "for":
+		return l.forOf(value, caller)
+	case "forRange":
+		return l.forRangeOf(value, caller)
+	case "select":
+		return l.selectOf(value, caller)
+	case "return":
+		items, err := l.expressions(value)
+		if err != nil {
+			return nil, err
+		}
+		return &ReturnStatement{
+			returnItems: items,
+			caller:      caller,
+		}, nil
+	case "var":
+		return l.varOf(value, caller)
+	case "const":
+		return l.constOf(value, caller)
+	case "assign":
+		return l.assignOf(value, caller)
+	case "block":
+		statements, err := l.statements(value)
+		if err != nil {
+			return nil, err
+		}
+		return &CodeBlock{
+			statements: statements,
+			caller:     caller,
+		}, nil
+	case "defer", "go":
+		stmt, err := l.expression(value)
+		if err != nil {
+			return nil, err
+		}
+		if key.Value == "go" {
+			return &Go{
+				statement: stmt,
+				caller:    caller,
+			}, nil
+		}
+		return &Defer{
+			statement: stmt,
+			caller:    caller,
+		}, nil
+	case "label":
+		name, err := l.str(value)
+		if err != nil {
+			return nil, err
+		}
+		return &Label{
+			name:   name,
+			caller: caller,
+		}, nil
+	case "break", "continue", "goto":
+		label, err := l.str(value)
+		if err != nil {
+			return nil, err
+		}
+		return &Branch{
+			keyword: key.Value,
+			label:   label,
+			caller:  caller,
+		}, nil
+	case "inc", "dec":
+		target, err := l.str(value)
+		if err != nil {
+			return nil, err
+		}
+		operator := "++"
+		if key.Value == "dec" {
+			operator = "--"
+		}
+		return &IncDec{
+			target:   target,
+			operator: operator,
+			caller:   caller,
+		}, nil
+	case "literal":
+		return l.literalOf(value, caller)
+	case "enum":
+		return l.enumOf(value, caller)
+	}
+	return nil, l.errorf(key, "unknown kind of the statement '%s'", key.Value)
+}
+
+func (l *specLoader) raw(node *yaml.Node, caller string) (Statement, error) {
+	if node.Kind == yaml.ScalarNode {
+		return &RawStatement{
+			statement:   node.Value,
+			withNewline: true,
+			caller:      caller,
+		}, nil
+	}
+
+	fields, err := l.mapping(node, "code", "newline")
+	if err != nil {
+		return nil, err
+	}
+	code, err := l.str(fields["code"])
+	if err != nil {
+		return nil, err
+	}
+	newline, err := l.boolean(fields["newline"], true)
+	if err != nil {
+		return nil, err
+	}
+	return &RawStatement{
+		statement:   code,
+		withNewline: newline,
+		caller:      caller,
+	}, nil
+}
+
+func (l *specLoader) structOf(node *yaml.Node, caller string) (Statement, error) {
+	fields, err := l.mapping(node, "name", "fields", "tagNamings")
+	if err != nil {
+		return nil, err
+	}
+	name, err := l.requiredStr(node, fields, "name")
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := l.sequence(fields["fields"])
+	if err != nil {
+		return nil, err
+	}
+	structFields := make([]*StructField, 0, len(items))
+	fieldsCallers := make([]string, 0, len(items))
+	for _, item := range items {
+		f, err := l.mapping(item, "name", "type", "tag", "comment", "embedded")
+		if err != nil {
+			return nil, err
+		}
+		typ, err := l.requiredStr(item, f, "type")
+		if err != nil {
+			return nil, err
+		}
+		fieldName, err := l.str(f["name"])
+		if err != nil {
+			return nil, err
+		}
+		tag, err := l.str(f["tag"])
+		if err != nil {
+			return nil, err
+		}
+		comment, err := l.str(f["comment"])
+		if err != nil {
+			return nil, err
+		}
+		embedded, err := l.boolean(f["embedded"], false)
+		if err != nil {
+			return nil, err
+		}
+		if embedded && fieldName == "" {
+			fieldName = embeddedFieldName(typ)
+		}
+
+		structFields = append(structFields, &StructField{
+			name:     fieldName,
+			typ:      typ,
+			tag:      tag,
+			comment:  comment,
+			embedded: embedded,
+		})
+		fieldsCallers = append(fieldsCallers, l.position(item))
+	}
+
+	items, err = l.sequence(fields["tagNamings"])
+	if err != nil {
+		return nil, err
+	}
+	tagNamings := make([]*tagNaming, 0, len(items))
+	for _, item := range items {
+		f, err := l.mapping(item, "key", "strategy")
+		if err != nil {
+			return nil, err
+		}
+		key, err := l.requiredStr(item, f, "key")
+		if err != nil {
+			return nil, err
+		}
+		strategyName, err := l.requiredStr(item, f, "strategy")
+		if err != nil {
+			return nil, err
+		}
+		strategy, ok := specTagNamingStrategies[strategyName]
+		if !ok {
+			return nil, l.errorf(f["strategy"], "unknown tag naming strategy '%s'; it must be snake or camel", strategyName)
+		}
+		tagNamings = append(tagNamings, &tagNaming{
+			key:      key,
+			strategy: strategy,
+		})
+	}
+
+	return &Struct{
+		name:          name,
+		fields:        structFields,
+		nameCaller:    caller,
+		fieldsCallers: fieldsCallers,
+		tagNamings:    tagNamings,
+	}, nil
+}
+
+func (l *specLoader) interfaceOf(node *yaml.Node, caller string) (Statement, error) {
+	fields, err := l.mapping(node, "name", "methods")
+	if err != nil {
+		return nil, err
+	}
+	name, err := l.requiredStr(node, fields, "name")
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := l.sequence(fields["methods"])
+	if err != nil {
+		return nil, err
+	}
+	signatures := make([]*FuncSignature, 0, len(items))
+	for _, item := range items {
+		f, err := l.mapping(item, "name", "params", "results")
+		if err != nil {
+			return nil, err
+		}
+		sig, err := l.funcSignature(f, l.position(item))
+		if err != nil {
+			return nil, err
+		}
+		signatures = append(signatures, sig)
+	}
+
+	return &Interface{
+		name:           name,
+		funcSignatures: signatures,
+		caller:         caller,
+	}, nil
+}
+
+func (l *specLoader) funcOf(node *yaml.Node, caller string) (Statement, error) {
+	fields, err := l.mapping(node, "receiver", "name", "params", "results", "body")
+	if err != nil {
+		return nil, err
+	}
+
+	var receiver *FuncReceiver
+	if n := fields["receiver"]; n != nil {
+		f, err := l.mapping(n, "name", "type")
+		if err != nil {
+			return nil, err
+		}
+		name, err := l.str(f["name"])
+		if err != nil {
+			return nil, err
+		}
+		typ, err := l.requiredStr(n, f, "type")
+		if err != nil {
+			return nil, err
+		}
+		receiver = &FuncReceiver{
+			name:   name,
+			typ:    typ,
+			caller: l.position(n),
+		}
+	}
+	sig, err := l.funcSignature(fields, caller)
+	if err != nil {
+		return nil, err
+	}
+	statements, err := l.statements(fields["body"])
+	if err != nil {
+		return nil, err
+	}
+
+	return &Func{
+		funcReceiver:  receiver,
+		funcSignature: sig,
+		statements:    statements,
+		caller:        caller,
+	}, nil
+}
+
+func (l *specLoader) funcSignature(fields map[string]*yaml.Node, caller string) (*FuncSignature, error) {
+	name, err := l.str(fields["name"])
+	if err != nil {
+		return nil, err
+	}
+	params, paramCallers, err := l.funcParameters(fields["params"])
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := l.sequence(fields["results"])
+	if err != nil {
+		return nil, err
+	}
+	returnTypes := make([]*FuncReturnType, 0, len(items))
+	returnTypesCallers := make([]string, 0, len(items))
+	for _, item := range items {
+		returnType := &FuncReturnType{}
+		if item.Kind == yaml.ScalarNode {
+			returnType.typ = item.Value
+		} else {
+			f, err := l.mapping(item, "name", "type")
+			if err != nil {
+				return nil, err
+			}
+			if returnType.name, err = l.str(f["name"]); err != nil {
+				return nil, err
+			}
+			if returnType.typ, err = l.requiredStr(item, f, "type"); err != nil {
+				return nil, err
+			}
+		}
+		returnTypes = append(returnTypes, returnType)
+		returnTypesCallers = append(returnTypesCallers, l.position(item))
+	}
+
+	return &FuncSignature{
+		funcName:           name,
+		funcParameters:     params,
+		returnTypes:        returnTypes,
+		paramCallers:       paramCallers,
+		funcNameCaller:     caller,
+		returnTypesCallers: returnTypesCallers,
+	}, nil
+}
+
+func (l *specLoader) funcParameters(node *yaml.Node) ([]*FuncParameter, []string, error) {
+	items, err := l.sequence(node)
+	if err != nil {
+		return nil, nil, err
+	}
+	params := make([]*FuncParameter, 0, len(items))
+	callers := make([]string, 0, len(items))
+	for _, item := range items {
+		f, err := l.mapping(item, "name", "type")
+		if err != nil {
+			return nil, nil, err
+		}
+		name, err := l.str(f["name"])
+		if err != nil {
+			return nil, nil, err
+		}
+		typ, err := l.str(f["type"])
+		if err != nil {
+			return nil, nil, err
+		}
+		params = append(params, &FuncParameter{
+			name: name,
+			typ:  typ,
+		})
+		callers = append(callers, l.position(item))
+	}
+	return params, callers, nil
+}
+
+func (l *specLoader) anonymousFuncOf(node *yaml.Node, caller string) (Statement, error) {
+	fields, err := l.mapping(node, "go", "params", "results", "body", "invoke")
+	if err != nil {
+		return nil, err
+	}
+	goFunc, err := l.boolean(fields["go"], false)
+	if err != nil {
+		return nil, err
+	}
+	params, paramCallers, err := l.funcParameters(fields["params"])
+	if err != nil {
+		return nil, err
+	}
+	results, err := l.strs(fields["results"])
+	if err != nil {
+		return nil, err
+	}
+	statements, err := l.statements(fields["body"])
+	if err != nil {
+		return nil, err
+	}
+
+	var invocation *FuncInvocation
+	if n := fields["invoke"]; n != nil {
+		items, err := l.sequence(n)
+		if err != nil {
+			return nil, err
+		}
+		args := make([]string, 0, len(items))
+		argCallers := make([]string, 0, len(items))
+		for _, item := range items {
+			arg, err := l.str(item)
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, arg)
+			argCallers = append(argCallers, l.position(item))
+		}
+		invocation = &FuncInvocation{
+			parameters: args,
+			callers:    argCallers,
+			caller:     l.position(n),
+		}
+	}
+
+	return &AnonymousFunc{
+		goFunc: goFunc,
+		anonymousFuncSignature: &AnonymousFuncSignature{
+			funcParameters: params,
+			returnTypes:    results,
+			callers:        paramCallers,
+			caller:         caller,
+		},
+		statements:     statements,
+		funcInvocation: invocation,
+		caller:         caller,
+	}, nil
+}
+
+func (l *specLoader) callOf(node *yaml.Node, caller string) (Statement, error) {
+	fields, err := l.mapping(node, "callee", "args", "typeArgs", "spread", "multiline", "chain")
+	if err != nil {
+		return nil, err
+	}
+	call, err := l.chainedCall(node, fields, nil, caller)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := l.sequence(fields["chain"])
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		f, err := l.mapping(item, "callee", "args", "typeArgs", "spread", "multiline")
+		if err != nil {
+			return nil, err
+		}
+		if call, err = l.chainedCall(item, f, call, l.position(item)); err != nil {
+			return nil, err
+		}
+	}
+	return call, nil
+}
+
+func (l *specLoader) chainedCall(node *yaml.Node, fields map[string]*yaml.Node, receiver *Call, caller string) (*Call, error) {
+	callee, err := l.requiredStr(node, fields, "callee")
+	if err != nil {
+		return nil, err
+	}
+	args, err := l.expressions(fields["args"])
+	if err != nil {
+		return nil, err
+	}
+	typeArgs, err := l.strs(fields["typeArgs"])
+	if err != nil {
+		return nil, err
+	}
+	spread, err := l.boolean(fields["spread"], false)
+	if err != nil {
+		return nil, err
+	}
+	multiline, err := l.boolean(fields["multiline"], false)
+	if err != nil {
+		return nil, err
+	}
+	return &Call{
+		receiver:           receiver,
+		callee:             callee,
+		typeArguments:      typeArgs,
+		arguments:          args,
+		spread:             spread,
+		multilineArguments: multiline,
+		caller:             caller,
+	}, nil
+}
+
+func (l *specLoader) ifOf(node *yaml.Node, caller string) (Statement, error) {
+	fields, err := l.mapping(node, "init", "cond", "body", "elseIf", "else")
+	if err != nil {
+		return nil, err
+	}
+	init, err := l.optionalExpression(fields["init"])
+	if err != nil {
+		return nil, err
+	}
+	cond, err := l.requiredStr(node, fields, "cond")
+	if err != nil {
+		return nil, err
+	}
+	statements, err := l.statements(fields["body"])
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := l.sequence(fields["elseIf"])
+	if err != nil {
+		return nil, err
+	}
+	elseIfBlocks := make([]*ElseIf, 0, len(items))
+	for _, item := range items {
+		f, err := l.mapping(item, "init", "cond", "body")
+		if err != nil {
+			return nil, err
+		}
+		elseIfInit, err := l.optionalExpression(f["init"])
+		if err != nil {
+			return nil, err
+		}
+		elseIfCond, err := l.requiredStr(item, f, "cond")
+		if err != nil {
+			return nil, err
+		}
+		elseIfStatements, err := l.statements(f["body"])
+		if err != nil {
+			return nil, err
+		}
+		elseIfBlocks = append(elseIfBlocks, &ElseIf{
+			initStatement: elseIfInit,
+			condition:     elseIfCond,
+			statements:    elseIfStatements,
+			caller:        l.position(item),
+		})
+	}
+
+	var elseBlock *Else
+	if n := fields["else"]; n != nil {
+		elseStatements, err := l.statements(n)
+		if err != nil {
+			return nil, err
+		}
+		elseBlock = &Else{
+			statements: elseStatements,
+			caller:     l.position(n),
+		}
+	}
+
+	return &If{
+		initStatement: init,
+		condition:     cond,
+		statements:    statements,
+		elseIfBlocks:  elseIfBlocks,
+		elseBlock:     elseBlock,
+		caller:        caller,
+	}, nil
+}
+
+func (l *specLoader) switchOf(node *yaml.Node, caller string) (Statement, error) {
+	fields, err := l.mapping(node, "init", "cond", "cases", "default")
+	if err != nil {
+		return nil, err
+	}
+	init, err := l.optionalExpression(fields["init"])
+	if err != nil {
+		return nil, err
+	}
+	cond, err := l.str(fields["cond"])
+	if err != nil {
+		return nil, err
+	}
+	cases, err := l.cases(fields["cases"], "values", "fallthrough")
+	if err != nil {
+		return nil, err
+	}
+	defaultCase, err := l.defaultCase(fields["default"])
+	if err != nil {
+		return nil, err
+	}
+
+	return &Switch{
+		initStatement:    init,
+		condition:        cond,
+		caseStatements:   cases,
+		defaultStatement: defaultCase,
+		caller:           caller,
+	}, nil
+}
+
+func (l *specLoader) typeSwitchOf(node *yaml.Node, caller string) (Statement, error) {
+	fields, err := l.mapping(node, "init", "bind", "expr", "cases", "default")
+	if err != nil {
+		return nil, err
+	}
+	init, err := l.optionalExpression(fields["init"])
+	if err != nil {
+		return nil, err
+	}
+	binding, err := l.str(fields["bind"])
+	if err != nil {
+		return nil, err
+	}
+	expr, err := l.requiredStr(node, fields, "expr")
+	if err != nil {
+		return nil, err
+	}
+	cases, err := l.cases(fields["cases"], "types")
+	if err != nil {
+		return nil, err
+	}
+	defaultCase, err := l.defaultCase(fields["default"])
+	if err != nil {
+		return nil, err
+	}
+
+	return &TypeSwitch{
+		initStatement:    init,
+		binding:          binding,
+		expression:       expr,
+		caseStatements:   cases,
+		defaultStatement: defaultCase,
+		caller:           caller,
+	}, nil
+}
+
+// cases builds the cases of `switch`; `keys` are the key of the conditions and the optional key of `fallthrough`.
+func (l *specLoader) cases(node *yaml.Node, keys ...string) ([]*Case, error) {
+	items, err := l.sequence(node)
+	if err != nil {
+		return nil, err
+	}
+	cases := make([]*Case, 0, len(items))
+	for _, item := range items {
+		f, err := l.mapping(item, append([]string{"body"}, keys...)...)
+		if err != nil {
+			return nil, err
+		}
+		conditions, err := l.strs(f[keys[0]])
+		if err != nil {
+			return nil, err
+		}
+		statements, err := l.statements(f["body"])
+		if err != nil {
+			return nil, err
+		}
+		withFallthrough := false
+		if len(keys) > 1 {
+			if withFallthrough, err = l.boolean(f[keys[1]], false); err != nil {
+				return nil, err
+			}
+		}
+		cases = append(cases, &Case{
+			conditions:      conditions,
+			statements:      statements,
+			withFallthrough: withFallthrough,
+			caller:          l.position(item),
+		})
+	}
+	return cases, nil
+}
+
+func (l *specLoader) defaultCase(node *yaml.Node) (*DefaultCase, error) {
+	if node == nil {
+		return nil, nil
+	}
+	statements, err := l.statements(node)
+	if err != nil {
+		return nil, err
+	}
+	return &DefaultCase{
+		statements: statements,
+		caller:     l.position(node),
+	}, nil
+}
+
+func (l *specLoader) forOf(node *yaml.Node, caller string) (Statement, error) {
+	fields, err := l.mapping(node, "init", "cond", "post", "body")
+	if err != nil {
+		return nil, err
+	}
+	init, err := l.optionalExpression(fields["init"])
+	if err != nil {
+		return nil, err
+	}
+	cond, err := l.str(fields["cond"])
+	if err != nil {
+		return nil, err
+	}
+	post, err := l.optionalExpression(fields["post"])
+	if err != nil {
+		return nil, err
+	}
+	statements, err := l.statements(fields["body"])
+	if err != nil {
+		return nil, err
+	}
+
+	return &For{
+		initStatement: init,
+		condition:     cond,
+		postStatement: post,
+		statements:    statements,
+		caller:        caller,
+	}, nil
+}
+
+func (l *specLoader) forRangeOf(node *yaml.Node, caller string) (Statement, error) {
+	fields, err := l.mapping(node, "key", "value", "expr", "define", "body")
+	if err != nil {
+		return nil, err
+	}
+	key, err := l.str(fields["key"])
+	if err != nil {
+		return nil, err
+	}
+	value, err := l.str(fields["value"])
+	if err != nil {
+		return nil, err
+	}
+	expr, err := l.requiredStr(node, fields, "expr")
+	if err != nil {
+		return nil, err
+	}
+	define, err := l.boolean(fields["define"], true)
+	if err != nil {
+		return nil, err
+	}
+	statements, err := l.statements(fields["body"])
+	if err != nil {
+		return nil, err
+	}
+
+	return &ForRange{
+		key:        key,
+		value:      value,
+		expression: expr,
+		define:     define,
+		statements: statements,
+		caller:     caller,
+	}, nil
+}
+
+func (l *specLoader) selectOf(node *yaml.Node, caller string) (Statement, error) {
+	items, err := l.sequence(node)
+	if err != nil {
+		return nil, err
+	}
+	cases := make([]*SelectCase, 0, len(items))
+	for _, item := range items {
+		f, err := l.mapping(item, "vars", "define", "chan", "value", "body")
+		if err != nil {
+			return nil, err
+		}
+		variables, err := l.strs(f["vars"])
+		if err != nil {
+			return nil, err
+		}
+		define, err := l.boolean(f["define"], true)
+		if err != nil {
+			return nil, err
+		}
+		channel, err := l.str(f["chan"])
+		if err != nil {
+			return nil, err
+		}
+		value, err := l.str(f["value"])
+		if err != nil {
+			return nil, err
+		}
+		statements, err := l.statements(f["body"])
+		if err != nil {
+			return nil, err
+		}
+
+		kind := selectDefaultCase
+		if f["value"] != nil {
+			kind = selectSendCase
+		} else if f["chan"] != nil {
+			kind = selectRecvCase
+		}
+		cases = append(cases, &SelectCase{
+			kind:       kind,
+			variables:  variables,
+			define:     define,
+			channel:    channel,
+			value:      value,
+			statements: statements,
+			caller:     l.position(item),
+		})
+	}
+
+	return &Select{
+		caseStatements: cases,
+		caller:         caller,
+	}, nil
+}
+
+func (l *specLoader) varOf(node *yaml.Node, caller string) (Statement, error) {
+	fields, err := l.mapping(node, "names", "type", "values")
+	if err != nil {
+		return nil, err
+	}
+	names, err := l.strs(fields["names"])
+	if err != nil {
+		return nil, err
+	}
+	typ, err := l.str(fields["type"])
+	if err != nil {
+		return nil, err
+	}
+	values, err := l.expressions(fields["values"])
+	if err != nil {
+		return nil, err
+	}
+
+	return &Var{
+		names:  names,
+		typ:    typ,
+		values: values,
+		caller: caller,
+	}, nil
+}
+
+func (l *specLoader) constOf(node *yaml.Node, caller string) (Statement, error) {
+	items := []*yaml.Node{node}
+	if node.Kind != yaml.MappingNode {
+		var err error
+		if items, err = l.sequence(node); err != nil {
+			return nil, err
+		}
+	}
+
+	specs := make([]*ConstSpec, 0, len(items))
+	for _, item := range items {
+		f, err := l.mapping(item, "names", "type", "values")
+		if err != nil {
+			return nil, err
+		}
+		names, err := l.strs(f["names"])
+		if err != nil {
+			return nil, err
+		}
+		typ, err := l.str(f["type"])
+		if err != nil {
+			return nil, err
+		}
+		values, err := l.expressions(f["values"])
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, &ConstSpec{
+			names:  names,
+			typ:    typ,
+			values: values,
+			caller: l.position(item),
+		})
+	}
+
+	return &Const{
+		specs:  specs,
+		caller: caller,
+	}, nil
+}
+
+func (l *specLoader) assignOf(node *yaml.Node, caller string) (Statement, error) {
+	fields, err := l.mapping(node, "lhs", "op", "rhs")
+	if err != nil {
+		return nil, err
+	}
+	lhs, err := l.strs(fields["lhs"])
+	if err != nil {
+		return nil, err
+	}
+	operator, err := l.str(fields["op"])
+	if err != nil {
+		return nil, err
+	}
+	if operator == "" {
+		operator = "="
+	}
+	rhs, err := l.expressions(fields["rhs"])
+	if err != nil {
+		return nil, err
+	}
+
+	return &Assign{
+		leftHandSides:  lhs,
+		operator:       operator,
+		rightHandSides: rhs,
+		caller:         caller,
+	}, nil
+}
+
+func (l *specLoader) literalOf(node *yaml.Node, caller string) (Statement, error) {
+	fields, err := l.mapping(node, "type", "fields")
+	if err != nil {
+		return nil, err
+	}
+	typ, err := l.str(fields["type"])
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := l.sequence(fields["fields"])
+	if err != nil {
+		return nil, err
+	}
+	literalFields := make([]*compositeLiteralField, 0, len(items))
+	callers := make([]string, 0, len(items))
+	for _, item := range items {
+		f, err := l.mapping(item, "key", "value")
+		if err != nil {
+			return nil, err
+		}
+		key, err := l.str(f["key"])
+		if err != nil {
+			return nil, err
+		}
+		valueNode, err := l.required(item, f, "value")
+		if err != nil {
+			return nil, err
+		}
+		value, err := l.expression(valueNode)
+		if err != nil {
+			return nil, err
+		}
+		literalFields = append(literalFields, &compositeLiteralField{
+			key:   key,
+			value: value,
+		})
+		callers = append(callers, l.position(item))
+	}
+
+	return &CompositeLiteral{
+		typ:     typ,
+		fields:  literalFields,
+		callers: callers,
+		caller:  caller,
+	}, nil
+}
+
+func (l *specLoader) enumOf(node *yaml.Node, caller string) (Statement, error) {
+	fields, err := l.mapping(node, "name", "type", "values", "unknown", "bitFlags")
+	if err != nil {
+		return nil, err
+	}
+	name, err := l.requiredStr(node, fields, "name")
+	if err != nil {
+		return nil, err
+	}
+	typ, err := l.str(fields["type"])
+	if err != nil {
+		return nil, err
+	}
+	bitFlags, err := l.boolean(fields["bitFlags"], false)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := l.sequence(fields["values"])
+	if err != nil {
+		return nil, err
+	}
+	values := make([]*EnumValue, 0, len(items))
+	valuesCallers := make([]string, 0, len(items))
+	for _, item := range items {
+		value, err := l.enumValue(item)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+		valuesCallers = append(valuesCallers, l.position(item))
+	}
+	var unknownValue *EnumValue
+	if n := fields["unknown"]; n != nil {
+		if unknownValue, err = l.enumValue(n); err != nil {
+			return nil, err
+		}
+	}
+
+	return &Enum{
+		typeName:       name,
+		underlyingType: typ,
+		values:         values,
+		unknownValue:   unknownValue,
+		bitFlags:       bitFlags,
+		caller:         caller,
+		valuesCallers:  valuesCallers,
+	}, nil
+}
+
+func (l *specLoader) enumValue(node *yaml.Node) (*EnumValue, error) {
+	node = resolveYAMLAlias(node)
+	if node.Kind == yaml.ScalarNode {
+		return &EnumValue{
+			name: node.Value,
+		}, nil
+	}
+
+	fields, err := l.mapping(node, "name", "string", "value")
+	if err != nil {
+		return nil, err
+	}
+	name, err := l.requiredStr(node, fields, "name")
+	if err != nil {
+		return nil, err
+	}
+	stringName, err := l.str(fields["string"])
+	if err != nil {
+		return nil, err
+	}
+	value, err := l.str(fields["value"])
+	if err != nil {
+		return nil, err
+	}
+	return &EnumValue{
+		name:       name,
+		stringName: stringName,
+		value:      value,
+	}, nil
+}
+
+// mapping returns the values of the mapping by the keys; the key that is not in `keys` is an error.
+func (l *specLoader) mapping(node *yaml.Node, keys ...string) (map[string]*yaml.Node, error) {
+	node = resolveYAMLAlias(node)
+	if node.Kind != yaml.MappingNode {
+		return nil, l.errorf(node, "mapping is expected")
+	}
+
+	fields := make(map[string]*yaml.Node, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i]
+		known := false
+		for _, k := range keys {
+			if k == key.Value {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, l.errorf(key, "unknown key '%s'; it must be one of %s", key.Value, strings.Join(keys, ", "))
+		}
+		if _, ok := fields[key.Value]; ok {
+			return nil, l.errorf(key, "key '%s' is duplicated", key.Value)
+		}
+		fields[key.Value] = resolveYAMLAlias(node.Content[i+1])
+	}
+	return fields, nil
+}
+
+func (l *specLoader) required(node *yaml.Node, fields map[string]*yaml.Node, key string) (*yaml.Node, error) {
+	value := fields[key]
+	if value == nil {
+		return nil, l.errorf(node, "'%s' is required", key)
+	}
+	return value, nil
+}
+
+func (l *specLoader) requiredStr(node *yaml.Node, fields map[string]*yaml.Node, key string) (string, error) {
+	value, err := l.required(node, fields, key)
+	if err != nil {
+		return "", err
+	}
+	s, err := l.str(value)
+	if err != nil {
+		return "", err
+	}
+	if s == "" {
+		return "", l.errorf(value, "'%s' is empty", key)
+	}
+	return s, nil
+}
+
+// str returns the string of the scalar; the missing value and the null are the empty string.
+func (l *specLoader) str(node *yaml.Node) (string, error) {
+	if node == nil {
+		return "", nil
+	}
+	node = resolveYAMLAlias(node)
+	if node.Kind != yaml.ScalarNode {
+		return "", l.errorf(node, "string is expected")
+	}
+	if node.ShortTag() == "!!null" {
+		return "", nil
+	}
+	return node.Value, nil
+}
+
+// strs returns the strings of the sequence; the scalar is the sequence that has only one item.
+func (l *specLoader) strs(node *yaml.Node) ([]string, error) {
+	if node == nil {
+		return nil, nil
+	}
+	node = resolveYAMLAlias(node)
+	if node.Kind == yaml.ScalarNode {
+		s, err := l.str(node)
+		if err != nil || s == "" {
+			return nil, err
+		}
+		return []string{s}, nil
+	}
+
+	items, err := l.sequence(node)
+	if err != nil {
+		return nil, err
+	}
+	ss := make([]string, 0, len(items))
+	for _, item := range items {
+		s, err := l.str(item)
+		if err != nil {
+			return nil, err
+		}
+		ss = append(ss, s)
+	}
+	return ss, nil
+}
+
+func (l *specLoader) boolean(node *yaml.Node, defaultValue bool) (bool, error) {
+	if node == nil {
+		return defaultValue, nil
+	}
+	node = resolveYAMLAlias(node)
+	if node.Kind != yaml.ScalarNode || node.ShortTag() != "!!bool" {
+		return false, l.errorf(node, "boolean is expected")
+	}
+	var b bool
+	if err := node.Decode(&b); err != nil {
+		return false, l.errorf(node, "%s", err)
+	}
+	return b, nil
+}
+
+// sequence returns the items of the sequence; the missing value and the null are the empty sequence.
+func (l *specLoader) sequence(node *yaml.Node) ([]*yaml.Node, error) {
+	if node == nil {
+		return nil, nil
+	}
+	node = resolveYAMLAlias(node)
+	if node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null" {
+		return nil, nil
+	}
+	if node.Kind != yaml.SequenceNode {
+		return nil, l.errorf(node, "sequence is expected")
+	}
+
+	items := make([]*yaml.Node, len(node.Content))
+	for i, item := range node.Content {
+		items[i] = resolveYAMLAlias(item)
+	}
+	return items, nil
+}
+
+func resolveYAMLAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	return node
+}
@@ -0,0 +1,51 @@
+package generator
+
+import (
+	"fmt"
+	"log"
+)
+
+func ExampleParseSpec() {
+	spec, err := ParseSpec("spec.yaml", []byte(`
+files:
+  user.go:
+    gofmt: true
+    statements:
+      - package: user
+      - newline:
+      - struct:
+          name: User
+          fields:
+            - {name: ID, type: int64, tag: 'json:"id"'}
+      - newline:
+      - func:
+          receiver: {name: u, type: "*User"}
+          name: IsValid
+          results: [bool]
+          body:
+            - return: u.ID > 0
+`))
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	files, err := spec.Generate()
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(files["user.go"])
+}
+
+func ExampleSpec_YAML() {
+	spec := NewSpec(NewSpecFile("user.go", NewRoot(
+		NewPackage("user"),
+		NewNewline(),
+		NewStruct("User").AddField("ID", "int64", `json:"id"`),
+	).Gofmt()))
+
+	exported, err := spec.YAML()
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(string(exported))
+}
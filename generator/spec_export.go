@@ -0,0 +1,645 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// YAML exports `Spec` as the YAML document that `ParseSpec()` reads.
+// The generator that doesn't have its kind in the spec (e.g. `Mock` and `JSONTypes`) is exported as `raw` of the generated code,
+// so the spec generates the same code; the struct that has the custom `TagNamingStrategy` is also exported as `raw`.
+func (s *Spec) YAML() ([]byte, error) {
+	node, err := s.node()
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// JSON exports `Spec` as the JSON document that `ParseSpec()` reads; please see also `YAML()`.
+func (s *Spec) JSON() ([]byte, error) {
+	node, err := s.node()
+	if err != nil {
+		return nil, err
+	}
+
+	var compact bytes.Buffer
+	if err := writeYAMLNodeAsJSON(&compact, node); err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, compact.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+func (s *Spec) node() (*yaml.Node, error) {
+	files := specMapping()
+	for _, f := range s.files {
+		root, err := exportSpecRoot(f.root)
+		if err != nil {
+			return nil, err
+		}
+		files.Content = append(files.Content, specString(f.path), root)
+	}
+
+	doc := specMapping()
+	doc.Content = append(doc.Content, specString("files"), files)
+	return doc, nil
+}
+
+func exportSpecRoot(root *Root) (*yaml.Node, error) {
+	node := specMapping()
+	if len(root.gofmtOptions) > 0 {
+		specSet(node, "gofmt", specStrings(root.gofmtOptions))
+	} else if root.gofmt {
+		specSet(node, "gofmt", specBool(true))
+	}
+	if root.goimports {
+		specSet(node, "goimports", specBool(true))
+	}
+	if root.syntaxChecking {
+		specSet(node, "syntaxChecking", specBool(true))
+	}
+	if root.typeCheckDir != "" {
+		specSet(node, "typeChecking", specString(root.typeCheckDir))
+	} else if root.typeChecking {
+		specSet(node, "typeChecking", specBool(true))
+	}
+
+	statements, err := exportSpecStatements(root.statements)
+	if err != nil {
+		return nil, err
+	}
+	specSet(node, "statements", statements)
+	return node, nil
+}
+
+func exportSpecStatements(statements []Statement) (*yaml.Node, error) {
+	node := specSequence()
+	for _, stmt := range statements {
+		if stmt == nil {
+			continue
+		}
+		item, err := exportSpecStatement(stmt, false)
+		if err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, item)
+	}
+	return node, nil
+}
+
+func exportSpecExpressions(exprs []Statement) (*yaml.Node, error) {
+	node := specSequence()
+	for _, expr := range exprs {
+		item, err := exportSpecStatement(expr, true)
+		if err != nil {
+			return nil, err
+		}
+		node.Content = append(node.Content, item)
+	}
+	return node, nil
+}
+
+// exportSpecStatement exports the statement; `expression` means the statement is used as an expression,
+// where the string is the raw expression without the newline.
+func exportSpecStatement(stmt Statement, expression bool) (*yaml.Node, error) {
+	switch s := stmt.(type) {
+	case *RawStatement:
+		if s.withNewline != expression {
+			return specString(s.statement), nil
+		}
+		if s.withNewline {
+			return specKind("raw", specString(s.statement)), nil
+		}
+		raw := specMapping()
+		specSet(raw, "code", specString(s.statement))
+		specSet(raw, "newline", specBool(false))
+		return specKind("raw", raw), nil
+	case *Comment:
+		return specKind("comment", specString(s.comment)), nil
+	case *Newline:
+		return specKind("newline", specNull()), nil
+	case *Package:
+		return specKind("package", specString(s.name)), nil
+	case *Import:
+		return specKind("import", specStrings(s.names)), nil
+	case *Struct:
+		if node, ok := exportSpecStruct(s); ok {
+			return specKind("struct", node), nil
+		}
+	case *Interface:
+		node := specMapping()
+		specSet(node, "name", specString(s.name))
+		methods := specSequence()
+		for _, sig := range s.funcSignatures {
+			methods.Content = append(methods.Content, exportSpecFuncSignature(sig, specMapping()))
+		}
+		specSet(node, "methods", methods)
+		return specKind("interface", node), nil
+	case *Func:
+		node := specMapping()
+		if s.funcReceiver != nil {
+			receiver := specMapping()
+			specSetString(receiver, "name", s.funcReceiver.name)
+			specSet(receiver, "type", specString(s.funcReceiver.typ))
+			specSet(node, "receiver", receiver)
+		}
+		if s.funcSignature != nil {
+			exportSpecFuncSignature(s.funcSignature, node)
+		}
+		if err := specSetStatements(node, "body", s.statements); err != nil {
+			return nil, err
+		}
+		return specKind("func", node), nil
+	case *AnonymousFunc:
+		node := specMapping()
+		if s.goFunc {
+			specSet(node, "go", specBool(true))
+		}
+		if sig := s.anonymousFuncSignature; sig != nil {
+			if len(sig.funcParameters) > 0 {
+				specSet(node, "params", exportSpecFuncParameters(sig.funcParameters))
+			}
+			if len(sig.returnTypes) > 0 {
+				specSet(node, "results", specStrings(sig.returnTypes))
+			}
+		}
+		if err := specSetStatements(node, "body", s.statements); err != nil {
+			return nil, err
+		}
+		if s.funcInvocation != nil {
+			specSet(node, "invoke", specStrings(s.funcInvocation.parameters))
+		}
+		return specKind("anonymousFunc", node), nil
+	case *Call:
+		var calls []*Call
+		for c := s; c != nil; c = c.receiver {
+			calls = append([]*Call{c}, calls...)
+		}
+		node, err := exportSpecCall(calls[0])
+		if err != nil {
+			return nil, err
+		}
+		if len(calls) > 1 {
+			chain := specSequence()
+			for _, c := range calls[1:] {
+				item, err := exportSpecCall(c)
+				if err != nil {
+					return nil, err
+				}
+				chain.Content = append(chain.Content, item)
+			}
+			specSet(node, "chain", chain)
+		}
+		return specKind("call", node), nil
+	case *If:
+		node := specMapping()
+		if err := specSetExpression(node, "init", s.initStatement); err != nil {
+			return nil, err
+		}
+		specSet(node, "cond", specString(s.condition))
+		if err := specSetStatements(node, "body", s.statements); err != nil {
+			return nil, err
+		}
+		if len(s.elseIfBlocks) > 0 {
+			elseIfBlocks := specSequence()
+			for _, elseIf := range s.elseIfBlocks {
+				item := specMapping()
+				if err := specSetExpression(item, "init", elseIf.initStatement); err != nil {
+					return nil, err
+				}
+				specSet(item, "cond", specString(elseIf.condition))
+				if err := specSetStatements(item, "body", elseIf.statements); err != nil {
+					return nil, err
+				}
+				elseIfBlocks.Content = append(elseIfBlocks.Content, item)
+			}
+			specSet(node, "elseIf", elseIfBlocks)
+		}
+		if s.elseBlock != nil {
+			statements, err := exportSpecStatements(s.elseBlock.statements)
+			if err != nil {
+				return nil, err
+			}
+			specSet(node, "else", statements)
+		}
+		return specKind("if", node), nil
+	case *Switch:
+		node := specMapping()
+		if err := specSetExpression(node, "init", s.initStatement); err != nil {
+			return nil, err
+		}
+		specSetString(node, "cond", s.condition)
+		if err := exportSpecCases(node, s.caseStatements, s.defaultStatement, "values"); err != nil {
+			return nil, err
+		}
+		return specKind("switch", node), nil
+	case *TypeSwitch:
+		node := specMapping()
+		if err := specSetExpression(node, "init", s.initStatement); err != nil {
+			return nil, err
+		}
+		specSetString(node, "bind", s.binding)
+		specSet(node, "expr", specString(s.expression))
+		if err := exportSpecCases(node, s.caseStatements, s.defaultStatement, "types"); err != nil {
+			return nil, err
+		}
+		return specKind("typeSwitch", node), nil
+	case *For:
+		node := specMapping()
+		if err := specSetExpression(node, "init", s.initStatement); err != nil {
+			return nil, err
+		}
+		specSetString(node, "cond", s.condition)
+		if err := specSetExpression(node, "post", s.postStatement); err != nil {
+			return nil, err
+		}
+		if err := specSetStatements(node, "body", s.statements); err != nil {
+			return nil, err
+		}
+		return specKind("for", node), nil
+	case *ForRange:
+		node := specMapping()
+		specSetString(node, "key", s.key)
+		specSetString(node, "value", s.value)
+		specSet(node, "expr", specString(s.expression))
+		if !s.define {
+			specSet(node, "define", specBool(false))
+		}
+		if err := specSetStatements(node, "body", s.statements); err != nil {
+			return nil, err
+		}
+		return specKind("forRange", node), nil
+	case *Select:
+		node := specSequence()
+		for _, sc := range s.caseStatements {
+			item := specMapping()
+			switch sc.kind {
+			case selectRecvCase:
+				if len(sc.variables) > 0 {
+					specSet(item, "vars", specStrings(sc.variables))
+					if !sc.define {
+						specSet(item, "define", specBool(false))
+					}
+				}
+				specSet(item, "chan", specString(sc.channel))
+			case selectSendCase:
+				specSet(item, "chan", specString(sc.channel))
+				specSet(item, "value", specString(sc.value))
+			}
+			if err := specSetStatements(item, "body", sc.statements); err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, item)
+		}
+		return specKind("select", node), nil
+	case *ReturnStatement:
+		items, err := exportSpecExpressions(s.returnItems)
+		if err != nil {
+			return nil, err
+		}
+		return specKind("return", items), nil
+	case *Var:
+		node := specMapping()
+		specSet(node, "names", specStrings(s.names))
+		specSetString(node, "type", s.typ)
+		if err := specSetExpressions(node, "values", s.values); err != nil {
+			return nil, err
+		}
+		return specKind("var", node), nil
+	case *Const:
+		node := specSequence()
+		for _, spec := range s.specs {
+			item := specMapping()
+			specSet(item, "names", specStrings(spec.names))
+			specSetString(item, "type", spec.typ)
+			if err := specSetExpressions(item, "values", spec.values); err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, item)
+		}
+		return specKind("const", node), nil
+	case *Assign:
+		node := specMapping()
+		specSet(node, "lhs", specStrings(s.leftHandSides))
+		if s.operator != "=" {
+			specSet(node, "op", specString(s.operator))
+		}
+		if err := specSetExpressions(node, "rhs", s.rightHandSides); err != nil {
+			return nil, err
+		}
+		return specKind("assign", node), nil
+	case *CodeBlock:
+		statements, err := exportSpecStatements(s.statements)
+		if err != nil {
+			return nil, err
+		}
+		return specKind("block", statements), nil
+	case *Defer:
+		call, err := exportSpecStatement(s.statement, true)
+		if err != nil {
+			return nil, err
+		}
+		return specKind("defer", call), nil
+	case *Go:
+		call, err := exportSpecStatement(s.statement, true)
+		if err != nil {
+			return nil, err
+		}
+		return specKind("go", call), nil
+	case *Label:
+		return specKind("label", specString(s.name)), nil
+	case *Branch:
+		if s.label == "" {
+			return specKind(s.keyword, specNull()), nil
+		}
+		return specKind(s.keyword, specString(s.label)), nil
+	case *IncDec:
+		if s.operator == "--" {
+			return specKind("dec", specString(s.target)), nil
+		}
+		return specKind("inc", specString(s.target)), nil
+	case *CompositeLiteral:
+		node := specMapping()
+		specSetString(node, "type", s.typ)
+		fields := specSequence()
+		for _, field := range s.fields {
+			item := specMapping()
+			specSetString(item, "key", field.key)
+			value, err := exportSpecStatement(field.value, true)
+			if err != nil {
+				return nil, err
+			}
+			specSet(item, "value", value)
+			fields.Content = append(fields.Content, item)
+		}
+		specSet(node, "fields", fields)
+		return specKind("literal", node), nil
+	case *Enum:
+		node := specMapping()
+		specSet(node, "name", specString(s.typeName))
+		specSetString(node, "type", s.underlyingType)
+		values := specSequence()
+		for _, value := range s.values {
+			values.Content = append(values.Content, exportSpecEnumValue(value))
+		}
+		specSet(node, "values", values)
+		if s.unknownValue != nil {
+			specSet(node, "unknown", exportSpecEnumValue(s.unknownValue))
+		}
+		if s.bitFlags {
+			specSet(node, "bitFlags", specBool(true))
+		}
+		return specKind("enum", node), nil
+	}
+
+	// the generator that doesn't have its kind falls back to the raw code
+	generated, err := stmt.Generate(0)
+	if err != nil {
+		return nil, err
+	}
+	return exportSpecStatement(&RawStatement{
+		statement:   strings.TrimSuffix(generated, "\n"),
+		withNewline: !expression,
+	}, expression)
+}
+
+// exportSpecStruct exports the struct; it is not exportable if the struct has the custom tag naming strategy.
+func exportSpecStruct(s *Struct) (*yaml.Node, bool) {
+	node := specMapping()
+	specSet(node, "name", specString(s.name))
+
+	fields := specSequence()
+	for _, field := range s.fields {
+		item := specMapping()
+		if field.embedded {
+			if field.name != embeddedFieldName(field.typ) {
+				specSet(item, "name", specString(field.name))
+			}
+		} else {
+			specSet(item, "name", specString(field.name))
+		}
+		specSet(item, "type", specString(field.typ))
+		specSetString(item, "tag", field.tag)
+		specSetString(item, "comment", field.comment)
+		if field.embedded {
+			specSet(item, "embedded", specBool(true))
+		}
+		fields.Content = append(fields.Content, item)
+	}
+	specSet(node, "fields", fields)
+
+	if len(s.tagNamings) > 0 {
+		tagNamings := specSequence()
+		for _, naming := range s.tagNamings {
+			strategyName := ""
+			for name, strategy := range specTagNamingStrategies {
+				if reflect.ValueOf(strategy).Pointer() == reflect.ValueOf(naming.strategy).Pointer() {
+					strategyName = name
+				}
+			}
+			if strategyName == "" {
+				return nil, false
+			}
+
+			item := specMapping()
+			specSet(item, "key", specString(naming.key))
+			specSet(item, "strategy", specString(strategyName))
+			tagNamings.Content = append(tagNamings.Content, item)
+		}
+		specSet(node, "tagNamings", tagNamings)
+	}
+	return node, true
+}
+
+// exportSpecFuncSignature sets the keys of the signature to `node`, and returns `node`.
+func exportSpecFuncSignature(sig *FuncSignature, node *yaml.Node) *yaml.Node {
+	specSetString(node, "name", sig.funcName)
+	if len(sig.funcParameters) > 0 {
+		specSet(node, "params", exportSpecFuncParameters(sig.funcParameters))
+	}
+	if len(sig.returnTypes) > 0 {
+		results := specSequence()
+		for _, returnType := range sig.returnTypes {
+			if returnType.name == "" {
+				results.Content = append(results.Content, specString(returnType.typ))
+				continue
+			}
+			item := specMapping()
+			specSet(item, "name", specString(returnType.name))
+			specSet(item, "type", specString(returnType.typ))
+			results.Content = append(results.Content, item)
+		}
+		specSet(node, "results", results)
+	}
+	return node
+}
+
+func exportSpecFuncParameters(params []*FuncParameter) *yaml.Node {
+	node := specSequence()
+	for _, param := range params {
+		item := specMapping()
+		specSetString(item, "name", param.name)
+		specSetString(item, "type", param.typ)
+		node.Content = append(node.Content, item)
+	}
+	return node
+}
+
+func exportSpecCall(c *Call) (*yaml.Node, error) {
+	node := specMapping()
+	specSet(node, "callee", specString(c.callee))
+	if err := specSetExpressions(node, "args", c.arguments); err != nil {
+		return nil, err
+	}
+	if len(c.typeArguments) > 0 {
+		specSet(node, "typeArgs", specStrings(c.typeArguments))
+	}
+	if c.spread {
+		specSet(node, "spread", specBool(true))
+	}
+	if c.multilineArguments {
+		specSet(node, "multiline", specBool(true))
+	}
+	return node, nil
+}
+
+func exportSpecCases(node *yaml.Node, cases []*Case, defaultCase *DefaultCase, conditionsKey string) error {
+	if len(cases) > 0 {
+		items := specSequence()
+		for _, c := range cases {
+			item := specMapping()
+			specSet(item, conditionsKey, specStrings(c.conditions))
+			if err := specSetStatements(item, "body", c.statements); err != nil {
+				return err
+			}
+			if c.withFallthrough {
+				specSet(item, "fallthrough", specBool(true))
+			}
+			items.Content = append(items.Content, item)
+		}
+		specSet(node, "cases", items)
+	}
+	if defaultCase != nil {
+		statements, err := exportSpecStatements(defaultCase.statements)
+		if err != nil {
+			return err
+		}
+		specSet(node, "default", statements)
+	}
+	return nil
+}
+
+func exportSpecEnumValue(value *EnumValue) *yaml.Node {
+	if value.stringName == "" && value.value == "" {
+		return specString(value.name)
+	}
+	node := specMapping()
+	specSet(node, "name", specString(value.name))
+	specSetString(node, "string", value.stringName)
+	specSetString(node, "value", value.value)
+	return node
+}
+
+func specKind(kind string, value *yaml.Node) *yaml.Node {
+	node := specMapping()
+	specSet(node, kind, value)
+	return node
+}
+
+func specMapping() *yaml.Node {
+	return &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+}
+
+func specSequence() *yaml.Node {
+	return &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+}
+
+func specString(s string) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: s}
+	if strings.Contains(s, "\n") {
+		node.Style = yaml.LiteralStyle
+	}
+	return node
+}
+
+func specStrings(ss []string) *yaml.Node {
+	node := specSequence()
+	for _, s := range ss {
+		node.Content = append(node.Content, specString(s))
+	}
+	return node
+}
+
+func specBool(b bool) *yaml.Node {
+	if b {
+		return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true"}
+	}
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "false"}
+}
+
+func specNull() *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}
+}
+
+func specSet(node *yaml.Node, key string, value *yaml.Node) {
+	node.Content = append(node.Content, specString(key), value)
+}
+
+// specSetString sets the string only if it is not empty.
+func specSetString(node *yaml.Node, key string, value string) {
+	if value != "" {
+		specSet(node, key, specString(value))
+	}
+}
+
+func specSetStatements(node *yaml.Node, key string, statements []Statement) error {
+	items, err := exportSpecStatements(statements)
+	if err != nil {
+		return err
+	}
+	specSet(node, key, items)
+	return nil
+}
+
+func specSetExpression(node *yaml.Node, key string, expr Statement) error {
+	if expr == nil {
+		return nil
+	}
+	item, err := exportSpecStatement(expr, true)
+	if err != nil {
+		return err
+	}
+	specSet(node, key, item)
+	return nil
+}
+
+func specSetExpressions(node *yaml.Node, key string, exprs []Statement) error {
+	if len(exprs) <= 0 {
+		return nil
+	}
+	items, err := exportSpecExpressions(exprs)
+	if err != nil {
+		return err
+	}
+	specSet(node, key, items)
+	return nil
+}
@@ -0,0 +1,195 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldGenerateFromSpec(t *testing.T) {
+	spec, err := ParseSpec("spec.yaml", []byte(`
+files:
+  a.go:
+    statements:
+      - package: a
+      - newline:
+      - raw: "// Code generated by gowrtr. DO NOT EDIT."
+      - func:
+          name: Sum
+          params:
+            - {name: values, type: "...int"}
+          results: [int]
+          body:
+            - var: {names: [sum], values: 0}
+            - for:
+                init: {assign: {lhs: [i], op: ":=", rhs: 0}}
+                cond: i < len(values)
+                post: {inc: i}
+                body:
+                  - assign: {lhs: [sum], op: "+=", rhs: "values[i]"}
+            - defer: {call: {callee: fmt.Println, args: [sum]}}
+            - return: sum
+  b/b.go:
+    statements:
+      - package: b
+      - newline:
+      - const:
+          - {names: [A], values: '"a"'}
+          - {names: [B], values: '"b"'}
+`))
+	assert.NoError(t, err)
+	assert.Len(t, spec.Files(), 2)
+	assert.Equal(t, "a.go", spec.Files()[0].Path())
+	assert.Equal(t, "b/b.go", spec.Files()[1].Path())
+
+	files, err := spec.Generate()
+	assert.NoError(t, err)
+	assert.Equal(t, `package a
+
+// Code generated by gowrtr. DO NOT EDIT.
+func Sum(values ...int) int {
+	var sum = 0
+	for i := 0; i < len(values); i++ {
+		sum += values[i]
+	}
+	defer fmt.Println(sum)
+	return sum
+}
+`, files["a.go"])
+	assert.Equal(t, `package b
+
+const (
+	A = "a"
+	B = "b"
+)
+`, files["b/b.go"])
+}
+
+func TestShouldLoadSpecInYAMLAndJSON(t *testing.T) {
+	spec, err := LoadSpec("./testdata/spec/user.json")
+	assert.NoError(t, err)
+	files, err := spec.Generate()
+	assert.NoError(t, err)
+	assert.Equal(t, "package user\n\ntype User struct {\n\tID int64 `json:\"id\"`\n}\n", files["user.go"])
+
+	spec, err = LoadSpec("./testdata/spec/user.yaml")
+	assert.NoError(t, err)
+	files, err = spec.Generate()
+	assert.NoError(t, err)
+	assert.Contains(t, files["user.go"], "type User struct {\n\tID int64 `json:\"id\"`\n\t// Name is the name.\n\tName string `json:\"name\"`\n}\n")
+	assert.Contains(t, files["user.go"], "func (u *User) Validate() error {\n")
+
+	if testing.Short() {
+		return
+	}
+	runGoCommandsOnGeneratedPackage(t, files, []string{"vet", "."})
+}
+
+func TestShouldExportSpec(t *testing.T) {
+	root := NewRoot(
+		NewPackage("user"),
+		NewNewline(),
+		NewStruct("User").
+			AddField("ID", "int64").
+			AddEmbeddedField("*bytes.Buffer").
+			TagNaming("json", CamelCaseTagNaming),
+		NewFunc(
+			NewFuncReceiver("u", "*User"),
+			NewFuncSignature("Kind").AddReturnTypes("string"),
+			NewTypeSwitch("v", "interface{}(u)").AddCase(
+				NewCase("*User", NewReturnStatement(`"user"`)),
+			),
+			NewReturnStatement().AddReturnStatements(NewCall("fmt.Sprint").AddArguments(NewRawStatement("u.ID")).Chain("String")),
+		),
+		NewGetterMethods(NewStruct("Point").AddField("X", "int")),
+	).Gofmt("-s")
+
+	spec := NewSpec(NewSpecFile("user.go", root))
+	expected, err := root.Generate(0)
+	assert.NoError(t, err)
+
+	y, err := spec.YAML()
+	assert.NoError(t, err)
+	assert.Contains(t, string(y), "          tagNamings:\n            - key: json\n              strategy: camel\n")
+	j, err := spec.JSON()
+	assert.NoError(t, err)
+	assert.Contains(t, string(j), `"gofmt": [`)
+
+	for _, doc := range [][]byte{y, j} {
+		exported, err := ParseSpec("exported", doc)
+		assert.NoError(t, err)
+		files, err := exported.Generate()
+		assert.NoError(t, err)
+		assert.Equal(t, expected, files["user.go"])
+	}
+}
+
+func TestShouldExportStructWithCustomTagNamingAsRaw(t *testing.T) {
+	spec := NewSpec(NewSpecFile("a.go", NewRoot(
+		NewStruct("A").AddField("ID", "int").TagNaming("db", strings.ToLower),
+	)))
+
+	y, err := spec.YAML()
+	assert.NoError(t, err)
+	assert.Equal(t, "files:\n  a.go:\n    statements:\n      - |-\n        type A struct {\n        \tID int `db:\"id\"`\n        }\n", string(y))
+}
+
+func TestShouldRaiseErrorWithSpecLocation(t *testing.T) {
+	_, err := ParseSpec("spec.yaml", []byte(`files:
+  a.go:
+    statements:
+      - struct:
+          name: A
+          field: []
+`))
+	assert.Regexp(t, regexp.MustCompile(`^\`+strings.Split(errmsg.SpecIsInvalidError("", "").Error(), " ")[0]), err.Error())
+	assert.Contains(t, err.Error(), "unknown key 'field'")
+	assert.Contains(t, err.Error(), "(caused at spec.yaml:6:11)")
+
+	_, err = ParseSpec("spec.yaml", []byte(`files:
+  a.go:
+    statements:
+      - unknown: A
+`))
+	assert.Contains(t, err.Error(), "unknown kind of the statement 'unknown' (caused at spec.yaml:4:9)")
+
+	_, err = ParseSpec("spec.yaml", []byte(`files:
+  a.go:
+    statements:
+      - if: {body: []}
+`))
+	assert.Contains(t, err.Error(), "'cond' is required (caused at spec.yaml:4:13)")
+
+	_, err = ParseSpec("spec.yaml", []byte("files: [\n"))
+	assert.Regexp(t, regexp.MustCompile(`^\`+strings.Split(errmsg.SpecIsInvalidError("", "").Error(), " ")[0]), err.Error())
+}
+
+func TestShouldRaiseGenerationErrorWithSpecLocation(t *testing.T) {
+	spec, err := ParseSpec("spec.json", []byte(`{
+  "files": {
+    "a.go": {
+      "statements": [
+        {"package": "a"},
+        {"struct": {"name": "A", "fields": [
+          {"name": "ID", "type": "int"},
+          {"type": "string"}
+        ]}}
+      ]
+    }
+  }
+}`))
+	assert.NoError(t, err)
+
+	_, err = spec.Generate()
+	assert.Regexp(t, regexp.MustCompile(`^\`+strings.Split(errmsg.StructFieldNameIsEmptyErr("").Error(), " ")[0]), err.Error())
+	assert.Contains(t, err.Error(), "(caused at spec.json:8:11)")
+}
+
+func TestShouldRaiseErrorWhenSpecFileIsMissing(t *testing.T) {
+	_, err := LoadSpec("./testdata/spec/missing.yaml")
+	assert.Regexp(t, regexp.MustCompile(`^\`+strings.Split(errmsg.SpecLoadingError("", "", "").Error(), " ")[0]), err.Error())
+}
@@ -0,0 +1,419 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+)
+
+type sqlTokenKind int
+
+const (
+	sqlTokenWord sqlTokenKind = iota // keyword, unquoted identifier or number
+	sqlTokenQuoted
+	sqlTokenString
+	sqlTokenSymbol
+)
+
+type sqlToken struct {
+	kind  sqlTokenKind
+	text  string // as it is written in the schema
+	value string // unquoted
+	line  int
+}
+
+// is reports whether the token is the one of the keywords (case-insensitive).
+func (t *sqlToken) is(keywords ...string) bool {
+	if t.kind != sqlTokenWord {
+		return false
+	}
+	for _, keyword := range keywords {
+		if strings.EqualFold(t.text, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *sqlToken) isSymbol(symbol string) bool {
+	return t.kind == sqlTokenSymbol && t.text == symbol
+}
+
+func (t *sqlToken) isName() bool {
+	return t.kind == sqlTokenWord || t.kind == sqlTokenQuoted
+}
+
+// tokenizeSQL splits the SQL into the tokens with skipping the comments.
+func tokenizeSQL(sql string) ([]*sqlToken, error) {
+	var tokens []*sqlToken
+	line := 1
+	for i := 0; i < len(sql); {
+		c := sql[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f':
+			i++
+		case strings.HasPrefix(sql[i:], "--"):
+			for i < len(sql) && sql[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(sql[i:], "/*"):
+			end := strings.Index(sql[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: comment is not terminated", line)
+			}
+			line += strings.Count(sql[i:i+2+end], "\n")
+			i += end + 4
+		case c == '\'' || c == '"' || c == '`' || c == '[':
+			closing := c
+			if c == '[' {
+				closing = ']'
+			}
+			start, startLine := i, line
+			var value strings.Builder
+			for i++; ; i++ {
+				if i >= len(sql) {
+					return nil, fmt.Errorf("line %d: %c is not terminated", startLine, c)
+				}
+				if sql[i] == closing {
+					if closing != ']' && i+1 < len(sql) && sql[i+1] == closing {
+						// doubled quote is the escaped one
+						value.WriteByte(closing)
+						i++
+						continue
+					}
+					break
+				}
+				if sql[i] == '\n' {
+					line++
+				}
+				value.WriteByte(sql[i])
+			}
+			i++
+
+			kind := sqlTokenQuoted
+			if c == '\'' {
+				kind = sqlTokenString
+			}
+			tokens = append(tokens, &sqlToken{kind: kind, text: sql[start:i], value: value.String(), line: startLine})
+		case isSQLWordByte(c):
+			start := i
+			for i < len(sql) && isSQLWordByte(sql[i]) {
+				i++
+			}
+			tokens = append(tokens, &sqlToken{kind: sqlTokenWord, text: sql[start:i], value: sql[start:i], line: line})
+		default:
+			tokens = append(tokens, &sqlToken{kind: sqlTokenSymbol, text: string(c), value: string(c), line: line})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+func isSQLWordByte(c byte) bool {
+	return c == '_' || c == '$' || c >= 0x80 || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z') || ('0' <= c && c <= '9')
+}
+
+// sqlTable is a table that is declared by `CREATE TABLE`.
+type sqlTable struct {
+	name    string // unqualified and unquoted
+	sqlName string // as it is written in the schema (e.g. `public."users"`)
+	comment string
+	columns []*sqlColumn
+	line    int
+
+	// the names in golang
+	rowType    string
+	pluralName string
+}
+
+type sqlColumn struct {
+	name          string
+	sqlName       string
+	sqlType       string
+	comment       string
+	notNull       bool
+	primaryKey    bool
+	autoIncrement bool
+	generated     bool
+
+	// the field of the row in golang
+	fieldName string
+	baseType  string
+}
+
+// sqlColumnConstraintKeywords are the keywords that terminate the type of the column.
+var sqlColumnConstraintKeywords = []string{
+	"CONSTRAINT", "NOT", "NULL", "PRIMARY", "UNIQUE", "DEFAULT", "REFERENCES", "CHECK", "AUTO_INCREMENT", "AUTOINCREMENT",
+	"IDENTITY", "COLLATE", "GENERATED", "AS", "COMMENT", "ON",
+}
+
+// parseSQLTables parses `CREATE TABLE` statements of the SQL schema; the other statements are ignored.
+func parseSQLTables(sql string) ([]*sqlTable, error) {
+	tokens, err := tokenizeSQL(sql)
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []*sqlTable
+	for len(tokens) > 0 {
+		end := 0
+		for end < len(tokens) && !tokens[end].isSymbol(";") {
+			end++
+		}
+		stmt := tokens[:end]
+		if end < len(tokens) {
+			end++
+		}
+		tokens = tokens[end:]
+
+		if len(stmt) <= 0 || !stmt[0].is("CREATE") {
+			continue
+		}
+		i := 1
+		for i < len(stmt) && stmt[i].is("TEMP", "TEMPORARY", "UNLOGGED", "GLOBAL", "LOCAL") {
+			i++
+		}
+		if i >= len(stmt) || !stmt[i].is("TABLE") {
+			continue
+		}
+
+		table, err := parseSQLCreateTable(stmt[0].line, stmt[i+1:])
+		if err != nil {
+			return nil, err
+		}
+		tables = append(tables, table)
+	}
+	return tables, nil
+}
+
+// parseSQLCreateTable parses the statement that follows `CREATE TABLE`.
+func parseSQLCreateTable(line int, tokens []*sqlToken) (*sqlTable, error) {
+	if len(tokens) >= 3 && tokens[0].is("IF") && tokens[1].is("NOT") && tokens[2].is("EXISTS") {
+		tokens = tokens[3:]
+	}
+
+	table := &sqlTable{line: line}
+	i := 0
+	for ; i < len(tokens) && tokens[i].isName(); i++ {
+		table.name = tokens[i].value
+		table.sqlName += tokens[i].text
+		if i+1 >= len(tokens) || !tokens[i+1].isSymbol(".") {
+			i++
+			break
+		}
+		table.sqlName += "."
+		i++
+	}
+	if table.name == "" {
+		return nil, fmt.Errorf("line %d: name of the table is missing", line)
+	}
+	if i >= len(tokens) || !tokens[i].isSymbol("(") {
+		return nil, fmt.Errorf("line %d: table %s must have the column definitions", line, table.name)
+	}
+
+	// split the definitions by the commas at the top level of the parentheses
+	var defs [][]*sqlToken
+	depth := 0
+	start := i + 1
+	for i++; i < len(tokens); i++ {
+		switch {
+		case tokens[i].isSymbol("("):
+			depth++
+		case tokens[i].isSymbol(")") && depth > 0:
+			depth--
+		case tokens[i].isSymbol(",") && depth == 0:
+			defs = append(defs, tokens[start:i])
+			start = i + 1
+		case tokens[i].isSymbol(")"):
+			defs = append(defs, tokens[start:i])
+			start = -1
+		}
+		if start < 0 {
+			break
+		}
+	}
+	if start >= 0 {
+		return nil, fmt.Errorf("line %d: parenthesis of the table %s is not closed", line, table.name)
+	}
+	table.comment = sqlCommentOf(tokens[i+1:])
+
+	var primaryKeys []string
+	for _, def := range defs {
+		if len(def) <= 0 {
+			return nil, fmt.Errorf("line %d: definition of the table %s is empty", line, table.name)
+		}
+
+		first := def[0]
+		if first.is("CONSTRAINT") && len(def) > 2 {
+			def = def[2:]
+			first = def[0]
+		}
+		switch {
+		case first.is("PRIMARY"):
+			for _, t := range def[1:] {
+				if t.isName() && !t.is("KEY") {
+					primaryKeys = append(primaryKeys, t.value)
+				}
+			}
+			continue
+		case first.is("UNIQUE", "FOREIGN", "CHECK", "KEY", "INDEX", "FULLTEXT", "SPATIAL", "EXCLUDE", "CONSTRAINT"):
+			continue
+		}
+
+		column, err := parseSQLColumn(def)
+		if err != nil {
+			return nil, err
+		}
+		table.columns = append(table.columns, column)
+	}
+
+	for _, name := range primaryKeys {
+		column := table.column(name)
+		if column == nil {
+			return nil, fmt.Errorf("line %d: primary key %s is not a column of the table %s", line, name, table.name)
+		}
+		column.primaryKey = true
+		column.notNull = true
+	}
+	return table, nil
+}
+
+func (t *sqlTable) column(name string) *sqlColumn {
+	for _, column := range t.columns {
+		if column.name == name {
+			return column
+		}
+	}
+	return nil
+}
+
+// parseSQLColumn parses the definition of the column, i.e. the name, the type and the constraints.
+func parseSQLColumn(def []*sqlToken) (*sqlColumn, error) {
+	if !def[0].isName() {
+		return nil, fmt.Errorf("line %d: name of the column is expected, but it gets %s", def[0].line, def[0].text)
+	}
+	column := &sqlColumn{name: def[0].value, sqlName: def[0].text}
+
+	// the type continues until the constraint, e.g. `DOUBLE PRECISION` and `NUMERIC(10, 2)`
+	i, depth := 1, 0
+	for ; i < len(def); i++ {
+		t := def[i]
+		if depth == 0 && t.is(sqlColumnConstraintKeywords...) {
+			break
+		}
+		switch {
+		case t.isSymbol("("):
+			depth++
+		case t.isSymbol(")"):
+			depth--
+		}
+		if column.sqlType != "" && t.kind != sqlTokenSymbol && !strings.HasSuffix(column.sqlType, "(") {
+			column.sqlType += " "
+		}
+		column.sqlType += t.text
+		if t.isSymbol(",") {
+			column.sqlType += " "
+		}
+	}
+
+	depth = 0
+	for ; i < len(def); i++ {
+		t := def[i]
+		switch {
+		case t.isSymbol("("):
+			depth++
+			continue
+		case t.isSymbol(")"):
+			depth--
+			continue
+		case depth > 0:
+			continue
+		}
+
+		var next *sqlToken
+		if i+1 < len(def) {
+			next = def[i+1]
+		}
+		switch {
+		case t.is("NOT") && next != nil && next.is("NULL"):
+			column.notNull = true
+		case t.is("PRIMARY"):
+			column.primaryKey = true
+		case t.is("AUTO_INCREMENT", "AUTOINCREMENT", "IDENTITY"):
+			column.autoIncrement = true
+		case t.is("GENERATED") || (t.is("AS") && next != nil && next.isSymbol("(")):
+			column.generated = true
+		case t.is("COMMENT") && next != nil && next.kind == sqlTokenString:
+			column.comment = next.value
+		}
+	}
+
+	switch strings.ToUpper(sqlBaseTypeOf(column.sqlType)) {
+	case "SERIAL", "SMALLSERIAL", "BIGSERIAL", "SERIAL2", "SERIAL4", "SERIAL8":
+		column.autoIncrement = true
+	}
+	if column.autoIncrement {
+		// e.g. `GENERATED ALWAYS AS IDENTITY` is the auto-increment column, that is not the computed one
+		column.generated = false
+	}
+	if column.primaryKey || column.autoIncrement {
+		column.notNull = true
+	}
+	return column, nil
+}
+
+// sqlCommentOf returns the comment of the table options (e.g. `COMMENT='users of the service'` of MySQL).
+func sqlCommentOf(options []*sqlToken) string {
+	for i, t := range options {
+		if !t.is("COMMENT") {
+			continue
+		}
+		for _, next := range options[i+1:] {
+			if next.kind == sqlTokenString {
+				return next.value
+			}
+			if !next.isSymbol("=") {
+				break
+			}
+		}
+	}
+	return ""
+}
+
+// sqlBaseTypeOf returns the first word of the type (e.g. `VARCHAR(255)` => `VARCHAR`).
+func sqlBaseTypeOf(sqlType string) string {
+	if i := strings.IndexAny(sqlType, " ("); i >= 0 {
+		return sqlType[:i]
+	}
+	return sqlType
+}
+
+// sqlGoTypeOf returns the golang type of the non-null value of the column; it returns empty string for the unsupported type.
+func sqlGoTypeOf(sqlType string) string {
+	if strings.Contains(sqlType, "[") || strings.Contains(strings.ToUpper(sqlType), "ARRAY") {
+		return ""
+	}
+
+	switch strings.ToUpper(sqlBaseTypeOf(sqlType)) {
+	case "INT", "INTEGER", "TINYINT", "SMALLINT", "MEDIUMINT", "BIGINT", "INT2", "INT4", "INT8",
+		"SERIAL", "SMALLSERIAL", "BIGSERIAL", "SERIAL2", "SERIAL4", "SERIAL8":
+		return "int64"
+	case "REAL", "FLOAT", "FLOAT4", "FLOAT8", "DOUBLE":
+		return "float64"
+	case "DECIMAL", "DEC", "NUMERIC", "MONEY":
+		// keep the exact value
+		return "string"
+	case "BOOL", "BOOLEAN":
+		return "bool"
+	case "CHAR", "CHARACTER", "VARCHAR", "NCHAR", "NVARCHAR", "VARCHAR2", "TEXT", "TINYTEXT", "MEDIUMTEXT", "LONGTEXT",
+		"CLOB", "CITEXT", "UUID", "JSON", "JSONB", "XML", "ENUM", "SET", "INET", "CIDR", "INTERVAL", "TIME", "TIMETZ":
+		return "string"
+	case "DATE", "DATETIME", "DATETIME2", "TIMESTAMP", "TIMESTAMPTZ":
+		return "time.Time"
+	case "BLOB", "TINYBLOB", "MEDIUMBLOB", "LONGBLOB", "BYTEA", "BINARY", "VARBINARY":
+		return "[]byte"
+	}
+	return ""
+}
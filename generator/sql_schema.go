@@ -0,0 +1,497 @@
+package generator
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
+
+// SQLSchema represents a code generator for the row types and the repositories of the tables, like the `sqlc` tool.
+// The tables are derived from `CREATE TABLE` statements of the SQL schema; please see `NewSQLSchema()` for the details.
+type SQLSchema struct {
+	tables      []*sqlTable
+	nullStyle   SQLNullStyle
+	placeholder SQLPlaceholder
+}
+
+// SQLNullStyle is the style of the golang type of the nullable column.
+type SQLNullStyle int
+
+const (
+	// SQLNullTypes represents the nullable column by the types of `database/sql` (e.g. `sql.NullString`); this is the default.
+	SQLNullTypes SQLNullStyle = iota
+	// SQLPointerTypes represents the nullable column by the pointer (e.g. `*string`).
+	SQLPointerTypes
+)
+
+// SQLPlaceholder is the style of the placeholder of the queries; it depends on the database driver.
+type SQLPlaceholder int
+
+const (
+	// SQLQuestionPlaceholder is `?` (e.g. MySQL and SQLite); this is the default.
+	// The insertion sets the auto-increment column by `LastInsertId()` if the table has only one such column.
+	SQLQuestionPlaceholder SQLPlaceholder = iota
+	// SQLDollarPlaceholder is `$1`, `$2`... (e.g. PostgreSQL).
+	// The insertion sets the auto-increment columns by `RETURNING` clause.
+	SQLDollarPlaceholder
+)
+
+// LoadSQLSchema reads the SQL schema from the local file, and returns a new `SQLSchema`.
+// Please see `NewSQLSchema()` for the details.
+func LoadSQLSchema(path string) (*SQLSchema, error) {
+	caller := fetchClientCallerLine()
+
+	ddl, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errmsg.SQLSchemaLoadingError(path, err.Error(), caller)
+	}
+	return newSQLSchema(string(ddl), caller)
+}
+
+// NewSQLSchema returns a new `SQLSchema` from `CREATE TABLE` statements of the SQL schema; the other statements are ignored.
+// Each table becomes the following:
+//
+//   - the row struct that is named by the singular form of the table (e.g. `users` => `User`), and each field has the `db` tag
+//   - the nullable column is `sql.NullString` and so on, or the pointer by `NullStyle(SQLPointerTypes)`
+//   - the queries of `const` block, and `scanXxx()` that scans the columns into the row
+//   - `XxxRepository` interface of `Insert()`, `Get()`, `List()`, `Update()` and `Delete()`, and the implementation by `database/sql`;
+//     `Get()`, `Update()` and `Delete()` are available only for the table that has the primary key
+//
+// The auto-increment columns (`AUTO_INCREMENT`, `AUTOINCREMENT`, `SERIAL` and `IDENTITY`) are set by the database on the insertion,
+// and the generated columns are read-only. The types are mapped in the usual way of `database/sql`; the integers are `int64`,
+// `DECIMAL` and `NUMERIC` are `string` to keep the exact value, and `DATE` and `TIMESTAMP` are `time.Time`.
+func NewSQLSchema(ddl string) (*SQLSchema, error) {
+	return newSQLSchema(ddl, fetchClientCallerLine())
+}
+
+func newSQLSchema(ddl string, caller string) (*SQLSchema, error) {
+	tables, err := parseSQLTables(ddl)
+	if err != nil {
+		return nil, errmsg.SQLSchemaIsInvalidError(err.Error(), caller)
+	}
+	if len(tables) <= 0 {
+		return nil, errmsg.SQLSchemaIsInvalidError("there is no CREATE TABLE statement", caller)
+	}
+
+	names := jsonTypeNames{"DBTX": true}
+	for _, table := range tables {
+		table.rowType = names.reserve(singularize(table.name))
+		table.pluralName = ToExportedName(table.name)
+
+		used := map[string]bool{}
+		for _, column := range table.columns {
+			column.baseType = sqlGoTypeOf(column.sqlType)
+			if column.baseType == "" {
+				return nil, errmsg.SQLColumnTypeIsNotSupportedError(column.sqlType, column.name, table.name, caller)
+			}
+			column.fieldName = uniqueFieldName(column.name, used)
+		}
+	}
+
+	return &SQLSchema{
+		tables: tables,
+	}, nil
+}
+
+// NullStyle sets the style of the golang type of the nullable column.
+// This method returns a *new* `SQLSchema`; it means this method acts as immutable.
+func (s *SQLSchema) NullStyle(style SQLNullStyle) *SQLSchema {
+	return &SQLSchema{
+		tables:      s.tables,
+		nullStyle:   style,
+		placeholder: s.placeholder,
+	}
+}
+
+// Placeholder sets the style of the placeholder of the queries.
+// This method returns a *new* `SQLSchema`; it means this method acts as immutable.
+func (s *SQLSchema) Placeholder(placeholder SQLPlaceholder) *SQLSchema {
+	return &SQLSchema{
+		tables:      s.tables,
+		nullStyle:   s.nullStyle,
+		placeholder: placeholder,
+	}
+}
+
+// Roots returns `Root` of each file of the package; the key is the file name (i.e. `rows.go` and `repository.go`).
+// Please see also `Files()`.
+func (s *SQLSchema) Roots(packageName string) map[string]*Root {
+	return map[string]*Root{
+		"rows.go":       NewRoot(s.rowsStatements(packageName)...),
+		"repository.go": NewRoot(s.repositoryStatements(packageName)...),
+	}
+}
+
+// Files generates the files of the package with `gofmt`; the key is the file name.
+func (s *SQLSchema) Files(packageName string) (map[string]string, error) {
+	files := map[string]string{}
+	for name, root := range s.Roots(packageName) {
+		generated, err := root.Gofmt().Generate(0)
+		if err != nil {
+			return nil, err
+		}
+		files[name] = generated
+	}
+	return files, nil
+}
+
+// typeOf returns the golang type of the field of the column.
+func (s *SQLSchema) typeOf(column *sqlColumn) string {
+	if column.notNull || column.baseType == "[]byte" {
+		return column.baseType
+	}
+	if s.nullStyle == SQLPointerTypes {
+		return "*" + column.baseType
+	}
+
+	switch column.baseType {
+	case "int64":
+		return "sql.NullInt64"
+	case "float64":
+		return "sql.NullFloat64"
+	case "bool":
+		return "sql.NullBool"
+	case "time.Time":
+		return "sql.NullTime"
+	}
+	return "sql.NullString"
+}
+
+func (s *SQLSchema) rowsStatements(packageName string) []Statement {
+	var groups [][]Statement
+	usesSQL, usesTime := false, false
+	for _, table := range s.tables {
+		fields := make([]*StructField, len(table.columns))
+		for i, column := range table.columns {
+			typ := s.typeOf(column)
+			usesSQL = usesSQL || strings.HasPrefix(typ, "sql.")
+			usesTime = usesTime || strings.Contains(typ, "time.")
+
+			fields[i] = NewStructField(column.fieldName, typ).Tag(NewStructTag().Add("db", column.name))
+			if comment := strings.TrimSpace(column.comment); comment != "" {
+				fields[i] = fields[i].Comment(" " + strings.Replace(comment, "\n", "\n ", -1))
+			}
+		}
+
+		comments := []Statement{NewCommentf(" %s is the row of the table `%s`.", table.rowType, table.name)}
+		if comment := strings.TrimSpace(table.comment); comment != "" {
+			for _, line := range strings.Split(comment, "\n") {
+				comments = append(comments, NewComment(" "+line))
+			}
+		}
+		groups = append(groups, append(comments, NewStruct(table.rowType).AddFields(fields...)))
+	}
+
+	var imports []string
+	if usesSQL {
+		imports = append(imports, "database/sql")
+	}
+	if usesTime {
+		imports = append(imports, "time")
+	}
+	return statementsWithImports(packageName, imports, groups)
+}
+
+// sqlColumnsFilter filters the columns of the table.
+type sqlColumnsFilter func(column *sqlColumn) bool
+
+func (t *sqlTable) columnsOf(filter sqlColumnsFilter) []*sqlColumn {
+	var columns []*sqlColumn
+	for _, column := range t.columns {
+		if filter(column) {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+func (t *sqlTable) primaryKeys() []*sqlColumn {
+	return t.columnsOf(func(column *sqlColumn) bool { return column.primaryKey })
+}
+
+func (t *sqlTable) insertedColumns() []*sqlColumn {
+	return t.columnsOf(func(column *sqlColumn) bool { return !column.autoIncrement && !column.generated })
+}
+
+func (t *sqlTable) autoIncrementColumns() []*sqlColumn {
+	return t.columnsOf(func(column *sqlColumn) bool { return column.autoIncrement })
+}
+
+func (t *sqlTable) updatedColumns() []*sqlColumn {
+	return t.columnsOf(func(column *sqlColumn) bool {
+		return !column.primaryKey && !column.autoIncrement && !column.generated
+	})
+}
+
+func sqlNamesOf(columns []*sqlColumn) []string {
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.sqlName
+	}
+	return names
+}
+
+func rowFieldsOf(columns []*sqlColumn, prefix string) []string {
+	fields := make([]string, len(columns))
+	for i, column := range columns {
+		fields[i] = prefix + column.fieldName
+	}
+	return fields
+}
+
+// keyParamName returns the name of the parameter of the primary key; it doesn't conflict with the receiver and the context.
+func keyParamName(column *sqlColumn) string {
+	name := ToUnexportedName(column.name)
+	if name == "r" || name == "ctx" {
+		name += "_"
+	}
+	return name
+}
+
+// placeholders returns the placeholders of the query; `offset` is the number of the placeholders before them.
+func (s *SQLSchema) placeholders(offset int, n int) []string {
+	placeholders := make([]string, n)
+	for i := range placeholders {
+		if s.placeholder == SQLDollarPlaceholder {
+			placeholders[i] = "$" + strconv.Itoa(offset+i+1)
+		} else {
+			placeholders[i] = "?"
+		}
+	}
+	return placeholders
+}
+
+// conditions returns the conditions of the primary keys (e.g. `id = ?`).
+func (s *SQLSchema) conditions(keys []*sqlColumn, offset int) string {
+	conditions := make([]string, len(keys))
+	for i, placeholder := range s.placeholders(offset, len(keys)) {
+		conditions[i] = keys[i].sqlName + " = " + placeholder
+	}
+	return strings.Join(conditions, " AND ")
+}
+
+func (t *sqlTable) queryName(operation string) string {
+	if operation == "list" {
+		return operation + t.pluralName + "Query"
+	}
+	return operation + t.rowType + "Query"
+}
+
+// queries returns the queries of the table in the order of `Insert()`, `Get()`, `List()`, `Update()` and `Delete()`.
+func (s *SQLSchema) queries(t *sqlTable) []*ConstSpec {
+	columns := strings.Join(sqlNamesOf(t.columns), ", ")
+	keys := t.primaryKeys()
+
+	inserted := t.insertedColumns()
+	insert := "INSERT INTO " + t.sqlName + " DEFAULT VALUES"
+	if len(inserted) > 0 {
+		insert = fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+			t.sqlName, strings.Join(sqlNamesOf(inserted), ", "), strings.Join(s.placeholders(0, len(inserted)), ", "))
+	}
+	if autos := t.autoIncrementColumns(); s.placeholder == SQLDollarPlaceholder && len(autos) > 0 {
+		insert += " RETURNING " + strings.Join(sqlNamesOf(autos), ", ")
+	}
+	specs := []*ConstSpec{NewConstSpec([]string{t.queryName("insert")}, "", NewRawStatement(strconv.Quote(insert)))}
+
+	list := fmt.Sprintf("SELECT %s FROM %s", columns, t.sqlName)
+	if len(keys) <= 0 {
+		return append(specs, NewConstSpec([]string{t.queryName("list")}, "", NewRawStatement(strconv.Quote(list))))
+	}
+
+	list += " ORDER BY " + strings.Join(sqlNamesOf(keys), ", ")
+	get := fmt.Sprintf("SELECT %s FROM %s WHERE %s", columns, t.sqlName, s.conditions(keys, 0))
+	specs = append(specs,
+		NewConstSpec([]string{t.queryName("get")}, "", NewRawStatement(strconv.Quote(get))),
+		NewConstSpec([]string{t.queryName("list")}, "", NewRawStatement(strconv.Quote(list))),
+	)
+
+	if updated := t.updatedColumns(); len(updated) > 0 {
+		sets := make([]string, len(updated))
+		for i, placeholder := range s.placeholders(0, len(updated)) {
+			sets[i] = updated[i].sqlName + " = " + placeholder
+		}
+		update := fmt.Sprintf("UPDATE %s SET %s WHERE %s", t.sqlName, strings.Join(sets, ", "), s.conditions(keys, len(updated)))
+		specs = append(specs, NewConstSpec([]string{t.queryName("update")}, "", NewRawStatement(strconv.Quote(update))))
+	}
+
+	del := fmt.Sprintf("DELETE FROM %s WHERE %s", t.sqlName, s.conditions(keys, 0))
+	return append(specs, NewConstSpec([]string{t.queryName("delete")}, "", NewRawStatement(strconv.Quote(del))))
+}
+
+func (s *SQLSchema) repositoryStatements(packageName string) []Statement {
+	var specs []*ConstSpec
+	for _, table := range s.tables {
+		specs = append(specs, s.queries(table)...)
+	}
+
+	groups := [][]Statement{
+		{NewConst(specs...)},
+		{
+			NewComment(" DBTX is the interface of *sql.DB and *sql.Tx, that the repositories use."),
+			NewInterface("DBTX",
+				NewFuncSignature("ExecContext").
+					AddParameters(NewFuncParameter("ctx", "context.Context"), NewFuncParameter("query", "string"), NewFuncParameter("args", "...interface{}")).
+					AddReturnTypes("sql.Result", "error"),
+				NewFuncSignature("QueryContext").
+					AddParameters(NewFuncParameter("ctx", "context.Context"), NewFuncParameter("query", "string"), NewFuncParameter("args", "...interface{}")).
+					AddReturnTypes("*sql.Rows", "error"),
+				NewFuncSignature("QueryRowContext").
+					AddParameters(NewFuncParameter("ctx", "context.Context"), NewFuncParameter("query", "string"), NewFuncParameter("args", "...interface{}")).
+					AddReturnTypes("*sql.Row"),
+			),
+		},
+		{
+			NewComment(" rowScanner is the interface of *sql.Row and *sql.Rows."),
+			NewInterface("rowScanner",
+				NewFuncSignature("Scan").AddParameters(NewFuncParameter("dest", "...interface{}")).AddReturnTypes("error"),
+			),
+		},
+	}
+	for _, table := range s.tables {
+		groups = append(groups, s.tableRepositoryStatementGroups(table)...)
+	}
+
+	return statementsWithImports(packageName, []string{"context", "database/sql"}, groups)
+}
+
+func (s *SQLSchema) tableRepositoryStatementGroups(t *sqlTable) [][]Statement {
+	scanName := "scan" + t.rowType
+	ifaceName := t.rowType + "Repository"
+	implName := ToUnexportedName(ifaceName)
+	recv := NewFuncReceiver("r", "*"+implName)
+	keys := t.primaryKeys()
+
+	keyParams := make([]*FuncParameter, len(keys))
+	keyArgs := make([]string, len(keys))
+	for i, key := range keys {
+		keyArgs[i] = keyParamName(key)
+		keyParams[i] = NewFuncParameter(keyArgs[i], key.baseType)
+	}
+	ctxParam := NewFuncParameter("ctx", "context.Context")
+	rowParam := NewFuncParameter("row", "*"+t.rowType)
+
+	groups := [][]Statement{
+		{
+			NewCommentf(" %s scans the columns of the table `%s` into %s.", scanName, t.name, t.rowType),
+			NewFunc(
+				nil,
+				NewFuncSignature(scanName).AddParameters(NewFuncParameter("scanner", "rowScanner")).AddReturnTypes("*"+t.rowType, "error"),
+				NewVar([]string{"row"}, t.rowType),
+				NewIf("err != nil", NewReturnStatement("nil", "err")).
+					Init(NewShortVarDecl([]string{"err"}, NewRawStatementf("scanner.Scan(%s)", strings.Join(rowFieldsOf(t.columns, "&row."), ", ")))),
+				NewReturnStatement("&row", "nil"),
+			),
+		},
+	}
+
+	insertSig := NewFuncSignature("Insert").AddParameters(ctxParam, rowParam).AddReturnTypes("error")
+	listSig := NewFuncSignature("List").AddParameters(ctxParam).AddReturnTypes("[]*"+t.rowType, "error")
+	methods := [][]Statement{
+		{
+			NewCommentf(" Insert inserts the row into the table `%s`.", t.name),
+			NewFunc(recv, insertSig, s.insertStatements(t)...),
+		},
+	}
+	var getSig, updateSig, deleteSig *FuncSignature
+	if len(keys) > 0 {
+		getSig = NewFuncSignature("Get").AddParameters(ctxParam).AddParameters(keyParams...).AddReturnTypes("*"+t.rowType, "error")
+		methods = append(methods, []Statement{
+			NewCommentf(" Get returns the row of the table `%s` by the primary key; it returns sql.ErrNoRows if the row doesn't exist.", t.name),
+			NewFunc(recv, getSig,
+				NewReturnStatement(fmt.Sprintf("%s(r.db.QueryRowContext(%s))", scanName, strings.Join(append([]string{"ctx", t.queryName("get")}, keyArgs...), ", "))),
+			),
+		})
+	}
+	methods = append(methods, []Statement{
+		NewCommentf(" List returns all of the rows of the table `%s`.", t.name),
+		NewFunc(recv, listSig,
+			NewShortVarDecl([]string{"rows", "err"}, NewRawStatementf("r.db.QueryContext(ctx, %s)", t.queryName("list"))),
+			NewIf("err != nil", NewReturnStatement("nil", "err")),
+			NewDefer(NewRawStatement("rows.Close()")),
+			NewNewline(),
+			NewVar([]string{"result"}, "[]*"+t.rowType),
+			NewFor("rows.Next()",
+				NewShortVarDecl([]string{"row", "err"}, NewRawStatementf("%s(rows)", scanName)),
+				NewIf("err != nil", NewReturnStatement("nil", "err")),
+				NewAssign([]string{"result"}, "=", NewRawStatement("append(result, row)")),
+			),
+			NewReturnStatement("result", "rows.Err()"),
+		),
+	})
+	if len(keys) > 0 {
+		if updated := t.updatedColumns(); len(updated) > 0 {
+			updateSig = NewFuncSignature("Update").AddParameters(ctxParam, rowParam).AddReturnTypes("error")
+			args := append([]string{"ctx", t.queryName("update")}, rowFieldsOf(updated, "row.")...)
+			methods = append(methods, []Statement{
+				NewCommentf(" Update updates the row of the table `%s` that has the same primary key.", t.name),
+				NewFunc(recv, updateSig,
+					NewShortVarDecl([]string{"_", "err"}, NewRawStatementf("r.db.ExecContext(%s)", strings.Join(append(args, rowFieldsOf(keys, "row.")...), ", "))),
+					NewReturnStatement("err"),
+				),
+			})
+		}
+
+		deleteSig = NewFuncSignature("Delete").AddParameters(ctxParam).AddParameters(keyParams...).AddReturnTypes("error")
+		methods = append(methods, []Statement{
+			NewCommentf(" Delete deletes the row of the table `%s` by the primary key.", t.name),
+			NewFunc(recv, deleteSig,
+				NewShortVarDecl([]string{"_", "err"}, NewRawStatementf("r.db.ExecContext(%s)", strings.Join(append([]string{"ctx", t.queryName("delete")}, keyArgs...), ", "))),
+				NewReturnStatement("err"),
+			),
+		})
+	}
+
+	var sigs []*FuncSignature
+	for _, sig := range []*FuncSignature{insertSig, getSig, listSig, updateSig, deleteSig} {
+		if sig != nil {
+			sigs = append(sigs, sig)
+		}
+	}
+	groups = append(groups,
+		[]Statement{
+			NewCommentf(" %s is the repository of the table `%s`.", ifaceName, t.name),
+			NewInterface(ifaceName, sigs...),
+		},
+		[]Statement{
+			NewStruct(implName).AddField("db", "DBTX"),
+		},
+		[]Statement{
+			NewCommentf(" New%s returns a new %s; db is *sql.DB or *sql.Tx.", ifaceName, ifaceName),
+			NewFunc(
+				nil,
+				NewFuncSignature("New"+ifaceName).AddParameters(NewFuncParameter("db", "DBTX")).AddReturnTypes(ifaceName),
+				NewReturnStatement(fmt.Sprintf("&%s{db: db}", implName)),
+			),
+		},
+	)
+	return append(groups, methods...)
+}
+
+// insertStatements returns the body of `Insert()`, that sets the auto-increment columns to the row after the insertion.
+func (s *SQLSchema) insertStatements(t *sqlTable) []Statement {
+	args := strings.Join(append([]string{"ctx", t.queryName("insert")}, rowFieldsOf(t.insertedColumns(), "row.")...), ", ")
+
+	autos := t.autoIncrementColumns()
+	switch {
+	case len(autos) > 0 && s.placeholder == SQLDollarPlaceholder:
+		return []Statement{
+			NewReturnStatement(fmt.Sprintf("r.db.QueryRowContext(%s).Scan(%s)", args, strings.Join(rowFieldsOf(autos, "&row."), ", "))),
+		}
+	case len(autos) == 1:
+		return []Statement{
+			NewShortVarDecl([]string{"result", "err"}, NewRawStatementf("r.db.ExecContext(%s)", args)),
+			NewIf("err != nil", NewReturnStatement("err")),
+			NewShortVarDecl([]string{"id", "err"}, NewRawStatement("result.LastInsertId()")),
+			NewIf("err != nil", NewReturnStatement("err")),
+			NewAssign([]string{"row." + autos[0].fieldName}, "=", NewRawStatement("id")),
+			NewReturnStatement("nil"),
+		}
+	}
+	return []Statement{
+		NewShortVarDecl([]string{"_", "err"}, NewRawStatementf("r.db.ExecContext(%s)", args)),
+		NewReturnStatement("err"),
+	}
+}
@@ -0,0 +1,26 @@
+package generator
+
+import (
+	"fmt"
+	"log"
+)
+
+func ExampleNewSQLSchema() {
+	schema, err := NewSQLSchema(`
+CREATE TABLE users (
+    id BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    email TEXT
+);`)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	files, err := schema.Files("users")
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, name := range []string{"rows.go", "repository.go"} {
+		fmt.Println(files[name])
+	}
+}
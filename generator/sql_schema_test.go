@@ -0,0 +1,376 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldGenerateSQLSchemaFiles(t *testing.T) {
+	schema, err := NewSQLSchema(`
+CREATE TABLE items (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name TEXT NOT NULL, -- the name
+    price NUMERIC(10, 2)
+);`)
+	assert.NoError(t, err)
+
+	files, err := schema.Files("items")
+	assert.NoError(t, err)
+	assert.Len(t, files, 2)
+
+	assert.Equal(t, "package items\n"+
+		"\n"+
+		"import (\n"+
+		"\t\"database/sql\"\n"+
+		")\n"+
+		"\n"+
+		"// Item is the row of the table `items`.\n"+
+		"type Item struct {\n"+
+		"\tID    int64          `db:\"id\"`\n"+
+		"\tName  string         `db:\"name\"`\n"+
+		"\tPrice sql.NullString `db:\"price\"`\n"+
+		"}\n", files["rows.go"])
+
+	repository := files["repository.go"]
+	for _, expected := range []string{
+		"\tinsertItemQuery = \"INSERT INTO items (name, price) VALUES (?, ?)\"\n",
+		"\tgetItemQuery    = \"SELECT id, name, price FROM items WHERE id = ?\"\n",
+		"\tlistItemsQuery  = \"SELECT id, name, price FROM items ORDER BY id\"\n",
+		"\tupdateItemQuery = \"UPDATE items SET name = ?, price = ? WHERE id = ?\"\n",
+		"\tdeleteItemQuery = \"DELETE FROM items WHERE id = ?\"\n",
+		"type DBTX interface {\n",
+		"func scanItem(scanner rowScanner) (*Item, error) {\n",
+		"\tif err := scanner.Scan(&row.ID, &row.Name, &row.Price); err != nil {\n",
+		"type ItemRepository interface {\n",
+		"func NewItemRepository(db DBTX) ItemRepository {\n\treturn &itemRepository{db: db}\n}\n",
+		"\tid, err := result.LastInsertId()\n",
+		"\trow.ID = id\n",
+		"\treturn scanItem(r.db.QueryRowContext(ctx, getItemQuery, id))\n",
+		"\t_, err := r.db.ExecContext(ctx, updateItemQuery, row.Name, row.Price, row.ID)\n",
+		"\t_, err := r.db.ExecContext(ctx, deleteItemQuery, id)\n",
+	} {
+		assert.Contains(t, repository, expected)
+	}
+}
+
+func TestShouldGenerateSQLSchemaWithPointerTypesAndDollarPlaceholder(t *testing.T) {
+	schema, err := NewSQLSchema(`
+CREATE TABLE public."events" (
+    id BIGSERIAL PRIMARY KEY,
+    "type" VARCHAR(16),
+    happened_at TIMESTAMP WITH TIME ZONE,
+    total INT GENERATED ALWAYS AS (1 + 1) STORED
+);`)
+	assert.NoError(t, err)
+
+	files, err := schema.NullStyle(SQLPointerTypes).Placeholder(SQLDollarPlaceholder).Files("events")
+	assert.NoError(t, err)
+
+	assert.Contains(t, files["rows.go"], "import (\n\t\"time\"\n)\n")
+	assert.Contains(t, files["rows.go"], "\tType       *string    `db:\"type\"`\n")
+	assert.Contains(t, files["rows.go"], "\tHappenedAt *time.Time `db:\"happened_at\"`\n")
+
+	repository := files["repository.go"]
+	for _, expected := range []string{
+		"\tinsertEventQuery = \"INSERT INTO public.\\\"events\\\" (\\\"type\\\", happened_at) VALUES ($1, $2) RETURNING id\"\n",
+		"\tupdateEventQuery = \"UPDATE public.\\\"events\\\" SET \\\"type\\\" = $1, happened_at = $2 WHERE id = $3\"\n",
+		"\tdeleteEventQuery = \"DELETE FROM public.\\\"events\\\" WHERE id = $1\"\n",
+		"\treturn r.db.QueryRowContext(ctx, insertEventQuery, row.Type, row.HappenedAt).Scan(&row.ID)\n",
+	} {
+		assert.Contains(t, repository, expected)
+	}
+}
+
+func TestShouldGenerateSQLSchemaOfTableWithoutPrimaryKey(t *testing.T) {
+	schema, err := NewSQLSchema("CREATE TABLE logs (message TEXT NOT NULL); CREATE INDEX logs_message ON logs (message);")
+	assert.NoError(t, err)
+
+	files, err := schema.Files("logs")
+	assert.NoError(t, err)
+
+	assert.Equal(t, "package logs\n"+
+		"\n"+
+		"// Log is the row of the table `logs`.\n"+
+		"type Log struct {\n"+
+		"\tMessage string `db:\"message\"`\n"+
+		"}\n", files["rows.go"])
+	assert.Contains(t, files["repository.go"], "type LogRepository interface {\n"+
+		"\tInsert(\n\t\tctx context.Context,\n\t\trow *Log,\n\t) error\n"+
+		"\tList(ctx context.Context) ([]*Log, error)\n"+
+		"}\n")
+	assert.NotContains(t, files["repository.go"], "Get(")
+}
+
+func TestShouldParseSQLTables(t *testing.T) {
+	tables, err := parseSQLTables(`
+/* comment; with semicolon */
+CREATE TEMPORARY TABLE IF NOT EXISTS ` + "`order_items`" + ` (
+    ` + "`order_id`" + ` BIGINT UNSIGNED NOT NULL,
+    line INT NOT NULL DEFAULT 0 CHECK (line >= 0),
+    note VARCHAR(255) CHARACTER SET utf8mb4 NULL COMMENT 'it''s the note',
+    CONSTRAINT pk PRIMARY KEY (` + "`order_id`" + `, line),
+    KEY order_items_note (note)
+) ENGINE=InnoDB COMMENT='items of the order';
+DROP TABLE foo;
+`)
+	assert.NoError(t, err)
+	assert.Len(t, tables, 1)
+
+	table := tables[0]
+	assert.Equal(t, "order_items", table.name)
+	assert.Equal(t, "`order_items`", table.sqlName)
+	assert.Equal(t, "items of the order", table.comment)
+	assert.Equal(t, 3, table.line)
+	assert.Len(t, table.columns, 3)
+
+	assert.Equal(t, &sqlColumn{name: "order_id", sqlName: "`order_id`", sqlType: "BIGINT UNSIGNED", notNull: true, primaryKey: true}, table.columns[0])
+	assert.Equal(t, &sqlColumn{name: "line", sqlName: "line", sqlType: "INT", notNull: true, primaryKey: true}, table.columns[1])
+	assert.Equal(t, &sqlColumn{name: "note", sqlName: "note", sqlType: "VARCHAR(255) CHARACTER SET utf8mb4", comment: "it's the note"}, table.columns[2])
+}
+
+func TestShouldNewSQLSchemaRaiseError(t *testing.T) {
+	for _, tc := range []struct {
+		ddl      string
+		expected error
+	}{
+		{"CREATE INDEX a ON b (c);", errmsg.SQLSchemaIsInvalidError("", "")},
+		{"CREATE TABLE a (id INT); /* comment", errmsg.SQLSchemaIsInvalidError("", "")},
+		{"CREATE TABLE a (name 'text);", errmsg.SQLSchemaIsInvalidError("", "")},
+		{"CREATE TABLE a AS SELECT 1;", errmsg.SQLSchemaIsInvalidError("", "")},
+		{"CREATE TABLE a (id INT", errmsg.SQLSchemaIsInvalidError("", "")},
+		{"CREATE TABLE a (id INT,);", errmsg.SQLSchemaIsInvalidError("", "")},
+		{"CREATE TABLE a (id INT, PRIMARY KEY (b));", errmsg.SQLSchemaIsInvalidError("", "")},
+		{"CREATE TABLE a (id INT, tags TEXT[]);", errmsg.SQLColumnTypeIsNotSupportedError("", "", "", "")},
+		{"CREATE TABLE a (id INT, location POINT);", errmsg.SQLColumnTypeIsNotSupportedError("", "", "", "")},
+		{"CREATE TABLE a (id);", errmsg.SQLColumnTypeIsNotSupportedError("", "", "", "")},
+	} {
+		_, err := NewSQLSchema(tc.ddl)
+		assert.Error(t, err, tc.ddl)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(tc.expected.Error(), " ")[0],
+		), err.Error(), tc.ddl)
+	}
+}
+
+func TestShouldLoadSQLSchemaRaiseErrorWhenFileIsMissing(t *testing.T) {
+	_, err := LoadSQLSchema("./testdata/sql/missing.sql")
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.SQLSchemaLoadingError("", "", "").Error(), " ")[0],
+	), err.Error())
+}
+
+const sqlRepositoryTestCode = `package users
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type fakeCall struct {
+	query string
+	args  []driver.Value
+}
+
+// fakeDriver records the queries, and returns the rows that are set by the test.
+type fakeDriver struct {
+	calls []fakeCall
+	rows  [][]driver.Value
+}
+
+func (d *fakeDriver) Open(string) (driver.Conn, error) { return &fakeConn{d: d}, nil }
+
+func (d *fakeDriver) lastCall() fakeCall { return d.calls[len(d.calls)-1] }
+
+type fakeConn struct{ d *fakeDriver }
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{d: c.d, query: query}, nil
+}
+func (c *fakeConn) Close() error              { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error) { return nil, errors.New("not supported") }
+
+type fakeStmt struct {
+	d     *fakeDriver
+	query string
+}
+
+func (s *fakeStmt) Close() error  { return nil }
+func (s *fakeStmt) NumInput() int { return -1 }
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.calls = append(s.d.calls, fakeCall{query: s.query, args: args})
+	return fakeResult{}, nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.calls = append(s.d.calls, fakeCall{query: s.query, args: args})
+	return &fakeRows{rows: s.d.rows}, nil
+}
+
+type fakeResult struct{}
+
+func (fakeResult) LastInsertId() (int64, error) { return 42, nil }
+func (fakeResult) RowsAffected() (int64, error) { return 1, nil }
+
+type fakeRows struct{ rows [][]driver.Value }
+
+func (r *fakeRows) Columns() []string {
+	if len(r.rows) <= 0 {
+		return nil
+	}
+	return make([]string, len(r.rows[0]))
+}
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) <= 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+var fake = &fakeDriver{}
+
+func init() {
+	sql.Register("fake", fake)
+}
+
+func assertCall(t *testing.T, query string, args ...driver.Value) {
+	t.Helper()
+	call := fake.lastCall()
+	if call.query != query || (len(call.args) > 0 || len(args) > 0) && !reflect.DeepEqual(call.args, args) {
+		t.Fatalf("unexpected call: %q %#v", call.query, call.args)
+	}
+}
+
+func TestUserRepository(t *testing.T) {
+	db, err := sql.Open("fake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	repo := NewUserRepository(db)
+	ctx := context.Background()
+	createdAt := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	row := &User{Name: "alice", Email: sql.NullString{String: "alice@example.com", Valid: true}, Active: true, CreatedAt: createdAt}
+	if err := repo.Insert(ctx, row); err != nil {
+		t.Fatal(err)
+	}
+	if row.ID != 42 {
+		t.Fatalf("unexpected id: %d", row.ID)
+	}
+	assertCall(t, insertUserQuery, "alice", "alice@example.com", nil, nil, true, []byte(nil), createdAt, nil)
+
+	fake.rows = [][]driver.Value{
+		{int64(42), "alice", nil, int64(20), 1.5, true, []byte("png"), createdAt, nil},
+	}
+	got, err := repo.Get(ctx, 42)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &User{
+		ID:        42,
+		Name:      "alice",
+		Age:       sql.NullInt64{Int64: 20, Valid: true},
+		Score:     sql.NullFloat64{Float64: 1.5, Valid: true},
+		Active:    true,
+		Avatar:    []byte("png"),
+		CreatedAt: createdAt,
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Fatalf("unexpected row: %#v", got)
+	}
+	assertCall(t, getUserQuery, int64(42))
+
+	fake.rows = nil
+	if _, err := repo.Get(ctx, 43); err != sql.ErrNoRows {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	fake.rows = [][]driver.Value{
+		{int64(1), "a", nil, nil, nil, true, nil, createdAt, nil},
+		{int64(2), "b", "b@example.com", nil, nil, false, nil, createdAt, createdAt},
+	}
+	rows, err := repo.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || rows[0].ID != 1 || rows[1].Email.String != "b@example.com" || !rows[1].DeletedAt.Time.Equal(createdAt) {
+		t.Fatalf("unexpected rows: %#v", rows)
+	}
+	assertCall(t, listUsersQuery)
+
+	row.Name = "bob"
+	if err := repo.Update(ctx, row); err != nil {
+		t.Fatal(err)
+	}
+	assertCall(t, updateUserQuery, "bob", "alice@example.com", nil, nil, true, []byte(nil), createdAt, nil, int64(42))
+
+	if err := repo.Delete(ctx, 42); err != nil {
+		t.Fatal(err)
+	}
+	assertCall(t, deleteUserQuery, int64(42))
+}
+
+func TestUserRoleRepository(t *testing.T) {
+	db, err := sql.Open("fake", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	repo := NewUserRoleRepository(db)
+	ctx := context.Background()
+
+	if err := repo.Insert(ctx, &UserRole{UserID: 42, Role: "admin"}); err != nil {
+		t.Fatal(err)
+	}
+	assertCall(t, insertUserRoleQuery, int64(42), "admin", nil)
+
+	if err := repo.Delete(ctx, 42, "admin"); err != nil {
+		t.Fatal(err)
+	}
+	assertCall(t, deleteUserRoleQuery, int64(42), "admin")
+
+	logs := NewAuditLogRepository(db)
+	if err := logs.Insert(ctx, &AuditLog{Message: "hello"}); err != nil {
+		t.Fatal(err)
+	}
+	assertCall(t, insertAuditLogQuery, "hello", nil)
+}
+`
+
+func TestShouldGeneratedSQLRepositoryWork(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this test builds the generated code")
+	}
+
+	schema, err := LoadSQLSchema("./testdata/sql/schema.sql")
+	assert.NoError(t, err)
+
+	files, err := schema.Files("users")
+	assert.NoError(t, err)
+
+	files["users_test.go"] = sqlRepositoryTestCode
+	runGoCommandsOnGeneratedPackage(t, files, []string{"vet", "."}, []string{"test", "."})
+
+	files, err = schema.NullStyle(SQLPointerTypes).Placeholder(SQLDollarPlaceholder).Files("users")
+	assert.NoError(t, err)
+	runGoCommandsOnGeneratedPackage(t, files, []string{"vet", "."})
+}
@@ -1,5 +1,7 @@
 package generator
 
+import "strings"
+
 // Statement is an interface that has a responsibility to generate the golang code.
 type Statement interface {
 	Generate(indentLevel int) (string, error)
@@ -13,3 +15,53 @@ func BuildIndent(indentLevel int) string {
 	}
 	return indent
 }
+
+// generateSimpleStatement generates a statement as a part of other statement (e.g. the init statement of `if` and `switch`);
+// that means leading indent and trailing newline are trimmed.
+func generateSimpleStatement(statement Statement) (string, error) {
+	gen, err := statement.Generate(0)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(gen), nil
+}
+
+// statementContainer is an interface for statements that contain other statements
+// (e.g. blocks, cases and the nested expressions like arguments of a call).
+type statementContainer interface {
+	childStatements() []Statement
+}
+
+// statementComposer is an interface for the high-level generators that compose their statements by the generators internally
+// (e.g. `Mock`). Such statements are built on demand, so their callers point to the code that triggers it (e.g. `Generate()`);
+// the errors, the source map and the nesting paths use the caller of the composer for them instead.
+type statementComposer interface {
+	composesStatements()
+}
+
+// generateStatementGroups generates each group of the statements, separated by a blank line.
+func generateStatementGroups(groups [][]Statement, indentLevel int) (string, error) {
+	stmt := ""
+	for i, group := range groups {
+		if i > 0 {
+			stmt += "\n"
+		}
+		for _, statement := range group {
+			gen, err := statement.Generate(indentLevel)
+			if err != nil {
+				return "", err
+			}
+			stmt += gen
+		}
+	}
+	return stmt, nil
+}
+
+// flattenStatementGroups returns the statements of the groups as a flat list.
+func flattenStatementGroups(groups [][]Statement) []Statement {
+	var statements []Statement
+	for _, group := range groups {
+		statements = append(statements, group...)
+	}
+	return statements
+}
@@ -2,15 +2,54 @@ package generator
 
 import (
 	"fmt"
+	"go/ast"
+	"reflect"
+	"strconv"
+	"strings"
 
 	"github.com/moznion/gowrtr/internal/errmsg"
 )
 
 // StructField represents a field of the struct.
 type StructField struct {
-	name string
-	typ  string
-	tag  string
+	name     string
+	typ      string
+	tag      string
+	comment  string
+	embedded bool
+}
+
+// NewStructField returns a new `StructField`; it is added to `Struct` by `AddFields()`.
+func NewStructField(name string, typ string) *StructField {
+	return &StructField{
+		name: name,
+		typ:  typ,
+	}
+}
+
+// Tag sets the tag that is built by `StructTag` to `StructField`.
+// This method returns a *new* `StructField`; it means this method acts as immutable.
+func (f *StructField) Tag(tag *StructTag) *StructField {
+	return &StructField{
+		name:     f.name,
+		typ:      f.typ,
+		tag:      tag.String(),
+		comment:  f.comment,
+		embedded: f.embedded,
+	}
+}
+
+// Comment sets the doc comment to `StructField`. Like `Comment`, each line of the comment is written after `//` as it is
+// (e.g. `" The name of the user."` => `// The name of the user.`).
+// This method returns a *new* `StructField`; it means this method acts as immutable.
+func (f *StructField) Comment(comment string) *StructField {
+	return &StructField{
+		name:     f.name,
+		typ:      f.typ,
+		tag:      f.tag,
+		comment:  comment,
+		embedded: f.embedded,
+	}
 }
 
 // Struct represents a code generator for `struct` notation.
@@ -19,6 +58,7 @@ type Struct struct {
 	fields        []*StructField
 	nameCaller    string
 	fieldsCallers []string
+	tagNamings    []*tagNaming
 }
 
 // NewStruct returns a new `Struct`.
@@ -30,6 +70,7 @@ func NewStruct(name string) *Struct {
 }
 
 // AddField adds a struct field to `Struct`.
+// `tag` is the raw struct tag without the surrounding backquotes (e.g. `json:"name"`); please see also `AddFieldWithTag()`.
 // This method returns a *new* `Struct`; it means this method acts as immutable.
 func (sg *Struct) AddField(name string, typ string, tag ...string) *Struct {
 	l := len(tag)
@@ -47,28 +88,107 @@ func (sg *Struct) AddField(name string, typ string, tag ...string) *Struct {
 		}),
 		nameCaller:    sg.nameCaller,
 		fieldsCallers: append(sg.fieldsCallers, fetchClientCallerLine()),
+		tagNamings:    sg.tagNamings,
+	}
+}
+
+// AddFields adds struct fields that are built by `NewStructField()` to `Struct`.
+// This method returns a *new* `Struct`; it means this method acts as immutable.
+func (sg *Struct) AddFields(fields ...*StructField) *Struct {
+	return &Struct{
+		name:          sg.name,
+		fields:        append(sg.fields, fields...),
+		nameCaller:    sg.nameCaller,
+		fieldsCallers: append(sg.fieldsCallers, fetchClientCallerLineAsSlice(len(fields))...),
+		tagNamings:    sg.tagNamings,
+	}
+}
+
+// AddFieldWithTag adds a struct field with the tag that is built by `StructTag` to `Struct`.
+// This method returns a *new* `Struct`; it means this method acts as immutable.
+func (sg *Struct) AddFieldWithTag(name string, typ string, tag *StructTag) *Struct {
+	return &Struct{
+		name: sg.name,
+		fields: append(sg.fields, &StructField{
+			name: name,
+			typ:  typ,
+			tag:  tag.String(),
+		}),
+		nameCaller:    sg.nameCaller,
+		fieldsCallers: append(sg.fieldsCallers, fetchClientCallerLine()),
+		tagNamings:    sg.tagNamings,
+	}
+}
+
+// TagNaming sets the naming strategy to derive the tag of `key` from the field name (e.g. `json:"user_id"` for `UserID`
+// by `SnakeCaseTagNaming`). The derived tag is added to each exported and non-embedded field that doesn't have `key` yet.
+// This method returns a *new* `Struct`; it means this method acts as immutable.
+func (sg *Struct) TagNaming(key string, strategy TagNamingStrategy) *Struct {
+	tagNamings := make([]*tagNaming, 0, len(sg.tagNamings)+1)
+	for _, naming := range sg.tagNamings {
+		if naming.key != key {
+			tagNamings = append(tagNamings, naming)
+		}
+	}
+
+	return &Struct{
+		name:          sg.name,
+		fields:        sg.fields,
+		nameCaller:    sg.nameCaller,
+		fieldsCallers: sg.fieldsCallers,
+		tagNamings: append(tagNamings, &tagNaming{
+			key:      key,
+			strategy: strategy,
+		}),
+	}
+}
+
+// AddEmbeddedField adds an embedded field to `Struct` (e.g. `*bytes.Buffer`).
+// The name of the field is derived from the type (e.g. `Buffer`).
+// This method returns a *new* `Struct`; it means this method acts as immutable.
+func (sg *Struct) AddEmbeddedField(typ string, tag ...string) *Struct {
+	t := ""
+	if len(tag) > 0 {
+		t = tag[0]
+	}
+
+	return &Struct{
+		name: sg.name,
+		fields: append(sg.fields, &StructField{
+			name:     embeddedFieldName(typ),
+			typ:      typ,
+			tag:      t,
+			embedded: true,
+		}),
+		nameCaller:    sg.nameCaller,
+		fieldsCallers: append(sg.fieldsCallers, fetchClientCallerLine()),
+		tagNamings:    sg.tagNamings,
 	}
 }
 
 // Generate generates `struct` block as golang code.
-func (sg *Struct) Generate(indentLevel int) (string, error) {
-	if sg.name == "" {
-		return "", errmsg.StructNameIsNilErr(sg.nameCaller)
+func (sg *Struct) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, sg.pathSegment())
+
+	if err := firstError(sg.validate()); err != nil {
+		return "", err
 	}
 
 	indent := BuildIndent(indentLevel)
 	stmt := fmt.Sprintf("%stype %s struct {\n", indent, sg.name)
 
-	for i, field := range sg.fields {
-		if field.name == "" {
-			return "", errmsg.StructFieldNameIsEmptyErr(sg.fieldsCallers[i])
+	for _, field := range sg.fields {
+		if field.comment != "" {
+			for _, line := range strings.Split(field.comment, "\n") {
+				stmt += fmt.Sprintf("%s\t//%s\n", indent, line)
+			}
 		}
-		if field.typ == "" {
-			return "", errmsg.StructFieldTypeIsEmptyErr(sg.fieldsCallers[i])
+		if field.embedded {
+			stmt += fmt.Sprintf("%s\t%s", indent, field.typ)
+		} else {
+			stmt += fmt.Sprintf("%s\t%s %s", indent, field.name, field.typ)
 		}
-
-		stmt += fmt.Sprintf("%s\t%s %s", indent, field.name, field.typ)
-		if tag := field.tag; tag != "" {
+		if tag := sg.tagOf(field); tag != "" {
 			stmt += fmt.Sprintf(" `%s`", tag)
 		}
 		stmt += "\n"
@@ -77,3 +197,86 @@ func (sg *Struct) Generate(indentLevel int) (string, error) {
 
 	return stmt, nil
 }
+
+func (sg *Struct) pathSegment() errorPathSegment {
+	return errorPathSegment{name: namedPathSegment("Struct", sg.name), caller: sg.nameCaller}
+}
+
+func (sg *Struct) validate() []error {
+	var errs []error
+	if sg.name == "" {
+		errs = append(errs, errmsg.StructNameIsNilErr(sg.nameCaller))
+	}
+	errs = appendIdentifierError(errs, sg.name, sg.nameCaller)
+	for i, field := range sg.fields {
+		if field.name == "" && !field.embedded {
+			errs = append(errs, errmsg.StructFieldNameIsEmptyErr(sg.fieldsCallers[i]))
+		}
+		errs = appendIdentifierError(errs, field.name, sg.fieldsCallers[i])
+		if field.typ == "" {
+			errs = append(errs, errmsg.StructFieldTypeIsEmptyErr(sg.fieldsCallers[i]))
+		}
+
+		tag := sg.tagOf(field)
+		if strings.Contains(tag, "`") {
+			errs = append(errs, errmsg.StructTagContainsBackquoteError(tag, sg.fieldsCallers[i]))
+		} else if !isValidStructTag(tag) {
+			errs = append(errs, errmsg.StructTagIsMalformedError(tag, sg.fieldsCallers[i]))
+		}
+	}
+	return errs
+}
+
+// tagOf returns the tag of the field with the tags that are derived by `TagNaming()`.
+func (sg *Struct) tagOf(field *StructField) string {
+	tag := field.tag
+	if field.embedded || !ast.IsExported(field.name) {
+		return tag
+	}
+
+	for _, naming := range sg.tagNamings {
+		if _, ok := reflect.StructTag(field.tag).Lookup(naming.key); ok {
+			continue
+		}
+		if tag != "" {
+			tag += " "
+		}
+		tag += naming.key + ":" + strconv.Quote(naming.strategy(field.name))
+	}
+	return tag
+}
+
+func (sg *Struct) warnings() []error {
+	return appendShadowingWarning(nil, sg.name, sg.nameCaller)
+}
+
+// embeddedFieldName returns the name of the embedded field, that is the type name without the pointer and the package
+// (e.g. `*bytes.Buffer` => `Buffer`).
+func embeddedFieldName(typ string) string {
+	name := strings.TrimPrefix(typ, "*")
+	if i := strings.Index(name, "["); i >= 0 {
+		name = name[:i]
+	}
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
+
+// defaultValue returns the default value of the field as an expression, that is specified by `default` key of the tag.
+// The value of the string field is quoted (e.g. `default:"localhost"` => `"localhost"`), and the others are used as they are.
+func (f *StructField) defaultValue() (string, bool) {
+	value, ok := reflect.StructTag(f.tag).Lookup("default")
+	if !ok {
+		return "", false
+	}
+	if f.typ == "string" {
+		return strconv.Quote(value), true
+	}
+	return value, true
+}
+
+// isRequired reports whether the field is required, that is specified by `required:"true"` of the tag.
+func (f *StructField) isRequired() bool {
+	return reflect.StructTag(f.tag).Get("required") == "true"
+}
@@ -17,3 +17,33 @@ func ExampleStruct_Generate() {
 	}
 	fmt.Println(generated)
 }
+
+func ExampleStruct_AddFieldWithTag() {
+	generator := NewStruct("User").
+		TagNaming("json", SnakeCaseTagNaming).
+		AddField("UserID", "int64").
+		AddFieldWithTag("Name", "string", NewStructTag().Add("json", "name", "omitempty").Add("validate", "required"))
+
+	generated, err := generator.Generate(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(generated)
+}
+
+func ExampleStruct_AddFields() {
+	generator := NewRoot(
+		NewStruct("User").AddFields(
+			NewStructField("ID", "int64").Tag(NewStructTag().Add("json", "id")),
+			NewStructField("Name", "string").
+				Comment(" Name is the display name of the user.").
+				Tag(NewStructTag().Add("json", "name", "omitempty")),
+		),
+	)
+
+	generated, err := generator.Generate(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(generated)
+}
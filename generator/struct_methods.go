@@ -0,0 +1,489 @@
+package generator
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
+
+// EqualMethod represents a code generator for `Equal(other *T) bool` method of the struct.
+//
+// The method compares the fields deeply: the pointers are compared by the pointed values, and the slices and the maps
+// are compared by the elements (a nil slice or map equals to the empty one). The nested structs that are registered by
+// `NestedStructs()` (and the struct itself) are compared by their `Equal()` method, the func fields are ignored,
+// and the other types are compared by `==`, so they must be comparable.
+type EqualMethod struct {
+	structure     *Struct
+	nestedStructs []string
+	caller        string
+}
+
+// NewEqualMethod returns a new `EqualMethod` for the struct.
+func NewEqualMethod(structure *Struct) *EqualMethod {
+	return &EqualMethod{
+		structure: structure,
+		caller:    fetchClientCallerLine(),
+	}
+}
+
+// NestedStructs sets the names of the struct types that have `Equal()` method (e.g. the structs that are also
+// generated by this generator). The fields of such types (and the pointers of them) are compared by that method.
+// This method returns a *new* `EqualMethod`; it means this method acts as immutable.
+func (m *EqualMethod) NestedStructs(typeNames ...string) *EqualMethod {
+	return &EqualMethod{
+		structure:     m.structure,
+		nestedStructs: typeNames,
+		caller:        m.caller,
+	}
+}
+
+// Generate generates `Equal()` method as golang code.
+func (m *EqualMethod) Generate(indentLevel int) (generated string, err error) {
+	defer annotateComposedErrorPath(&err, m.pathSegment())
+
+	if err := firstError(m.validate()); err != nil {
+		return "", err
+	}
+
+	return generateStatementGroups(m.statementGroups(), indentLevel)
+}
+
+func (m *EqualMethod) statementGroups() [][]Statement {
+	structName := m.structure.name
+	recv := structVariableName(m.structure)
+	types := newDerivedTypes(structName, m.nestedStructs)
+
+	statements := []Statement{
+		NewIf(recv+" == nil || other == nil", NewReturnStatement(recv+" == other")),
+	}
+	for _, field := range m.structure.fields {
+		statements = append(statements, types.equalStatements(recv+"."+field.name, "other."+field.name, field.typ, 0)...)
+	}
+	statements = append(statements, NewReturnStatement("true"))
+
+	return [][]Statement{
+		{
+			NewCommentf(" Equal reports whether the %s equals to other deeply.", structName),
+			NewFunc(
+				NewFuncReceiver(recv, "*"+structName),
+				NewFuncSignature("Equal").AddParameters(NewFuncParameter("other", "*"+structName)).AddReturnTypes("bool"),
+				statements...,
+			),
+		},
+	}
+}
+
+func (m *EqualMethod) validate() []error {
+	return validateDerivedStruct(m.structure, m.caller)
+}
+
+func (m *EqualMethod) childStatements() []Statement {
+	if len(m.validate()) > 0 {
+		return nil
+	}
+	return flattenStatementGroups(m.statementGroups())
+}
+
+func (m *EqualMethod) pathSegment() errorPathSegment {
+	return errorPathSegment{name: namedPathSegment("EqualMethod", derivedStructName(m.structure)), caller: m.caller}
+}
+
+func (m *EqualMethod) composesStatements() {}
+
+// CloneMethod represents a code generator for `Clone() *T` method of the struct.
+//
+// The method copies the fields deeply: the pointers, the slices and the maps are newly allocated with the copied elements.
+// The nested structs that are registered by `NestedStructs()` (and the struct itself) are copied by their `Clone()`
+// method, and the other types are copied by the assignment.
+type CloneMethod struct {
+	structure     *Struct
+	nestedStructs []string
+	caller        string
+}
+
+// NewCloneMethod returns a new `CloneMethod` for the struct.
+func NewCloneMethod(structure *Struct) *CloneMethod {
+	return &CloneMethod{
+		structure: structure,
+		caller:    fetchClientCallerLine(),
+	}
+}
+
+// NestedStructs sets the names of the struct types that have `Clone()` method (e.g. the structs that are also
+// generated by this generator). The fields of such types (and the pointers of them) are copied by that method.
+// This method returns a *new* `CloneMethod`; it means this method acts as immutable.
+func (m *CloneMethod) NestedStructs(typeNames ...string) *CloneMethod {
+	return &CloneMethod{
+		structure:     m.structure,
+		nestedStructs: typeNames,
+		caller:        m.caller,
+	}
+}
+
+// Generate generates `Clone()` method as golang code.
+func (m *CloneMethod) Generate(indentLevel int) (generated string, err error) {
+	defer annotateComposedErrorPath(&err, m.pathSegment())
+
+	if err := firstError(m.validate()); err != nil {
+		return "", err
+	}
+
+	return generateStatementGroups(m.statementGroups(), indentLevel)
+}
+
+func (m *CloneMethod) statementGroups() [][]Statement {
+	structName := m.structure.name
+	recv := structVariableName(m.structure)
+	types := newDerivedTypes(structName, m.nestedStructs)
+
+	statements := []Statement{
+		NewIf(recv+" == nil", NewReturnStatement("nil")),
+		NewShortVarDecl([]string{"cloned"}, NewRawStatement("*"+recv)),
+	}
+	for _, field := range m.structure.fields {
+		statements = append(statements, types.cloneStatements("cloned."+field.name, recv+"."+field.name, field.typ, 0)...)
+	}
+	statements = append(statements, NewReturnStatement("&cloned"))
+
+	return [][]Statement{
+		{
+			NewCommentf(" Clone returns a deep copy of the %s.", structName),
+			NewFunc(
+				NewFuncReceiver(recv, "*"+structName),
+				NewFuncSignature("Clone").AddReturnTypes("*"+structName),
+				statements...,
+			),
+		},
+	}
+}
+
+func (m *CloneMethod) validate() []error {
+	return validateDerivedStruct(m.structure, m.caller)
+}
+
+func (m *CloneMethod) childStatements() []Statement {
+	if len(m.validate()) > 0 {
+		return nil
+	}
+	return flattenStatementGroups(m.statementGroups())
+}
+
+func (m *CloneMethod) pathSegment() errorPathSegment {
+	return errorPathSegment{name: namedPathSegment("CloneMethod", derivedStructName(m.structure)), caller: m.caller}
+}
+
+func (m *CloneMethod) composesStatements() {}
+
+// GetterMethods represents a code generator for the getter methods of the struct (e.g. `GetName() string`).
+// Like the getters of protobuf, they are nil-safe; they return the zero value if the receiver is nil.
+type GetterMethods struct {
+	structure *Struct
+	caller    string
+}
+
+// NewGetterMethods returns a new `GetterMethods` for the struct.
+func NewGetterMethods(structure *Struct) *GetterMethods {
+	return &GetterMethods{
+		structure: structure,
+		caller:    fetchClientCallerLine(),
+	}
+}
+
+// Generate generates the getter methods as golang code.
+func (m *GetterMethods) Generate(indentLevel int) (generated string, err error) {
+	defer annotateComposedErrorPath(&err, m.pathSegment())
+
+	if err := firstError(m.validate()); err != nil {
+		return "", err
+	}
+
+	return generateStatementGroups(m.statementGroups(), indentLevel)
+}
+
+func (m *GetterMethods) statementGroups() [][]Statement {
+	structName := m.structure.name
+	recv := structVariableName(m.structure)
+
+	groups := make([][]Statement, 0, len(m.structure.fields))
+	for _, field := range m.structure.fields {
+		methodName := "Get" + accessorName(field.name)
+		groups = append(groups, []Statement{
+			NewCommentf(" %s returns %s of the %s; it returns the zero value if the %s is nil.", methodName, field.name, structName, structName),
+			NewFunc(
+				NewFuncReceiver(recv, "*"+structName),
+				NewFuncSignature(methodName).AddReturnTypes(field.typ),
+				NewIf(recv+" == nil", NewReturnStatement(zeroValueOf(field.typ))),
+				NewReturnStatement(recv+"."+field.name),
+			),
+		})
+	}
+	return groups
+}
+
+func (m *GetterMethods) validate() []error {
+	return validateDerivedStruct(m.structure, m.caller)
+}
+
+func (m *GetterMethods) childStatements() []Statement {
+	if len(m.validate()) > 0 {
+		return nil
+	}
+	return flattenStatementGroups(m.statementGroups())
+}
+
+func (m *GetterMethods) pathSegment() errorPathSegment {
+	return errorPathSegment{name: namedPathSegment("GetterMethods", derivedStructName(m.structure)), caller: m.caller}
+}
+
+func (m *GetterMethods) composesStatements() {}
+
+// SetterMethods represents a code generator for the setter methods of the struct (e.g. `SetName(name string)`).
+type SetterMethods struct {
+	structure *Struct
+	caller    string
+}
+
+// NewSetterMethods returns a new `SetterMethods` for the struct.
+func NewSetterMethods(structure *Struct) *SetterMethods {
+	return &SetterMethods{
+		structure: structure,
+		caller:    fetchClientCallerLine(),
+	}
+}
+
+// Generate generates the setter methods as golang code.
+func (m *SetterMethods) Generate(indentLevel int) (generated string, err error) {
+	defer annotateComposedErrorPath(&err, m.pathSegment())
+
+	if err := firstError(m.validate()); err != nil {
+		return "", err
+	}
+
+	return generateStatementGroups(m.statementGroups(), indentLevel)
+}
+
+func (m *SetterMethods) statementGroups() [][]Statement {
+	structName := m.structure.name
+	recv := structVariableName(m.structure)
+
+	groups := make([][]Statement, 0, len(m.structure.fields))
+	for _, field := range m.structure.fields {
+		methodName := "Set" + accessorName(field.name)
+		param := ToUnexportedName(field.name)
+		groups = append(groups, []Statement{
+			NewCommentf(" %s sets %s of the %s.", methodName, field.name, structName),
+			NewFunc(
+				NewFuncReceiver(recv, "*"+structName),
+				NewFuncSignature(methodName).AddParameters(NewFuncParameter(param, field.typ)),
+				NewAssign([]string{recv + "." + field.name}, "=", NewRawStatement(param)),
+			),
+		})
+	}
+	return groups
+}
+
+func (m *SetterMethods) validate() []error {
+	return validateDerivedStruct(m.structure, m.caller)
+}
+
+func (m *SetterMethods) childStatements() []Statement {
+	if len(m.validate()) > 0 {
+		return nil
+	}
+	return flattenStatementGroups(m.statementGroups())
+}
+
+func (m *SetterMethods) pathSegment() errorPathSegment {
+	return errorPathSegment{name: namedPathSegment("SetterMethods", derivedStructName(m.structure)), caller: m.caller}
+}
+
+func (m *SetterMethods) composesStatements() {}
+
+func validateDerivedStruct(structure *Struct, caller string) []error {
+	if structure == nil {
+		return []error{errmsg.DerivedStructIsNilError(caller)}
+	}
+	return structure.validate()
+}
+
+func derivedStructName(structure *Struct) string {
+	if structure == nil {
+		return ""
+	}
+	return structure.name
+}
+
+// derivedTypes knows the struct types that have the derived methods (i.e. `Equal()` and `Clone()`).
+type derivedTypes map[string]bool
+
+func newDerivedTypes(structName string, nestedStructs []string) derivedTypes {
+	types := derivedTypes{structName: true}
+	for _, name := range nestedStructs {
+		types[name] = true
+	}
+	return types
+}
+
+// needsDeepCopy reports whether the value of the type shares the memory with its copy by the assignment.
+func (types derivedTypes) needsDeepCopy(typ string) bool {
+	return types[typ] || strings.HasPrefix(typ, "*") || strings.HasPrefix(typ, "[]") || strings.HasPrefix(typ, "map[")
+}
+
+// equalStatements returns the statements that return false if `a` doesn't equal to `b`.
+// `depth` is used to name the loop variables uniquely.
+func (types derivedTypes) equalStatements(a string, b string, typ string, depth int) []Statement {
+	returnFalse := NewReturnStatement("false")
+
+	switch {
+	case types[typ]:
+		return []Statement{NewIf(fmt.Sprintf("!%s.Equal(&%s)", a, b), returnFalse)}
+
+	case strings.HasPrefix(typ, "*"):
+		elem := typ[1:]
+		if types[elem] {
+			return []Statement{NewIf(fmt.Sprintf("!%s.Equal(%s)", a, b), returnFalse)}
+		}
+		if isFuncType(elem) {
+			return nil
+		}
+		if !types.needsDeepCopy(elem) {
+			return []Statement{
+				NewIf(fmt.Sprintf("(%s == nil) != (%s == nil) || (%s != nil && *%s != *%s)", a, b, a, a, b), returnFalse),
+			}
+		}
+		return []Statement{
+			NewIf(fmt.Sprintf("(%s == nil) != (%s == nil)", a, b), returnFalse),
+			NewIf(
+				a+" != nil",
+				types.equalStatements("(*"+a+")", "(*"+b+")", elem, depth)...,
+			),
+		}
+
+	case strings.HasPrefix(typ, "[]"):
+		i := fmt.Sprintf("i%d", depth)
+		return []Statement{
+			NewIf(fmt.Sprintf("len(%s) != len(%s)", a, b), returnFalse),
+			NewForRange(
+				i, "", a,
+				types.equalStatements(a+"["+i+"]", b+"["+i+"]", typ[2:], depth+1)...,
+			),
+		}
+
+	case strings.HasPrefix(typ, "map["):
+		_, valueType := splitMapType(typ)
+		k := fmt.Sprintf("k%d", depth)
+		v := fmt.Sprintf("v%d", depth)
+		w := fmt.Sprintf("w%d", depth)
+		ok := fmt.Sprintf("ok%d", depth)
+		body := []Statement{
+			NewShortVarDecl([]string{w, ok}, NewRawStatementf("%s[%s]", b, k)),
+			NewIf("!"+ok, returnFalse),
+		}
+		body = append(body, types.equalStatements(v, w, valueType, depth+1)...)
+		return []Statement{
+			NewIf(fmt.Sprintf("len(%s) != len(%s)", a, b), returnFalse),
+			NewForRange(k, v, a, body...),
+		}
+
+	case isFuncType(typ):
+		// func is not comparable
+		return nil
+	}
+
+	return []Statement{NewIf(fmt.Sprintf("%s != %s", a, b), returnFalse)}
+}
+
+// cloneStatements returns the statements that assign the deep copy of `src` to `dst`.
+// `dst` must already have the shallow copy of `src`, so this returns nothing for the types that don't need the deep copy.
+// `depth` is used to name the loop variables uniquely.
+func (types derivedTypes) cloneStatements(dst string, src string, typ string, depth int) []Statement {
+	switch {
+	case types[typ]:
+		return []Statement{NewAssign([]string{dst}, "=", NewRawStatementf("*%s.Clone()", src))}
+
+	case strings.HasPrefix(typ, "*"):
+		elem := typ[1:]
+		if types[elem] {
+			return []Statement{NewAssign([]string{dst}, "=", NewRawStatementf("%s.Clone()", src))}
+		}
+		v := fmt.Sprintf("v%d", depth)
+		body := []Statement{NewShortVarDecl([]string{v}, NewRawStatement("*"+src))}
+		body = append(body, types.cloneStatements(v, "(*"+src+")", elem, depth+1)...)
+		body = append(body, NewAssign([]string{dst}, "=", NewRawStatement("&"+v)))
+		return []Statement{NewIf(src+" != nil", body...)}
+
+	case strings.HasPrefix(typ, "[]"):
+		elem := typ[2:]
+		body := []Statement{
+			NewAssign([]string{dst}, "=", NewCall("make", NewRawStatement(typ), NewRawStatementf("len(%s)", src))),
+		}
+		if types.needsDeepCopy(elem) {
+			// each element is deep copied, and the element of the zero value is left as it is
+			i := fmt.Sprintf("i%d", depth)
+			body = append(body, NewForRange(
+				i, "", src,
+				types.cloneStatements(dst+"["+i+"]", src+"["+i+"]", elem, depth+1)...,
+			))
+		} else {
+			body = append(body, NewRawStatementf("copy(%s, %s)", dst, src))
+		}
+		return []Statement{NewIf(src+" != nil", body...)}
+
+	case strings.HasPrefix(typ, "map["):
+		_, valueType := splitMapType(typ)
+		k := fmt.Sprintf("k%d", depth)
+		v := fmt.Sprintf("v%d", depth)
+		var loopBody []Statement
+		if !types.clonesAlways(valueType) {
+			// the value is stored as it is, even if it is nil
+			loopBody = append(loopBody, NewAssign([]string{dst + "[" + k + "]"}, "=", NewRawStatement(v)))
+		}
+		loopBody = append(loopBody, types.cloneStatements(dst+"["+k+"]", v, valueType, depth+1)...)
+		return []Statement{
+			NewIf(
+				src+" != nil",
+				NewAssign([]string{dst}, "=", NewCall("make", NewRawStatement(typ), NewRawStatementf("len(%s)", src))),
+				NewForRange(k, v, src, loopBody...),
+			),
+		}
+	}
+
+	return nil
+}
+
+// clonesAlways reports whether `cloneStatements()` always assigns the deep copy, regardless of the value.
+func (types derivedTypes) clonesAlways(typ string) bool {
+	return types[typ] || (strings.HasPrefix(typ, "*") && types[typ[1:]])
+}
+
+// accessorName returns the name of the field that is used in the names of the getter and setter (e.g. `GetName`).
+// The exported name is used as it is (e.g. `MM` and `PIn`), and the unexported one is exported.
+func accessorName(fieldName string) string {
+	if token.IsExported(fieldName) {
+		return fieldName
+	}
+	return ToExportedName(fieldName)
+}
+
+// splitMapType splits the map type into the key type and the value type (e.g. `map[string][]int` => `string`, `[]int`).
+func splitMapType(typ string) (string, string) {
+	depth := 0
+	for i, c := range typ {
+		switch c {
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				return typ[len("map["):i], typ[i+1:]
+			}
+		}
+	}
+	return "", ""
+}
+
+func isFuncType(typ string) bool {
+	return strings.HasPrefix(typ, "func(") || strings.HasPrefix(typ, "func (")
+}
@@ -0,0 +1,52 @@
+package generator
+
+import (
+	"fmt"
+	"log"
+)
+
+func ExampleEqualMethod_Generate() {
+	structure := NewStruct("User").
+		AddField("Name", "string").
+		AddField("Tags", "[]string")
+
+	generated, err := NewEqualMethod(structure).Generate(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(generated)
+}
+
+func ExampleCloneMethod_Generate() {
+	structure := NewStruct("User").
+		AddField("Name", "string").
+		AddField("Tags", "[]string")
+
+	generated, err := NewCloneMethod(structure).Generate(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(generated)
+}
+
+func ExampleGetterMethods_Generate() {
+	structure := NewStruct("User").
+		AddField("Name", "string")
+
+	generated, err := NewGetterMethods(structure).Generate(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(generated)
+}
+
+func ExampleSetterMethods_Generate() {
+	structure := NewStruct("User").
+		AddField("Name", "string")
+
+	generated, err := NewSetterMethods(structure).Generate(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(generated)
+}
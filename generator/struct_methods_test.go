@@ -0,0 +1,315 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldGenerateEqualMethod(t *testing.T) {
+	structure := NewStruct("User").
+		AddEmbeddedField("Base").
+		AddField("Name", "string").
+		AddField("Age", "*int").
+		AddField("Friend", "*User").
+		AddField("Tags", "[]string").
+		AddField("Attrs", "map[string][]byte").
+		AddField("Hook", "func()")
+
+	expected := `// Equal reports whether the User equals to other deeply.
+func (u *User) Equal(other *User) bool {
+	if u == nil || other == nil {
+		return u == other
+	}
+	if !u.Base.Equal(&other.Base) {
+		return false
+	}
+	if u.Name != other.Name {
+		return false
+	}
+	if (u.Age == nil) != (other.Age == nil) || (u.Age != nil && *u.Age != *other.Age) {
+		return false
+	}
+	if !u.Friend.Equal(other.Friend) {
+		return false
+	}
+	if len(u.Tags) != len(other.Tags) {
+		return false
+	}
+	for i0 := range u.Tags {
+		if u.Tags[i0] != other.Tags[i0] {
+			return false
+		}
+	}
+	if len(u.Attrs) != len(other.Attrs) {
+		return false
+	}
+	for k0, v0 := range u.Attrs {
+		w0, ok0 := other.Attrs[k0]
+		if !ok0 {
+			return false
+		}
+		if len(v0) != len(w0) {
+			return false
+		}
+		for i1 := range v0 {
+			if v0[i1] != w0[i1] {
+				return false
+			}
+		}
+	}
+	return true
+}
+`
+
+	gen, err := NewEqualMethod(structure).NestedStructs("Base").Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+}
+
+func TestShouldGenerateCloneMethod(t *testing.T) {
+	structure := NewStruct("User").
+		AddEmbeddedField("Base").
+		AddField("Name", "string").
+		AddField("Age", "*int").
+		AddField("Friend", "*User").
+		AddField("Tags", "[]string").
+		AddField("Attrs", "map[string][]byte").
+		AddField("Friends", "[]*User").
+		AddField("Bases", "map[string]Base")
+
+	expected := `// Clone returns a deep copy of the User.
+func (u *User) Clone() *User {
+	if u == nil {
+		return nil
+	}
+	cloned := *u
+	cloned.Base = *u.Base.Clone()
+	if u.Age != nil {
+		v0 := *u.Age
+		cloned.Age = &v0
+	}
+	cloned.Friend = u.Friend.Clone()
+	if u.Tags != nil {
+		cloned.Tags = make([]string, len(u.Tags))
+		copy(cloned.Tags, u.Tags)
+	}
+	if u.Attrs != nil {
+		cloned.Attrs = make(map[string][]byte, len(u.Attrs))
+		for k0, v0 := range u.Attrs {
+			cloned.Attrs[k0] = v0
+			if v0 != nil {
+				cloned.Attrs[k0] = make([]byte, len(v0))
+				copy(cloned.Attrs[k0], v0)
+			}
+		}
+	}
+	if u.Friends != nil {
+		cloned.Friends = make([]*User, len(u.Friends))
+		for i0 := range u.Friends {
+			cloned.Friends[i0] = u.Friends[i0].Clone()
+		}
+	}
+	if u.Bases != nil {
+		cloned.Bases = make(map[string]Base, len(u.Bases))
+		for k0, v0 := range u.Bases {
+			cloned.Bases[k0] = *v0.Clone()
+		}
+	}
+	return &cloned
+}
+`
+
+	gen, err := NewCloneMethod(structure).NestedStructs("Base").Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+}
+
+func TestShouldGenerateGetterAndSetterMethods(t *testing.T) {
+	structure := NewStruct("User").
+		AddField("Name", "string").
+		AddField("u", "*int").
+		AddField("MM", "int").
+		AddField("PIn", "string")
+
+	{
+		expected := `// GetName returns Name of the User; it returns the zero value if the User is nil.
+func (u_ *User) GetName() string {
+	if u_ == nil {
+		return ""
+	}
+	return u_.Name
+}
+
+// GetU returns u of the User; it returns the zero value if the User is nil.
+func (u_ *User) GetU() *int {
+	if u_ == nil {
+		return nil
+	}
+	return u_.u
+}
+
+// GetMM returns MM of the User; it returns the zero value if the User is nil.
+func (u_ *User) GetMM() int {
+	if u_ == nil {
+		return 0
+	}
+	return u_.MM
+}
+
+// GetPIn returns PIn of the User; it returns the zero value if the User is nil.
+func (u_ *User) GetPIn() string {
+	if u_ == nil {
+		return ""
+	}
+	return u_.PIn
+}
+`
+		gen, err := NewGetterMethods(structure).Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, gen)
+	}
+
+	{
+		expected := `// SetName sets Name of the User.
+func (u_ *User) SetName(name string) {
+	u_.Name = name
+}
+
+// SetU sets u of the User.
+func (u_ *User) SetU(u *int) {
+	u_.u = u
+}
+
+// SetMM sets MM of the User.
+func (u_ *User) SetMM(mm int) {
+	u_.MM = mm
+}
+
+// SetPIn sets PIn of the User.
+func (u_ *User) SetPIn(pIn string) {
+	u_.PIn = pIn
+}
+`
+		gen, err := NewSetterMethods(structure).Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, gen)
+	}
+}
+
+func TestShouldRaiseErrorWhenStructOfMethodsIsInvalid(t *testing.T) {
+	for _, generator := range []Statement{
+		NewEqualMethod(nil),
+		NewCloneMethod(nil),
+		NewGetterMethods(nil),
+		NewSetterMethods(nil),
+	} {
+		_, err := generator.Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.DerivedStructIsNilError("").Error(), " ")[0],
+		), err.Error())
+	}
+
+	for _, generator := range []Statement{
+		NewEqualMethod(NewStruct("User").AddField("", "string")),
+		NewCloneMethod(NewStruct("User").AddField("", "string")),
+		NewGetterMethods(NewStruct("User").AddField("", "string")),
+		NewSetterMethods(NewStruct("User").AddField("", "string")),
+	} {
+		_, err := generator.Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.StructFieldNameIsEmptyErr("").Error(), " ")[0],
+		), err.Error())
+	}
+}
+
+func TestShouldSplitMapType(t *testing.T) {
+	key, value := splitMapType("map[string][]int")
+	assert.Equal(t, "string", key)
+	assert.Equal(t, "[]int", value)
+
+	key, value = splitMapType("map[[2]int]map[string]bool")
+	assert.Equal(t, "[2]int", key)
+	assert.Equal(t, "map[string]bool", value)
+}
+
+func TestShouldGeneratedStructMethodsWork(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this test builds the generated code")
+	}
+
+	base := NewStruct("Base").AddField("ID", "int")
+	node := NewStruct("Node").
+		AddEmbeddedField("Base").
+		AddField("Name", "string").
+		AddField("Next", "*Node").
+		AddField("Count", "*int").
+		AddField("Children", "[]*Base").
+		AddField("Attrs", "map[string][]string").
+		AddField("Bases", "map[string]Base").
+		AddField("Hook", "func()")
+
+	code, err := NewRoot(
+		NewPackage("node"),
+		NewNewline(),
+		base,
+		NewEqualMethod(base),
+		NewCloneMethod(base),
+		NewNewline(),
+		node,
+		NewEqualMethod(node).NestedStructs("Base"),
+		NewCloneMethod(node).NestedStructs("Base"),
+		NewGetterMethods(node),
+		NewSetterMethods(node),
+	).Gofmt().EnableTypeChecking("").Generate(0)
+	assert.NoError(t, err)
+
+	testCode, err := NewRoot(
+		NewPackage("node"),
+		NewImport("testing"),
+		NewNewline(),
+		NewFunc(
+			nil,
+			NewFuncSignature("TestNode").AddParameters(NewFuncParameter("t", "*testing.T")),
+			NewShortVarDecl([]string{"count"}, NewRawStatement("1")),
+			NewShortVarDecl([]string{"n"}, NewRawStatement(`&Node{
+				Base:     Base{ID: 1},
+				Name:     "a",
+				Next:     &Node{Name: "b"},
+				Count:    &count,
+				Children: []*Base{{ID: 2}, nil},
+				Attrs:    map[string][]string{"k": {"v"}, "nil": nil},
+				Bases:    map[string]Base{"k": {ID: 4}},
+			}`)),
+			NewShortVarDecl([]string{"c"}, NewRawStatement("n.Clone()")),
+			NewIf("!n.Equal(c) || !c.Equal(n)", NewRawStatement(`t.Fatal("clone must equal to the original")`)),
+			NewRawStatement("*c.Count = 2"),
+			NewRawStatement("c.Next.Name = \"c\""),
+			NewRawStatement("c.Children[0].ID = 3"),
+			NewRawStatement("c.Attrs[\"k\"][0] = \"w\""),
+			NewIf(`_, ok := c.Attrs["nil"]; !ok || c.Children[1] != nil || c.Bases["k"].ID != 4`, NewRawStatement(`t.Fatal("clone must keep the nil values")`)),
+			NewIf(
+				`*n.Count != 1 || n.Next.Name != "b" || n.Children[0].ID != 2 || n.Attrs["k"][0] != "v"`,
+				NewRawStatement(`t.Fatal("clone must not share the memory with the original")`),
+			),
+			NewIf("n.Equal(c)", NewRawStatement(`t.Fatal("modified clone must not equal to the original")`)),
+			NewVar([]string{"nilNode"}, "*Node"),
+			NewIf(
+				`nilNode.GetName() != "" || nilNode.GetNext() != nil || nilNode.Clone() != nil || !nilNode.Equal(nil) || nilNode.Equal(n)`,
+				NewRawStatement(`t.Fatal("methods must be nil-safe")`),
+			),
+			NewRawStatement(`n.SetName("z")`),
+			NewIf(`n.GetName() != "z"`, NewRawStatement(`t.Fatal("setter must set the value")`)),
+		),
+	).Gofmt().Generate(0)
+	assert.NoError(t, err)
+
+	runGoCommandsOnGeneratedPackage(t, map[string]string{
+		"node.go":      code,
+		"node_test.go": testCode,
+	}, []string{"vet", "."}, []string{"test", "."})
+}
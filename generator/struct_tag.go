@@ -0,0 +1,138 @@
+package generator
+
+import (
+	"strconv"
+	"strings"
+)
+
+// StructTag represents a builder of the struct tag (e.g. `json:"name,omitempty" db:"name"`).
+// The keys are written in the order of addition, so the generated tag is deterministic.
+type StructTag struct {
+	entries []*structTagEntry
+}
+
+type structTagEntry struct {
+	key     string
+	value   string
+	options []string
+}
+
+// NewStructTag returns a new `StructTag`.
+func NewStructTag() *StructTag {
+	return &StructTag{}
+}
+
+// Add adds a key to `StructTag` with the value and the options (e.g. `Add("json", "name", "omitempty")` => `json:"name,omitempty"`).
+// If the key has been already added, this replaces the value and the options of that at the same position.
+// To exclude the field (e.g. from `encoding/json`), please specify `-` as the value.
+// This method returns a *new* `StructTag`; it means this method acts as immutable.
+func (t *StructTag) Add(key string, value string, options ...string) *StructTag {
+	entry := &structTagEntry{
+		key:     key,
+		value:   value,
+		options: options,
+	}
+
+	entries := make([]*structTagEntry, 0, len(t.entries)+1)
+	replaced := false
+	for _, e := range t.entries {
+		if e.key == key {
+			entries = append(entries, entry)
+			replaced = true
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if !replaced {
+		entries = append(entries, entry)
+	}
+
+	return &StructTag{
+		entries: entries,
+	}
+}
+
+// String returns the struct tag without the surrounding backquotes.
+func (t *StructTag) String() string {
+	if t == nil {
+		return ""
+	}
+
+	pairs := make([]string, len(t.entries))
+	for i, e := range t.entries {
+		value := strings.Join(append([]string{e.value}, e.options...), ",")
+		pairs[i] = e.key + ":" + strconv.Quote(value)
+	}
+	return strings.Join(pairs, " ")
+}
+
+// TagNamingStrategy is a function that derives the value of the struct tag from the field name.
+type TagNamingStrategy func(fieldName string) string
+
+// SnakeCaseTagNaming is a `TagNamingStrategy` that converts the field name into snake_case (e.g. `UserID` => `user_id`).
+func SnakeCaseTagNaming(fieldName string) string {
+	words := splitWords(fieldName)
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+	return strings.Join(words, "_")
+}
+
+// CamelCaseTagNaming is a `TagNamingStrategy` that converts the field name into camelCase (e.g. `UserID` => `userId`).
+func CamelCaseTagNaming(fieldName string) string {
+	name := ""
+	for i, word := range splitWords(fieldName) {
+		if i == 0 {
+			name += strings.ToLower(word)
+			continue
+		}
+		name += capitalize(word)
+	}
+	return name
+}
+
+type tagNaming struct {
+	key      string
+	strategy TagNamingStrategy
+}
+
+// isValidStructTag reports whether the tag is the conventional form of the struct tag, that is space-separated `key:"value"` pairs.
+// This follows the parsing of `reflect.StructTag` and the check of `go vet`.
+func isValidStructTag(tag string) bool {
+	for tag != "" {
+		// key
+		i := 0
+		for i < len(tag) && tag[i] > ' ' && tag[i] != ':' && tag[i] != '"' && tag[i] != 0x7f {
+			i++
+		}
+		if i == 0 || i+1 >= len(tag) || tag[i] != ':' || tag[i+1] != '"' {
+			return false
+		}
+		tag = tag[i+1:]
+
+		// quoted value
+		i = 1
+		for i < len(tag) && tag[i] != '"' {
+			if tag[i] == '\\' {
+				i++
+			}
+			i++
+		}
+		if i >= len(tag) {
+			return false
+		}
+		if _, err := strconv.Unquote(tag[:i+1]); err != nil {
+			return false
+		}
+		tag = tag[i+1:]
+
+		if tag == "" {
+			break
+		}
+		if tag[0] != ' ' {
+			return false
+		}
+		tag = strings.TrimLeft(tag, " ")
+	}
+	return true
+}
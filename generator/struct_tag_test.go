@@ -0,0 +1,104 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldBuildStructTag(t *testing.T) {
+	tag := NewStructTag().
+		Add("json", "user_id", "omitempty").
+		Add("yaml", "userId").
+		Add("db", "user_id").
+		Add("validate", "required,min=1")
+	assert.Equal(t, `json:"user_id,omitempty" yaml:"userId" db:"user_id" validate:"required,min=1"`, tag.String())
+
+	replaced := tag.Add("yaml", "-")
+	assert.Equal(t, `json:"user_id,omitempty" yaml:"-" db:"user_id" validate:"required,min=1"`, replaced.String())
+	assert.Equal(t, `json:"user_id,omitempty" yaml:"userId" db:"user_id" validate:"required,min=1"`, tag.String(), "must be immutable")
+
+	assert.Equal(t, `regexp:"^\"[a-z]+\"$"`, NewStructTag().Add("regexp", `^"[a-z]+"$`).String())
+	assert.Equal(t, "", NewStructTag().String())
+}
+
+func TestShouldDeriveTagValueByNamingStrategy(t *testing.T) {
+	for name, expected := range map[string][2]string{
+		"UserID":     {"user_id", "userId"},
+		"HTTPServer": {"http_server", "httpServer"},
+		"Name":       {"name", "name"},
+		"createdAt":  {"created_at", "createdAt"},
+	} {
+		assert.Equal(t, expected[0], SnakeCaseTagNaming(name))
+		assert.Equal(t, expected[1], CamelCaseTagNaming(name))
+	}
+}
+
+func TestShouldValidateStructTag(t *testing.T) {
+	for _, tag := range []string{
+		``,
+		`json:"name"`,
+		`json:"name,omitempty" yaml:"name"`,
+		`json:"name"  db:"name"`,
+		`regexp:"^\"[a-z]+\"$"`,
+	} {
+		assert.True(t, isValidStructTag(tag), tag)
+	}
+
+	for _, tag := range []string{
+		`json:name`,
+		`json`,
+		`json:"name`,
+		`json:"name"db:"name"`,
+		`:"name"`,
+		` json:"name"`,
+		`json :"name"`,
+		`json:"\z"`,
+	} {
+		assert.False(t, isValidStructTag(tag), tag)
+	}
+}
+
+func TestShouldGenerateStructWithTagBuilderAndNaming(t *testing.T) {
+	structGenerator := NewStruct("User").
+		TagNaming("json", SnakeCaseTagNaming).
+		AddField("UserID", "int64").
+		AddFieldWithTag("Name", "string", NewStructTag().Add("json", "name", "omitempty").Add("db", "name")).
+		AddField("Password", "string", `json:"-"`).
+		AddField("internal", "string").
+		AddEmbeddedField("Base").
+		TagNaming("yaml", CamelCaseTagNaming)
+
+	expected := "type User struct {\n" +
+		"	UserID int64 `json:\"user_id\" yaml:\"userId\"`\n" +
+		"	Name string `json:\"name,omitempty\" db:\"name\" yaml:\"name\"`\n" +
+		"	Password string `json:\"-\" yaml:\"password\"`\n" +
+		"	internal string\n" +
+		"	Base\n" +
+		"}\n"
+
+	gen, err := structGenerator.Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+}
+
+func TestShouldRaiseErrorWhenStructTagIsInvalid(t *testing.T) {
+	_, err := NewStruct("User").AddField("Name", "string", "json:\"`name`\"").Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.StructTagContainsBackquoteError("", "").Error(), " ")[0],
+	), err.Error())
+
+	_, err = NewStruct("User").AddField("Name", "string", "json:name").Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.StructTagIsMalformedError("", "").Error(), " ")[0],
+	), err.Error())
+
+	_, err = NewStruct("User").AddFieldWithTag("Name", "string", NewStructTag().Add("my key", "name")).Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.StructTagIsMalformedError("", "").Error(), " ")[0],
+	), err.Error())
+}
@@ -65,3 +65,55 @@ func TestShouldRaiseErrorWhenFieldTypeIsEmpty(t *testing.T) {
 		`^\`+strings.Split(errmsg.StructFieldTypeIsEmptyErr("").Error(), " ")[0],
 	), err.Error())
 }
+
+func TestShouldGenerateStructWithEmbeddedFields(t *testing.T) {
+	structGenerator := NewStruct("TestStruct").
+		AddEmbeddedField("*bytes.Buffer").
+		AddEmbeddedField("Base", `json:"base"`).
+		AddField("Foo", "string")
+
+	gen, err := structGenerator.Generate(0)
+	expected := "type TestStruct struct {\n" +
+		"	*bytes.Buffer\n" +
+		"	Base `json:\"base\"`\n" +
+		"	Foo string\n" +
+		"}\n"
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+
+	assert.Equal(t, "Buffer", structGenerator.fields[0].name)
+	assert.Equal(t, "Base", structGenerator.fields[1].name)
+}
+
+func TestShouldRaiseErrorWhenEmbeddedFieldTypeIsEmpty(t *testing.T) {
+	structGenerator := NewStruct("TestStruct").AddEmbeddedField("")
+	_, err := structGenerator.Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.StructFieldTypeIsEmptyErr("").Error(), " ")[0],
+	), err.Error())
+}
+
+func TestShouldGenerateStructWithFieldBuilder(t *testing.T) {
+	structGenerator := NewStruct("User").AddFields(
+		NewStructField("ID", "int64").Tag(NewStructTag().Add("json", "id")),
+		NewStructField("Name", "string").
+			Comment(" Name is the name of the user.\n It must not be empty.").
+			Tag(NewStructTag().Add("json", "name", "omitempty")),
+	)
+
+	expected := "type User struct {\n" +
+		"	ID int64 `json:\"id\"`\n" +
+		"	// Name is the name of the user.\n" +
+		"	// It must not be empty.\n" +
+		"	Name string `json:\"name,omitempty\"`\n" +
+		"}\n"
+
+	gen, err := structGenerator.Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+
+	_, err = NewStruct("User").AddFields(NewStructField("", "string")).Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.StructFieldNameIsEmptyErr("").Error(), " ")[0],
+	), err.Error())
+}
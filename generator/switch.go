@@ -1,19 +1,39 @@
 package generator
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
 
 // Switch represents a code generator for `switch` statement.
+// If the condition is empty, it generates a tagless switch (i.e. `switch {`).
 // See also: https://tour.golang.org/flowcontrol/9
 type Switch struct {
+	initStatement    Statement
 	condition        string
 	caseStatements   []*Case
 	defaultStatement *DefaultCase
+	caller           string
 }
 
 // NewSwitch returns a new `Switch`.
 func NewSwitch(condition string) *Switch {
 	return &Switch{
 		condition: condition,
+		caller:    fetchClientCallerLine(),
+	}
+}
+
+// Init sets an init statement to `Switch` (e.g. `x := f()` of `switch x := f(); x {`).
+// This method returns a *new* `Switch`; it means this method acts as immutable.
+func (s *Switch) Init(init Statement) *Switch {
+	return &Switch{
+		initStatement:    init,
+		condition:        s.condition,
+		caseStatements:   s.caseStatements,
+		defaultStatement: s.defaultStatement,
+		caller:           s.caller,
 	}
 }
 
@@ -21,9 +41,11 @@ func NewSwitch(condition string) *Switch {
 // This method returns a *new* `Switch`; it means this method acts as immutable.
 func (s *Switch) AddCase(statements ...*Case) *Switch {
 	return &Switch{
+		initStatement:    s.initStatement,
 		condition:        s.condition,
 		caseStatements:   append(s.caseStatements, statements...),
 		defaultStatement: s.defaultStatement,
+		caller:           s.caller,
 	}
 }
 
@@ -31,9 +53,11 @@ func (s *Switch) AddCase(statements ...*Case) *Switch {
 // This method returns a *new* `Switch`; it means this method acts as immutable.
 func (s *Switch) Case(statements ...*Case) *Switch {
 	return &Switch{
+		initStatement:    s.initStatement,
 		condition:        s.condition,
 		caseStatements:   statements,
 		defaultStatement: s.defaultStatement,
+		caller:           s.caller,
 	}
 }
 
@@ -41,21 +65,65 @@ func (s *Switch) Case(statements ...*Case) *Switch {
 // This method returns a *new* `Switch`; it means this method acts as immutable.
 func (s *Switch) Default(statement *DefaultCase) *Switch {
 	return &Switch{
+		initStatement:    s.initStatement,
 		condition:        s.condition,
 		caseStatements:   s.caseStatements,
 		defaultStatement: statement,
+		caller:           s.caller,
 	}
 }
 
 // Generate generates `switch` statement as golang code.
-func (s *Switch) Generate(indentLevel int) (string, error) {
+func (s *Switch) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, s.pathSegment())
+
+	if err := firstError(s.validate()); err != nil {
+		return "", err
+	}
+
 	indent := BuildIndent(indentLevel)
 
-	stmt := fmt.Sprintf("%sswitch %s {\n", indent, s.condition)
-	for _, statement := range s.caseStatements {
+	header, err := generateSwitchHeader(s.initStatement, s.condition)
+	if err != nil {
+		return "", err
+	}
+
+	stmt := fmt.Sprintf("%sswitch %s{\n", indent, header)
+
+	body, err := generateSwitchBody(s.caseStatements, s.defaultStatement, indentLevel)
+	if err != nil {
+		return "", err
+	}
+	stmt += body
+
+	stmt += fmt.Sprintf("%s}\n", indent)
+
+	return stmt, nil
+}
+
+// generateSwitchHeader generates the part between `switch` and `{`; it contains a trailing space if it is not empty.
+func generateSwitchHeader(init Statement, tag string) (string, error) {
+	header := ""
+	if init != nil {
+		gen, err := generateSimpleStatement(init)
+		if err != nil {
+			return "", err
+		}
+		header += gen + "; "
+	}
+	if tag != "" {
+		header += tag + " "
+	}
+	return header, nil
+}
+
+func generateSwitchBody(caseStatements []*Case, defaultStatement *DefaultCase, indentLevel int) (string, error) {
+	stmt := ""
+	for _, statement := range caseStatements {
 		if statement == nil {
 			continue
 		}
+
 		gen, err := statement.Generate(indentLevel)
 		if err != nil {
 			return "", err
@@ -63,7 +131,7 @@ func (s *Switch) Generate(indentLevel int) (string, error) {
 		stmt += gen
 	}
 
-	if defaultStatement := s.defaultStatement; defaultStatement != nil {
+	if defaultStatement != nil {
 		gen, err := defaultStatement.Generate(indentLevel)
 		if err != nil {
 			return "", err
@@ -71,7 +139,55 @@ func (s *Switch) Generate(indentLevel int) (string, error) {
 		stmt += gen
 	}
 
-	stmt += fmt.Sprintf("%s}\n", indent)
-
 	return stmt, nil
 }
+
+// validateFallthrough checks that `fallthrough` is used only in the case that has the following case.
+func validateFallthrough(caseStatements []*Case, defaultStatement *DefaultCase, isTypeSwitch bool) []error {
+	lastCaseIndex := -1
+	for i, statement := range caseStatements {
+		if statement != nil {
+			lastCaseIndex = i
+		}
+	}
+
+	var errs []error
+	for i, statement := range caseStatements {
+		if statement == nil || !statement.withFallthrough {
+			continue
+		}
+
+		if isTypeSwitch {
+			errs = append(errs, errmsg.FallthroughInTypeSwitchError(statement.caller))
+			continue
+		}
+		if i == lastCaseIndex && defaultStatement == nil {
+			errs = append(errs, errmsg.FallthroughInFinalCaseError(statement.caller))
+		}
+	}
+	return errs
+}
+
+func (s *Switch) validate() []error {
+	return validateFallthrough(s.caseStatements, s.defaultStatement, false)
+}
+
+func (s *Switch) childStatements() []Statement {
+	children := make([]Statement, 0, len(s.caseStatements)+2)
+	if s.initStatement != nil {
+		children = append(children, s.initStatement)
+	}
+	for _, statement := range s.caseStatements {
+		if statement != nil {
+			children = append(children, statement)
+		}
+	}
+	if s.defaultStatement != nil {
+		children = append(children, s.defaultStatement)
+	}
+	return children
+}
+
+func (s *Switch) pathSegment() errorPathSegment {
+	return errorPathSegment{name: "Switch", caller: s.caller}
+}
@@ -95,3 +95,96 @@ func TestShouldGenerateSwitchRaisesErrorWhenDefaultRaisesError(t *testing.T) {
 		`^\`+strings.Split(errmsg.FuncNameIsEmptyError("").Error(), " ")[0],
 	), err.Error())
 }
+
+func TestShouldGenerateSwitchWithInitStatement(t *testing.T) {
+	generator := NewSwitch("x").
+		Init(NewShortVarDecl([]string{"x"}, NewRawStatement("f()"))).
+		AddCase(NewCase("1", NewComment(" one")))
+
+	expected := `switch x := f(); x {
+case 1:
+	// one
+}
+`
+	gen, err := generator.Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+}
+
+func TestShouldGenerateTaglessSwitch(t *testing.T) {
+	generator := NewSwitch("").
+		AddCase(NewCase("x < 0", NewComment(" negative"))).
+		Default(NewDefaultCase(NewComment(" default")))
+
+	{
+		expected := `switch {
+case x < 0:
+	// negative
+default:
+	// default
+}
+`
+		gen, err := generator.Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, gen)
+	}
+
+	{
+		expected := `switch x := f(); {
+case x < 0:
+	// negative
+default:
+	// default
+}
+`
+		gen, err := generator.Init(NewShortVarDecl([]string{"x"}, NewRawStatement("f()"))).Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, gen)
+	}
+}
+
+func TestShouldGenerateSwitchWithFallthrough(t *testing.T) {
+	generator := NewSwitch("x").AddCase(
+		NewCase("1", NewComment(" one")).WithFallthrough(true),
+		NewTypeCase([]string{"2", "3"}, NewComment(" two or three")),
+	)
+
+	expected := `switch x {
+case 1:
+	// one
+	fallthrough
+case 2, 3:
+	// two or three
+}
+`
+	gen, err := generator.Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+}
+
+func TestShouldGenerateSwitchRaisesErrorWhenFinalCaseFallthrough(t *testing.T) {
+	generator := NewSwitch("x").AddCase(
+		NewCase("1").WithFallthrough(true),
+		nil,
+	)
+
+	{
+		_, err := generator.Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.FallthroughInFinalCaseError("").Error(), " ")[0],
+		), err.Error())
+	}
+
+	{
+		gen, err := generator.Default(NewDefaultCase()).Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "switch x {\ncase 1:\n\tfallthrough\ndefault:\n}\n", gen)
+	}
+}
+
+func TestShouldGenerateSwitchRaisesErrorWhenInitStatementRaisesError(t *testing.T) {
+	_, err := NewSwitch("x").Init(NewIncrement("")).Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.IncDecTargetIsEmptyError("").Error(), " ")[0],
+	), err.Error())
+}
@@ -0,0 +1,551 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
+
+// TableDrivenTest represents a code generator for the table-driven test of the func, like the `gotests` tool.
+//
+// It generates `TestXxx(t *testing.T)` (or `TestType_Method` for the method) that has the table of the test cases;
+// each case has `name`, `receiver` (only for the method), `args`, `want` for each return type and `wantErr`
+// (only if the last return type is `error`). The results are compared by `!=` for the basic types and by
+// `reflect.DeepEqual()` for the others; the results of the func type are not compared.
+//
+// The generated code depends on `testing` package (and `reflect` package if it uses `reflect.DeepEqual()`);
+// please add the imports, or enable `goimports`.
+type TableDrivenTest struct {
+	fn        *Func
+	cases     []*TableTestCase
+	benchmark bool
+	fuzz      bool
+	caller    string
+}
+
+// NewTableDrivenTest returns a new `TableDrivenTest` for the func.
+func NewTableDrivenTest(fn *Func, cases ...*TableTestCase) *TableDrivenTest {
+	return &TableDrivenTest{
+		fn:     fn,
+		cases:  cases,
+		caller: fetchClientCallerLine(),
+	}
+}
+
+// AddCases adds test cases to `TableDrivenTest`. This does *not* set, just add.
+// This method returns a *new* `TableDrivenTest`; it means this method acts as immutable.
+func (t *TableDrivenTest) AddCases(cases ...*TableTestCase) *TableDrivenTest {
+	return &TableDrivenTest{
+		fn:        t.fn,
+		cases:     append(t.cases, cases...),
+		benchmark: t.benchmark,
+		fuzz:      t.fuzz,
+		caller:    t.caller,
+	}
+}
+
+// Cases sets test cases to `TableDrivenTest`. This does *not* add, just set.
+// This method returns a *new* `TableDrivenTest`; it means this method acts as immutable.
+func (t *TableDrivenTest) Cases(cases ...*TableTestCase) *TableDrivenTest {
+	return &TableDrivenTest{
+		fn:        t.fn,
+		cases:     cases,
+		benchmark: t.benchmark,
+		fuzz:      t.fuzz,
+		caller:    t.caller,
+	}
+}
+
+// Benchmark enables to generate `BenchmarkXxx(b *testing.B)` in addition to the test.
+// It runs the func with the args of each test case as a sub-benchmark.
+// This method returns a *new* `TableDrivenTest`; it means this method acts as immutable.
+func (t *TableDrivenTest) Benchmark(enabled bool) *TableDrivenTest {
+	return &TableDrivenTest{
+		fn:        t.fn,
+		cases:     t.cases,
+		benchmark: enabled,
+		fuzz:      t.fuzz,
+		caller:    t.caller,
+	}
+}
+
+// Fuzz enables to generate the fuzz target `FuzzXxx(f *testing.F)` in addition to the test.
+// The args of the test cases are added to the seed corpus. Each parameter must be a type that is supported by fuzzing
+// (i.e. string, []byte, bool and the numeric types), and the receiver is the zero value (or `new(T)` for the pointer).
+// This method returns a *new* `TableDrivenTest`; it means this method acts as immutable.
+func (t *TableDrivenTest) Fuzz(enabled bool) *TableDrivenTest {
+	return &TableDrivenTest{
+		fn:        t.fn,
+		cases:     t.cases,
+		benchmark: t.benchmark,
+		fuzz:      enabled,
+		caller:    t.caller,
+	}
+}
+
+// Generate generates the table-driven test as golang code.
+func (t *TableDrivenTest) Generate(indentLevel int) (generated string, err error) {
+	defer annotateComposedErrorPath(&err, t.pathSegment())
+
+	if err := firstError(t.validate()); err != nil {
+		return "", err
+	}
+
+	return generateStatementGroups(t.statementGroups(), indentLevel)
+}
+
+// tableTestTarget holds the names that are derived from the func to test.
+type tableTestTarget struct {
+	displayName  string
+	testName     string
+	receiverType string
+	params       []*FuncParameter
+	wantTypes    []string
+	hasErr       bool
+}
+
+func (t *TableDrivenTest) target() *tableTestTarget {
+	sig := t.fn.funcSignature
+	target := &tableTestTarget{
+		displayName: sig.funcName,
+		testName:    sig.funcName,
+		params:      sig.expandedParameters(),
+	}
+
+	if recv := t.fn.funcReceiver; recv != nil && recv.typ != "" {
+		typeName := strings.TrimPrefix(recv.typ, "*")
+		target.receiverType = recv.typ
+		target.displayName = typeName + "." + sig.funcName
+		target.testName = typeName + "_" + sig.funcName
+	}
+
+	for i, ret := range sig.returnTypes {
+		if i == len(sig.returnTypes)-1 && ret.typ == "error" {
+			target.hasErr = true
+			continue
+		}
+		target.wantTypes = append(target.wantTypes, ret.typ)
+	}
+	return target
+}
+
+// call returns the expression to call the func with the receiver and the args.
+func (target *tableTestTarget) call(receiver string, args []string) string {
+	callee := target.displayName
+	if target.receiverType != "" {
+		callee = receiver + "." + strings.SplitN(target.displayName, ".", 2)[1]
+	}
+	return callee + "(" + strings.Join(args, ", ") + ")"
+}
+
+// argsOf returns the args that refer the fields of `args` struct of the table (e.g. `tt.args.a`).
+func (target *tableTestTarget) argsOf(argsExpr string) []string {
+	args := make([]string, len(target.params))
+	for i, param := range target.params {
+		args[i] = argsExpr + "." + param.name
+		if strings.HasPrefix(param.typ, "...") {
+			args[i] += "..."
+		}
+	}
+	return args
+}
+
+func (target *tableTestTarget) argsStruct() *Struct {
+	argsStruct := NewStruct("args")
+	for _, param := range target.params {
+		typ := param.typ
+		if strings.HasPrefix(typ, "...") {
+			typ = "[]" + strings.TrimPrefix(typ, "...")
+		}
+		argsStruct = argsStruct.AddField(param.name, typ)
+	}
+	return argsStruct
+}
+
+func wantName(i int) string {
+	if i == 0 {
+		return "want"
+	}
+	return fmt.Sprintf("want%d", i)
+}
+
+func gotName(i int) string {
+	if i == 0 {
+		return "got"
+	}
+	return fmt.Sprintf("got%d", i)
+}
+
+func (t *TableDrivenTest) statementGroups() [][]Statement {
+	target := t.target()
+
+	groups := [][]Statement{
+		{t.testFunc(target)},
+	}
+	if t.benchmark {
+		groups = append(groups, []Statement{t.benchmarkFunc(target)})
+	}
+	if t.fuzz {
+		groups = append(groups, []Statement{t.fuzzFunc(target)})
+	}
+	return groups
+}
+
+// tableType returns the type of the table; `withResults` includes the fields of the expected results.
+func (t *TableDrivenTest) tableType(target *tableTestTarget, withResults bool) string {
+	fields := []string{"name string"}
+	if target.receiverType != "" {
+		fields = append(fields, "receiver "+target.receiverType)
+	}
+	if len(target.params) > 0 {
+		fields = append(fields, "args args")
+	}
+	if withResults {
+		for i, typ := range target.wantTypes {
+			fields = append(fields, wantName(i)+" "+typ)
+		}
+		if target.hasErr {
+			fields = append(fields, "wantErr bool")
+		}
+	}
+	return "[]struct {\n\t" + strings.Join(fields, "\n\t") + "\n}"
+}
+
+// table returns the statements that declare the table (and `args` struct) as `name`.
+func (t *TableDrivenTest) table(target *tableTestTarget, name string, withResults bool) []Statement {
+	var statements []Statement
+	if len(target.params) > 0 {
+		statements = append(statements, target.argsStruct())
+	}
+
+	table := NewCompositeLiteral(t.tableType(target, withResults))
+	for _, c := range t.cases {
+		if c == nil {
+			continue
+		}
+		table = table.AddField("", c.literal(target, withResults))
+	}
+	return append(statements, NewShortVarDecl([]string{name}, table))
+}
+
+func (t *TableDrivenTest) testFunc(target *tableTestTarget) Statement {
+	args := target.argsOf("tt.args")
+	call := NewRawStatement(target.call("tt.receiver", args))
+
+	var gots []string
+	for i := range target.wantTypes {
+		gots = append(gots, gotName(i))
+	}
+	if target.hasErr {
+		gots = append(gots, "err")
+	}
+
+	var body []Statement
+	if len(gots) > 0 {
+		body = append(body, NewShortVarDecl(gots, call))
+	} else {
+		body = append(body, call)
+	}
+
+	if target.hasErr {
+		body = append(body, NewIf(
+			"(err != nil) != tt.wantErr",
+			NewRawStatementf(`t.Errorf("%s() error = %%v, wantErr %%v", err, tt.wantErr)`, target.displayName),
+			NewReturnStatement(),
+		))
+	}
+
+	for i, typ := range target.wantTypes {
+		got := gotName(i)
+		want := "tt." + wantName(i)
+
+		var cond string
+		switch {
+		case isFuncType(typ):
+			continue
+		case isBasicType(typ):
+			cond = got + " != " + want
+		default:
+			cond = "!reflect.DeepEqual(" + got + ", " + want + ")"
+		}
+
+		label := ""
+		if len(target.wantTypes) > 1 {
+			label = " " + got
+		}
+		body = append(body, NewIf(
+			cond,
+			NewRawStatementf(`t.Errorf("%s()%s = %%v, want %%v", %s, %s)`, target.displayName, label, got, want),
+		))
+	}
+
+	statements := t.table(target, "tests", true)
+	statements = append(statements, NewForRange(
+		"_", "tt", "tests",
+		NewCall(
+			"t.Run",
+			NewRawStatement("tt.name"),
+			NewAnonymousFunc(false, NewAnonymousFuncSignature().AddParameters(NewFuncParameter("t", "*testing.T")), body...),
+		),
+	))
+
+	return NewFunc(
+		nil,
+		NewFuncSignature("Test"+target.testName).AddParameters(NewFuncParameter("t", "*testing.T")),
+		statements...,
+	)
+}
+
+func (t *TableDrivenTest) benchmarkFunc(target *tableTestTarget) Statement {
+	call := NewRawStatement(target.call("bb.receiver", target.argsOf("bb.args")))
+
+	statements := t.table(target, "benchmarks", false)
+	statements = append(statements, NewForRange(
+		"_", "bb", "benchmarks",
+		NewCall(
+			"b.Run",
+			NewRawStatement("bb.name"),
+			NewAnonymousFunc(
+				false,
+				NewAnonymousFuncSignature().AddParameters(NewFuncParameter("b", "*testing.B")),
+				NewForClause(NewShortVarDecl([]string{"i"}, NewRawStatement("0")), "i < b.N", NewIncrement("i"), call),
+			),
+		),
+	))
+
+	return NewFunc(
+		nil,
+		NewFuncSignature("Benchmark"+target.testName).AddParameters(NewFuncParameter("b", "*testing.B")),
+		statements...,
+	)
+}
+
+func (t *TableDrivenTest) fuzzFunc(target *tableTestTarget) Statement {
+	var statements []Statement
+	if len(target.params) > 0 {
+		for _, c := range t.cases {
+			if c == nil {
+				continue
+			}
+			seeds := make([]Statement, len(c.args))
+			for i, arg := range c.args {
+				seeds[i] = fuzzSeed(arg, target.params[i].typ)
+			}
+			statements = append(statements, NewCall("f.Add", seeds...))
+		}
+	}
+
+	fuzzParams := []*FuncParameter{NewFuncParameter("t", "*testing.T")}
+	args := make([]string, len(target.params))
+	for i, param := range target.params {
+		name := param.name
+		if name == "t" || name == "receiver" {
+			name += "_"
+		}
+		fuzzParams = append(fuzzParams, NewFuncParameter(name, param.typ))
+		args[i] = name
+	}
+
+	var body []Statement
+	if target.receiverType != "" {
+		if strings.HasPrefix(target.receiverType, "*") {
+			body = append(body, NewShortVarDecl(
+				[]string{"receiver"},
+				NewRawStatementf("new(%s)", strings.TrimPrefix(target.receiverType, "*")),
+			))
+		} else {
+			body = append(body, NewVar([]string{"receiver"}, target.receiverType))
+		}
+	}
+	body = append(body, NewRawStatement(target.call("receiver", args)))
+
+	statements = append(statements, NewCall(
+		"f.Fuzz",
+		NewAnonymousFunc(false, NewAnonymousFuncSignature().Parameters(fuzzParams...), body...),
+	))
+
+	return NewFunc(
+		nil,
+		NewFuncSignature("Fuzz"+target.testName).AddParameters(NewFuncParameter("f", "*testing.F")),
+		statements...,
+	)
+}
+
+// fuzzSeed converts the seed value into the type of the parameter, because the type of the seed must be same as that.
+// The untyped constant of int, string, bool and float64 doesn't need the conversion.
+func fuzzSeed(value Statement, typ string) Statement {
+	switch typ {
+	case "int", "string", "bool", "float64":
+		return value
+	}
+	return NewCall(typ, value)
+}
+
+// isBasicType reports whether the type is a predeclared type that can be compared by `!=` (e.g. `string` and `int`).
+func isBasicType(typ string) bool {
+	switch zeroValueOf(typ) {
+	case "0", `""`, "false":
+		return true
+	}
+	return false
+}
+
+// isFuzzableType reports whether the type is supported as a parameter of the fuzz target.
+func isFuzzableType(typ string) bool {
+	switch typ {
+	case "string", "[]byte", "bool", "byte", "rune", "float32", "float64",
+		"int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return true
+	}
+	return false
+}
+
+func (t *TableDrivenTest) validate() []error {
+	if t.fn == nil {
+		return []error{errmsg.TableTestFuncIsNilError(t.caller)}
+	}
+	if t.fn.funcSignature == nil {
+		return []error{errmsg.FuncSignatureIsNilError(t.fn.caller)}
+	}
+
+	errs := t.fn.funcSignature.validate()
+	if len(errs) > 0 {
+		return errs
+	}
+
+	target := t.target()
+	for _, c := range t.cases {
+		if c == nil {
+			continue
+		}
+		if len(c.args) != len(target.params) {
+			errs = append(errs, errmsg.TableTestCaseArgsCountMismatchError(len(target.params), len(c.args), c.caller))
+		}
+		if len(c.wants) != len(target.wantTypes) {
+			errs = append(errs, errmsg.TableTestCaseWantsCountMismatchError(len(target.wantTypes), len(c.wants), c.caller))
+		}
+	}
+
+	if t.fuzz {
+		for _, param := range target.params {
+			if !isFuzzableType(param.typ) {
+				errs = append(errs, errmsg.FuzzParameterTypeIsNotSupportedError(param.name, param.typ, t.caller))
+			}
+		}
+	}
+
+	return errs
+}
+
+func (t *TableDrivenTest) childStatements() []Statement {
+	if len(t.validate()) > 0 {
+		return nil
+	}
+	return flattenStatementGroups(t.statementGroups())
+}
+
+func (t *TableDrivenTest) pathSegment() errorPathSegment {
+	name := ""
+	if t.fn != nil && t.fn.funcSignature != nil {
+		name = t.fn.funcSignature.funcName
+	}
+	return errorPathSegment{name: namedPathSegment("TableDrivenTest", name), caller: t.caller}
+}
+
+func (t *TableDrivenTest) composesStatements() {}
+
+// TableTestCase represents a test case of `TableDrivenTest`.
+type TableTestCase struct {
+	name     string
+	receiver Statement
+	args     []Statement
+	wants    []Statement
+	wantErr  bool
+	caller   string
+}
+
+// NewTableTestCase returns a new `TableTestCase`.
+func NewTableTestCase(name string) *TableTestCase {
+	return &TableTestCase{
+		name:   name,
+		caller: fetchClientCallerLine(),
+	}
+}
+
+// Receiver sets the receiver of the method to test. If it is not set, the receiver is the zero value.
+// This method returns a *new* `TableTestCase`; it means this method acts as immutable.
+func (c *TableTestCase) Receiver(receiver Statement) *TableTestCase {
+	return &TableTestCase{
+		name:     c.name,
+		receiver: receiver,
+		args:     c.args,
+		wants:    c.wants,
+		wantErr:  c.wantErr,
+		caller:   c.caller,
+	}
+}
+
+// Args sets the args of the func; the number of them must be same as the number of the parameters.
+// This method returns a *new* `TableTestCase`; it means this method acts as immutable.
+func (c *TableTestCase) Args(args ...Statement) *TableTestCase {
+	return &TableTestCase{
+		name:     c.name,
+		receiver: c.receiver,
+		args:     args,
+		wants:    c.wants,
+		wantErr:  c.wantErr,
+		caller:   c.caller,
+	}
+}
+
+// Wants sets the expected results of the func; the number of them must be same as the number of the return types
+// except the last `error`.
+// This method returns a *new* `TableTestCase`; it means this method acts as immutable.
+func (c *TableTestCase) Wants(wants ...Statement) *TableTestCase {
+	return &TableTestCase{
+		name:     c.name,
+		receiver: c.receiver,
+		args:     c.args,
+		wants:    wants,
+		wantErr:  c.wantErr,
+		caller:   c.caller,
+	}
+}
+
+// WantErr sets whether the func is expected to return an error.
+// This method returns a *new* `TableTestCase`; it means this method acts as immutable.
+func (c *TableTestCase) WantErr(wantErr bool) *TableTestCase {
+	return &TableTestCase{
+		name:     c.name,
+		receiver: c.receiver,
+		args:     c.args,
+		wants:    c.wants,
+		wantErr:  wantErr,
+		caller:   c.caller,
+	}
+}
+
+// literal returns the element of the table for the case; `withResults` includes the expected results.
+func (c *TableTestCase) literal(target *tableTestTarget, withResults bool) *CompositeLiteral {
+	literal := NewCompositeLiteral("").AddField("name", NewRawStatement(fmt.Sprintf("%q", c.name)))
+	if target.receiverType != "" && c.receiver != nil {
+		literal = literal.AddField("receiver", c.receiver)
+	}
+	if len(target.params) > 0 {
+		args := NewCompositeLiteral("args")
+		for i, arg := range c.args {
+			args = args.AddField(target.params[i].name, arg)
+		}
+		literal = literal.AddField("args", args)
+	}
+	if withResults {
+		for i, want := range c.wants {
+			literal = literal.AddField(wantName(i), want)
+		}
+		if c.wantErr {
+			literal = literal.AddField("wantErr", NewRawStatement("true"))
+		}
+	}
+	return literal
+}
@@ -0,0 +1,29 @@
+package generator
+
+import (
+	"fmt"
+	"log"
+)
+
+func ExampleTableDrivenTest_Generate() {
+	fn := NewFunc(
+		nil,
+		NewFuncSignature("Divide").
+			AddParameters(NewFuncParameter("a", "int"), NewFuncParameter("b", "int")).
+			AddReturnTypes("int", "error"),
+	)
+
+	generated, err := NewRoot(
+		NewPackage("mypkg"),
+		NewImport("testing"),
+		NewTableDrivenTest(
+			fn,
+			NewTableTestCase("divide").Args(NewRawStatement("6"), NewRawStatement("3")).Wants(NewRawStatement("2")),
+			NewTableTestCase("by zero").Args(NewRawStatement("1"), NewRawStatement("0")).Wants(NewRawStatement("0")).WantErr(true),
+		).Benchmark(true).Fuzz(true),
+	).Gofmt().Generate(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(generated)
+}
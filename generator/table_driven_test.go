@@ -0,0 +1,271 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldGenerateTableDrivenTest(t *testing.T) {
+	fn := NewFunc(
+		nil,
+		NewFuncSignature("Split").
+			AddParameters(NewFuncParameter("s", "string"), NewFuncParameter("n", "int")).
+			AddReturnTypes("[]string", "int", "error"),
+	)
+
+	expected := `func TestSplit(t *testing.T) {
+	type args struct {
+		s string
+		n int
+	}
+	tests := []struct {
+		name string
+		args args
+		want []string
+		want1 int
+		wantErr bool
+	}{
+		{
+			name: "ok",
+			args: args{
+				s: "a,b",
+				n: 2,
+			},
+			want: []string{"a", "b"},
+			want1: 2,
+		},
+		{
+			name: "error",
+			args: args{
+				s: "",
+				n: 0,
+			},
+			want: nil,
+			want1: 0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, got1, err := Split(tt.args.s, tt.args.n)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Split() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Split() got = %v, want %v", got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("Split() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+`
+
+	gen, err := NewTableDrivenTest(
+		fn,
+		NewTableTestCase("ok").
+			Args(NewRawStatement(`"a,b"`), NewRawStatement("2")).
+			Wants(NewRawStatement(`[]string{"a", "b"}`), NewRawStatement("2")),
+	).AddCases(
+		NewTableTestCase("error").
+			Args(NewRawStatement(`""`), NewRawStatement("0")).
+			Wants(NewRawStatement("nil"), NewRawStatement("0")).
+			WantErr(true),
+	).Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+}
+
+func TestShouldGenerateTableDrivenTestForMethod(t *testing.T) {
+	fn := NewFunc(
+		NewFuncReceiver("s", "*Stack"),
+		NewFuncSignature("Push").AddParameters(NewFuncParameter("values", "...int")),
+	)
+
+	expected := `func TestStack_Push(t *testing.T) {
+	type args struct {
+		values []int
+	}
+	tests := []struct {
+		name string
+		receiver *Stack
+		args args
+	}{
+		{
+			name: "push",
+			receiver: &Stack{},
+			args: args{
+				values: []int{1, 2},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.receiver.Push(tt.args.values...)
+		})
+	}
+}
+
+func BenchmarkStack_Push(b *testing.B) {
+	type args struct {
+		values []int
+	}
+	benchmarks := []struct {
+		name string
+		receiver *Stack
+		args args
+	}{
+		{
+			name: "push",
+			receiver: &Stack{},
+			args: args{
+				values: []int{1, 2},
+			},
+		},
+	}
+	for _, bb := range benchmarks {
+		b.Run(bb.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				bb.receiver.Push(bb.args.values...)
+			}
+		})
+	}
+}
+`
+
+	gen, err := NewTableDrivenTest(
+		fn,
+		NewTableTestCase("push").
+			Receiver(NewRawStatement("&Stack{}")).
+			Args(NewRawStatement("[]int{1, 2}")),
+	).Benchmark(true).Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+}
+
+func TestShouldGenerateFuzzTarget(t *testing.T) {
+	fn := NewFunc(
+		NewFuncReceiver("p", "Parser"),
+		NewFuncSignature("Parse").
+			AddParameters(NewFuncParameter("t", "string"), NewFuncParameter("limit", "uint8")).
+			AddReturnTypes("bool"),
+	)
+
+	expected := `func FuzzParser_Parse(f *testing.F) {
+	f.Add("abc", uint8(3))
+	f.Fuzz(func(t *testing.T, t_ string, limit uint8) {
+		var receiver Parser
+		receiver.Parse(t_, limit)
+	})
+}
+`
+
+	gen, err := NewTableDrivenTest(
+		fn,
+		NewTableTestCase("ok").
+			Args(NewRawStatement(`"abc"`), NewRawStatement("3")).
+			Wants(NewRawStatement("true")),
+	).Fuzz(true).Generate(0)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasSuffix(gen, "\n"+expected), gen)
+}
+
+func TestShouldRaiseErrorWhenTableDrivenTestIsInvalid(t *testing.T) {
+	_, err := NewTableDrivenTest(nil).Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.TableTestFuncIsNilError("").Error(), " ")[0],
+	), err.Error())
+
+	_, err = NewTableDrivenTest(NewFunc(nil, nil)).Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.FuncSignatureIsNilError("").Error(), " ")[0],
+	), err.Error())
+
+	fn := NewFunc(
+		nil,
+		NewFuncSignature("Upper").AddParameters(NewFuncParameter("s", "string")).AddReturnTypes("string"),
+	)
+
+	_, err = NewTableDrivenTest(fn, NewTableTestCase("ok").Wants(NewRawStatement(`"A"`))).Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.TableTestCaseArgsCountMismatchError(0, 0, "").Error(), " ")[0],
+	), err.Error())
+
+	_, err = NewTableDrivenTest(fn, NewTableTestCase("ok").Args(NewRawStatement(`"a"`))).Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.TableTestCaseWantsCountMismatchError(0, 0, "").Error(), " ")[0],
+	), err.Error())
+
+	_, err = NewTableDrivenTest(
+		NewFunc(nil, NewFuncSignature("Sum").AddParameters(NewFuncParameter("values", "[]int"))),
+	).Fuzz(true).Generate(0)
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.FuzzParameterTypeIsNotSupportedError("", "", "").Error(), " ")[0],
+	), err.Error())
+}
+
+func TestShouldGeneratedTableDrivenTestRun(t *testing.T) {
+	if testing.Short() {
+		t.Skip("this test builds the generated code")
+	}
+
+	divide := NewFunc(
+		nil,
+		NewFuncSignature("Divide").
+			AddParameters(NewFuncParameter("a", "int64"), NewFuncParameter("b", "int64")).
+			AddReturnTypes("int64", "error"),
+		NewIf("b == 0", NewReturnStatement("0", `errors.New("division by zero")`)),
+		NewReturnStatement("a / b", "nil"),
+	)
+	counterType := NewStruct("Counter").AddField("n", "int")
+	add := NewFunc(
+		NewFuncReceiver("c", "*Counter"),
+		NewFuncSignature("Add").AddParameters(NewFuncParameter("values", "...int")).AddReturnTypes("[]int"),
+		NewForRange("_", "v", "values", NewRawStatement("c.n += v")),
+		NewReturnStatement("[]int{c.n}"),
+	)
+
+	code, err := NewRoot(
+		NewPackage("calc"),
+		NewImport("errors"),
+		NewNewline(),
+		divide,
+		NewNewline(),
+		counterType,
+		NewNewline(),
+		add,
+	).Gofmt().Generate(0)
+	assert.NoError(t, err)
+
+	testCode, err := NewRoot(
+		NewPackage("calc"),
+		NewImport("reflect", "testing"),
+		NewNewline(),
+		NewTableDrivenTest(
+			divide,
+			NewTableTestCase("divide").Args(NewRawStatement("6"), NewRawStatement("3")).Wants(NewRawStatement("2")),
+			NewTableTestCase("by zero").Args(NewRawStatement("1"), NewRawStatement("0")).Wants(NewRawStatement("0")).WantErr(true),
+		).Benchmark(true).Fuzz(true),
+		NewNewline(),
+		NewTableDrivenTest(
+			add,
+			NewTableTestCase("add").
+				Receiver(NewRawStatement("&Counter{n: 1}")).
+				Args(NewRawStatement("[]int{2, 3}")).
+				Wants(NewRawStatement("[]int{6}")),
+		),
+	).Gofmt().Generate(0)
+	assert.NoError(t, err)
+
+	runGoCommandsOnGeneratedPackage(t, map[string]string{
+		"calc.go":      code,
+		"calc_test.go": testCode,
+	}, []string{"vet", "."}, []string{"test", "-bench", ".", "-benchtime", "1x", "."})
+}
@@ -0,0 +1,28 @@
+-- the schema of the users service
+CREATE TABLE IF NOT EXISTS users (
+    id BIGINT NOT NULL AUTO_INCREMENT,
+    name VARCHAR(255) NOT NULL COMMENT 'display name',
+    email TEXT,
+    age INT,
+    score DOUBLE PRECISION,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    avatar BLOB,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME NULL,
+    PRIMARY KEY (id)
+) COMMENT = 'users of the service';
+
+CREATE INDEX users_email ON users (email);
+
+/* the many-to-many relation */
+CREATE TABLE user_roles (
+    user_id BIGINT NOT NULL REFERENCES users (id),
+    role VARCHAR(32) NOT NULL,
+    granted_at TIMESTAMP,
+    CONSTRAINT user_roles_pk PRIMARY KEY (user_id, role)
+);
+
+CREATE TABLE audit_logs (
+    message TEXT NOT NULL,
+    "type" VARCHAR(16)
+);
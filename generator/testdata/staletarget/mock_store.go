@@ -0,0 +1,10 @@
+package staletarget
+
+// MockStore is the stale mock that doesn't implement Store.Delete.
+type MockStore struct{}
+
+var _ Store = (*MockStore)(nil)
+
+func (m *MockStore) Get(key string) ([]byte, error) {
+	return nil, nil
+}
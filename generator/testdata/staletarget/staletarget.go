@@ -0,0 +1,12 @@
+package staletarget
+
+// Store is an interface that has been changed after the mock was generated.
+type Store interface {
+	Delete(key string) error
+	Get(key string) ([]byte, error)
+}
+
+// Broken is an interface that refers to the undefined type.
+type Broken interface {
+	Get(key string) Undefined
+}
@@ -0,0 +1,24 @@
+package stubtarget
+
+import "io"
+
+// Store is an interface for the tests of the stub and mock generators.
+type Store interface {
+	io.Closer
+	Get(key string) (value []byte, err error)
+	Put(string, []byte) error
+	Keys(prefix string, limit ...int) []string
+}
+
+// PartialStore implements a part of Store.
+type PartialStore struct{}
+
+// Close closes the store.
+func (s *PartialStore) Close() error {
+	return nil
+}
+
+// Registry is an interface that refers to the type of this package.
+type Registry interface {
+	Lookup(name string) (*PartialStore, error)
+}
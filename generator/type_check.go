@@ -0,0 +1,125 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/scanner"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
+
+// TypeCheckPackage generates the code of each `Root` as a file of the same package and type-checks them together by `go/types`.
+// `files` is a map of the file name to the `Root` that generates the file.
+// `srcDir` is the directory where the generated code will be placed; please see also `Root#EnableTypeChecking()`.
+//
+// If the type checking fails, this returns `*ValidationErrors` that contains each diagnostic;
+// each diagnostic points to the generator that produces the line.
+func TypeCheckPackage(srcDir string, files map[string]*Root) error {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	generatedFiles := make([]*generatedFile, len(names))
+	for i, name := range names {
+		root := files[name]
+		file, err := root.generateFile(0)
+		if err != nil {
+			annotateErrorPath(&err, errorPathSegment{name: namedPathSegment("Root", name), caller: root.caller})
+			return err
+		}
+		file.name = name
+		file.rootSegment = errorPathSegment{name: namedPathSegment("Root", name)}
+		generatedFiles[i] = file
+	}
+
+	return typeCheck(srcDir, generatedFiles)
+}
+
+func typeCheck(srcDir string, files []*generatedFile) error {
+	if srcDir == "" {
+		srcDir = "."
+	}
+	if absDir, err := filepath.Abs(srcDir); err == nil {
+		srcDir = absDir
+	}
+
+	fset := token.NewFileSet()
+	filesByName := map[string]*generatedFile{}
+	var diagnostics []error
+	report := func(pos token.Position, msg string) {
+		file := filesByName[pos.Filename]
+		if file == nil {
+			return
+		}
+		diagnostics = append(diagnostics, file.diagnostic(pos, msg))
+	}
+
+	astFiles := make([]*ast.File, 0, len(files))
+	for _, file := range files {
+		name := file.name
+		if name == "" {
+			name = "gowrtr_generated.go"
+		}
+		path := filepath.Join(srcDir, name)
+		filesByName[path] = file
+
+		astFile, err := parser.ParseFile(fset, path, file.code, parser.AllErrors)
+		if err != nil {
+			if errs, ok := err.(scanner.ErrorList); ok {
+				for _, e := range errs {
+					report(e.Pos, e.Msg)
+				}
+			} else {
+				report(token.Position{Filename: path, Line: 1, Column: 1}, err.Error())
+			}
+			continue
+		}
+		astFiles = append(astFiles, astFile)
+	}
+
+	if len(diagnostics) <= 0 && len(astFiles) > 0 {
+		conf := types.Config{
+			Importer: importer.ForCompiler(fset, "source", nil),
+			Error: func(err error) {
+				if typeErr, ok := err.(types.Error); ok {
+					report(typeErr.Fset.Position(typeErr.Pos), typeErr.Msg)
+				}
+			},
+		}
+		_, _ = conf.Check(astFiles[0].Name.Name, fset, astFiles, nil)
+	}
+
+	if len(diagnostics) <= 0 {
+		return nil
+	}
+	return &ValidationErrors{
+		errs: diagnostics,
+	}
+}
+
+// diagnostic makes an error of the diagnostic that points to the innermost generator that produces the line.
+func (f *generatedFile) diagnostic(pos token.Position, msg string) error {
+	position := fmt.Sprintf("%d:%d", pos.Line, pos.Column)
+	if f.name != "" {
+		position = f.name + ":" + position
+	}
+
+	path := []errorPathSegment{f.rootSegment}
+	caller := ""
+	if entry := f.lookupByOffset(f.code, pos.Offset); entry != nil {
+		path = append([]errorPathSegment{}, entry.path...)
+		caller = entry.caller
+	}
+
+	genErr := newGeneratorError(errmsg.TypeCheckError(position, msg, caller))
+	genErr.path = path
+	return genErr
+}
@@ -0,0 +1,29 @@
+package generator
+
+import (
+	"log"
+)
+
+func ExampleTypeCheckPackage() {
+	err := TypeCheckPackage("", map[string]*Root{
+		"a.go": NewRoot(
+			NewPackage("mypkg"),
+			NewFunc(
+				nil,
+				NewFuncSignature("A").AddReturnTypes("int"),
+				NewReturnStatement().AddReturnStatements(NewCall("b")),
+			),
+		),
+		"b.go": NewRoot(
+			NewPackage("mypkg"),
+			NewFunc(
+				nil,
+				NewFuncSignature("b").AddReturnTypes("int"),
+				NewReturnStatement("1"),
+			),
+		),
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+}
@@ -0,0 +1,176 @@
+package generator
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldTypeCheckSuccessfully(t *testing.T) {
+	generated, err := NewRoot(
+		NewPackage("mypkg"),
+		NewImport("strings"),
+		NewFunc(
+			nil,
+			NewFuncSignature("Upper").AddParameters(NewFuncParameter("s", "string")).AddReturnTypes("string"),
+			NewReturnStatement().AddReturnStatements(NewCall("strings.ToUpper", NewRawStatement("s"))),
+		),
+	).Gofmt().EnableTypeChecking("").Generate(0)
+
+	assert.NoError(t, err)
+	assert.Contains(t, generated, "func Upper(s string) string {")
+}
+
+func TestShouldTypeCheckReportDiagnosticsWithGenerators(t *testing.T) {
+	_, err := NewRoot(
+		NewPackage("mypkg"),
+		NewNewline(),
+		NewImport("strings", "fmt"),
+		NewNewline(),
+		NewFunc(
+			nil,
+			NewFuncSignature("Upper").AddParameters(NewFuncParameter("s", "string")).AddReturnTypes("string"),
+			NewReturnStatement().AddReturnStatements(NewCall("strings.ToUpper", NewRawStatement("undefinedVar"))),
+		),
+		NewNewline(),
+		NewFunc(
+			nil,
+			NewFuncSignature("Pair").AddReturnTypes("int", "int"),
+			NewReturnStatement("1"),
+		),
+	).Gofmt().EnableTypeChecking("").Generate(0)
+
+	var validationErrs *ValidationErrors
+	assert.True(t, errors.As(err, &validationErrs))
+	assert.True(t, errors.Is(err, ErrTypeCheck))
+
+	messages := []string{}
+	paths := []string{}
+	for _, e := range validationErrs.Errors() {
+		var genErr *GeneratorError
+		assert.True(t, errors.As(e, &genErr))
+		assert.Equal(t, ErrorKindTypeCheck, genErr.Kind())
+		assert.Regexp(t, regexp.MustCompile(`:\d+$`), genErr.Caller())
+		messages = append(messages, genErr.Message())
+		paths = append(paths, regexp.MustCompile(` \([^)]+\)`).ReplaceAllString(genErr.Path(), ""))
+	}
+
+	assert.Len(t, messages, 3)
+	assert.Contains(t, strings.Join(messages, "\n"), "undefined: undefinedVar")
+	assert.Contains(t, strings.Join(messages, "\n"), `"fmt" imported and not used`)
+	assert.Contains(t, paths, `Root > Import`)
+	assert.Contains(t, paths, `Root > Func "Upper" > ReturnStatement > Call "strings.ToUpper"`)
+	assert.Contains(t, paths, `Root > Func "Pair" > ReturnStatement`)
+}
+
+func TestShouldTypeCheckDiagnosticsOfComposedStatementsHaveCallerOfComposer(t *testing.T) {
+	iface := NewInterface("Getter", NewFuncSignature("Get").AddParameters(NewFuncParameter("key", "UndefinedKey")))
+	mock := NewMock("GetterMock", iface)
+
+	var err error
+	inAnotherGoroutine(func() {
+		_, err = NewRoot(
+			NewPackage("mypkg"),
+			NewNewline(),
+			NewImport("sync"),
+			NewNewline(),
+			iface,
+			NewNewline(),
+			mock,
+		).Gofmt().EnableTypeChecking("").Generate(0)
+	})
+
+	var validationErrs *ValidationErrors
+	assert.True(t, errors.As(err, &validationErrs))
+
+	mockErrs := 0
+	for _, e := range validationErrs.Errors() {
+		var genErr *GeneratorError
+		assert.True(t, errors.As(e, &genErr))
+		assert.Contains(t, genErr.Message(), "undefined: UndefinedKey")
+		if !strings.Contains(genErr.Path(), `Mock "GetterMock"`) {
+			continue
+		}
+		mockErrs++
+		assert.Equal(t, mock.caller, genErr.Caller())
+
+		segments := genErr.PathSegments()
+		assert.True(t, len(segments) > 2)
+		for _, segment := range segments[1:] {
+			assert.True(t, strings.HasSuffix(segment, " ("+mock.caller+")"), segment)
+		}
+	}
+	assert.True(t, mockErrs > 0)
+}
+
+func TestShouldTypeCheckMapLinesOfFormattedCode(t *testing.T) {
+	// gofmt changes the layout (e.g. removes redundant newlines), but the diagnostic should point to the right generator
+	_, err := NewRoot(
+		NewPackage("mypkg"),
+		NewNewline(),
+		NewNewline(),
+		NewNewline(),
+		NewFunc(nil, NewFuncSignature("F1")),
+		NewNewline(),
+		NewNewline(),
+		NewNewline(),
+		NewFunc(nil, NewFuncSignature("F2"), NewRawStatement("x := 1")),
+	).Gofmt().EnableTypeChecking("").Generate(0)
+
+	var validationErrs *ValidationErrors
+	assert.True(t, errors.As(err, &validationErrs))
+	assert.Len(t, validationErrs.Errors(), 1)
+
+	var genErr *GeneratorError
+	assert.True(t, errors.As(validationErrs.Errors()[0], &genErr))
+	assert.Regexp(t, regexp.MustCompile(`^Root > Func "F2" `), genErr.Path())
+	assert.Regexp(t, regexp.MustCompile(`at 7:2 of the generated code: declared and not used: x`), genErr.Message())
+}
+
+func TestShouldTypeCheckPackage(t *testing.T) {
+	err := TypeCheckPackage("", map[string]*Root{
+		"a.go": NewRoot(
+			NewPackage("mypkg"),
+			NewFunc(nil, NewFuncSignature("A").AddReturnTypes("int"), NewReturnStatement().AddReturnStatements(NewCall("b"))),
+		),
+		"b.go": NewRoot(
+			NewPackage("mypkg"),
+			NewFunc(nil, NewFuncSignature("b").AddReturnTypes("int"), NewReturnStatement("1")),
+		),
+	})
+	assert.NoError(t, err)
+
+	err = TypeCheckPackage("", map[string]*Root{
+		"a.go": NewRoot(
+			NewPackage("mypkg"),
+			NewFunc(nil, NewFuncSignature("A").AddReturnTypes("string"), NewReturnStatement().AddReturnStatements(NewCall("b"))),
+		),
+		"b.go": NewRoot(
+			NewPackage("mypkg"),
+			NewFunc(nil, NewFuncSignature("b").AddReturnTypes("int"), NewReturnStatement("1")),
+		),
+	})
+
+	var validationErrs *ValidationErrors
+	assert.True(t, errors.As(err, &validationErrs))
+	assert.Len(t, validationErrs.Errors(), 1)
+
+	var genErr *GeneratorError
+	assert.True(t, errors.As(validationErrs.Errors()[0], &genErr))
+	assert.Regexp(t, regexp.MustCompile(`^Root "a.go" > Func "A" `), genErr.Path())
+	assert.Regexp(t, regexp.MustCompile(`at a.go:\d+:\d+ of the generated code`), genErr.Message())
+}
+
+func TestShouldTypeCheckPackageRaiseGeneratorError(t *testing.T) {
+	err := TypeCheckPackage("", map[string]*Root{
+		"a.go": NewRoot(NewPackage("mypkg"), NewIf("")),
+	})
+
+	var genErr *GeneratorError
+	assert.True(t, errors.As(err, &genErr))
+	assert.Equal(t, ErrorKindValidation, genErr.Kind())
+	assert.Regexp(t, regexp.MustCompile(`^Root "a.go" .+ > If `), genErr.Path())
+}
@@ -0,0 +1,173 @@
+package generator
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
+
+// LoadInterface loads the interface type from a real package by `go/types`, and converts it into `Interface`.
+// `srcDir` is the directory where the package is resolved from; empty `srcDir` means the current directory.
+// The embedded interfaces are expanded, so the returned `Interface` has all of the methods.
+//
+// The types that belong to other packages are qualified by the package name (e.g. `io.Reader`), including the types of the loaded package itself;
+// please use `NewInterfaceFromType()` with a custom qualifier if you'd like to change that.
+func LoadInterface(srcDir string, pkgPath string, name string) (*Interface, error) {
+	return loadInterface(srcDir, pkgPath, name, false, fetchClientCallerLine())
+}
+
+// LoadLocalInterface is the same as `LoadInterface()`, but the types of the loaded package itself are not qualified
+// (e.g. `Item` instead of `store.Item`), so the generated code can be placed in that package.
+func LoadLocalInterface(srcDir string, pkgPath string, name string) (*Interface, error) {
+	return loadInterface(srcDir, pkgPath, name, true, fetchClientCallerLine())
+}
+
+func loadInterface(srcDir string, pkgPath string, name string, local bool, caller string) (*Interface, error) {
+	obj, err := lookupType(srcDir, pkgPath, name, caller)
+	if err != nil {
+		return nil, err
+	}
+
+	iface, ok := obj.Type().Underlying().(*types.Interface)
+	if !ok {
+		return nil, errmsg.TypeIsNotInterfaceError(name, pkgPath, caller)
+	}
+
+	return NewInterfaceFromType(name, iface, func(pkg *types.Package) string {
+		if local && pkg == obj.Pkg() {
+			return ""
+		}
+		return pkg.Name()
+	}), nil
+}
+
+// NewInterfaceFromType converts the interface type of `go/types` into `Interface`.
+// The embedded interfaces are expanded, and each type is written with `qualifier`.
+// The unnamed parameters are named `arg0`, `arg1` and so on, since `FuncSignature` requires the parameter names.
+func NewInterfaceFromType(name string, iface *types.Interface, qualifier types.Qualifier) *Interface {
+	iface = iface.Complete()
+
+	sigs := make([]*FuncSignature, iface.NumMethods())
+	for i := 0; i < iface.NumMethods(); i++ {
+		method := iface.Method(i)
+		sigs[i] = newFuncSignatureFromType(method.Name(), method.Type().(*types.Signature), qualifier)
+	}
+
+	return &Interface{
+		name:           name,
+		funcSignatures: sigs,
+		caller:         fetchClientCallerLine(),
+	}
+}
+
+// LoadMethodNames loads the named type from a real package by `go/types`, and returns the names of the methods
+// that the type (and the pointer of that) has. The names are sorted.
+// This is useful to skip the methods that are already implemented; please see also `InterfaceStub#ExistingType()`.
+func LoadMethodNames(srcDir string, pkgPath string, typeName string) ([]string, error) {
+	caller := fetchClientCallerLine()
+
+	obj, err := lookupType(srcDir, pkgPath, typeName, caller)
+	if err != nil {
+		return nil, err
+	}
+
+	methodSet := types.NewMethodSet(types.NewPointer(obj.Type()))
+	names := make([]string, methodSet.Len())
+	for i := 0; i < methodSet.Len(); i++ {
+		names[i] = methodSet.At(i).Obj().Name()
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func newFuncSignatureFromType(name string, sig *types.Signature, qualifier types.Qualifier) *FuncSignature {
+	params := make([]*FuncParameter, sig.Params().Len())
+	for i := 0; i < sig.Params().Len(); i++ {
+		param := sig.Params().At(i)
+
+		paramName := param.Name()
+		if paramName == "" || paramName == "_" {
+			paramName = fmt.Sprintf("arg%d", i)
+		}
+
+		typ := types.TypeString(param.Type(), qualifier)
+		if sig.Variadic() && i == sig.Params().Len()-1 {
+			typ = "..." + types.TypeString(param.Type().(*types.Slice).Elem(), qualifier)
+		}
+		params[i] = NewFuncParameter(paramName, typ)
+	}
+
+	named := true
+	for i := 0; i < sig.Results().Len(); i++ {
+		if n := sig.Results().At(i).Name(); n == "" || n == "_" {
+			named = false
+		}
+	}
+	returnTypes := make([]*FuncReturnType, sig.Results().Len())
+	for i := 0; i < sig.Results().Len(); i++ {
+		result := sig.Results().At(i)
+		typ := types.TypeString(result.Type(), qualifier)
+		if named {
+			returnTypes[i] = NewFuncReturnType(typ, result.Name())
+			continue
+		}
+		returnTypes[i] = NewFuncReturnType(typ)
+	}
+
+	return NewFuncSignature(name).Parameters(params...).ReturnTypeStatements(returnTypes...)
+}
+
+// lookupType type-checks the package and looks up the named type.
+// The type errors of the package are tolerated as long as the type itself is valid, so that the type can be loaded
+// even if the stale generated code in the same package (e.g. the mock of the old interface) does not compile.
+func lookupType(srcDir string, pkgPath string, name string, caller string) (types.Object, error) {
+	if srcDir == "" {
+		srcDir = "."
+	}
+	if absDir, err := filepath.Abs(srcDir); err == nil {
+		srcDir = absDir
+	}
+
+	buildPkg, err := build.Import(pkgPath, srcDir, 0)
+	if err != nil {
+		return nil, errmsg.PackageLoadingError(pkgPath, err.Error(), caller)
+	}
+
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, filename := range append(buildPkg.GoFiles, buildPkg.CgoFiles...) {
+		file, err := parser.ParseFile(fset, filepath.Join(buildPkg.Dir, filename), nil, 0)
+		if err != nil {
+			return nil, errmsg.PackageLoadingError(pkgPath, err.Error(), caller)
+		}
+		files = append(files, file)
+	}
+
+	var typeErrs []error
+	conf := &types.Config{
+		Importer:    importer.ForCompiler(fset, "source", nil),
+		FakeImportC: true,
+		Error: func(err error) {
+			typeErrs = append(typeErrs, err)
+		},
+	}
+	pkg, _ := conf.Check(buildPkg.ImportPath, fset, files, nil)
+
+	obj := pkg.Scope().Lookup(name)
+	if _, ok := obj.(*types.TypeName); !ok {
+		return nil, errmsg.TypeIsNotFoundError(name, pkgPath, caller)
+	}
+	if len(typeErrs) > 0 && strings.Contains(types.TypeString(obj.Type().Underlying(), nil), "invalid type") {
+		return nil, errmsg.PackageLoadingError(pkgPath, typeErrs[0].Error(), caller)
+	}
+	return obj, nil
+}
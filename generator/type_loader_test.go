@@ -0,0 +1,119 @@
+package generator
+
+import (
+	"go/types"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldLoadInterface(t *testing.T) {
+	iface, err := LoadInterface("", "net/http", "Handler")
+	assert.NoError(t, err)
+
+	generated, err := NewRoot(iface).Gofmt().Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, `type Handler interface {
+	ServeHTTP(
+		arg0 http.ResponseWriter,
+		arg1 *http.Request,
+	)
+}
+`, generated)
+}
+
+func TestShouldLoadLocalInterfaceWithoutQualifyingItsPackage(t *testing.T) {
+	iface, err := LoadInterface("", "./testdata/stubtarget", "Registry")
+	assert.NoError(t, err)
+	generated, err := iface.Generate(0)
+	assert.NoError(t, err)
+	assert.Contains(t, generated, "Lookup(name string) (*stubtarget.PartialStore, error)")
+
+	iface, err = LoadLocalInterface("", "./testdata/stubtarget", "Registry")
+	assert.NoError(t, err)
+	generated, err = iface.Generate(0)
+	assert.NoError(t, err)
+	assert.Contains(t, generated, "Lookup(name string) (*PartialStore, error)")
+}
+
+func TestShouldLoadInterfaceWithEmbeddedInterfaces(t *testing.T) {
+	iface, err := LoadInterface("", "io", "ReadWriteCloser")
+	assert.NoError(t, err)
+
+	names := []string{}
+	for _, sig := range iface.funcSignatures {
+		names = append(names, sig.funcName)
+	}
+	assert.Equal(t, []string{"Close", "Read", "Write"}, names)
+
+	generated, err := iface.Generate(0)
+	assert.NoError(t, err)
+	assert.Contains(t, generated, "Read(p []byte) (n int, err error)")
+}
+
+func TestShouldNewInterfaceFromTypeUseQualifier(t *testing.T) {
+	pkg := types.NewPackage("example.com/foo", "foo")
+	item := types.NewNamed(types.NewTypeName(0, pkg, "Item", nil), types.NewStruct(nil, nil), nil)
+	sig := types.NewSignatureType(
+		nil, nil, nil,
+		types.NewTuple(types.NewVar(0, pkg, "items", types.NewSlice(types.NewPointer(item)))),
+		types.NewTuple(types.NewVar(0, pkg, "", types.Universe.Lookup("error").Type())),
+		true,
+	)
+	iface := types.NewInterfaceType([]*types.Func{types.NewFunc(0, pkg, "Save", sig)}, nil)
+
+	generated, err := NewInterfaceFromType("Saver", iface, types.RelativeTo(pkg)).Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, `type Saver interface {
+	Save(items ...*Item) error
+}
+`, generated)
+}
+
+func TestShouldLoadInterfaceFromPackageThatHasTypeErrors(t *testing.T) {
+	iface, err := LoadLocalInterface("", "./testdata/staletarget", "Store")
+	assert.NoError(t, err)
+
+	generated, err := iface.Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, `type Store interface {
+	Delete(key string) error
+	Get(key string) ([]byte, error)
+}
+`, generated)
+
+	names, err := LoadMethodNames("", "./testdata/staletarget", "MockStore")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Get"}, names)
+}
+
+func TestShouldRaiseErrorWhenLoadingTypeFails(t *testing.T) {
+	_, err := LoadInterface("", "./testdata/not_existing", "Store")
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.PackageLoadingError("", "", "").Error(), " ")[0],
+	), err.Error())
+
+	_, err = LoadInterface("", "./testdata/stubtarget", "NotExisting")
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.TypeIsNotFoundError("", "", "").Error(), " ")[0],
+	), err.Error())
+
+	_, err = LoadInterface("", "./testdata/stubtarget", "PartialStore")
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.TypeIsNotInterfaceError("", "", "").Error(), " ")[0],
+	), err.Error())
+
+	_, err = LoadInterface("", "./testdata/staletarget", "Broken")
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.PackageLoadingError("", "", "").Error(), " ")[0],
+	), err.Error())
+
+	_, err = LoadMethodNames("", "./testdata/stubtarget", "NotExisting")
+	assert.Regexp(t, regexp.MustCompile(
+		`^\`+strings.Split(errmsg.TypeIsNotFoundError("", "", "").Error(), " ")[0],
+	), err.Error())
+}
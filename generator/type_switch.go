@@ -0,0 +1,147 @@
+package generator
+
+import (
+	"fmt"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
+
+// TypeSwitch represents a code generator for type switch statement.
+//
+// example:
+//
+//	switch v := x.(type) {
+//	case int, int64:
+//	  // do something
+//	}
+type TypeSwitch struct {
+	initStatement    Statement
+	binding          string
+	expression       string
+	caseStatements   []*Case
+	defaultStatement *DefaultCase
+	caller           string
+}
+
+// NewTypeSwitch returns a new `TypeSwitch`.
+// `binding` is an optional parameter; if it is empty, it generates `switch x.(type) {`.
+func NewTypeSwitch(binding string, expression string) *TypeSwitch {
+	return &TypeSwitch{
+		binding:    binding,
+		expression: expression,
+		caller:     fetchClientCallerLine(),
+	}
+}
+
+// Init sets an init statement to `TypeSwitch` (e.g. `x := f()` of `switch x := f(); v := x.(type) {`).
+// This method returns a *new* `TypeSwitch`; it means this method acts as immutable.
+func (ts *TypeSwitch) Init(init Statement) *TypeSwitch {
+	return &TypeSwitch{
+		initStatement:    init,
+		binding:          ts.binding,
+		expression:       ts.expression,
+		caseStatements:   ts.caseStatements,
+		defaultStatement: ts.defaultStatement,
+		caller:           ts.caller,
+	}
+}
+
+// AddCase adds `case` statements to `TypeSwitch`. This does *not* set, just add.
+// This method returns a *new* `TypeSwitch`; it means this method acts as immutable.
+func (ts *TypeSwitch) AddCase(statements ...*Case) *TypeSwitch {
+	return &TypeSwitch{
+		initStatement:    ts.initStatement,
+		binding:          ts.binding,
+		expression:       ts.expression,
+		caseStatements:   append(ts.caseStatements, statements...),
+		defaultStatement: ts.defaultStatement,
+		caller:           ts.caller,
+	}
+}
+
+// Case sets `case` statements to `TypeSwitch`. This does *not* add, just set.
+// This method returns a *new* `TypeSwitch`; it means this method acts as immutable.
+func (ts *TypeSwitch) Case(statements ...*Case) *TypeSwitch {
+	return &TypeSwitch{
+		initStatement:    ts.initStatement,
+		binding:          ts.binding,
+		expression:       ts.expression,
+		caseStatements:   statements,
+		defaultStatement: ts.defaultStatement,
+		caller:           ts.caller,
+	}
+}
+
+// Default sets a `default` statement to `TypeSwitch`.
+// This method returns a *new* `TypeSwitch`; it means this method acts as immutable.
+func (ts *TypeSwitch) Default(statement *DefaultCase) *TypeSwitch {
+	return &TypeSwitch{
+		initStatement:    ts.initStatement,
+		binding:          ts.binding,
+		expression:       ts.expression,
+		caseStatements:   ts.caseStatements,
+		defaultStatement: statement,
+		caller:           ts.caller,
+	}
+}
+
+// Generate generates type switch statement as golang code.
+func (ts *TypeSwitch) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, ts.pathSegment())
+
+	if err := firstError(ts.validate()); err != nil {
+		return "", err
+	}
+
+	indent := BuildIndent(indentLevel)
+
+	guard := ts.expression + ".(type)"
+	if ts.binding != "" {
+		guard = ts.binding + " := " + guard
+	}
+
+	header, err := generateSwitchHeader(ts.initStatement, guard)
+	if err != nil {
+		return "", err
+	}
+
+	stmt := fmt.Sprintf("%sswitch %s{\n", indent, header)
+
+	body, err := generateSwitchBody(ts.caseStatements, ts.defaultStatement, indentLevel)
+	if err != nil {
+		return "", err
+	}
+	stmt += body
+
+	stmt += fmt.Sprintf("%s}\n", indent)
+
+	return stmt, nil
+}
+
+func (ts *TypeSwitch) validate() []error {
+	var errs []error
+	if ts.expression == "" {
+		errs = append(errs, errmsg.TypeSwitchExpressionIsEmptyError(ts.caller))
+	}
+	return append(errs, validateFallthrough(ts.caseStatements, ts.defaultStatement, true)...)
+}
+
+func (ts *TypeSwitch) childStatements() []Statement {
+	children := make([]Statement, 0, len(ts.caseStatements)+2)
+	if ts.initStatement != nil {
+		children = append(children, ts.initStatement)
+	}
+	for _, statement := range ts.caseStatements {
+		if statement != nil {
+			children = append(children, statement)
+		}
+	}
+	if ts.defaultStatement != nil {
+		children = append(children, ts.defaultStatement)
+	}
+	return children
+}
+
+func (ts *TypeSwitch) pathSegment() errorPathSegment {
+	return errorPathSegment{name: "TypeSwitch", caller: ts.caller}
+}
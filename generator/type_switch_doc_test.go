@@ -0,0 +1,21 @@
+package generator
+
+import (
+	"fmt"
+	"log"
+)
+
+func ExampleTypeSwitch_Generate() {
+	generator := NewTypeSwitch("v", "x").AddCase(
+		NewTypeCase([]string{"int", "int64"}, NewRawStatement(`fmt.Printf("integer: %d\n", v)`)),
+		NewCase("string", NewRawStatement(`fmt.Printf("string: %s\n", v)`)),
+	).Default(
+		NewDefaultCase(NewRawStatement(`fmt.Printf("unknown: %v\n", v)`)),
+	)
+
+	generated, err := generator.Generate(0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(generated)
+}
@@ -0,0 +1,119 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldGenerateTypeSwitch(t *testing.T) {
+	generator := NewTypeSwitch("v", "x")
+
+	{
+		gen, err := generator.Generate(0)
+		assert.NoError(t, err)
+		expected := `switch v := x.(type) {
+}
+`
+		assert.Equal(t, expected, gen)
+	}
+
+	generator = generator.AddCase(
+		NewTypeCase([]string{"int", "int64"}, NewComment(" integer")),
+		nil,
+		NewCase("nil", NewComment(" nil")),
+	).Default(
+		NewDefaultCase(NewComment(" default")),
+	)
+
+	{
+		gen, err := generator.Generate(0)
+		assert.NoError(t, err)
+		expected := `switch v := x.(type) {
+case int, int64:
+	// integer
+case nil:
+	// nil
+default:
+	// default
+}
+`
+		assert.Equal(t, expected, gen)
+	}
+
+	{
+		gen, err := generator.Generate(2)
+		assert.NoError(t, err)
+		expected := `		switch v := x.(type) {
+		case int, int64:
+			// integer
+		case nil:
+			// nil
+		default:
+			// default
+		}
+`
+		assert.Equal(t, expected, gen)
+	}
+
+	{
+		generator = generator.Case(NewCase("string", NewComment("modified")))
+		gen, err := generator.Generate(0)
+		assert.NoError(t, err)
+		expected := `switch v := x.(type) {
+case string:
+	//modified
+default:
+	// default
+}
+`
+		assert.Equal(t, expected, gen)
+	}
+}
+
+func TestShouldGenerateTypeSwitchWithoutBinding(t *testing.T) {
+	generator := NewTypeSwitch("", "x").
+		Init(NewShortVarDecl([]string{"x"}, NewRawStatement("f()"))).
+		AddCase(NewCase("error"))
+
+	expected := `switch x := f(); x.(type) {
+case error:
+}
+`
+	gen, err := generator.Generate(0)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, gen)
+}
+
+func TestShouldGenerateTypeSwitchRaisesError(t *testing.T) {
+	{
+		_, err := NewTypeSwitch("v", "").Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.TypeSwitchExpressionIsEmptyError("").Error(), " ")[0],
+		), err.Error())
+	}
+
+	{
+		_, err := NewTypeSwitch("v", "x").AddCase(NewCase("int").WithFallthrough(true)).Default(NewDefaultCase()).Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.FallthroughInTypeSwitchError("").Error(), " ")[0],
+		), err.Error())
+	}
+
+	{
+		_, err := NewTypeSwitch("v", "x").AddCase(NewCase("")).Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.CaseConditionIsEmptyError("").Error(), " ")[0],
+		), err.Error())
+	}
+
+	{
+		_, err := NewTypeSwitch("v", "x").Default(NewDefaultCase(NewFunc(nil, NewFuncSignature("")))).Generate(0)
+		assert.Regexp(t, regexp.MustCompile(
+			`^\`+strings.Split(errmsg.FuncNameIsEmptyError("").Error(), " ")[0],
+		), err.Error())
+	}
+}
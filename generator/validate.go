@@ -0,0 +1,129 @@
+package generator
+
+import (
+	"strings"
+)
+
+// validator is an interface for generators that can check their own problems without generating code.
+// `validate()` doesn't inspect the nested generators; they are inspected through `statementContainer`.
+type validator interface {
+	validate() []error
+}
+
+// warner is an interface for generators that can report the warnings; they are not the errors, but they might be mistakes.
+type warner interface {
+	warnings() []error
+}
+
+// ValidationErrors represents the multiple errors that are reported by `Root.Validate()` and the type checking.
+// Each error is a `*GeneratorError`, so it has the caller location and the nesting path.
+type ValidationErrors struct {
+	errs []error
+}
+
+// Error returns the messages of all errors, separated by newline.
+func (e *ValidationErrors) Error() string {
+	msgs := make([]string, len(e.errs))
+	for i, err := range e.errs {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Errors returns all errors.
+func (e *ValidationErrors) Errors() []error {
+	return append([]error{}, e.errs...)
+}
+
+// Unwrap returns all errors. This makes `errors.Is()` and `errors.As()` inspect each error.
+func (e *ValidationErrors) Unwrap() []error {
+	return e.Errors()
+}
+
+// Validate validates all of the registered statements without generating code.
+// Unlike `Generate()`, this doesn't stop at the first problem; this reports every problem as `*ValidationErrors`.
+// This returns nil if there is no problem.
+//
+// Note that this doesn't apply the code formatter, so the problems that can be detected only by the formatter are not reported.
+func (g *Root) Validate() error {
+	errs := inspectStatement(g, nil, nil, func(statement Statement) []error {
+		if v, ok := statement.(validator); ok {
+			return v.validate()
+		}
+		return nil
+	})
+	if len(errs) <= 0 {
+		return nil
+	}
+	return &ValidationErrors{
+		errs: errs,
+	}
+}
+
+// Warnings returns the warnings of all of the registered statements (e.g. a parameter name that shadows the predeclared identifier).
+// The warnings don't cause the failure of `Generate()` and `Validate()`. Each warning is a `*GeneratorError` that has `ErrorKindWarning`.
+func (g *Root) Warnings() []error {
+	return inspectStatement(g, nil, nil, func(statement Statement) []error {
+		if w, ok := statement.(warner); ok {
+			return w.warnings()
+		}
+		return nil
+	})
+}
+
+// inspectStatement applies `inspect` to the statement and the nested statements recursively,
+// and annotates each reported error with the nesting path.
+// If `composer` is not nil (i.e. the statement is composed by `statementComposer`), the errors and the path segment have the caller of the composer.
+func inspectStatement(statement Statement, parentPath []errorPathSegment, composer *errorPathSegment, inspect func(statement Statement) []error) []error {
+	path := parentPath
+	if s, ok := statement.(interface{ pathSegment() errorPathSegment }); ok {
+		segment := s.pathSegment()
+		if composer != nil {
+			segment.caller = composer.caller
+		}
+		path = append(append([]errorPathSegment{}, parentPath...), segment)
+		if _, ok := statement.(statementComposer); ok && composer == nil {
+			composer = &segment
+		}
+	}
+
+	var errs []error
+	for _, err := range inspect(statement) {
+		genErr := newGeneratorError(err)
+		genErr.path = path
+		if composer != nil && genErr.caller != "" {
+			genErr.caller = composer.caller
+		}
+		errs = append(errs, genErr)
+	}
+
+	if c, ok := statement.(statementContainer); ok {
+		for _, child := range c.childStatements() {
+			if child == nil {
+				continue
+			}
+			errs = append(errs, inspectStatement(child, path, composer, inspect)...)
+		}
+	}
+
+	return errs
+}
+
+// nonNilStatements returns the statements except nil.
+func nonNilStatements(statements []Statement) []Statement {
+	children := make([]Statement, 0, len(statements))
+	for _, statement := range statements {
+		if statement != nil {
+			children = append(children, statement)
+		}
+	}
+	return children
+}
+
+// firstError returns the first error of `errs`, or nil if `errs` is empty.
+func firstError(errs []error) error {
+	if len(errs) <= 0 {
+		return nil
+	}
+	return errs[0]
+}
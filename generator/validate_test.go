@@ -0,0 +1,98 @@
+package generator
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldValidateRootSuccessfully(t *testing.T) {
+	generator := NewRoot(
+		NewPackage("mypkg"),
+		NewStruct("MyStruct").AddField("Foo", "string"),
+		NewFunc(
+			NewFuncReceiver("m", "*MyStruct"),
+			NewFuncSignature("MyFunc").AddReturnTypes("error"),
+			NewIf("m.Foo == \"\"",
+				NewReturnStatement().AddReturnStatements(NewCall("errors.New", NewRawStatement(`"empty"`))),
+			),
+			NewReturnStatement("nil"),
+		),
+	)
+
+	assert.NoError(t, generator.Validate())
+}
+
+func TestShouldValidateRootCollectAllErrors(t *testing.T) {
+	generator := NewRoot(
+		NewPackage("mypkg"),
+		NewStruct("MyStruct").
+			AddField("", "string").
+			AddField("Bar", ""),
+		NewFunc(
+			NewFuncReceiver("", "*MyStruct"),
+			NewFuncSignature("MyFunc").
+				AddParameters(NewFuncParameter("", "string")),
+			NewIf("",
+				NewSwitch("x").AddCase(NewCase("")),
+			),
+			NewBreak("undefined"),
+			NewAssign([]string{"x"}, "=", NewCall("")),
+		),
+	)
+
+	err := generator.Validate()
+	assert.Error(t, err)
+
+	var validationErrs *ValidationErrors
+	assert.True(t, errors.As(err, &validationErrs))
+
+	codes := []string{}
+	paths := []string{}
+	for _, e := range validationErrs.Errors() {
+		var genErr *GeneratorError
+		assert.True(t, errors.As(e, &genErr))
+		assert.Equal(t, ErrorKindValidation, genErr.Kind())
+		assert.Regexp(t, regexp.MustCompile(`:\d+$`), genErr.Caller())
+		codes = append(codes, genErr.Code())
+		paths = append(paths, regexp.MustCompile(` \([^)]+\)`).ReplaceAllString(genErr.Path(), ""))
+	}
+
+	assert.Equal(t, []string{
+		"GOWRTR-2",
+		"GOWRTR-3",
+		"GOWRTR-39",
+		"GOWRTR-8",
+		"GOWRTR-4",
+		"GOWRTR-15",
+		"GOWRTR-14",
+		"GOWRTR-42",
+	}, codes)
+	assert.Equal(t, []string{
+		`Root > Struct "MyStruct"`,
+		`Root > Struct "MyStruct"`,
+		`Root > Func "MyFunc"`,
+		`Root > Func "MyFunc" > FuncReceiver`,
+		`Root > Func "MyFunc" > FuncSignature "MyFunc"`,
+		`Root > Func "MyFunc" > If`,
+		`Root > Func "MyFunc" > If > Switch > Case`,
+		`Root > Func "MyFunc" > Assign > Call ""`,
+	}, paths)
+
+	assert.True(t, errors.Is(err, ErrValidation))
+	assert.Regexp(t, regexp.MustCompile(`^\[GOWRTR-2\] .+\n\[GOWRTR-3\] `), err.Error())
+}
+
+func TestShouldGenerateStillFailOnFirstError(t *testing.T) {
+	_, err := NewRoot(
+		NewStruct("MyStruct").
+			AddField("", "string").
+			AddField("Bar", ""),
+	).Generate(0)
+
+	var genErr *GeneratorError
+	assert.True(t, errors.As(err, &genErr))
+	assert.Equal(t, "GOWRTR-2", genErr.Code())
+}
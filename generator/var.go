@@ -0,0 +1,117 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+)
+
+// Var represents a code generator for local variable declaration.
+//
+// example:
+// var x T = v
+type Var struct {
+	names  []string
+	typ    string
+	values []Statement
+	caller string
+}
+
+// NewVar returns a new `Var`.
+// `typ` can be empty when `values` are specified, and `values` can be empty when `typ` is specified.
+func NewVar(names []string, typ string, values ...Statement) *Var {
+	return &Var{
+		names:  names,
+		typ:    typ,
+		values: values,
+		caller: fetchClientCallerLine(),
+	}
+}
+
+// AddValues adds values to `Var`. This does *not* set, just add.
+// This method returns a *new* `Var`; it means this method acts as immutable.
+func (v *Var) AddValues(values ...Statement) *Var {
+	return &Var{
+		names:  v.names,
+		typ:    v.typ,
+		values: append(v.values, values...),
+		caller: v.caller,
+	}
+}
+
+// Values sets values to `Var`. This does *not* add, just set.
+// This method returns a *new* `Var`; it means this method acts as immutable.
+func (v *Var) Values(values ...Statement) *Var {
+	return &Var{
+		names:  v.names,
+		typ:    v.typ,
+		values: values,
+		caller: v.caller,
+	}
+}
+
+// Generate generates a variable declaration as golang code.
+func (v *Var) Generate(indentLevel int) (generated string, err error) {
+	defer annotateErrorPath(&err, v.pathSegment())
+
+	if err := firstError(v.validate()); err != nil {
+		return "", err
+	}
+
+	indent := BuildIndent(indentLevel)
+	stmt := indent + "var " + strings.Join(v.names, ", ")
+	if v.typ != "" {
+		stmt += " " + v.typ
+	}
+
+	if len(v.values) > 0 {
+		values, err := generateExpressions(v.values, indentLevel, v.caller)
+		if err != nil {
+			return "", err
+		}
+		stmt += " = " + strings.Join(values, ", ")
+	}
+	stmt += "\n"
+
+	return stmt, nil
+}
+
+func (v *Var) validate() []error {
+	var errs []error
+
+	if len(v.names) <= 0 {
+		errs = append(errs, errmsg.VarNameIsEmptyError(v.caller))
+	}
+	for _, name := range v.names {
+		if name == "" {
+			errs = append(errs, errmsg.VarNameIsEmptyError(v.caller))
+			break
+		}
+	}
+
+	if v.typ == "" && len(v.values) <= 0 {
+		errs = append(errs, errmsg.VarTypeAndValueAreEmptyError(v.caller))
+	}
+	for _, value := range v.values {
+		if value == nil {
+			errs = append(errs, errmsg.AssignRightHandSideIsEmptyError(v.caller))
+			break
+		}
+	}
+
+	nameCount := len(v.names)
+	valueCount := len(v.values)
+	if nameCount > 0 && valueCount > 0 && nameCount != valueCount && !(valueCount == 1 && isMultiValueExpression(v.values[0], nameCount)) {
+		errs = append(errs, errmsg.AssignOperandsCountMismatchError(nameCount, valueCount, v.caller))
+	}
+
+	return errs
+}
+
+func (v *Var) childStatements() []Statement {
+	return nonNilStatements(v.values)
+}
+
+func (v *Var) pathSegment() errorPathSegment {
+	return errorPathSegment{name: "Var", caller: v.caller}
+}
@@ -0,0 +1,84 @@
+package generator
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/moznion/gowrtr/internal/errmsg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldGenerateVar(t *testing.T) {
+	{
+		gen, err := NewVar([]string{"x"}, "int").Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "var x int\n", gen)
+	}
+
+	{
+		gen, err := NewVar([]string{"x"}, "int64", NewRawStatement("1")).Generate(1)
+		assert.NoError(t, err)
+		assert.Equal(t, "\tvar x int64 = 1\n", gen)
+	}
+
+	{
+		gen, err := NewVar([]string{"a", "b"}, "").AddValues(NewRawStatement("1")).AddValues(NewRawStatement(`"b"`)).Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "var a, b = 1, \"b\"\n", gen)
+	}
+
+	{
+		gen, err := NewVar([]string{"v", "ok"}, "", NewRawStatement("m[k]")).Generate(0)
+		assert.NoError(t, err)
+		assert.Equal(t, "var v, ok = m[k]\n", gen)
+	}
+
+	{
+		gen, err := NewVar([]string{"s"}, "*Struct", NewRawStatement("nil")).
+			Values(NewCompositeLiteral("&Struct").AddFieldRaw("foo", 1)).
+			Generate(0)
+		assert.NoError(t, err)
+		expected := `var s *Struct = &Struct{
+	foo: 1,
+}
+`
+		assert.Equal(t, expected, gen)
+	}
+}
+
+func TestShouldGenerateVarRaisesError(t *testing.T) {
+	errPattern := func(err error) *regexp.Regexp {
+		return regexp.MustCompile(`^\` + strings.Split(err.Error(), " ")[0])
+	}
+
+	{
+		_, err := NewVar(nil, "int").Generate(0)
+		assert.Regexp(t, errPattern(errmsg.VarNameIsEmptyError("")), err.Error())
+	}
+
+	{
+		_, err := NewVar([]string{""}, "int").Generate(0)
+		assert.Regexp(t, errPattern(errmsg.VarNameIsEmptyError("")), err.Error())
+	}
+
+	{
+		_, err := NewVar([]string{"x"}, "").Generate(0)
+		assert.Regexp(t, errPattern(errmsg.VarTypeAndValueAreEmptyError("")), err.Error())
+	}
+
+	{
+		_, err := NewVar([]string{"a", "b", "c"}, "int", NewRawStatement("1"), NewRawStatement("2")).Generate(0)
+		assert.Regexp(t, errPattern(errmsg.AssignOperandsCountMismatchError(0, 0, "")), err.Error())
+	}
+
+	{
+		_, err := NewVar([]string{"a", "b"}, "int", NewRawStatement("1")).Generate(0)
+		assert.Regexp(t, errPattern(errmsg.AssignOperandsCountMismatchError(0, 0, "")), err.Error())
+	}
+
+	{
+		_, err := NewVar([]string{"x"}, "int", NewRawStatement("")).Generate(0)
+		assert.Regexp(t, errPattern(errmsg.AssignRightHandSideIsEmptyError("")), err.Error())
+	}
+}
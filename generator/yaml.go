@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"gopkg.in/yaml.v3"
+)
+
+// yamlToJSON converts the YAML document into JSON with keeping the order of the keys, so that the documents that can be
+// written in both YAML and JSON (e.g. OpenAPI) are handled by `encoding/json`. The JSON document is returned as it is.
+func yamlToJSON(data []byte) ([]byte, error) {
+	if json.Valid(data) {
+		return data, nil
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) <= 0 {
+		return nil, fmt.Errorf("document is empty")
+	}
+
+	var buf bytes.Buffer
+	if err := writeYAMLNodeAsJSON(&buf, doc.Content[0]); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func writeYAMLNodeAsJSON(buf *bytes.Buffer, node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.AliasNode:
+		return writeYAMLNodeAsJSON(buf, node.Alias)
+	case yaml.MappingNode:
+		buf.WriteByte('{')
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			key := node.Content[i]
+			if key.Kind != yaml.ScalarNode {
+				return fmt.Errorf("line %d, column %d: key of mapping must be a scalar", key.Line, key.Column)
+			}
+			encoded, _ := json.Marshal(key.Value)
+			buf.Write(encoded)
+			buf.WriteByte(':')
+			if err := writeYAMLNodeAsJSON(buf, node.Content[i+1]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+		return nil
+	case yaml.SequenceNode:
+		buf.WriteByte('[')
+		for i, item := range node.Content {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeYAMLNodeAsJSON(buf, item); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+		return nil
+	case yaml.ScalarNode:
+		var value interface{}
+		switch node.ShortTag() {
+		case "!!null", "!!bool", "!!int", "!!float":
+			if err := node.Decode(&value); err != nil {
+				return fmt.Errorf("line %d, column %d: %s", node.Line, node.Column, err)
+			}
+			if f, ok := value.(float64); ok && (math.IsInf(f, 0) || math.IsNaN(f)) {
+				return fmt.Errorf("line %d, column %d: %s cannot be represented in JSON", node.Line, node.Column, node.Value)
+			}
+		default:
+			// the other scalars (e.g. timestamp) are kept as the string
+			value = node.Value
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return fmt.Errorf("line %d, column %d: %s", node.Line, node.Column, err)
+		}
+		buf.Write(encoded)
+		return nil
+	}
+	return fmt.Errorf("line %d, column %d: unsupported YAML node", node.Line, node.Column)
+}
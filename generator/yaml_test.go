@@ -0,0 +1,32 @@
+package generator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShouldConvertYAMLToJSON(t *testing.T) {
+	converted, err := yamlToJSON([]byte(`
+b: 1
+a:
+  - &item {x: 1.5, "y": null}
+  - *item
+  - true
+  - 2020-01-02
+  - "text"
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"b":1,"a":[{"x":1.5,"y":null},{"x":1.5,"y":null},true,"2020-01-02","text"]}`, string(converted))
+
+	converted, err = yamlToJSON([]byte(`{"b": 1, "a": 2}`))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"b": 1, "a": 2}`, string(converted))
+}
+
+func TestShouldYAMLToJSONRaiseError(t *testing.T) {
+	for _, doc := range []string{"a: [1", "", "? [a]\n: 1", "a: .inf"} {
+		_, err := yamlToJSON([]byte(doc))
+		assert.Error(t, err, doc)
+	}
+}
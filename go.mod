@@ -3,9 +3,8 @@ module github.com/moznion/gowrtr
 go 1.14
 
 require (
-	github.com/iancoleman/strcase v0.1.3 // indirect
-	github.com/moznion/go-errgen v1.8.1
 	github.com/pkg/errors v0.9.1
 	github.com/stretchr/testify v1.6.0
 	golang.org/x/lint v0.0.0-20200302205851-738671d3881b
+	gopkg.in/yaml.v3 v3.0.1
 )
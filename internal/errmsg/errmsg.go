@@ -1,6 +1,6 @@
 package errmsg
 
-//go:generate errgen -type=errs -prefix=GOWRTR-
+//go:generate go run gen.go
 type errs struct {
 	StructNameIsNilErr                                error `errmsg:"struct name must not be empty, but it gets empty (caused at %s)" vars:"caller string"`
 	StructFieldNameIsEmptyErr                         error `errmsg:"field name must not be empty, but it gets empty (caused at %s)" vars:"caller string"`
@@ -19,4 +19,82 @@ type errs struct {
 	IfConditionIsEmptyError                           error `errmsg:"condition of if must not be empty, but it gets empty (caused at %s)" vars:"caller string"`
 	UnnamedReturnTypeAppearsAfterNamedReturnTypeError error `errmsg:"unnamed return type appears after named return type (caused at %s)" vars:"caller string"`
 	ValueOfCompositeLiteralIsEmptyError               error `errmsg:"a value of composite literal must not be empty, but it gets empty (caused at %s)" vars:"caller string"`
+	AssignLeftHandSideIsEmptyError                    error `errmsg:"left-hand side of assignment must not be empty, but it gets empty (caused at %s)" vars:"caller string"`
+	AssignRightHandSideIsEmptyError                   error `errmsg:"right-hand side of assignment must not be empty, but it gets empty (caused at %s)" vars:"caller string"`
+	AssignOperatorIsInvalidError                      error `errmsg:"assignment operator is invalid: '%s' (caused at %s)" vars:"operator string, caller string"`
+	AssignOperandsCountMismatchError                  error `errmsg:"assignment count mismatch: %d = %d (caused at %s)" vars:"lhsCount int, rhsCount int, caller string"`
+	CompoundAssignOperandIsNotSingleError             error `errmsg:"compound assignment operator '%s' takes exactly one operand on each side (caused at %s)" vars:"operator string, caller string"`
+	VarNameIsEmptyError                               error `errmsg:"name of var must not be empty, but it gets empty (caused at %s)" vars:"caller string"`
+	VarTypeAndValueAreEmptyError                      error `errmsg:"either type or value of var must be specified, but both are empty (caused at %s)" vars:"caller string"`
+	IncDecTargetIsEmptyError                          error `errmsg:"target of increment/decrement statement must not be empty, but it gets empty (caused at %s)" vars:"caller string"`
+	ForRangeExpressionIsEmptyError                    error `errmsg:"range expression of for must not be empty, but it gets empty (caused at %s)" vars:"caller string"`
+	ForPostStatementIsShortVarDeclError               error `errmsg:"post statement of for must not be a short variable declaration (caused at %s)" vars:"caller string"`
+	TypeSwitchExpressionIsEmptyError                  error `errmsg:"expression of type switch must not be empty, but it gets empty (caused at %s)" vars:"caller string"`
+	FallthroughInTypeSwitchError                      error `errmsg:"fallthrough statement is not permitted in type switch (caused at %s)" vars:"caller string"`
+	FallthroughInFinalCaseError                       error `errmsg:"cannot fallthrough final case in switch (caused at %s)" vars:"caller string"`
+	SelectDefaultCaseIsDuplicatedError                error `errmsg:"select must not have more than one default case (caused at %s)" vars:"caller string"`
+	SelectCaseChannelIsEmptyError                     error `errmsg:"channel of select case must not be empty, but it gets empty (caused at %s)" vars:"caller string"`
+	SelectSendCaseValueIsEmptyError                   error `errmsg:"value of select send case must not be empty, but it gets empty (caused at %s)" vars:"caller string"`
+	SelectRecvCaseVariablesCountError                 error `errmsg:"receive case of select takes at most two variables, but it gets %d (caused at %s)" vars:"count int, caller string"`
+	DeferStatementIsEmptyError                        error `errmsg:"statement of defer must not be empty, but it gets empty (caused at %s)" vars:"caller string"`
+	GoStatementIsEmptyError                           error `errmsg:"statement of go must not be empty, but it gets empty (caused at %s)" vars:"caller string"`
+	LabelNameIsEmptyError                             error `errmsg:"name of label must not be empty, but it gets empty (caused at %s)" vars:"caller string"`
+	LabelIsDuplicatedError                            error `errmsg:"label '%s' is already defined in the enclosing func (caused at %s)" vars:"label string, caller string"`
+	LabelIsNotDefinedError                            error `errmsg:"label '%s' is not defined in the enclosing func (caused at %s)" vars:"label string, caller string"`
+	GotoLabelIsEmptyError                             error `errmsg:"label of goto must not be empty, but it gets empty (caused at %s)" vars:"caller string"`
+	ElseIfConditionIsEmptyError                       error `errmsg:"condition of else-if must not be empty, but it gets empty (caused at %s)" vars:"caller string"`
+	CallCalleeIsEmptyError                            error `errmsg:"callee of call expression must not be empty, but it gets empty (caused at %s)" vars:"caller string"`
+	CallArgumentIsEmptyError                          error `errmsg:"an argument of call expression must not be empty, but it gets empty (caused at %s)" vars:"caller string"`
+	CallTypeArgumentIsEmptyError                      error `errmsg:"a type argument of call expression must not be empty, but it gets empty (caused at %s)" vars:"caller string"`
+	CallSpreadWithoutArgumentsError                   error `errmsg:"call expression with variadic spread must have at least one argument (caused at %s)" vars:"caller string"`
+	ReturnItemIsEmptyError                            error `errmsg:"a return item must not be empty, but it gets empty (caused at %s)" vars:"caller string"`
+	IdentifierIsInvalidError                          error `errmsg:"'%s' is not a valid identifier (caused at %s)" vars:"name string, caller string"`
+	IdentifierIsKeywordError                          error `errmsg:"'%s' is a keyword, so it cannot be used as an identifier (caused at %s)" vars:"name string, caller string"`
+	PredeclaredIdentifierIsShadowedWarning            error `errmsg:"'%s' shadows the predeclared identifier (caused at %s)" vars:"name string, caller string"`
+	TypeCheckError                                    error `errmsg:"type checking raises error at %s of the generated code: %s (caused at %s)" vars:"position string, msg string, caller string"`
+	PackageLoadingError                               error `errmsg:"failed to load the package '%s': %s (caused at %s)" vars:"pkgPath string, msg string, caller string"`
+	TypeIsNotFoundError                               error `errmsg:"type '%s' is not found in the package '%s' (caused at %s)" vars:"typeName string, pkgPath string, caller string"`
+	TypeIsNotInterfaceError                           error `errmsg:"type '%s' of the package '%s' is not an interface (caused at %s)" vars:"typeName string, pkgPath string, caller string"`
+	StubTypeNameIsEmptyError                          error `errmsg:"type name of stub must not be empty, but it gets empty (caused at %s)" vars:"caller string"`
+	StubInterfaceIsNilError                           error `errmsg:"interface of stub must not be nil, but it gets nil (caused at %s)" vars:"caller string"`
+	MockTypeNameIsEmptyError                          error `errmsg:"type name of mock must not be empty, but it gets empty (caused at %s)" vars:"caller string"`
+	MockInterfaceIsNilError                           error `errmsg:"interface of mock must not be nil, but it gets nil (caused at %s)" vars:"caller string"`
+	ConstNameIsEmptyError                             error `errmsg:"name of const must not be empty, but it gets empty (caused at %s)" vars:"caller string"`
+	ConstValueIsEmptyError                            error `errmsg:"value of the first const must not be empty, but it gets empty (caused at %s)" vars:"caller string"`
+	ConstTypeWithoutValueError                        error `errmsg:"const that has a type must have a value (caused at %s)" vars:"caller string"`
+	EnumTypeNameIsEmptyError                          error `errmsg:"type name of enum must not be empty, but it gets empty (caused at %s)" vars:"caller string"`
+	EnumValueNameIsEmptyError                         error `errmsg:"name of enum value must not be empty, but it gets empty (caused at %s)" vars:"caller string"`
+	EnumValueIsDuplicatedError                        error `errmsg:"enum value '%s' is duplicated (caused at %s)" vars:"name string, caller string"`
+	DerivedStructIsNilError                           error `errmsg:"struct to derive the code from must not be nil, but it gets nil (caused at %s)" vars:"caller string"`
+	StructTagContainsBackquoteError                   error `errmsg:"struct tag must not contain backquote, but it gets '%s' (caused at %s)" vars:"tag string, caller string"`
+	StructTagIsMalformedError                         error `errmsg:"struct tag must be space-separated key:value pairs that are compatible with reflect.StructTag (the value is quoted), but it gets '%s' (caused at %s)" vars:"tag string, caller string"`
+	TableTestFuncIsNilError                           error `errmsg:"func to generate the test from must not be nil, but it gets nil (caused at %s)" vars:"caller string"`
+	TableTestCaseArgsCountMismatchError               error `errmsg:"the number of args of the test case must be %d (the number of the parameters), but it gets %d (caused at %s)" vars:"expected int, actual int, caller string"`
+	TableTestCaseWantsCountMismatchError              error `errmsg:"the number of wants of the test case must be %d (the number of the return types except error), but it gets %d (caused at %s)" vars:"expected int, actual int, caller string"`
+	FuzzParameterTypeIsNotSupportedError              error `errmsg:"type of the parameter '%s' is not supported by fuzzing, but it gets '%s' (caused at %s)" vars:"name string, typ string, caller string"`
+	JSONTypesRootTypeNameIsEmptyError                 error `errmsg:"root type name of the JSON types must not be empty, but it gets empty (caused at %s)" vars:"caller string"`
+	JSONFileLoadingError                              error `errmsg:"failed to load the JSON file '%s': %s (caused at %s)" vars:"path string, msg string, caller string"`
+	JSONSchemaIsInvalidError                          error `errmsg:"JSON schema is invalid: %s (caused at %s)" vars:"msg string, caller string"`
+	JSONSchemaRefIsNotSupportedError                  error `errmsg:"$ref '%s' is not supported; only the reference in the same document (e.g. #/definitions/xxx) is supported (caused at %s)" vars:"ref string, caller string"`
+	JSONSchemaRefIsNotFoundError                      error `errmsg:"$ref '%s' is not found in the JSON schema (caused at %s)" vars:"ref string, caller string"`
+	JSONSchemaEnumIsNotSupportedError                 error `errmsg:"enum of '%s' must consist of only string values or only integer values (caused at %s)" vars:"typeName string, caller string"`
+	JSONSchemaOneOfVariantIsNotSupportedError         error `errmsg:"variant '%s' of oneOf/anyOf must be a concrete type; neither oneOf/anyOf nor a schema without the type is supported (caused at %s)" vars:"typeName string, caller string"`
+	JSONSampleIsInvalidError                          error `errmsg:"JSON sample #%d is invalid: %s (caused at %s)" vars:"index int, msg string, caller string"`
+	OpenAPILoadingError                               error `errmsg:"failed to load the OpenAPI document '%s': %s (caused at %s)" vars:"path string, msg string, caller string"`
+	OpenAPIIsInvalidError                             error `errmsg:"OpenAPI document is invalid: %s (caused at %s)" vars:"msg string, caller string"`
+	OpenAPIOperationIsDuplicatedError                 error `errmsg:"operation '%s' is duplicated; please specify the unique operationId (caused at %s)" vars:"name string, caller string"`
+	OpenAPIParameterIsNotSupportedError               error `errmsg:"parameter '%s' of the operation '%s' is not supported; it must be in path, query or header, and the type must be a primitive (or an array of primitives in query) (caused at %s)" vars:"name string, operation string, caller string"`
+	OpenAPIContentTypeIsNotSupportedError             error `errmsg:"content type '%s' of the operation '%s' is not supported; only JSON is supported (caused at %s)" vars:"contentType string, operation string, caller string"`
+	SQLSchemaLoadingError                             error `errmsg:"failed to load the SQL schema '%s': %s (caused at %s)" vars:"path string, msg string, caller string"`
+	SQLSchemaIsInvalidError                           error `errmsg:"SQL schema is invalid: %s (caused at %s)" vars:"msg string, caller string"`
+	SQLColumnTypeIsNotSupportedError                  error `errmsg:"type '%s' of the column '%s' of the table '%s' is not supported (caused at %s)" vars:"typ string, column string, table string, caller string"`
+	ProtoLoadingError                                 error `errmsg:"failed to load the proto file '%s': %s (caused at %s)" vars:"path string, msg string, caller string"`
+	ProtoIsInvalidError                               error `errmsg:"proto is invalid: %s (caused at %s)" vars:"msg string, caller string"`
+	ProtoTypeIsNotFoundError                          error `errmsg:"type '%s' at line %d, column %d is not found; the types of the imported files are not supported (caused at %s)" vars:"typeName string, line int, column int, caller string"`
+	ProtoStreamingIsNotSupportedError                 error `errmsg:"rpc '%s' is streaming, but the streaming rpc is not supported (caused at %s)" vars:"name string, caller string"`
+	SpecLoadingError                                  error `errmsg:"failed to load the spec '%s': %s (caused at %s)" vars:"path string, msg string, caller string"`
+	SpecIsInvalidError                                error `errmsg:"spec is invalid: %s (caused at %s)" vars:"msg string, caller string"`
+	GotoJumpsIntoBlockError                           error `errmsg:"goto '%s' jumps into the block that doesn't enclose the goto (caused at %s)" vars:"label string, caller string"`
+	LabelIsNotFollowedByStatementError                error `errmsg:"label '%s' must be followed by a statement in the same block (caused at %s)" vars:"label string, caller string"`
+	MockMemberNameConflictsError                      error `errmsg:"'%s' of the mock '%s' conflicts with another field, method or type of the mock; please rename the method of the interface (caused at %s)" vars:"name string, typeName string, caller string"`
 }
@@ -0,0 +1,38 @@
+package errmsg
+
+// Error is the error of gowrtr that holds the error code (e.g. `GOWRTR-14`) and the caller location.
+// They are given when the error is built, so they can be inspected without parsing the message.
+type Error struct {
+	err    error
+	code   string
+	caller string
+}
+
+func newError(code string, caller string, err error) error {
+	return &Error{
+		err:    err,
+		code:   code,
+		caller: caller,
+	}
+}
+
+// Error returns the error message.
+func (e *Error) Error() string {
+	return e.err.Error()
+}
+
+// Code returns the error code (e.g. `GOWRTR-14`).
+func (e *Error) Code() string {
+	return e.code
+}
+
+// Caller returns the location of the client code that causes the error.
+// This returns empty string if the error doesn't have the location (e.g. the error of the code formatter).
+func (e *Error) Caller() string {
+	return e.caller
+}
+
+// Unwrap returns the underlying error; the `...Wrap` error reaches the wrapped one through this.
+func (e *Error) Unwrap() error {
+	return e.err
+}
@@ -1,190 +1,967 @@
-// This package was auto generated.
-// DO NOT EDIT BY YOUR HAND!
+// Code generated by gen.go from errmsg.go; DO NOT EDIT.
 
 package errmsg
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/pkg/errors"
 )
 
 // StructNameIsNilErr returns the error.
 func StructNameIsNilErr(caller string) error {
-	return fmt.Errorf(`[GOWRTR-1] struct name must not be empty, but it gets empty (caused at %s)`, caller)
+	return newError("GOWRTR-1", caller, fmt.Errorf("[GOWRTR-1] struct name must not be empty, but it gets empty (caused at %s)", caller))
 }
 
 // StructNameIsNilErrWrap wraps the error.
 func StructNameIsNilErrWrap(caller string, err error) error {
-	return errors.Wrap(err, "[GOWRTR-1] struct name must not be empty, but it gets empty (caused at %s)")
+	return newError("GOWRTR-1", caller, errors.Wrapf(err, "[GOWRTR-1] struct name must not be empty, but it gets empty (caused at %s)", caller))
 }
 
 // StructFieldNameIsEmptyErr returns the error.
 func StructFieldNameIsEmptyErr(caller string) error {
-	return fmt.Errorf(`[GOWRTR-2] field name must not be empty, but it gets empty (caused at %s)`, caller)
+	return newError("GOWRTR-2", caller, fmt.Errorf("[GOWRTR-2] field name must not be empty, but it gets empty (caused at %s)", caller))
 }
 
 // StructFieldNameIsEmptyErrWrap wraps the error.
 func StructFieldNameIsEmptyErrWrap(caller string, err error) error {
-	return errors.Wrap(err, "[GOWRTR-2] field name must not be empty, but it gets empty (caused at %s)")
+	return newError("GOWRTR-2", caller, errors.Wrapf(err, "[GOWRTR-2] field name must not be empty, but it gets empty (caused at %s)", caller))
 }
 
 // StructFieldTypeIsEmptyErr returns the error.
 func StructFieldTypeIsEmptyErr(caller string) error {
-	return fmt.Errorf(`[GOWRTR-3] field type must not be empty, but it gets empty (caused at %s)`, caller)
+	return newError("GOWRTR-3", caller, fmt.Errorf("[GOWRTR-3] field type must not be empty, but it gets empty (caused at %s)", caller))
 }
 
 // StructFieldTypeIsEmptyErrWrap wraps the error.
 func StructFieldTypeIsEmptyErrWrap(caller string, err error) error {
-	return errors.Wrap(err, "[GOWRTR-3] field type must not be empty, but it gets empty (caused at %s)")
+	return newError("GOWRTR-3", caller, errors.Wrapf(err, "[GOWRTR-3] field type must not be empty, but it gets empty (caused at %s)", caller))
 }
 
 // FuncParameterNameIsEmptyErr returns the error.
 func FuncParameterNameIsEmptyErr(caller string) error {
-	return fmt.Errorf(`[GOWRTR-4] func parameter name must not be empty, but it gets empty (caused at %s)`, caller)
+	return newError("GOWRTR-4", caller, fmt.Errorf("[GOWRTR-4] func parameter name must not be empty, but it gets empty (caused at %s)", caller))
 }
 
 // FuncParameterNameIsEmptyErrWrap wraps the error.
 func FuncParameterNameIsEmptyErrWrap(caller string, err error) error {
-	return errors.Wrap(err, "[GOWRTR-4] func parameter name must not be empty, but it gets empty (caused at %s)")
+	return newError("GOWRTR-4", caller, errors.Wrapf(err, "[GOWRTR-4] func parameter name must not be empty, but it gets empty (caused at %s)", caller))
 }
 
 // LastFuncParameterTypeIsEmptyErr returns the error.
 func LastFuncParameterTypeIsEmptyErr(caller string) error {
-	return fmt.Errorf(`[GOWRTR-5] the last func parameter type must not be empty, but it gets empty (caused at %s)`, caller)
+	return newError("GOWRTR-5", caller, fmt.Errorf("[GOWRTR-5] the last func parameter type must not be empty, but it gets empty (caused at %s)", caller))
 }
 
 // LastFuncParameterTypeIsEmptyErrWrap wraps the error.
 func LastFuncParameterTypeIsEmptyErrWrap(caller string, err error) error {
-	return errors.Wrap(err, "[GOWRTR-5] the last func parameter type must not be empty, but it gets empty (caused at %s)")
+	return newError("GOWRTR-5", caller, errors.Wrapf(err, "[GOWRTR-5] the last func parameter type must not be empty, but it gets empty (caused at %s)", caller))
 }
 
 // FuncNameIsEmptyError returns the error.
 func FuncNameIsEmptyError(caller string) error {
-	return fmt.Errorf(`[GOWRTR-6] name of func must not be empty, but it gets empty (caused at %s)`, caller)
+	return newError("GOWRTR-6", caller, fmt.Errorf("[GOWRTR-6] name of func must not be empty, but it gets empty (caused at %s)", caller))
 }
 
 // FuncNameIsEmptyErrorWrap wraps the error.
 func FuncNameIsEmptyErrorWrap(caller string, err error) error {
-	return errors.Wrap(err, "[GOWRTR-6] name of func must not be empty, but it gets empty (caused at %s)")
+	return newError("GOWRTR-6", caller, errors.Wrapf(err, "[GOWRTR-6] name of func must not be empty, but it gets empty (caused at %s)", caller))
 }
 
 // InterfaceNameIsEmptyError returns the error.
 func InterfaceNameIsEmptyError(caller string) error {
-	return fmt.Errorf(`[GOWRTR-7] name of interface must not be empty, but it gets empty (caused at %s)`, caller)
+	return newError("GOWRTR-7", caller, fmt.Errorf("[GOWRTR-7] name of interface must not be empty, but it gets empty (caused at %s)", caller))
 }
 
 // InterfaceNameIsEmptyErrorWrap wraps the error.
 func InterfaceNameIsEmptyErrorWrap(caller string, err error) error {
-	return errors.Wrap(err, "[GOWRTR-7] name of interface must not be empty, but it gets empty (caused at %s)")
+	return newError("GOWRTR-7", caller, errors.Wrapf(err, "[GOWRTR-7] name of interface must not be empty, but it gets empty (caused at %s)", caller))
 }
 
 // FuncReceiverNameIsEmptyError returns the error.
 func FuncReceiverNameIsEmptyError(caller string) error {
-	return fmt.Errorf(`[GOWRTR-8] name of func receiver must not be empty, but it gets empty (caused at %s)`, caller)
+	return newError("GOWRTR-8", caller, fmt.Errorf("[GOWRTR-8] name of func receiver must not be empty, but it gets empty (caused at %s)", caller))
 }
 
 // FuncReceiverNameIsEmptyErrorWrap wraps the error.
 func FuncReceiverNameIsEmptyErrorWrap(caller string, err error) error {
-	return errors.Wrap(err, "[GOWRTR-8] name of func receiver must not be empty, but it gets empty (caused at %s)")
+	return newError("GOWRTR-8", caller, errors.Wrapf(err, "[GOWRTR-8] name of func receiver must not be empty, but it gets empty (caused at %s)", caller))
 }
 
 // FuncReceiverTypeIsEmptyError returns the error.
 func FuncReceiverTypeIsEmptyError(caller string) error {
-	return fmt.Errorf(`[GOWRTR-9] type of func receiver must not be empty, but it gets empty (caused at %s)`, caller)
+	return newError("GOWRTR-9", caller, fmt.Errorf("[GOWRTR-9] type of func receiver must not be empty, but it gets empty (caused at %s)", caller))
 }
 
 // FuncReceiverTypeIsEmptyErrorWrap wraps the error.
 func FuncReceiverTypeIsEmptyErrorWrap(caller string, err error) error {
-	return errors.Wrap(err, "[GOWRTR-9] type of func receiver must not be empty, but it gets empty (caused at %s)")
+	return newError("GOWRTR-9", caller, errors.Wrapf(err, "[GOWRTR-9] type of func receiver must not be empty, but it gets empty (caused at %s)", caller))
 }
 
 // FuncSignatureIsNilError returns the error.
 func FuncSignatureIsNilError(caller string) error {
-	return fmt.Errorf(`[GOWRTR-10] func signature must not be nil, bit it gets nil (caused at %s)`, caller)
+	return newError("GOWRTR-10", caller, fmt.Errorf("[GOWRTR-10] func signature must not be nil, bit it gets nil (caused at %s)", caller))
 }
 
 // FuncSignatureIsNilErrorWrap wraps the error.
 func FuncSignatureIsNilErrorWrap(caller string, err error) error {
-	return errors.Wrap(err, "[GOWRTR-10] func signature must not be nil, bit it gets nil (caused at %s)")
+	return newError("GOWRTR-10", caller, errors.Wrapf(err, "[GOWRTR-10] func signature must not be nil, bit it gets nil (caused at %s)", caller))
 }
 
 // AnonymousFuncSignatureIsNilError returns the error.
 func AnonymousFuncSignatureIsNilError(caller string) error {
-	return fmt.Errorf(`[GOWRTR-11] anonymous func signature must not be nil, bit it gets nil (caused at %s)`, caller)
+	return newError("GOWRTR-11", caller, fmt.Errorf("[GOWRTR-11] anonymous func signature must not be nil, bit it gets nil (caused at %s)", caller))
 }
 
 // AnonymousFuncSignatureIsNilErrorWrap wraps the error.
 func AnonymousFuncSignatureIsNilErrorWrap(caller string, err error) error {
-	return errors.Wrap(err, "[GOWRTR-11] anonymous func signature must not be nil, bit it gets nil (caused at %s)")
+	return newError("GOWRTR-11", caller, errors.Wrapf(err, "[GOWRTR-11] anonymous func signature must not be nil, bit it gets nil (caused at %s)", caller))
 }
 
 // FuncInvocationParameterIsEmptyError returns the error.
 func FuncInvocationParameterIsEmptyError(caller string) error {
-	return fmt.Errorf(`[GOWRTR-12] a parameter of function invocation must not be nil, but it gets nil (caused at %s)`, caller)
+	return newError("GOWRTR-12", caller, fmt.Errorf("[GOWRTR-12] a parameter of function invocation must not be nil, but it gets nil (caused at %s)", caller))
 }
 
 // FuncInvocationParameterIsEmptyErrorWrap wraps the error.
 func FuncInvocationParameterIsEmptyErrorWrap(caller string, err error) error {
-	return errors.Wrap(err, "[GOWRTR-12] a parameter of function invocation must not be nil, but it gets nil (caused at %s)")
+	return newError("GOWRTR-12", caller, errors.Wrapf(err, "[GOWRTR-12] a parameter of function invocation must not be nil, but it gets nil (caused at %s)", caller))
 }
 
 // CodeFormatterError returns the error.
 func CodeFormatterError(cmd string, msg string, fmterr error) error {
-	return fmt.Errorf(`[GOWRTR-13] code formatter raises error: command='%s', err='%s', msg='%s'`, cmd, msg, fmterr)
+	return newError("GOWRTR-13", "", fmt.Errorf("[GOWRTR-13] code formatter raises error: command='%s', err='%s', msg='%s'", cmd, msg, fmterr))
 }
 
 // CodeFormatterErrorWrap wraps the error.
 func CodeFormatterErrorWrap(cmd string, msg string, fmterr error, err error) error {
-	return errors.Wrap(err, "[GOWRTR-13] code formatter raises error: command='%s', err='%s', msg='%s'")
+	return newError("GOWRTR-13", "", errors.Wrapf(err, "[GOWRTR-13] code formatter raises error: command='%s', err='%s', msg='%s'", cmd, msg, fmterr))
 }
 
 // CaseConditionIsEmptyError returns the error.
 func CaseConditionIsEmptyError(caller string) error {
-	return fmt.Errorf(`[GOWRTR-14] condition of case must not be empty, but it gets empty (caused at %s)`, caller)
+	return newError("GOWRTR-14", caller, fmt.Errorf("[GOWRTR-14] condition of case must not be empty, but it gets empty (caused at %s)", caller))
 }
 
 // CaseConditionIsEmptyErrorWrap wraps the error.
 func CaseConditionIsEmptyErrorWrap(caller string, err error) error {
-	return errors.Wrap(err, "[GOWRTR-14] condition of case must not be empty, but it gets empty (caused at %s)")
+	return newError("GOWRTR-14", caller, errors.Wrapf(err, "[GOWRTR-14] condition of case must not be empty, but it gets empty (caused at %s)", caller))
 }
 
 // IfConditionIsEmptyError returns the error.
 func IfConditionIsEmptyError(caller string) error {
-	return fmt.Errorf(`[GOWRTR-15] condition of if must not be empty, but it gets empty (caused at %s)`, caller)
+	return newError("GOWRTR-15", caller, fmt.Errorf("[GOWRTR-15] condition of if must not be empty, but it gets empty (caused at %s)", caller))
 }
 
 // IfConditionIsEmptyErrorWrap wraps the error.
 func IfConditionIsEmptyErrorWrap(caller string, err error) error {
-	return errors.Wrap(err, "[GOWRTR-15] condition of if must not be empty, but it gets empty (caused at %s)")
+	return newError("GOWRTR-15", caller, errors.Wrapf(err, "[GOWRTR-15] condition of if must not be empty, but it gets empty (caused at %s)", caller))
 }
 
 // UnnamedReturnTypeAppearsAfterNamedReturnTypeError returns the error.
 func UnnamedReturnTypeAppearsAfterNamedReturnTypeError(caller string) error {
-	return fmt.Errorf(`[GOWRTR-16] unnamed return type appears after named return type (caused at %s)`, caller)
+	return newError("GOWRTR-16", caller, fmt.Errorf("[GOWRTR-16] unnamed return type appears after named return type (caused at %s)", caller))
 }
 
 // UnnamedReturnTypeAppearsAfterNamedReturnTypeErrorWrap wraps the error.
 func UnnamedReturnTypeAppearsAfterNamedReturnTypeErrorWrap(caller string, err error) error {
-	return errors.Wrap(err, "[GOWRTR-16] unnamed return type appears after named return type (caused at %s)")
+	return newError("GOWRTR-16", caller, errors.Wrapf(err, "[GOWRTR-16] unnamed return type appears after named return type (caused at %s)", caller))
 }
 
 // ValueOfCompositeLiteralIsEmptyError returns the error.
 func ValueOfCompositeLiteralIsEmptyError(caller string) error {
-	return fmt.Errorf(`[GOWRTR-17] a value of composite literal must not be empty, but it gets empty (caused at %s)`, caller)
+	return newError("GOWRTR-17", caller, fmt.Errorf("[GOWRTR-17] a value of composite literal must not be empty, but it gets empty (caused at %s)", caller))
 }
 
 // ValueOfCompositeLiteralIsEmptyErrorWrap wraps the error.
 func ValueOfCompositeLiteralIsEmptyErrorWrap(caller string, err error) error {
-	return errors.Wrap(err, "[GOWRTR-17] a value of composite literal must not be empty, but it gets empty (caused at %s)")
+	return newError("GOWRTR-17", caller, errors.Wrapf(err, "[GOWRTR-17] a value of composite literal must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// AssignLeftHandSideIsEmptyError returns the error.
+func AssignLeftHandSideIsEmptyError(caller string) error {
+	return newError("GOWRTR-18", caller, fmt.Errorf("[GOWRTR-18] left-hand side of assignment must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// AssignLeftHandSideIsEmptyErrorWrap wraps the error.
+func AssignLeftHandSideIsEmptyErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-18", caller, errors.Wrapf(err, "[GOWRTR-18] left-hand side of assignment must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// AssignRightHandSideIsEmptyError returns the error.
+func AssignRightHandSideIsEmptyError(caller string) error {
+	return newError("GOWRTR-19", caller, fmt.Errorf("[GOWRTR-19] right-hand side of assignment must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// AssignRightHandSideIsEmptyErrorWrap wraps the error.
+func AssignRightHandSideIsEmptyErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-19", caller, errors.Wrapf(err, "[GOWRTR-19] right-hand side of assignment must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// AssignOperatorIsInvalidError returns the error.
+func AssignOperatorIsInvalidError(operator string, caller string) error {
+	return newError("GOWRTR-20", caller, fmt.Errorf("[GOWRTR-20] assignment operator is invalid: '%s' (caused at %s)", operator, caller))
+}
+
+// AssignOperatorIsInvalidErrorWrap wraps the error.
+func AssignOperatorIsInvalidErrorWrap(operator string, caller string, err error) error {
+	return newError("GOWRTR-20", caller, errors.Wrapf(err, "[GOWRTR-20] assignment operator is invalid: '%s' (caused at %s)", operator, caller))
+}
+
+// AssignOperandsCountMismatchError returns the error.
+func AssignOperandsCountMismatchError(lhsCount int, rhsCount int, caller string) error {
+	return newError("GOWRTR-21", caller, fmt.Errorf("[GOWRTR-21] assignment count mismatch: %d = %d (caused at %s)", lhsCount, rhsCount, caller))
+}
+
+// AssignOperandsCountMismatchErrorWrap wraps the error.
+func AssignOperandsCountMismatchErrorWrap(lhsCount int, rhsCount int, caller string, err error) error {
+	return newError("GOWRTR-21", caller, errors.Wrapf(err, "[GOWRTR-21] assignment count mismatch: %d = %d (caused at %s)", lhsCount, rhsCount, caller))
+}
+
+// CompoundAssignOperandIsNotSingleError returns the error.
+func CompoundAssignOperandIsNotSingleError(operator string, caller string) error {
+	return newError("GOWRTR-22", caller, fmt.Errorf("[GOWRTR-22] compound assignment operator '%s' takes exactly one operand on each side (caused at %s)", operator, caller))
+}
+
+// CompoundAssignOperandIsNotSingleErrorWrap wraps the error.
+func CompoundAssignOperandIsNotSingleErrorWrap(operator string, caller string, err error) error {
+	return newError("GOWRTR-22", caller, errors.Wrapf(err, "[GOWRTR-22] compound assignment operator '%s' takes exactly one operand on each side (caused at %s)", operator, caller))
+}
+
+// VarNameIsEmptyError returns the error.
+func VarNameIsEmptyError(caller string) error {
+	return newError("GOWRTR-23", caller, fmt.Errorf("[GOWRTR-23] name of var must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// VarNameIsEmptyErrorWrap wraps the error.
+func VarNameIsEmptyErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-23", caller, errors.Wrapf(err, "[GOWRTR-23] name of var must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// VarTypeAndValueAreEmptyError returns the error.
+func VarTypeAndValueAreEmptyError(caller string) error {
+	return newError("GOWRTR-24", caller, fmt.Errorf("[GOWRTR-24] either type or value of var must be specified, but both are empty (caused at %s)", caller))
+}
+
+// VarTypeAndValueAreEmptyErrorWrap wraps the error.
+func VarTypeAndValueAreEmptyErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-24", caller, errors.Wrapf(err, "[GOWRTR-24] either type or value of var must be specified, but both are empty (caused at %s)", caller))
+}
+
+// IncDecTargetIsEmptyError returns the error.
+func IncDecTargetIsEmptyError(caller string) error {
+	return newError("GOWRTR-25", caller, fmt.Errorf("[GOWRTR-25] target of increment/decrement statement must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// IncDecTargetIsEmptyErrorWrap wraps the error.
+func IncDecTargetIsEmptyErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-25", caller, errors.Wrapf(err, "[GOWRTR-25] target of increment/decrement statement must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// ForRangeExpressionIsEmptyError returns the error.
+func ForRangeExpressionIsEmptyError(caller string) error {
+	return newError("GOWRTR-26", caller, fmt.Errorf("[GOWRTR-26] range expression of for must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// ForRangeExpressionIsEmptyErrorWrap wraps the error.
+func ForRangeExpressionIsEmptyErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-26", caller, errors.Wrapf(err, "[GOWRTR-26] range expression of for must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// ForPostStatementIsShortVarDeclError returns the error.
+func ForPostStatementIsShortVarDeclError(caller string) error {
+	return newError("GOWRTR-27", caller, fmt.Errorf("[GOWRTR-27] post statement of for must not be a short variable declaration (caused at %s)", caller))
+}
+
+// ForPostStatementIsShortVarDeclErrorWrap wraps the error.
+func ForPostStatementIsShortVarDeclErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-27", caller, errors.Wrapf(err, "[GOWRTR-27] post statement of for must not be a short variable declaration (caused at %s)", caller))
+}
+
+// TypeSwitchExpressionIsEmptyError returns the error.
+func TypeSwitchExpressionIsEmptyError(caller string) error {
+	return newError("GOWRTR-28", caller, fmt.Errorf("[GOWRTR-28] expression of type switch must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// TypeSwitchExpressionIsEmptyErrorWrap wraps the error.
+func TypeSwitchExpressionIsEmptyErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-28", caller, errors.Wrapf(err, "[GOWRTR-28] expression of type switch must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// FallthroughInTypeSwitchError returns the error.
+func FallthroughInTypeSwitchError(caller string) error {
+	return newError("GOWRTR-29", caller, fmt.Errorf("[GOWRTR-29] fallthrough statement is not permitted in type switch (caused at %s)", caller))
+}
+
+// FallthroughInTypeSwitchErrorWrap wraps the error.
+func FallthroughInTypeSwitchErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-29", caller, errors.Wrapf(err, "[GOWRTR-29] fallthrough statement is not permitted in type switch (caused at %s)", caller))
+}
+
+// FallthroughInFinalCaseError returns the error.
+func FallthroughInFinalCaseError(caller string) error {
+	return newError("GOWRTR-30", caller, fmt.Errorf("[GOWRTR-30] cannot fallthrough final case in switch (caused at %s)", caller))
+}
+
+// FallthroughInFinalCaseErrorWrap wraps the error.
+func FallthroughInFinalCaseErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-30", caller, errors.Wrapf(err, "[GOWRTR-30] cannot fallthrough final case in switch (caused at %s)", caller))
+}
+
+// SelectDefaultCaseIsDuplicatedError returns the error.
+func SelectDefaultCaseIsDuplicatedError(caller string) error {
+	return newError("GOWRTR-31", caller, fmt.Errorf("[GOWRTR-31] select must not have more than one default case (caused at %s)", caller))
+}
+
+// SelectDefaultCaseIsDuplicatedErrorWrap wraps the error.
+func SelectDefaultCaseIsDuplicatedErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-31", caller, errors.Wrapf(err, "[GOWRTR-31] select must not have more than one default case (caused at %s)", caller))
+}
+
+// SelectCaseChannelIsEmptyError returns the error.
+func SelectCaseChannelIsEmptyError(caller string) error {
+	return newError("GOWRTR-32", caller, fmt.Errorf("[GOWRTR-32] channel of select case must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// SelectCaseChannelIsEmptyErrorWrap wraps the error.
+func SelectCaseChannelIsEmptyErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-32", caller, errors.Wrapf(err, "[GOWRTR-32] channel of select case must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// SelectSendCaseValueIsEmptyError returns the error.
+func SelectSendCaseValueIsEmptyError(caller string) error {
+	return newError("GOWRTR-33", caller, fmt.Errorf("[GOWRTR-33] value of select send case must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// SelectSendCaseValueIsEmptyErrorWrap wraps the error.
+func SelectSendCaseValueIsEmptyErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-33", caller, errors.Wrapf(err, "[GOWRTR-33] value of select send case must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// SelectRecvCaseVariablesCountError returns the error.
+func SelectRecvCaseVariablesCountError(count int, caller string) error {
+	return newError("GOWRTR-34", caller, fmt.Errorf("[GOWRTR-34] receive case of select takes at most two variables, but it gets %d (caused at %s)", count, caller))
+}
+
+// SelectRecvCaseVariablesCountErrorWrap wraps the error.
+func SelectRecvCaseVariablesCountErrorWrap(count int, caller string, err error) error {
+	return newError("GOWRTR-34", caller, errors.Wrapf(err, "[GOWRTR-34] receive case of select takes at most two variables, but it gets %d (caused at %s)", count, caller))
+}
+
+// DeferStatementIsEmptyError returns the error.
+func DeferStatementIsEmptyError(caller string) error {
+	return newError("GOWRTR-35", caller, fmt.Errorf("[GOWRTR-35] statement of defer must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// DeferStatementIsEmptyErrorWrap wraps the error.
+func DeferStatementIsEmptyErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-35", caller, errors.Wrapf(err, "[GOWRTR-35] statement of defer must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// GoStatementIsEmptyError returns the error.
+func GoStatementIsEmptyError(caller string) error {
+	return newError("GOWRTR-36", caller, fmt.Errorf("[GOWRTR-36] statement of go must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// GoStatementIsEmptyErrorWrap wraps the error.
+func GoStatementIsEmptyErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-36", caller, errors.Wrapf(err, "[GOWRTR-36] statement of go must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// LabelNameIsEmptyError returns the error.
+func LabelNameIsEmptyError(caller string) error {
+	return newError("GOWRTR-37", caller, fmt.Errorf("[GOWRTR-37] name of label must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// LabelNameIsEmptyErrorWrap wraps the error.
+func LabelNameIsEmptyErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-37", caller, errors.Wrapf(err, "[GOWRTR-37] name of label must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// LabelIsDuplicatedError returns the error.
+func LabelIsDuplicatedError(label string, caller string) error {
+	return newError("GOWRTR-38", caller, fmt.Errorf("[GOWRTR-38] label '%s' is already defined in the enclosing func (caused at %s)", label, caller))
+}
+
+// LabelIsDuplicatedErrorWrap wraps the error.
+func LabelIsDuplicatedErrorWrap(label string, caller string, err error) error {
+	return newError("GOWRTR-38", caller, errors.Wrapf(err, "[GOWRTR-38] label '%s' is already defined in the enclosing func (caused at %s)", label, caller))
+}
+
+// LabelIsNotDefinedError returns the error.
+func LabelIsNotDefinedError(label string, caller string) error {
+	return newError("GOWRTR-39", caller, fmt.Errorf("[GOWRTR-39] label '%s' is not defined in the enclosing func (caused at %s)", label, caller))
+}
+
+// LabelIsNotDefinedErrorWrap wraps the error.
+func LabelIsNotDefinedErrorWrap(label string, caller string, err error) error {
+	return newError("GOWRTR-39", caller, errors.Wrapf(err, "[GOWRTR-39] label '%s' is not defined in the enclosing func (caused at %s)", label, caller))
+}
+
+// GotoLabelIsEmptyError returns the error.
+func GotoLabelIsEmptyError(caller string) error {
+	return newError("GOWRTR-40", caller, fmt.Errorf("[GOWRTR-40] label of goto must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// GotoLabelIsEmptyErrorWrap wraps the error.
+func GotoLabelIsEmptyErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-40", caller, errors.Wrapf(err, "[GOWRTR-40] label of goto must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// ElseIfConditionIsEmptyError returns the error.
+func ElseIfConditionIsEmptyError(caller string) error {
+	return newError("GOWRTR-41", caller, fmt.Errorf("[GOWRTR-41] condition of else-if must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// ElseIfConditionIsEmptyErrorWrap wraps the error.
+func ElseIfConditionIsEmptyErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-41", caller, errors.Wrapf(err, "[GOWRTR-41] condition of else-if must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// CallCalleeIsEmptyError returns the error.
+func CallCalleeIsEmptyError(caller string) error {
+	return newError("GOWRTR-42", caller, fmt.Errorf("[GOWRTR-42] callee of call expression must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// CallCalleeIsEmptyErrorWrap wraps the error.
+func CallCalleeIsEmptyErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-42", caller, errors.Wrapf(err, "[GOWRTR-42] callee of call expression must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// CallArgumentIsEmptyError returns the error.
+func CallArgumentIsEmptyError(caller string) error {
+	return newError("GOWRTR-43", caller, fmt.Errorf("[GOWRTR-43] an argument of call expression must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// CallArgumentIsEmptyErrorWrap wraps the error.
+func CallArgumentIsEmptyErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-43", caller, errors.Wrapf(err, "[GOWRTR-43] an argument of call expression must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// CallTypeArgumentIsEmptyError returns the error.
+func CallTypeArgumentIsEmptyError(caller string) error {
+	return newError("GOWRTR-44", caller, fmt.Errorf("[GOWRTR-44] a type argument of call expression must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// CallTypeArgumentIsEmptyErrorWrap wraps the error.
+func CallTypeArgumentIsEmptyErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-44", caller, errors.Wrapf(err, "[GOWRTR-44] a type argument of call expression must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// CallSpreadWithoutArgumentsError returns the error.
+func CallSpreadWithoutArgumentsError(caller string) error {
+	return newError("GOWRTR-45", caller, fmt.Errorf("[GOWRTR-45] call expression with variadic spread must have at least one argument (caused at %s)", caller))
+}
+
+// CallSpreadWithoutArgumentsErrorWrap wraps the error.
+func CallSpreadWithoutArgumentsErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-45", caller, errors.Wrapf(err, "[GOWRTR-45] call expression with variadic spread must have at least one argument (caused at %s)", caller))
+}
+
+// ReturnItemIsEmptyError returns the error.
+func ReturnItemIsEmptyError(caller string) error {
+	return newError("GOWRTR-46", caller, fmt.Errorf("[GOWRTR-46] a return item must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// ReturnItemIsEmptyErrorWrap wraps the error.
+func ReturnItemIsEmptyErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-46", caller, errors.Wrapf(err, "[GOWRTR-46] a return item must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// IdentifierIsInvalidError returns the error.
+func IdentifierIsInvalidError(name string, caller string) error {
+	return newError("GOWRTR-47", caller, fmt.Errorf("[GOWRTR-47] '%s' is not a valid identifier (caused at %s)", name, caller))
+}
+
+// IdentifierIsInvalidErrorWrap wraps the error.
+func IdentifierIsInvalidErrorWrap(name string, caller string, err error) error {
+	return newError("GOWRTR-47", caller, errors.Wrapf(err, "[GOWRTR-47] '%s' is not a valid identifier (caused at %s)", name, caller))
+}
+
+// IdentifierIsKeywordError returns the error.
+func IdentifierIsKeywordError(name string, caller string) error {
+	return newError("GOWRTR-48", caller, fmt.Errorf("[GOWRTR-48] '%s' is a keyword, so it cannot be used as an identifier (caused at %s)", name, caller))
+}
+
+// IdentifierIsKeywordErrorWrap wraps the error.
+func IdentifierIsKeywordErrorWrap(name string, caller string, err error) error {
+	return newError("GOWRTR-48", caller, errors.Wrapf(err, "[GOWRTR-48] '%s' is a keyword, so it cannot be used as an identifier (caused at %s)", name, caller))
+}
+
+// PredeclaredIdentifierIsShadowedWarning returns the error.
+func PredeclaredIdentifierIsShadowedWarning(name string, caller string) error {
+	return newError("GOWRTR-49", caller, fmt.Errorf("[GOWRTR-49] '%s' shadows the predeclared identifier (caused at %s)", name, caller))
+}
+
+// PredeclaredIdentifierIsShadowedWarningWrap wraps the error.
+func PredeclaredIdentifierIsShadowedWarningWrap(name string, caller string, err error) error {
+	return newError("GOWRTR-49", caller, errors.Wrapf(err, "[GOWRTR-49] '%s' shadows the predeclared identifier (caused at %s)", name, caller))
+}
+
+// TypeCheckError returns the error.
+func TypeCheckError(position string, msg string, caller string) error {
+	return newError("GOWRTR-50", caller, fmt.Errorf("[GOWRTR-50] type checking raises error at %s of the generated code: %s (caused at %s)", position, msg, caller))
+}
+
+// TypeCheckErrorWrap wraps the error.
+func TypeCheckErrorWrap(position string, msg string, caller string, err error) error {
+	return newError("GOWRTR-50", caller, errors.Wrapf(err, "[GOWRTR-50] type checking raises error at %s of the generated code: %s (caused at %s)", position, msg, caller))
+}
+
+// PackageLoadingError returns the error.
+func PackageLoadingError(pkgPath string, msg string, caller string) error {
+	return newError("GOWRTR-51", caller, fmt.Errorf("[GOWRTR-51] failed to load the package '%s': %s (caused at %s)", pkgPath, msg, caller))
+}
+
+// PackageLoadingErrorWrap wraps the error.
+func PackageLoadingErrorWrap(pkgPath string, msg string, caller string, err error) error {
+	return newError("GOWRTR-51", caller, errors.Wrapf(err, "[GOWRTR-51] failed to load the package '%s': %s (caused at %s)", pkgPath, msg, caller))
+}
+
+// TypeIsNotFoundError returns the error.
+func TypeIsNotFoundError(typeName string, pkgPath string, caller string) error {
+	return newError("GOWRTR-52", caller, fmt.Errorf("[GOWRTR-52] type '%s' is not found in the package '%s' (caused at %s)", typeName, pkgPath, caller))
+}
+
+// TypeIsNotFoundErrorWrap wraps the error.
+func TypeIsNotFoundErrorWrap(typeName string, pkgPath string, caller string, err error) error {
+	return newError("GOWRTR-52", caller, errors.Wrapf(err, "[GOWRTR-52] type '%s' is not found in the package '%s' (caused at %s)", typeName, pkgPath, caller))
+}
+
+// TypeIsNotInterfaceError returns the error.
+func TypeIsNotInterfaceError(typeName string, pkgPath string, caller string) error {
+	return newError("GOWRTR-53", caller, fmt.Errorf("[GOWRTR-53] type '%s' of the package '%s' is not an interface (caused at %s)", typeName, pkgPath, caller))
+}
+
+// TypeIsNotInterfaceErrorWrap wraps the error.
+func TypeIsNotInterfaceErrorWrap(typeName string, pkgPath string, caller string, err error) error {
+	return newError("GOWRTR-53", caller, errors.Wrapf(err, "[GOWRTR-53] type '%s' of the package '%s' is not an interface (caused at %s)", typeName, pkgPath, caller))
+}
+
+// StubTypeNameIsEmptyError returns the error.
+func StubTypeNameIsEmptyError(caller string) error {
+	return newError("GOWRTR-54", caller, fmt.Errorf("[GOWRTR-54] type name of stub must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// StubTypeNameIsEmptyErrorWrap wraps the error.
+func StubTypeNameIsEmptyErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-54", caller, errors.Wrapf(err, "[GOWRTR-54] type name of stub must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// StubInterfaceIsNilError returns the error.
+func StubInterfaceIsNilError(caller string) error {
+	return newError("GOWRTR-55", caller, fmt.Errorf("[GOWRTR-55] interface of stub must not be nil, but it gets nil (caused at %s)", caller))
+}
+
+// StubInterfaceIsNilErrorWrap wraps the error.
+func StubInterfaceIsNilErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-55", caller, errors.Wrapf(err, "[GOWRTR-55] interface of stub must not be nil, but it gets nil (caused at %s)", caller))
+}
+
+// MockTypeNameIsEmptyError returns the error.
+func MockTypeNameIsEmptyError(caller string) error {
+	return newError("GOWRTR-56", caller, fmt.Errorf("[GOWRTR-56] type name of mock must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// MockTypeNameIsEmptyErrorWrap wraps the error.
+func MockTypeNameIsEmptyErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-56", caller, errors.Wrapf(err, "[GOWRTR-56] type name of mock must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// MockInterfaceIsNilError returns the error.
+func MockInterfaceIsNilError(caller string) error {
+	return newError("GOWRTR-57", caller, fmt.Errorf("[GOWRTR-57] interface of mock must not be nil, but it gets nil (caused at %s)", caller))
+}
+
+// MockInterfaceIsNilErrorWrap wraps the error.
+func MockInterfaceIsNilErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-57", caller, errors.Wrapf(err, "[GOWRTR-57] interface of mock must not be nil, but it gets nil (caused at %s)", caller))
+}
+
+// ConstNameIsEmptyError returns the error.
+func ConstNameIsEmptyError(caller string) error {
+	return newError("GOWRTR-58", caller, fmt.Errorf("[GOWRTR-58] name of const must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// ConstNameIsEmptyErrorWrap wraps the error.
+func ConstNameIsEmptyErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-58", caller, errors.Wrapf(err, "[GOWRTR-58] name of const must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// ConstValueIsEmptyError returns the error.
+func ConstValueIsEmptyError(caller string) error {
+	return newError("GOWRTR-59", caller, fmt.Errorf("[GOWRTR-59] value of the first const must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// ConstValueIsEmptyErrorWrap wraps the error.
+func ConstValueIsEmptyErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-59", caller, errors.Wrapf(err, "[GOWRTR-59] value of the first const must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// ConstTypeWithoutValueError returns the error.
+func ConstTypeWithoutValueError(caller string) error {
+	return newError("GOWRTR-60", caller, fmt.Errorf("[GOWRTR-60] const that has a type must have a value (caused at %s)", caller))
+}
+
+// ConstTypeWithoutValueErrorWrap wraps the error.
+func ConstTypeWithoutValueErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-60", caller, errors.Wrapf(err, "[GOWRTR-60] const that has a type must have a value (caused at %s)", caller))
+}
+
+// EnumTypeNameIsEmptyError returns the error.
+func EnumTypeNameIsEmptyError(caller string) error {
+	return newError("GOWRTR-61", caller, fmt.Errorf("[GOWRTR-61] type name of enum must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// EnumTypeNameIsEmptyErrorWrap wraps the error.
+func EnumTypeNameIsEmptyErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-61", caller, errors.Wrapf(err, "[GOWRTR-61] type name of enum must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// EnumValueNameIsEmptyError returns the error.
+func EnumValueNameIsEmptyError(caller string) error {
+	return newError("GOWRTR-62", caller, fmt.Errorf("[GOWRTR-62] name of enum value must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// EnumValueNameIsEmptyErrorWrap wraps the error.
+func EnumValueNameIsEmptyErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-62", caller, errors.Wrapf(err, "[GOWRTR-62] name of enum value must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// EnumValueIsDuplicatedError returns the error.
+func EnumValueIsDuplicatedError(name string, caller string) error {
+	return newError("GOWRTR-63", caller, fmt.Errorf("[GOWRTR-63] enum value '%s' is duplicated (caused at %s)", name, caller))
+}
+
+// EnumValueIsDuplicatedErrorWrap wraps the error.
+func EnumValueIsDuplicatedErrorWrap(name string, caller string, err error) error {
+	return newError("GOWRTR-63", caller, errors.Wrapf(err, "[GOWRTR-63] enum value '%s' is duplicated (caused at %s)", name, caller))
+}
+
+// DerivedStructIsNilError returns the error.
+func DerivedStructIsNilError(caller string) error {
+	return newError("GOWRTR-64", caller, fmt.Errorf("[GOWRTR-64] struct to derive the code from must not be nil, but it gets nil (caused at %s)", caller))
+}
+
+// DerivedStructIsNilErrorWrap wraps the error.
+func DerivedStructIsNilErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-64", caller, errors.Wrapf(err, "[GOWRTR-64] struct to derive the code from must not be nil, but it gets nil (caused at %s)", caller))
+}
+
+// StructTagContainsBackquoteError returns the error.
+func StructTagContainsBackquoteError(tag string, caller string) error {
+	return newError("GOWRTR-65", caller, fmt.Errorf("[GOWRTR-65] struct tag must not contain backquote, but it gets '%s' (caused at %s)", tag, caller))
+}
+
+// StructTagContainsBackquoteErrorWrap wraps the error.
+func StructTagContainsBackquoteErrorWrap(tag string, caller string, err error) error {
+	return newError("GOWRTR-65", caller, errors.Wrapf(err, "[GOWRTR-65] struct tag must not contain backquote, but it gets '%s' (caused at %s)", tag, caller))
+}
+
+// StructTagIsMalformedError returns the error.
+func StructTagIsMalformedError(tag string, caller string) error {
+	return newError("GOWRTR-66", caller, fmt.Errorf("[GOWRTR-66] struct tag must be space-separated key:value pairs that are compatible with reflect.StructTag (the value is quoted), but it gets '%s' (caused at %s)", tag, caller))
+}
+
+// StructTagIsMalformedErrorWrap wraps the error.
+func StructTagIsMalformedErrorWrap(tag string, caller string, err error) error {
+	return newError("GOWRTR-66", caller, errors.Wrapf(err, "[GOWRTR-66] struct tag must be space-separated key:value pairs that are compatible with reflect.StructTag (the value is quoted), but it gets '%s' (caused at %s)", tag, caller))
+}
+
+// TableTestFuncIsNilError returns the error.
+func TableTestFuncIsNilError(caller string) error {
+	return newError("GOWRTR-67", caller, fmt.Errorf("[GOWRTR-67] func to generate the test from must not be nil, but it gets nil (caused at %s)", caller))
+}
+
+// TableTestFuncIsNilErrorWrap wraps the error.
+func TableTestFuncIsNilErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-67", caller, errors.Wrapf(err, "[GOWRTR-67] func to generate the test from must not be nil, but it gets nil (caused at %s)", caller))
+}
+
+// TableTestCaseArgsCountMismatchError returns the error.
+func TableTestCaseArgsCountMismatchError(expected int, actual int, caller string) error {
+	return newError("GOWRTR-68", caller, fmt.Errorf("[GOWRTR-68] the number of args of the test case must be %d (the number of the parameters), but it gets %d (caused at %s)", expected, actual, caller))
+}
+
+// TableTestCaseArgsCountMismatchErrorWrap wraps the error.
+func TableTestCaseArgsCountMismatchErrorWrap(expected int, actual int, caller string, err error) error {
+	return newError("GOWRTR-68", caller, errors.Wrapf(err, "[GOWRTR-68] the number of args of the test case must be %d (the number of the parameters), but it gets %d (caused at %s)", expected, actual, caller))
+}
+
+// TableTestCaseWantsCountMismatchError returns the error.
+func TableTestCaseWantsCountMismatchError(expected int, actual int, caller string) error {
+	return newError("GOWRTR-69", caller, fmt.Errorf("[GOWRTR-69] the number of wants of the test case must be %d (the number of the return types except error), but it gets %d (caused at %s)", expected, actual, caller))
+}
+
+// TableTestCaseWantsCountMismatchErrorWrap wraps the error.
+func TableTestCaseWantsCountMismatchErrorWrap(expected int, actual int, caller string, err error) error {
+	return newError("GOWRTR-69", caller, errors.Wrapf(err, "[GOWRTR-69] the number of wants of the test case must be %d (the number of the return types except error), but it gets %d (caused at %s)", expected, actual, caller))
+}
+
+// FuzzParameterTypeIsNotSupportedError returns the error.
+func FuzzParameterTypeIsNotSupportedError(name string, typ string, caller string) error {
+	return newError("GOWRTR-70", caller, fmt.Errorf("[GOWRTR-70] type of the parameter '%s' is not supported by fuzzing, but it gets '%s' (caused at %s)", name, typ, caller))
+}
+
+// FuzzParameterTypeIsNotSupportedErrorWrap wraps the error.
+func FuzzParameterTypeIsNotSupportedErrorWrap(name string, typ string, caller string, err error) error {
+	return newError("GOWRTR-70", caller, errors.Wrapf(err, "[GOWRTR-70] type of the parameter '%s' is not supported by fuzzing, but it gets '%s' (caused at %s)", name, typ, caller))
+}
+
+// JSONTypesRootTypeNameIsEmptyError returns the error.
+func JSONTypesRootTypeNameIsEmptyError(caller string) error {
+	return newError("GOWRTR-71", caller, fmt.Errorf("[GOWRTR-71] root type name of the JSON types must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// JSONTypesRootTypeNameIsEmptyErrorWrap wraps the error.
+func JSONTypesRootTypeNameIsEmptyErrorWrap(caller string, err error) error {
+	return newError("GOWRTR-71", caller, errors.Wrapf(err, "[GOWRTR-71] root type name of the JSON types must not be empty, but it gets empty (caused at %s)", caller))
+}
+
+// JSONFileLoadingError returns the error.
+func JSONFileLoadingError(path string, msg string, caller string) error {
+	return newError("GOWRTR-72", caller, fmt.Errorf("[GOWRTR-72] failed to load the JSON file '%s': %s (caused at %s)", path, msg, caller))
+}
+
+// JSONFileLoadingErrorWrap wraps the error.
+func JSONFileLoadingErrorWrap(path string, msg string, caller string, err error) error {
+	return newError("GOWRTR-72", caller, errors.Wrapf(err, "[GOWRTR-72] failed to load the JSON file '%s': %s (caused at %s)", path, msg, caller))
+}
+
+// JSONSchemaIsInvalidError returns the error.
+func JSONSchemaIsInvalidError(msg string, caller string) error {
+	return newError("GOWRTR-73", caller, fmt.Errorf("[GOWRTR-73] JSON schema is invalid: %s (caused at %s)", msg, caller))
+}
+
+// JSONSchemaIsInvalidErrorWrap wraps the error.
+func JSONSchemaIsInvalidErrorWrap(msg string, caller string, err error) error {
+	return newError("GOWRTR-73", caller, errors.Wrapf(err, "[GOWRTR-73] JSON schema is invalid: %s (caused at %s)", msg, caller))
+}
+
+// JSONSchemaRefIsNotSupportedError returns the error.
+func JSONSchemaRefIsNotSupportedError(ref string, caller string) error {
+	return newError("GOWRTR-74", caller, fmt.Errorf("[GOWRTR-74] $ref '%s' is not supported; only the reference in the same document (e.g. #/definitions/xxx) is supported (caused at %s)", ref, caller))
+}
+
+// JSONSchemaRefIsNotSupportedErrorWrap wraps the error.
+func JSONSchemaRefIsNotSupportedErrorWrap(ref string, caller string, err error) error {
+	return newError("GOWRTR-74", caller, errors.Wrapf(err, "[GOWRTR-74] $ref '%s' is not supported; only the reference in the same document (e.g. #/definitions/xxx) is supported (caused at %s)", ref, caller))
+}
+
+// JSONSchemaRefIsNotFoundError returns the error.
+func JSONSchemaRefIsNotFoundError(ref string, caller string) error {
+	return newError("GOWRTR-75", caller, fmt.Errorf("[GOWRTR-75] $ref '%s' is not found in the JSON schema (caused at %s)", ref, caller))
+}
+
+// JSONSchemaRefIsNotFoundErrorWrap wraps the error.
+func JSONSchemaRefIsNotFoundErrorWrap(ref string, caller string, err error) error {
+	return newError("GOWRTR-75", caller, errors.Wrapf(err, "[GOWRTR-75] $ref '%s' is not found in the JSON schema (caused at %s)", ref, caller))
+}
+
+// JSONSchemaEnumIsNotSupportedError returns the error.
+func JSONSchemaEnumIsNotSupportedError(typeName string, caller string) error {
+	return newError("GOWRTR-76", caller, fmt.Errorf("[GOWRTR-76] enum of '%s' must consist of only string values or only integer values (caused at %s)", typeName, caller))
+}
+
+// JSONSchemaEnumIsNotSupportedErrorWrap wraps the error.
+func JSONSchemaEnumIsNotSupportedErrorWrap(typeName string, caller string, err error) error {
+	return newError("GOWRTR-76", caller, errors.Wrapf(err, "[GOWRTR-76] enum of '%s' must consist of only string values or only integer values (caused at %s)", typeName, caller))
+}
+
+// JSONSchemaOneOfVariantIsNotSupportedError returns the error.
+func JSONSchemaOneOfVariantIsNotSupportedError(typeName string, caller string) error {
+	return newError("GOWRTR-77", caller, fmt.Errorf("[GOWRTR-77] variant '%s' of oneOf/anyOf must be a concrete type; neither oneOf/anyOf nor a schema without the type is supported (caused at %s)", typeName, caller))
+}
+
+// JSONSchemaOneOfVariantIsNotSupportedErrorWrap wraps the error.
+func JSONSchemaOneOfVariantIsNotSupportedErrorWrap(typeName string, caller string, err error) error {
+	return newError("GOWRTR-77", caller, errors.Wrapf(err, "[GOWRTR-77] variant '%s' of oneOf/anyOf must be a concrete type; neither oneOf/anyOf nor a schema without the type is supported (caused at %s)", typeName, caller))
+}
+
+// JSONSampleIsInvalidError returns the error.
+func JSONSampleIsInvalidError(index int, msg string, caller string) error {
+	return newError("GOWRTR-78", caller, fmt.Errorf("[GOWRTR-78] JSON sample #%d is invalid: %s (caused at %s)", index, msg, caller))
+}
+
+// JSONSampleIsInvalidErrorWrap wraps the error.
+func JSONSampleIsInvalidErrorWrap(index int, msg string, caller string, err error) error {
+	return newError("GOWRTR-78", caller, errors.Wrapf(err, "[GOWRTR-78] JSON sample #%d is invalid: %s (caused at %s)", index, msg, caller))
+}
+
+// OpenAPILoadingError returns the error.
+func OpenAPILoadingError(path string, msg string, caller string) error {
+	return newError("GOWRTR-79", caller, fmt.Errorf("[GOWRTR-79] failed to load the OpenAPI document '%s': %s (caused at %s)", path, msg, caller))
+}
+
+// OpenAPILoadingErrorWrap wraps the error.
+func OpenAPILoadingErrorWrap(path string, msg string, caller string, err error) error {
+	return newError("GOWRTR-79", caller, errors.Wrapf(err, "[GOWRTR-79] failed to load the OpenAPI document '%s': %s (caused at %s)", path, msg, caller))
+}
+
+// OpenAPIIsInvalidError returns the error.
+func OpenAPIIsInvalidError(msg string, caller string) error {
+	return newError("GOWRTR-80", caller, fmt.Errorf("[GOWRTR-80] OpenAPI document is invalid: %s (caused at %s)", msg, caller))
+}
+
+// OpenAPIIsInvalidErrorWrap wraps the error.
+func OpenAPIIsInvalidErrorWrap(msg string, caller string, err error) error {
+	return newError("GOWRTR-80", caller, errors.Wrapf(err, "[GOWRTR-80] OpenAPI document is invalid: %s (caused at %s)", msg, caller))
+}
+
+// OpenAPIOperationIsDuplicatedError returns the error.
+func OpenAPIOperationIsDuplicatedError(name string, caller string) error {
+	return newError("GOWRTR-81", caller, fmt.Errorf("[GOWRTR-81] operation '%s' is duplicated; please specify the unique operationId (caused at %s)", name, caller))
+}
+
+// OpenAPIOperationIsDuplicatedErrorWrap wraps the error.
+func OpenAPIOperationIsDuplicatedErrorWrap(name string, caller string, err error) error {
+	return newError("GOWRTR-81", caller, errors.Wrapf(err, "[GOWRTR-81] operation '%s' is duplicated; please specify the unique operationId (caused at %s)", name, caller))
+}
+
+// OpenAPIParameterIsNotSupportedError returns the error.
+func OpenAPIParameterIsNotSupportedError(name string, operation string, caller string) error {
+	return newError("GOWRTR-82", caller, fmt.Errorf("[GOWRTR-82] parameter '%s' of the operation '%s' is not supported; it must be in path, query or header, and the type must be a primitive (or an array of primitives in query) (caused at %s)", name, operation, caller))
+}
+
+// OpenAPIParameterIsNotSupportedErrorWrap wraps the error.
+func OpenAPIParameterIsNotSupportedErrorWrap(name string, operation string, caller string, err error) error {
+	return newError("GOWRTR-82", caller, errors.Wrapf(err, "[GOWRTR-82] parameter '%s' of the operation '%s' is not supported; it must be in path, query or header, and the type must be a primitive (or an array of primitives in query) (caused at %s)", name, operation, caller))
+}
+
+// OpenAPIContentTypeIsNotSupportedError returns the error.
+func OpenAPIContentTypeIsNotSupportedError(contentType string, operation string, caller string) error {
+	return newError("GOWRTR-83", caller, fmt.Errorf("[GOWRTR-83] content type '%s' of the operation '%s' is not supported; only JSON is supported (caused at %s)", contentType, operation, caller))
+}
+
+// OpenAPIContentTypeIsNotSupportedErrorWrap wraps the error.
+func OpenAPIContentTypeIsNotSupportedErrorWrap(contentType string, operation string, caller string, err error) error {
+	return newError("GOWRTR-83", caller, errors.Wrapf(err, "[GOWRTR-83] content type '%s' of the operation '%s' is not supported; only JSON is supported (caused at %s)", contentType, operation, caller))
+}
+
+// SQLSchemaLoadingError returns the error.
+func SQLSchemaLoadingError(path string, msg string, caller string) error {
+	return newError("GOWRTR-84", caller, fmt.Errorf("[GOWRTR-84] failed to load the SQL schema '%s': %s (caused at %s)", path, msg, caller))
+}
+
+// SQLSchemaLoadingErrorWrap wraps the error.
+func SQLSchemaLoadingErrorWrap(path string, msg string, caller string, err error) error {
+	return newError("GOWRTR-84", caller, errors.Wrapf(err, "[GOWRTR-84] failed to load the SQL schema '%s': %s (caused at %s)", path, msg, caller))
+}
+
+// SQLSchemaIsInvalidError returns the error.
+func SQLSchemaIsInvalidError(msg string, caller string) error {
+	return newError("GOWRTR-85", caller, fmt.Errorf("[GOWRTR-85] SQL schema is invalid: %s (caused at %s)", msg, caller))
+}
+
+// SQLSchemaIsInvalidErrorWrap wraps the error.
+func SQLSchemaIsInvalidErrorWrap(msg string, caller string, err error) error {
+	return newError("GOWRTR-85", caller, errors.Wrapf(err, "[GOWRTR-85] SQL schema is invalid: %s (caused at %s)", msg, caller))
+}
+
+// SQLColumnTypeIsNotSupportedError returns the error.
+func SQLColumnTypeIsNotSupportedError(typ string, column string, table string, caller string) error {
+	return newError("GOWRTR-86", caller, fmt.Errorf("[GOWRTR-86] type '%s' of the column '%s' of the table '%s' is not supported (caused at %s)", typ, column, table, caller))
+}
+
+// SQLColumnTypeIsNotSupportedErrorWrap wraps the error.
+func SQLColumnTypeIsNotSupportedErrorWrap(typ string, column string, table string, caller string, err error) error {
+	return newError("GOWRTR-86", caller, errors.Wrapf(err, "[GOWRTR-86] type '%s' of the column '%s' of the table '%s' is not supported (caused at %s)", typ, column, table, caller))
+}
+
+// ProtoLoadingError returns the error.
+func ProtoLoadingError(path string, msg string, caller string) error {
+	return newError("GOWRTR-87", caller, fmt.Errorf("[GOWRTR-87] failed to load the proto file '%s': %s (caused at %s)", path, msg, caller))
+}
+
+// ProtoLoadingErrorWrap wraps the error.
+func ProtoLoadingErrorWrap(path string, msg string, caller string, err error) error {
+	return newError("GOWRTR-87", caller, errors.Wrapf(err, "[GOWRTR-87] failed to load the proto file '%s': %s (caused at %s)", path, msg, caller))
+}
+
+// ProtoIsInvalidError returns the error.
+func ProtoIsInvalidError(msg string, caller string) error {
+	return newError("GOWRTR-88", caller, fmt.Errorf("[GOWRTR-88] proto is invalid: %s (caused at %s)", msg, caller))
+}
+
+// ProtoIsInvalidErrorWrap wraps the error.
+func ProtoIsInvalidErrorWrap(msg string, caller string, err error) error {
+	return newError("GOWRTR-88", caller, errors.Wrapf(err, "[GOWRTR-88] proto is invalid: %s (caused at %s)", msg, caller))
+}
+
+// ProtoTypeIsNotFoundError returns the error.
+func ProtoTypeIsNotFoundError(typeName string, line int, column int, caller string) error {
+	return newError("GOWRTR-89", caller, fmt.Errorf("[GOWRTR-89] type '%s' at line %d, column %d is not found; the types of the imported files are not supported (caused at %s)", typeName, line, column, caller))
+}
+
+// ProtoTypeIsNotFoundErrorWrap wraps the error.
+func ProtoTypeIsNotFoundErrorWrap(typeName string, line int, column int, caller string, err error) error {
+	return newError("GOWRTR-89", caller, errors.Wrapf(err, "[GOWRTR-89] type '%s' at line %d, column %d is not found; the types of the imported files are not supported (caused at %s)", typeName, line, column, caller))
+}
+
+// ProtoStreamingIsNotSupportedError returns the error.
+func ProtoStreamingIsNotSupportedError(name string, caller string) error {
+	return newError("GOWRTR-90", caller, fmt.Errorf("[GOWRTR-90] rpc '%s' is streaming, but the streaming rpc is not supported (caused at %s)", name, caller))
+}
+
+// ProtoStreamingIsNotSupportedErrorWrap wraps the error.
+func ProtoStreamingIsNotSupportedErrorWrap(name string, caller string, err error) error {
+	return newError("GOWRTR-90", caller, errors.Wrapf(err, "[GOWRTR-90] rpc '%s' is streaming, but the streaming rpc is not supported (caused at %s)", name, caller))
+}
+
+// SpecLoadingError returns the error.
+func SpecLoadingError(path string, msg string, caller string) error {
+	return newError("GOWRTR-91", caller, fmt.Errorf("[GOWRTR-91] failed to load the spec '%s': %s (caused at %s)", path, msg, caller))
+}
+
+// SpecLoadingErrorWrap wraps the error.
+func SpecLoadingErrorWrap(path string, msg string, caller string, err error) error {
+	return newError("GOWRTR-91", caller, errors.Wrapf(err, "[GOWRTR-91] failed to load the spec '%s': %s (caused at %s)", path, msg, caller))
+}
+
+// SpecIsInvalidError returns the error.
+func SpecIsInvalidError(msg string, caller string) error {
+	return newError("GOWRTR-92", caller, fmt.Errorf("[GOWRTR-92] spec is invalid: %s (caused at %s)", msg, caller))
+}
+
+// SpecIsInvalidErrorWrap wraps the error.
+func SpecIsInvalidErrorWrap(msg string, caller string, err error) error {
+	return newError("GOWRTR-92", caller, errors.Wrapf(err, "[GOWRTR-92] spec is invalid: %s (caused at %s)", msg, caller))
+}
+
+// GotoJumpsIntoBlockError returns the error.
+func GotoJumpsIntoBlockError(label string, caller string) error {
+	return newError("GOWRTR-93", caller, fmt.Errorf("[GOWRTR-93] goto '%s' jumps into the block that doesn't enclose the goto (caused at %s)", label, caller))
+}
+
+// GotoJumpsIntoBlockErrorWrap wraps the error.
+func GotoJumpsIntoBlockErrorWrap(label string, caller string, err error) error {
+	return newError("GOWRTR-93", caller, errors.Wrapf(err, "[GOWRTR-93] goto '%s' jumps into the block that doesn't enclose the goto (caused at %s)", label, caller))
+}
+
+// LabelIsNotFollowedByStatementError returns the error.
+func LabelIsNotFollowedByStatementError(label string, caller string) error {
+	return newError("GOWRTR-94", caller, fmt.Errorf("[GOWRTR-94] label '%s' must be followed by a statement in the same block (caused at %s)", label, caller))
+}
+
+// LabelIsNotFollowedByStatementErrorWrap wraps the error.
+func LabelIsNotFollowedByStatementErrorWrap(label string, caller string, err error) error {
+	return newError("GOWRTR-94", caller, errors.Wrapf(err, "[GOWRTR-94] label '%s' must be followed by a statement in the same block (caused at %s)", label, caller))
+}
+
+// MockMemberNameConflictsError returns the error.
+func MockMemberNameConflictsError(name string, typeName string, caller string) error {
+	return newError("GOWRTR-95", caller, fmt.Errorf("[GOWRTR-95] '%s' of the mock '%s' conflicts with another field, method or type of the mock; please rename the method of the interface (caused at %s)", name, typeName, caller))
+}
+
+// MockMemberNameConflictsErrorWrap wraps the error.
+func MockMemberNameConflictsErrorWrap(name string, typeName string, caller string, err error) error {
+	return newError("GOWRTR-95", caller, errors.Wrapf(err, "[GOWRTR-95] '%s' of the mock '%s' conflicts with another field, method or type of the mock; please rename the method of the interface (caused at %s)", name, typeName, caller))
 }
 
 // ErrsType represents the error type.
 type ErrsType int
 
 const (
-
 	// StructNameIsNilErrType represents the error type for StructNameIsNilErr.
 	StructNameIsNilErrType ErrsType = iota
 	// StructFieldNameIsEmptyErrType represents the error type for StructFieldNameIsEmptyErr.
@@ -219,53 +996,465 @@ const (
 	UnnamedReturnTypeAppearsAfterNamedReturnTypeErrorType
 	// ValueOfCompositeLiteralIsEmptyErrorType represents the error type for ValueOfCompositeLiteralIsEmptyError.
 	ValueOfCompositeLiteralIsEmptyErrorType
+	// AssignLeftHandSideIsEmptyErrorType represents the error type for AssignLeftHandSideIsEmptyError.
+	AssignLeftHandSideIsEmptyErrorType
+	// AssignRightHandSideIsEmptyErrorType represents the error type for AssignRightHandSideIsEmptyError.
+	AssignRightHandSideIsEmptyErrorType
+	// AssignOperatorIsInvalidErrorType represents the error type for AssignOperatorIsInvalidError.
+	AssignOperatorIsInvalidErrorType
+	// AssignOperandsCountMismatchErrorType represents the error type for AssignOperandsCountMismatchError.
+	AssignOperandsCountMismatchErrorType
+	// CompoundAssignOperandIsNotSingleErrorType represents the error type for CompoundAssignOperandIsNotSingleError.
+	CompoundAssignOperandIsNotSingleErrorType
+	// VarNameIsEmptyErrorType represents the error type for VarNameIsEmptyError.
+	VarNameIsEmptyErrorType
+	// VarTypeAndValueAreEmptyErrorType represents the error type for VarTypeAndValueAreEmptyError.
+	VarTypeAndValueAreEmptyErrorType
+	// IncDecTargetIsEmptyErrorType represents the error type for IncDecTargetIsEmptyError.
+	IncDecTargetIsEmptyErrorType
+	// ForRangeExpressionIsEmptyErrorType represents the error type for ForRangeExpressionIsEmptyError.
+	ForRangeExpressionIsEmptyErrorType
+	// ForPostStatementIsShortVarDeclErrorType represents the error type for ForPostStatementIsShortVarDeclError.
+	ForPostStatementIsShortVarDeclErrorType
+	// TypeSwitchExpressionIsEmptyErrorType represents the error type for TypeSwitchExpressionIsEmptyError.
+	TypeSwitchExpressionIsEmptyErrorType
+	// FallthroughInTypeSwitchErrorType represents the error type for FallthroughInTypeSwitchError.
+	FallthroughInTypeSwitchErrorType
+	// FallthroughInFinalCaseErrorType represents the error type for FallthroughInFinalCaseError.
+	FallthroughInFinalCaseErrorType
+	// SelectDefaultCaseIsDuplicatedErrorType represents the error type for SelectDefaultCaseIsDuplicatedError.
+	SelectDefaultCaseIsDuplicatedErrorType
+	// SelectCaseChannelIsEmptyErrorType represents the error type for SelectCaseChannelIsEmptyError.
+	SelectCaseChannelIsEmptyErrorType
+	// SelectSendCaseValueIsEmptyErrorType represents the error type for SelectSendCaseValueIsEmptyError.
+	SelectSendCaseValueIsEmptyErrorType
+	// SelectRecvCaseVariablesCountErrorType represents the error type for SelectRecvCaseVariablesCountError.
+	SelectRecvCaseVariablesCountErrorType
+	// DeferStatementIsEmptyErrorType represents the error type for DeferStatementIsEmptyError.
+	DeferStatementIsEmptyErrorType
+	// GoStatementIsEmptyErrorType represents the error type for GoStatementIsEmptyError.
+	GoStatementIsEmptyErrorType
+	// LabelNameIsEmptyErrorType represents the error type for LabelNameIsEmptyError.
+	LabelNameIsEmptyErrorType
+	// LabelIsDuplicatedErrorType represents the error type for LabelIsDuplicatedError.
+	LabelIsDuplicatedErrorType
+	// LabelIsNotDefinedErrorType represents the error type for LabelIsNotDefinedError.
+	LabelIsNotDefinedErrorType
+	// GotoLabelIsEmptyErrorType represents the error type for GotoLabelIsEmptyError.
+	GotoLabelIsEmptyErrorType
+	// ElseIfConditionIsEmptyErrorType represents the error type for ElseIfConditionIsEmptyError.
+	ElseIfConditionIsEmptyErrorType
+	// CallCalleeIsEmptyErrorType represents the error type for CallCalleeIsEmptyError.
+	CallCalleeIsEmptyErrorType
+	// CallArgumentIsEmptyErrorType represents the error type for CallArgumentIsEmptyError.
+	CallArgumentIsEmptyErrorType
+	// CallTypeArgumentIsEmptyErrorType represents the error type for CallTypeArgumentIsEmptyError.
+	CallTypeArgumentIsEmptyErrorType
+	// CallSpreadWithoutArgumentsErrorType represents the error type for CallSpreadWithoutArgumentsError.
+	CallSpreadWithoutArgumentsErrorType
+	// ReturnItemIsEmptyErrorType represents the error type for ReturnItemIsEmptyError.
+	ReturnItemIsEmptyErrorType
+	// IdentifierIsInvalidErrorType represents the error type for IdentifierIsInvalidError.
+	IdentifierIsInvalidErrorType
+	// IdentifierIsKeywordErrorType represents the error type for IdentifierIsKeywordError.
+	IdentifierIsKeywordErrorType
+	// PredeclaredIdentifierIsShadowedWarningType represents the error type for PredeclaredIdentifierIsShadowedWarning.
+	PredeclaredIdentifierIsShadowedWarningType
+	// TypeCheckErrorType represents the error type for TypeCheckError.
+	TypeCheckErrorType
+	// PackageLoadingErrorType represents the error type for PackageLoadingError.
+	PackageLoadingErrorType
+	// TypeIsNotFoundErrorType represents the error type for TypeIsNotFoundError.
+	TypeIsNotFoundErrorType
+	// TypeIsNotInterfaceErrorType represents the error type for TypeIsNotInterfaceError.
+	TypeIsNotInterfaceErrorType
+	// StubTypeNameIsEmptyErrorType represents the error type for StubTypeNameIsEmptyError.
+	StubTypeNameIsEmptyErrorType
+	// StubInterfaceIsNilErrorType represents the error type for StubInterfaceIsNilError.
+	StubInterfaceIsNilErrorType
+	// MockTypeNameIsEmptyErrorType represents the error type for MockTypeNameIsEmptyError.
+	MockTypeNameIsEmptyErrorType
+	// MockInterfaceIsNilErrorType represents the error type for MockInterfaceIsNilError.
+	MockInterfaceIsNilErrorType
+	// ConstNameIsEmptyErrorType represents the error type for ConstNameIsEmptyError.
+	ConstNameIsEmptyErrorType
+	// ConstValueIsEmptyErrorType represents the error type for ConstValueIsEmptyError.
+	ConstValueIsEmptyErrorType
+	// ConstTypeWithoutValueErrorType represents the error type for ConstTypeWithoutValueError.
+	ConstTypeWithoutValueErrorType
+	// EnumTypeNameIsEmptyErrorType represents the error type for EnumTypeNameIsEmptyError.
+	EnumTypeNameIsEmptyErrorType
+	// EnumValueNameIsEmptyErrorType represents the error type for EnumValueNameIsEmptyError.
+	EnumValueNameIsEmptyErrorType
+	// EnumValueIsDuplicatedErrorType represents the error type for EnumValueIsDuplicatedError.
+	EnumValueIsDuplicatedErrorType
+	// DerivedStructIsNilErrorType represents the error type for DerivedStructIsNilError.
+	DerivedStructIsNilErrorType
+	// StructTagContainsBackquoteErrorType represents the error type for StructTagContainsBackquoteError.
+	StructTagContainsBackquoteErrorType
+	// StructTagIsMalformedErrorType represents the error type for StructTagIsMalformedError.
+	StructTagIsMalformedErrorType
+	// TableTestFuncIsNilErrorType represents the error type for TableTestFuncIsNilError.
+	TableTestFuncIsNilErrorType
+	// TableTestCaseArgsCountMismatchErrorType represents the error type for TableTestCaseArgsCountMismatchError.
+	TableTestCaseArgsCountMismatchErrorType
+	// TableTestCaseWantsCountMismatchErrorType represents the error type for TableTestCaseWantsCountMismatchError.
+	TableTestCaseWantsCountMismatchErrorType
+	// FuzzParameterTypeIsNotSupportedErrorType represents the error type for FuzzParameterTypeIsNotSupportedError.
+	FuzzParameterTypeIsNotSupportedErrorType
+	// JSONTypesRootTypeNameIsEmptyErrorType represents the error type for JSONTypesRootTypeNameIsEmptyError.
+	JSONTypesRootTypeNameIsEmptyErrorType
+	// JSONFileLoadingErrorType represents the error type for JSONFileLoadingError.
+	JSONFileLoadingErrorType
+	// JSONSchemaIsInvalidErrorType represents the error type for JSONSchemaIsInvalidError.
+	JSONSchemaIsInvalidErrorType
+	// JSONSchemaRefIsNotSupportedErrorType represents the error type for JSONSchemaRefIsNotSupportedError.
+	JSONSchemaRefIsNotSupportedErrorType
+	// JSONSchemaRefIsNotFoundErrorType represents the error type for JSONSchemaRefIsNotFoundError.
+	JSONSchemaRefIsNotFoundErrorType
+	// JSONSchemaEnumIsNotSupportedErrorType represents the error type for JSONSchemaEnumIsNotSupportedError.
+	JSONSchemaEnumIsNotSupportedErrorType
+	// JSONSchemaOneOfVariantIsNotSupportedErrorType represents the error type for JSONSchemaOneOfVariantIsNotSupportedError.
+	JSONSchemaOneOfVariantIsNotSupportedErrorType
+	// JSONSampleIsInvalidErrorType represents the error type for JSONSampleIsInvalidError.
+	JSONSampleIsInvalidErrorType
+	// OpenAPILoadingErrorType represents the error type for OpenAPILoadingError.
+	OpenAPILoadingErrorType
+	// OpenAPIIsInvalidErrorType represents the error type for OpenAPIIsInvalidError.
+	OpenAPIIsInvalidErrorType
+	// OpenAPIOperationIsDuplicatedErrorType represents the error type for OpenAPIOperationIsDuplicatedError.
+	OpenAPIOperationIsDuplicatedErrorType
+	// OpenAPIParameterIsNotSupportedErrorType represents the error type for OpenAPIParameterIsNotSupportedError.
+	OpenAPIParameterIsNotSupportedErrorType
+	// OpenAPIContentTypeIsNotSupportedErrorType represents the error type for OpenAPIContentTypeIsNotSupportedError.
+	OpenAPIContentTypeIsNotSupportedErrorType
+	// SQLSchemaLoadingErrorType represents the error type for SQLSchemaLoadingError.
+	SQLSchemaLoadingErrorType
+	// SQLSchemaIsInvalidErrorType represents the error type for SQLSchemaIsInvalidError.
+	SQLSchemaIsInvalidErrorType
+	// SQLColumnTypeIsNotSupportedErrorType represents the error type for SQLColumnTypeIsNotSupportedError.
+	SQLColumnTypeIsNotSupportedErrorType
+	// ProtoLoadingErrorType represents the error type for ProtoLoadingError.
+	ProtoLoadingErrorType
+	// ProtoIsInvalidErrorType represents the error type for ProtoIsInvalidError.
+	ProtoIsInvalidErrorType
+	// ProtoTypeIsNotFoundErrorType represents the error type for ProtoTypeIsNotFoundError.
+	ProtoTypeIsNotFoundErrorType
+	// ProtoStreamingIsNotSupportedErrorType represents the error type for ProtoStreamingIsNotSupportedError.
+	ProtoStreamingIsNotSupportedErrorType
+	// SpecLoadingErrorType represents the error type for SpecLoadingError.
+	SpecLoadingErrorType
+	// SpecIsInvalidErrorType represents the error type for SpecIsInvalidError.
+	SpecIsInvalidErrorType
+	// GotoJumpsIntoBlockErrorType represents the error type for GotoJumpsIntoBlockError.
+	GotoJumpsIntoBlockErrorType
+	// LabelIsNotFollowedByStatementErrorType represents the error type for LabelIsNotFollowedByStatementError.
+	LabelIsNotFollowedByStatementErrorType
+	// MockMemberNameConflictsErrorType represents the error type for MockMemberNameConflictsError.
+	MockMemberNameConflictsErrorType
 	// ErrsUnknownType represents unknown type for Errs
 	ErrsUnknownType
 )
 
 // ListErrs returns the list of errors.
 func ListErrs() []string {
-	return []string{"[GOWRTR-1] struct name must not be empty, but it gets empty (caused at %s)", "[GOWRTR-2] field name must not be empty, but it gets empty (caused at %s)", "[GOWRTR-3] field type must not be empty, but it gets empty (caused at %s)", "[GOWRTR-4] func parameter name must not be empty, but it gets empty (caused at %s)", "[GOWRTR-5] the last func parameter type must not be empty, but it gets empty (caused at %s)", "[GOWRTR-6] name of func must not be empty, but it gets empty (caused at %s)", "[GOWRTR-7] name of interface must not be empty, but it gets empty (caused at %s)", "[GOWRTR-8] name of func receiver must not be empty, but it gets empty (caused at %s)", "[GOWRTR-9] type of func receiver must not be empty, but it gets empty (caused at %s)", "[GOWRTR-10] func signature must not be nil, bit it gets nil (caused at %s)", "[GOWRTR-11] anonymous func signature must not be nil, bit it gets nil (caused at %s)", "[GOWRTR-12] a parameter of function invocation must not be nil, but it gets nil (caused at %s)", "[GOWRTR-13] code formatter raises error: command='%s', err='%s', msg='%s'", "[GOWRTR-14] condition of case must not be empty, but it gets empty (caused at %s)", "[GOWRTR-15] condition of if must not be empty, but it gets empty (caused at %s)", "[GOWRTR-16] unnamed return type appears after named return type (caused at %s)", "[GOWRTR-17] a value of composite literal must not be empty, but it gets empty (caused at %s)"}
+	return []string{
+		"[GOWRTR-1] struct name must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-2] field name must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-3] field type must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-4] func parameter name must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-5] the last func parameter type must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-6] name of func must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-7] name of interface must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-8] name of func receiver must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-9] type of func receiver must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-10] func signature must not be nil, bit it gets nil (caused at %s)",
+		"[GOWRTR-11] anonymous func signature must not be nil, bit it gets nil (caused at %s)",
+		"[GOWRTR-12] a parameter of function invocation must not be nil, but it gets nil (caused at %s)",
+		"[GOWRTR-13] code formatter raises error: command='%s', err='%s', msg='%s'",
+		"[GOWRTR-14] condition of case must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-15] condition of if must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-16] unnamed return type appears after named return type (caused at %s)",
+		"[GOWRTR-17] a value of composite literal must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-18] left-hand side of assignment must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-19] right-hand side of assignment must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-20] assignment operator is invalid: '%s' (caused at %s)",
+		"[GOWRTR-21] assignment count mismatch: %d = %d (caused at %s)",
+		"[GOWRTR-22] compound assignment operator '%s' takes exactly one operand on each side (caused at %s)",
+		"[GOWRTR-23] name of var must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-24] either type or value of var must be specified, but both are empty (caused at %s)",
+		"[GOWRTR-25] target of increment/decrement statement must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-26] range expression of for must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-27] post statement of for must not be a short variable declaration (caused at %s)",
+		"[GOWRTR-28] expression of type switch must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-29] fallthrough statement is not permitted in type switch (caused at %s)",
+		"[GOWRTR-30] cannot fallthrough final case in switch (caused at %s)",
+		"[GOWRTR-31] select must not have more than one default case (caused at %s)",
+		"[GOWRTR-32] channel of select case must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-33] value of select send case must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-34] receive case of select takes at most two variables, but it gets %d (caused at %s)",
+		"[GOWRTR-35] statement of defer must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-36] statement of go must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-37] name of label must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-38] label '%s' is already defined in the enclosing func (caused at %s)",
+		"[GOWRTR-39] label '%s' is not defined in the enclosing func (caused at %s)",
+		"[GOWRTR-40] label of goto must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-41] condition of else-if must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-42] callee of call expression must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-43] an argument of call expression must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-44] a type argument of call expression must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-45] call expression with variadic spread must have at least one argument (caused at %s)",
+		"[GOWRTR-46] a return item must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-47] '%s' is not a valid identifier (caused at %s)",
+		"[GOWRTR-48] '%s' is a keyword, so it cannot be used as an identifier (caused at %s)",
+		"[GOWRTR-49] '%s' shadows the predeclared identifier (caused at %s)",
+		"[GOWRTR-50] type checking raises error at %s of the generated code: %s (caused at %s)",
+		"[GOWRTR-51] failed to load the package '%s': %s (caused at %s)",
+		"[GOWRTR-52] type '%s' is not found in the package '%s' (caused at %s)",
+		"[GOWRTR-53] type '%s' of the package '%s' is not an interface (caused at %s)",
+		"[GOWRTR-54] type name of stub must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-55] interface of stub must not be nil, but it gets nil (caused at %s)",
+		"[GOWRTR-56] type name of mock must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-57] interface of mock must not be nil, but it gets nil (caused at %s)",
+		"[GOWRTR-58] name of const must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-59] value of the first const must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-60] const that has a type must have a value (caused at %s)",
+		"[GOWRTR-61] type name of enum must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-62] name of enum value must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-63] enum value '%s' is duplicated (caused at %s)",
+		"[GOWRTR-64] struct to derive the code from must not be nil, but it gets nil (caused at %s)",
+		"[GOWRTR-65] struct tag must not contain backquote, but it gets '%s' (caused at %s)",
+		"[GOWRTR-66] struct tag must be space-separated key:value pairs that are compatible with reflect.StructTag (the value is quoted), but it gets '%s' (caused at %s)",
+		"[GOWRTR-67] func to generate the test from must not be nil, but it gets nil (caused at %s)",
+		"[GOWRTR-68] the number of args of the test case must be %d (the number of the parameters), but it gets %d (caused at %s)",
+		"[GOWRTR-69] the number of wants of the test case must be %d (the number of the return types except error), but it gets %d (caused at %s)",
+		"[GOWRTR-70] type of the parameter '%s' is not supported by fuzzing, but it gets '%s' (caused at %s)",
+		"[GOWRTR-71] root type name of the JSON types must not be empty, but it gets empty (caused at %s)",
+		"[GOWRTR-72] failed to load the JSON file '%s': %s (caused at %s)",
+		"[GOWRTR-73] JSON schema is invalid: %s (caused at %s)",
+		"[GOWRTR-74] $ref '%s' is not supported; only the reference in the same document (e.g. #/definitions/xxx) is supported (caused at %s)",
+		"[GOWRTR-75] $ref '%s' is not found in the JSON schema (caused at %s)",
+		"[GOWRTR-76] enum of '%s' must consist of only string values or only integer values (caused at %s)",
+		"[GOWRTR-77] variant '%s' of oneOf/anyOf must be a concrete type; neither oneOf/anyOf nor a schema without the type is supported (caused at %s)",
+		"[GOWRTR-78] JSON sample #%d is invalid: %s (caused at %s)",
+		"[GOWRTR-79] failed to load the OpenAPI document '%s': %s (caused at %s)",
+		"[GOWRTR-80] OpenAPI document is invalid: %s (caused at %s)",
+		"[GOWRTR-81] operation '%s' is duplicated; please specify the unique operationId (caused at %s)",
+		"[GOWRTR-82] parameter '%s' of the operation '%s' is not supported; it must be in path, query or header, and the type must be a primitive (or an array of primitives in query) (caused at %s)",
+		"[GOWRTR-83] content type '%s' of the operation '%s' is not supported; only JSON is supported (caused at %s)",
+		"[GOWRTR-84] failed to load the SQL schema '%s': %s (caused at %s)",
+		"[GOWRTR-85] SQL schema is invalid: %s (caused at %s)",
+		"[GOWRTR-86] type '%s' of the column '%s' of the table '%s' is not supported (caused at %s)",
+		"[GOWRTR-87] failed to load the proto file '%s': %s (caused at %s)",
+		"[GOWRTR-88] proto is invalid: %s (caused at %s)",
+		"[GOWRTR-89] type '%s' at line %d, column %d is not found; the types of the imported files are not supported (caused at %s)",
+		"[GOWRTR-90] rpc '%s' is streaming, but the streaming rpc is not supported (caused at %s)",
+		"[GOWRTR-91] failed to load the spec '%s': %s (caused at %s)",
+		"[GOWRTR-92] spec is invalid: %s (caused at %s)",
+		"[GOWRTR-93] goto '%s' jumps into the block that doesn't enclose the goto (caused at %s)",
+		"[GOWRTR-94] label '%s' must be followed by a statement in the same block (caused at %s)",
+		"[GOWRTR-95] '%s' of the mock '%s' conflicts with another field, method or type of the mock; please rename the method of the interface (caused at %s)",
+	}
 }
 
-// IdentifyErrs checks the identity of an error
+// IdentifyErrs checks the identity of an error by its error code.
 func IdentifyErrs(err error) ErrsType {
-	errStr := err.Error()
-	switch {
-	case strings.HasPrefix(errStr, "[GOWRTR-1]"):
+	var e *Error
+	if !errors.As(err, &e) {
+		return ErrsUnknownType
+	}
+
+	switch e.code {
+	case "GOWRTR-1":
 		return StructNameIsNilErrType
-	case strings.HasPrefix(errStr, "[GOWRTR-2]"):
+	case "GOWRTR-2":
 		return StructFieldNameIsEmptyErrType
-	case strings.HasPrefix(errStr, "[GOWRTR-3]"):
+	case "GOWRTR-3":
 		return StructFieldTypeIsEmptyErrType
-	case strings.HasPrefix(errStr, "[GOWRTR-4]"):
+	case "GOWRTR-4":
 		return FuncParameterNameIsEmptyErrType
-	case strings.HasPrefix(errStr, "[GOWRTR-5]"):
+	case "GOWRTR-5":
 		return LastFuncParameterTypeIsEmptyErrType
-	case strings.HasPrefix(errStr, "[GOWRTR-6]"):
+	case "GOWRTR-6":
 		return FuncNameIsEmptyErrorType
-	case strings.HasPrefix(errStr, "[GOWRTR-7]"):
+	case "GOWRTR-7":
 		return InterfaceNameIsEmptyErrorType
-	case strings.HasPrefix(errStr, "[GOWRTR-8]"):
+	case "GOWRTR-8":
 		return FuncReceiverNameIsEmptyErrorType
-	case strings.HasPrefix(errStr, "[GOWRTR-9]"):
+	case "GOWRTR-9":
 		return FuncReceiverTypeIsEmptyErrorType
-	case strings.HasPrefix(errStr, "[GOWRTR-10]"):
+	case "GOWRTR-10":
 		return FuncSignatureIsNilErrorType
-	case strings.HasPrefix(errStr, "[GOWRTR-11]"):
+	case "GOWRTR-11":
 		return AnonymousFuncSignatureIsNilErrorType
-	case strings.HasPrefix(errStr, "[GOWRTR-12]"):
+	case "GOWRTR-12":
 		return FuncInvocationParameterIsEmptyErrorType
-	case strings.HasPrefix(errStr, "[GOWRTR-13]"):
+	case "GOWRTR-13":
 		return CodeFormatterErrorType
-	case strings.HasPrefix(errStr, "[GOWRTR-14]"):
+	case "GOWRTR-14":
 		return CaseConditionIsEmptyErrorType
-	case strings.HasPrefix(errStr, "[GOWRTR-15]"):
+	case "GOWRTR-15":
 		return IfConditionIsEmptyErrorType
-	case strings.HasPrefix(errStr, "[GOWRTR-16]"):
+	case "GOWRTR-16":
 		return UnnamedReturnTypeAppearsAfterNamedReturnTypeErrorType
-	case strings.HasPrefix(errStr, "[GOWRTR-17]"):
+	case "GOWRTR-17":
 		return ValueOfCompositeLiteralIsEmptyErrorType
+	case "GOWRTR-18":
+		return AssignLeftHandSideIsEmptyErrorType
+	case "GOWRTR-19":
+		return AssignRightHandSideIsEmptyErrorType
+	case "GOWRTR-20":
+		return AssignOperatorIsInvalidErrorType
+	case "GOWRTR-21":
+		return AssignOperandsCountMismatchErrorType
+	case "GOWRTR-22":
+		return CompoundAssignOperandIsNotSingleErrorType
+	case "GOWRTR-23":
+		return VarNameIsEmptyErrorType
+	case "GOWRTR-24":
+		return VarTypeAndValueAreEmptyErrorType
+	case "GOWRTR-25":
+		return IncDecTargetIsEmptyErrorType
+	case "GOWRTR-26":
+		return ForRangeExpressionIsEmptyErrorType
+	case "GOWRTR-27":
+		return ForPostStatementIsShortVarDeclErrorType
+	case "GOWRTR-28":
+		return TypeSwitchExpressionIsEmptyErrorType
+	case "GOWRTR-29":
+		return FallthroughInTypeSwitchErrorType
+	case "GOWRTR-30":
+		return FallthroughInFinalCaseErrorType
+	case "GOWRTR-31":
+		return SelectDefaultCaseIsDuplicatedErrorType
+	case "GOWRTR-32":
+		return SelectCaseChannelIsEmptyErrorType
+	case "GOWRTR-33":
+		return SelectSendCaseValueIsEmptyErrorType
+	case "GOWRTR-34":
+		return SelectRecvCaseVariablesCountErrorType
+	case "GOWRTR-35":
+		return DeferStatementIsEmptyErrorType
+	case "GOWRTR-36":
+		return GoStatementIsEmptyErrorType
+	case "GOWRTR-37":
+		return LabelNameIsEmptyErrorType
+	case "GOWRTR-38":
+		return LabelIsDuplicatedErrorType
+	case "GOWRTR-39":
+		return LabelIsNotDefinedErrorType
+	case "GOWRTR-40":
+		return GotoLabelIsEmptyErrorType
+	case "GOWRTR-41":
+		return ElseIfConditionIsEmptyErrorType
+	case "GOWRTR-42":
+		return CallCalleeIsEmptyErrorType
+	case "GOWRTR-43":
+		return CallArgumentIsEmptyErrorType
+	case "GOWRTR-44":
+		return CallTypeArgumentIsEmptyErrorType
+	case "GOWRTR-45":
+		return CallSpreadWithoutArgumentsErrorType
+	case "GOWRTR-46":
+		return ReturnItemIsEmptyErrorType
+	case "GOWRTR-47":
+		return IdentifierIsInvalidErrorType
+	case "GOWRTR-48":
+		return IdentifierIsKeywordErrorType
+	case "GOWRTR-49":
+		return PredeclaredIdentifierIsShadowedWarningType
+	case "GOWRTR-50":
+		return TypeCheckErrorType
+	case "GOWRTR-51":
+		return PackageLoadingErrorType
+	case "GOWRTR-52":
+		return TypeIsNotFoundErrorType
+	case "GOWRTR-53":
+		return TypeIsNotInterfaceErrorType
+	case "GOWRTR-54":
+		return StubTypeNameIsEmptyErrorType
+	case "GOWRTR-55":
+		return StubInterfaceIsNilErrorType
+	case "GOWRTR-56":
+		return MockTypeNameIsEmptyErrorType
+	case "GOWRTR-57":
+		return MockInterfaceIsNilErrorType
+	case "GOWRTR-58":
+		return ConstNameIsEmptyErrorType
+	case "GOWRTR-59":
+		return ConstValueIsEmptyErrorType
+	case "GOWRTR-60":
+		return ConstTypeWithoutValueErrorType
+	case "GOWRTR-61":
+		return EnumTypeNameIsEmptyErrorType
+	case "GOWRTR-62":
+		return EnumValueNameIsEmptyErrorType
+	case "GOWRTR-63":
+		return EnumValueIsDuplicatedErrorType
+	case "GOWRTR-64":
+		return DerivedStructIsNilErrorType
+	case "GOWRTR-65":
+		return StructTagContainsBackquoteErrorType
+	case "GOWRTR-66":
+		return StructTagIsMalformedErrorType
+	case "GOWRTR-67":
+		return TableTestFuncIsNilErrorType
+	case "GOWRTR-68":
+		return TableTestCaseArgsCountMismatchErrorType
+	case "GOWRTR-69":
+		return TableTestCaseWantsCountMismatchErrorType
+	case "GOWRTR-70":
+		return FuzzParameterTypeIsNotSupportedErrorType
+	case "GOWRTR-71":
+		return JSONTypesRootTypeNameIsEmptyErrorType
+	case "GOWRTR-72":
+		return JSONFileLoadingErrorType
+	case "GOWRTR-73":
+		return JSONSchemaIsInvalidErrorType
+	case "GOWRTR-74":
+		return JSONSchemaRefIsNotSupportedErrorType
+	case "GOWRTR-75":
+		return JSONSchemaRefIsNotFoundErrorType
+	case "GOWRTR-76":
+		return JSONSchemaEnumIsNotSupportedErrorType
+	case "GOWRTR-77":
+		return JSONSchemaOneOfVariantIsNotSupportedErrorType
+	case "GOWRTR-78":
+		return JSONSampleIsInvalidErrorType
+	case "GOWRTR-79":
+		return OpenAPILoadingErrorType
+	case "GOWRTR-80":
+		return OpenAPIIsInvalidErrorType
+	case "GOWRTR-81":
+		return OpenAPIOperationIsDuplicatedErrorType
+	case "GOWRTR-82":
+		return OpenAPIParameterIsNotSupportedErrorType
+	case "GOWRTR-83":
+		return OpenAPIContentTypeIsNotSupportedErrorType
+	case "GOWRTR-84":
+		return SQLSchemaLoadingErrorType
+	case "GOWRTR-85":
+		return SQLSchemaIsInvalidErrorType
+	case "GOWRTR-86":
+		return SQLColumnTypeIsNotSupportedErrorType
+	case "GOWRTR-87":
+		return ProtoLoadingErrorType
+	case "GOWRTR-88":
+		return ProtoIsInvalidErrorType
+	case "GOWRTR-89":
+		return ProtoTypeIsNotFoundErrorType
+	case "GOWRTR-90":
+		return ProtoStreamingIsNotSupportedErrorType
+	case "GOWRTR-91":
+		return SpecLoadingErrorType
+	case "GOWRTR-92":
+		return SpecIsInvalidErrorType
+	case "GOWRTR-93":
+		return GotoJumpsIntoBlockErrorType
+	case "GOWRTR-94":
+		return LabelIsNotFollowedByStatementErrorType
+	case "GOWRTR-95":
+		return MockMemberNameConflictsErrorType
 	default:
 		return ErrsUnknownType
 	}
@@ -0,0 +1,188 @@
+//go:build ignore
+// +build ignore
+
+// This program generates the errors of gowrtr from the fields of `errs` struct in errmsg.go.
+//
+// Each field turns into the function that returns `*Error` (and `...Wrap` one that wraps the given error).
+// The error holds the code (e.g. `GOWRTR-14`) and the caller, so that they can be retrieved without parsing the message.
+// The code is numbered by the order of the fields, so please append the new field to the end of the struct.
+// The field that has `obsoleted` tag is not generated, but it keeps its number.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"log"
+	"reflect"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+const (
+	source     = "errmsg.go"
+	target     = "errs_errmsg_gen.go"
+	structName = "errs"
+	codePrefix = "GOWRTR-"
+)
+
+type errDef struct {
+	Name   string
+	Code   string
+	Msg    string
+	Params []string
+	Args   []string
+	Caller string
+}
+
+var tmpl = template.Must(template.New(target).Funcs(template.FuncMap{
+	"join": func(items []string) string {
+		return strings.Join(items, ", ")
+	},
+}).Parse(`// Code generated by gen.go from errmsg.go; DO NOT EDIT.
+
+package errmsg
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+{{range .}}
+// {{.Name}} returns the error.
+func {{.Name}}({{join .Params}}) error {
+	return newError({{printf "%q" .Code}}, {{.Caller}}, {{if .Args}}fmt.Errorf({{printf "%q" .Msg}}, {{join .Args}}){{else}}errors.New({{printf "%q" .Msg}}){{end}})
+}
+
+// {{.Name}}Wrap wraps the error.
+func {{.Name}}Wrap({{join .Params}}{{if .Params}}, {{end}}err error) error {
+	return newError({{printf "%q" .Code}}, {{.Caller}}, {{if .Args}}errors.Wrapf(err, {{printf "%q" .Msg}}, {{join .Args}}){{else}}errors.Wrap(err, {{printf "%q" .Msg}}){{end}})
+}
+{{end}}
+// ErrsType represents the error type.
+type ErrsType int
+
+const (
+{{- range $i, $def := .}}
+	// {{$def.Name}}Type represents the error type for {{$def.Name}}.
+	{{$def.Name}}Type{{if eq $i 0}} ErrsType = iota{{end}}
+{{- end}}
+	// ErrsUnknownType represents unknown type for Errs
+	ErrsUnknownType
+)
+
+// ListErrs returns the list of errors.
+func ListErrs() []string {
+	return []string{
+{{- range .}}
+		{{printf "%q" .Msg}},
+{{- end}}
+	}
+}
+
+// IdentifyErrs checks the identity of an error by its error code.
+func IdentifyErrs(err error) ErrsType {
+	var e *Error
+	if !errors.As(err, &e) {
+		return ErrsUnknownType
+	}
+
+	switch e.code {
+{{- range .}}
+	case {{printf "%q" .Code}}:
+		return {{.Name}}Type
+{{- end}}
+	default:
+		return ErrsUnknownType
+	}
+}
+`))
+
+func main() {
+	defs, err := loadErrDefs()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	buf := &bytes.Buffer{}
+	err = tmpl.Execute(buf, defs)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = ioutil.WriteFile(target, formatted, 0644)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func loadErrDefs() ([]*errDef, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), source, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields *ast.FieldList
+	ast.Inspect(f, func(node ast.Node) bool {
+		typeSpec, ok := node.(*ast.TypeSpec)
+		if !ok || typeSpec.Name.Name != structName {
+			return true
+		}
+		if structType, ok := typeSpec.Type.(*ast.StructType); ok {
+			fields = structType.Fields
+		}
+		return false
+	})
+	if fields == nil {
+		return nil, fmt.Errorf("struct %s is not found in %s", structName, source)
+	}
+
+	defs := make([]*errDef, 0, len(fields.List))
+	for i, field := range fields.List {
+		if len(field.Names) != 1 || field.Tag == nil {
+			return nil, fmt.Errorf("field #%d of %s must have exactly one name and the tag", i+1, structName)
+		}
+
+		rawTag, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			return nil, err
+		}
+		tag := reflect.StructTag(rawTag)
+		if _, obsoleted := tag.Lookup("obsoleted"); obsoleted {
+			continue
+		}
+
+		code := codePrefix + strconv.Itoa(i+1)
+		def := &errDef{
+			Name:   field.Names[0].Name,
+			Code:   code,
+			Msg:    "[" + code + "] " + tag.Get("errmsg"),
+			Caller: `""`,
+		}
+		if vars := strings.TrimSpace(tag.Get("vars")); vars != "" {
+			for _, v := range strings.Split(vars, ",") {
+				param := strings.Fields(v)
+				if len(param) != 2 {
+					return nil, fmt.Errorf("vars of %s must be comma-separated `name type` pairs, but it gets '%s'", def.Name, vars)
+				}
+				def.Params = append(def.Params, param[0]+" "+param[1])
+				def.Args = append(def.Args, param[0])
+				if param[0] == "caller" {
+					def.Caller = "caller"
+				}
+			}
+		}
+		defs = append(defs, def)
+	}
+	return defs, nil
+}
@@ -1,8 +1,6 @@
 package internal
 
 import (
-	// For vendoring
-	_ "github.com/moznion/go-errgen"
 	// For vendoring
 	_ "golang.org/x/lint"
 )